@@ -28,6 +28,8 @@ const (
 	DefaultClusterChecksRunnerResourceSuffix = "cluster-checks-runner"
 	// DefaultMetricsServerResourceSuffix use as suffix for cluster-agent metrics-server resource naming
 	DefaultMetricsServerResourceSuffix = "cluster-agent-metrics-server"
+	// DefaultClusterAgentTokenResourceSuffix use as suffix for the cluster-agent token secret naming
+	DefaultClusterAgentTokenResourceSuffix = "token"
 	// DefaultAPPKeyKey default app-key key (use in secret for instance).
 	DefaultAPPKeyKey = "app_key"
 	// DefaultAPIKeyKey default api-key key (use in secret for instance).
@@ -50,12 +52,18 @@ const (
 	DefaultDogstatsdPort = 8125
 	// DefaultDogstatsdPortName default dogstatsd port name
 	DefaultDogstatsdPortName = "dogstatsd"
+	// DefaultApmPort default apm port
+	DefaultApmPort = 8126
 	// DefaultApmPortName default apm port name
 	DefaultApmPortName = "apm"
+	// DefaultAdmissionServiceName default admission controller webhook service name
+	DefaultAdmissionServiceName = "datadog-admission-controller"
 	// DefaultMetricsProviderPort default metrics provider port
 	DefaultMetricsProviderPort int32 = 8443
 	// DefaultKubeStateMetricsCoreConf default ksm core ConfigMap name
 	DefaultKubeStateMetricsCoreConf string = "kube-state-metrics-core-config"
+	// DefaultOrchestratorExplorerConf default Orchestrator Explorer ConfigMap name
+	DefaultOrchestratorExplorerConf string = "orchestrator-explorer-config"
 	// DefaultSystemProbeSocketPath default System Probe socket path
 	DefaultSystemProbeSocketPath string = "/var/run/sysprobe/sysprobe.sock"
 
@@ -94,15 +102,19 @@ const (
 const (
 	SystemProbeAppArmorAnnotationKey   = "container.apparmor.security.beta.kubernetes.io/system-probe"
 	SystemProbeAppArmorAnnotationValue = "unconfined"
+	SystemProbeSecCompAnnotationKey    = "container.seccomp.security.alpha.kubernetes.io/system-probe"
 )
 
 // Datadog volume names and mount paths
 const (
-	ConfdVolumeName               = "confd"
-	ConfdVolumePath               = "/conf.d"
-	ConfigVolumeName              = "config"
-	ConfigVolumePath              = "/etc/datadog-agent"
-	KubeStateMetricCoreVolumeName = "ksm-core-config"
+	ConfdVolumeName                = "confd"
+	ConfdVolumePath                = "/conf.d"
+	ChecksdVolumeName              = "checksd"
+	ChecksdVolumePath              = "/checks.d"
+	ConfigVolumeName               = "config"
+	ConfigVolumePath               = "/etc/datadog-agent"
+	KubeStateMetricCoreVolumeName  = "ksm-core-config"
+	OrchestratorExplorerVolumeName = "orchestrator-explorer-config"
 
 	HostRootVolumeName = "hostroot"
 	HostRootHostPath   = "/"
@@ -131,6 +143,10 @@ const (
 	// same path on host and container
 	DebugfsPath = "/sys/kernel/debug"
 
+	TracefsVolumeName = "tracefs"
+	// same path on host and container
+	TracefsPath = "/sys/kernel/tracing"
+
 	ModulesVolumeName = "modules"
 	// same path on host and container
 	ModulesVolumePath = "/lib/modules"
@@ -139,6 +155,10 @@ const (
 	// same path on host and container
 	SrcVolumePath = "/usr/src"
 
+	OSReleaseVolumeName = "host-osrelease"
+	OSReleaseHostPath   = "/etc/os-release"
+	OSReleaseMountPath  = "/host/etc/os-release"
+
 	LogDatadogVolumeName       = "logdatadog"
 	LogDatadogVolumePath       = "/var/log/datadog"
 	TmpVolumeName              = "tmp"
@@ -160,4 +180,31 @@ const (
 	ContainerLogVolumePath     = "/var/lib/docker/containers"
 	SymlinkContainerVolumeName = "symlinkcontainerpath"
 	SymlinkContainerVolumePath = "/var/log/containers"
+
+	APMSocketVolumeName = "apmsocket"
+	APMSocketVolumePath = "/var/run/datadog/apm"
+
+	DogstatsdSocketVolumeName = "dsdsocket"
+	DogstatsdSocketVolumePath = "/var/run/datadog/statsd"
+
+	KubeletCAVolumeName       = "kubelet-ca"
+	DefaultKubeletAgentCAPath = "/var/run/host-kubelet-ca.crt"
+
+	CriSocketVolumeName     = "runtimesocketdir"
+	HostCriSocketPathPrefix = "/host"
+	DefaultRuntimeDir       = "/var/run"
+
+	SeccompSecurityVolumeName = "datadog-agent-security"
+	SeccompSecurityVolumePath = "/etc/config"
+	SeccompRootVolumeName     = "seccomp-root"
+	SeccompRootVolumePath     = "/host/var/lib/kubelet/seccomp"
+)
+
+// Autodiscovery config providers and listeners
+const (
+	ClusterChecksConfigProvider             = "clusterchecks"
+	EndpointsChecksConfigProvider           = "endpointschecks"
+	ClusterAndEndpointsConfigProviders      = "clusterchecks endpointschecks"
+	KubeServicesAndEndpointsConfigProviders = "kube_services kube_endpoints"
+	KubeServicesAndEndpointsListeners       = "kube_services kube_endpoints"
 )
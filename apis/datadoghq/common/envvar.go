@@ -7,28 +7,82 @@ package common
 
 // Datadog env var names
 const (
-	DDIgnoreAutoConf                      = "DD_IGNORE_AUTOCONF"
-	DDKubeStateMetricsCoreEnabled         = "DD_KUBE_STATE_METRICS_CORE_ENABLED"
-	DDKubeStateMetricsCoreConfigMap       = "DD_KUBE_STATE_METRICS_CORE_CONFIGMAP_NAME"
-	DDProcessAgentEnabledEnvVar           = "DD_PROCESS_AGENT_ENABLED"
-	DDSystemProbeNPMEnabledEnvVar         = "DD_SYSTEM_PROBE_NETWORK_ENABLED"
-	DDSystemProbeEnabledEnvVar            = "DD_SYSTEM_PROBE_ENABLED"
-	DDSystemProbeExternal                 = "DD_SYSTEM_PROBE_EXTERNAL"
-	DDSystemProbeServiceMonitoringEnabled = "DD_SYSTEM_PROBE_SERVICE_MONITORING_ENABLED"
-	DDSystemProbeSocket                   = "DD_SYSPROBE_SOCKET"
-	DDComplianceEnabled                   = "DD_COMPLIANCE_CONFIG_ENABLED"
-	DDComplianceCheckInterval             = "DD_COMPLIANCE_CONFIG_CHECK_INTERVAL"
-	DDHostRootEnvVar                      = "HOST_ROOT"
-	DDEnableOOMKillEnvVar                 = "DD_SYSTEM_PROBE_CONFIG_ENABLE_OOM_KILL"
-	DDEnableTCPQueueLengthEnvVar          = "DD_SYSTEM_PROBE_CONFIG_ENABLE_TCP_QUEUE_LENGTH"
-	DDLeaderElection                      = "DD_LEADER_ELECTION"
-	DDClusterAgentKubeServiceName         = "DD_CLUSTER_AGENT_KUBERNETES_SERVICE_NAME"
-	DDHealthPort                          = "DD_HEALTH_PORT"
-	DDLogsEnabled                         = "DD_LOGS_ENABLED"
-	DDLogsConfigContainerCollectAll       = "DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL"
-	DDLogsContainerCollectUsingFiles      = "DD_LOGS_CONFIG_K8S_CONTAINER_USE_FILE"
-	DDLogsConfigOpenFilesLimit            = "DD_LOGS_CONFIG_OPEN_FILES_LIMIT"
-	DDPrometheusScrapeEnabled             = "DD_PROMETHEUS_SCRAPE_ENABLED"
-	DDPrometheusScrapeServiceEndpoints    = "DD_PROMETHEUS_SCRAPE_SERVICE_ENDPOINTS"
-	DDPrometheusScrapeChecks              = "DD_PROMETHEUS_SCRAPE_CHECKS"
+	DDAdmissionControllerEnabled              = "DD_ADMISSION_CONTROLLER_ENABLED"
+	DDAdmissionControllerInjectConfigMode     = "DD_ADMISSION_CONTROLLER_INJECT_CONFIG_MODE"
+	DDAdmissionControllerLocalServiceName     = "DD_ADMISSION_CONTROLLER_INJECT_CONFIG_LOCAL_SERVICE_NAME"
+	DDAdmissionControllerMutateUnlabelled     = "DD_ADMISSION_CONTROLLER_MUTATE_UNLABELLED"
+	DDAdmissionControllerWebhookName          = "DD_ADMISSION_CONTROLLER_WEBHOOK_NAME"
+	DDAdmissionControllerServiceName          = "DD_ADMISSION_CONTROLLER_SERVICE_NAME"
+	DDAPMEnabled                              = "DD_APM_ENABLED"
+	DDAPMReceiverPort                         = "DD_APM_RECEIVER_PORT"
+	DDAPMReceiverSocket                       = "DD_APM_RECEIVER_SOCKET"
+	DDAPMNonLocalTraffic                      = "DD_APM_NON_LOCAL_TRAFFIC"
+	DDAppKey                                  = "DD_APP_KEY"
+	DDClusterAgentAuthToken                   = "DD_CLUSTER_AGENT_AUTH_TOKEN"
+	DDClusterAgentTokenName                   = "DD_CLUSTER_AGENT_TOKEN_NAME"
+	DDClusterChecksEnabled                    = "DD_CLUSTER_CHECKS_ENABLED"
+	DDClusterName                             = "DD_CLUSTER_NAME"
+	DDCollectKubernetesEvents                 = "DD_COLLECT_KUBERNETES_EVENTS"
+	DDCriSocketPath                           = "DD_CRI_SOCKET_PATH"
+	DDExternalMetricsProviderAPIKey           = "DD_EXTERNAL_METRICS_PROVIDER_API_KEY"
+	DDExternalMetricsProviderAppKey           = "DD_EXTERNAL_METRICS_PROVIDER_APP_KEY"
+	DDExternalMetricsProviderEnabled          = "DD_EXTERNAL_METRICS_PROVIDER_ENABLED"
+	DDExternalMetricsProviderEndpoint         = "DD_EXTERNAL_METRICS_PROVIDER_ENDPOINT"
+	DDExternalMetricsProviderPort             = "DD_EXTERNAL_METRICS_PROVIDER_PORT"
+	DDExternalMetricsProviderUseDatadogMetric = "DD_EXTERNAL_METRICS_PROVIDER_USE_DATADOGMETRIC_CRD"
+	DDExternalMetricsProviderWPAController    = "DD_EXTERNAL_METRICS_PROVIDER_WPA_CONTROLLER"
+	DDExtraConfigProviders                    = "DD_EXTRA_CONFIG_PROVIDERS"
+	DDExtraListeners                          = "DD_EXTRA_LISTENERS"
+	DDIgnoreAutoConf                          = "DD_IGNORE_AUTOCONF"
+	DDKubeStateMetricsCoreEnabled             = "DD_KUBE_STATE_METRICS_CORE_ENABLED"
+	DDKubeStateMetricsCoreConfigMap           = "DD_KUBE_STATE_METRICS_CORE_CONFIGMAP_NAME"
+	DDKubeletCAPath                           = "DD_KUBELET_CLIENT_CA"
+	DDKubeletHost                             = "DD_KUBERNETES_KUBELET_HOST"
+	DDKubeletTLSVerify                        = "DD_KUBELET_TLS_VERIFY"
+	DDLogLevel                                = "DD_LOG_LEVEL"
+	DDOrchestratorExplorerAdditionalEndpoints = "DD_ORCHESTRATOR_ADDITIONAL_ENDPOINTS"
+	DDOrchestratorExplorerContainerScrubbing  = "DD_ORCHESTRATOR_EXPLORER_CONTAINER_SCRUBBING_ENABLED"
+	DDOrchestratorExplorerDDUrl               = "DD_ORCHESTRATOR_EXPLORER_DD_URL"
+	DDOrchestratorExplorerEnabled             = "DD_ORCHESTRATOR_EXPLORER_ENABLED"
+	DDOrchestratorExplorerExtraTags           = "DD_ORCHESTRATOR_EXPLORER_EXTRA_TAGS"
+	DDPodAnnotationsAsTags                    = "DD_KUBERNETES_POD_ANNOTATIONS_AS_TAGS"
+	DDPodLabelsAsTags                         = "DD_KUBERNETES_POD_LABELS_AS_TAGS"
+	DDProcessAgentEnabledEnvVar               = "DD_PROCESS_AGENT_ENABLED"
+	DDProcessConfigContainerCollectionEnabled = "DD_PROCESS_CONFIG_CONTAINER_COLLECTION_ENABLED"
+	DDProcessConfigScrubArgs                  = "DD_PROCESS_CONFIG_SCRUB_ARGS"
+	DDProcessConfigStripArgs                  = "DD_PROCESS_CONFIG_STRIP_PROC_ARGUMENTS"
+	DDSystemProbeNPMEnabledEnvVar             = "DD_SYSTEM_PROBE_NETWORK_ENABLED"
+	DDSystemProbeEnabledEnvVar                = "DD_SYSTEM_PROBE_ENABLED"
+	DDSystemProbeExternal                     = "DD_SYSTEM_PROBE_EXTERNAL"
+	DDSystemProbeServiceMonitoringEnabled     = "DD_SYSTEM_PROBE_SERVICE_MONITORING_ENABLED"
+	DDSystemProbeSocket                       = "DD_SYSPROBE_SOCKET"
+	DDDogstatsdOriginDetection                = "DD_DOGSTATSD_ORIGIN_DETECTION"
+	DDDogstatsdPort                           = "DD_DOGSTATSD_PORT"
+	DDDogstatsdSocket                         = "DD_DOGSTATSD_SOCKET"
+	DDDogstatsdMapperProfiles                 = "DD_DOGSTATSD_MAPPER_PROFILES"
+	DDDogstatsdNonLocalTraffic                = "DD_DOGSTATSD_NON_LOCAL_TRAFFIC"
+	DDComplianceEnabled                       = "DD_COMPLIANCE_CONFIG_ENABLED"
+	DDComplianceCheckInterval                 = "DD_COMPLIANCE_CONFIG_CHECK_INTERVAL"
+	DDHostRootEnvVar                          = "HOST_ROOT"
+	DDEnableOOMKillEnvVar                     = "DD_SYSTEM_PROBE_CONFIG_ENABLE_OOM_KILL"
+	DDEnableTCPQueueLengthEnvVar              = "DD_SYSTEM_PROBE_CONFIG_ENABLE_TCP_QUEUE_LENGTH"
+	DDLeaderElection                          = "DD_LEADER_ELECTION"
+	DDLeaderLeaseName                         = "DD_LEADER_LEASE_NAME"
+	DDClusterAgentKubeServiceName             = "DD_CLUSTER_AGENT_KUBERNETES_SERVICE_NAME"
+	DDHealthPort                              = "DD_HEALTH_PORT"
+	DDLogsEnabled                             = "DD_LOGS_ENABLED"
+	DDLogsConfigContainerCollectAll           = "DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL"
+	DDLogsContainerCollectUsingFiles          = "DD_LOGS_CONFIG_K8S_CONTAINER_USE_FILE"
+	DDLogsConfigOpenFilesLimit                = "DD_LOGS_CONFIG_OPEN_FILES_LIMIT"
+	DDPrometheusScrapeEnabled                 = "DD_PROMETHEUS_SCRAPE_ENABLED"
+	DDPrometheusScrapeServiceEndpoints        = "DD_PROMETHEUS_SCRAPE_SERVICE_ENDPOINTS"
+	DDPrometheusScrapeChecks                  = "DD_PROMETHEUS_SCRAPE_CHECKS"
+	DDRuntimeSecurityConfigEnabled            = "DD_RUNTIME_SECURITY_CONFIG_ENABLED"
+	DDRuntimeSecurityConfigPoliciesDir        = "DD_RUNTIME_SECURITY_CONFIG_POLICIES_DIR"
+	DDRuntimeSecurityConfigSocket             = "DD_RUNTIME_SECURITY_CONFIG_SOCKET"
+	DDRuntimeSecurityConfigSyscallMonitor     = "DD_RUNTIME_SECURITY_CONFIG_SYSCALL_MONITOR_ENABLED"
+	DDRuntimeSecurityConfigRemoteTagger       = "DD_RUNTIME_SECURITY_CONFIG_REMOTE_TAGGER"
+	DDSite                                    = "DD_SITE"
+	DDTags                                    = "DD_TAGS"
+	DockerHost                                = "DOCKER_HOST"
 )
@@ -5,7 +5,10 @@
 
 package common
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // SecretConfig contains a secret name and an included key.
 // +kubebuilder:object:generate=true
@@ -78,6 +81,10 @@ const (
 	SecurityAgentContainerName AgentContainerName = "security-agent"
 	// SystemProbeContainerName is the name of the System Probe container
 	SystemProbeContainerName AgentContainerName = "system-probe"
+	// SeccompSetupContainerName is the name of the init container installing the System Probe seccomp profile
+	SeccompSetupContainerName AgentContainerName = "seccomp-setup"
+	// InitVolumeContainerName is the name of the init container copying configuration files into shared volumes
+	InitVolumeContainerName AgentContainerName = "init-volume"
 
 	// ClusterAgentContainerName is the name of the Cluster Agent container
 	ClusterAgentContainerName AgentContainerName = "cluster-agent"
@@ -85,3 +92,60 @@ const (
 	// ClusterChecksRunnersContainerName is the name of the Agent container in Cluster Checks Runners
 	ClusterChecksRunnersContainerName AgentContainerName = "agent"
 )
+
+// DaemonSetStatus defines the observed state of Agent running as DaemonSet.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type DaemonSetStatus struct {
+	Desired   int32 `json:"desired"`
+	Current   int32 `json:"current"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+	UpToDate  int32 `json:"upToDate"`
+
+	Status      string       `json:"status,omitempty"`
+	State       string       `json:"state,omitempty"`
+	LastUpdate  *metav1.Time `json:"lastUpdate,omitempty"`
+	CurrentHash string       `json:"currentHash,omitempty"`
+
+	// DaemonsetName corresponds to the name of the created DaemonSet.
+	DaemonsetName string `json:"daemonsetName,omitempty"`
+}
+
+// DeploymentStatus type representing a Deployment status.
+// +k8s:openapi-gen=true
+// +kubebuilder:object:generate=true
+type DeploymentStatus struct {
+	// Total number of non-terminated pods targeted by this deployment (their labels match the selector).
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Total number of non-terminated pods targeted by this deployment that have the desired template spec.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// Total number of ready pods targeted by this deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Total number of available pods (ready for at least minReadySeconds) targeted by this deployment.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// Total number of unavailable pods targeted by this deployment. This is the total number of
+	// pods that are still required for the deployment to have 100% available capacity. They may
+	// either be pods that are running but not yet available or pods that still have not been created.
+	// +optional
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	LastUpdate  *metav1.Time `json:"lastUpdate,omitempty"`
+	CurrentHash string       `json:"currentHash,omitempty"`
+
+	// Status corresponds to the Deployment computed status.
+	Status string `json:"status,omitempty"`
+	// State corresponds to the Deployment state.
+	State string `json:"state,omitempty"`
+
+	// DeploymentName corresponds to the name of the Deployment.
+	DeploymentName string `json:"deploymentName,omitempty"`
+}
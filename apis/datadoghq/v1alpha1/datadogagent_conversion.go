@@ -20,7 +20,7 @@ import (
 func (src *DatadogAgent) ConvertTo(dst conversion.Hub) error {
 	ddaV2 := dst.(*v2alpha1.DatadogAgent)
 
-	if err := ConvertTo(src, ddaV2); err != nil {
+	if err := convertToHub(src, ddaV2); err != nil {
 		return fmt.Errorf("unable to convert DatadogAgent %s/%s to version: %v, err: %w", src.Namespace, src.Name, dst.GetObjectKind().GroupVersionKind().Version, err)
 	}
 
@@ -28,15 +28,20 @@ func (src *DatadogAgent) ConvertTo(dst conversion.Hub) error {
 }
 
 // ConvertFrom converts a v2alpha1 (Hub) to v1alpha1 (local)
-// Not implemented
-func (dst *DatadogAgent) ConvertFrom(src conversion.Hub) error { //nolint
-	return fmt.Errorf("convert from v2alpha1 to %s is not implemented", src.GetObjectKind().GroupVersionKind().Version)
+func (dst *DatadogAgent) ConvertFrom(src conversion.Hub) error {
+	ddaV2 := src.(*v2alpha1.DatadogAgent)
+
+	if err := convertFromHub(ddaV2, dst); err != nil {
+		return fmt.Errorf("unable to convert DatadogAgent %s/%s from version: %v, err: %w", ddaV2.Namespace, ddaV2.Name, src.GetObjectKind().GroupVersionKind().Version, err)
+	}
+
+	return nil
 }
 
 // ConvertTo use to convert v1alpha1.DatadogAgent to v2alpha1.DatadogAgent
 func ConvertTo(src *DatadogAgent, dst *v2alpha1.DatadogAgent) error {
 	// Copying ObjectMeta as a whole
-	dst.ObjectMeta = src.ObjectMeta
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
 
 	// Convert spec
 	if err := convertSpec(&src.Spec, dst); err != nil {
@@ -48,6 +53,22 @@ func ConvertTo(src *DatadogAgent, dst *v2alpha1.DatadogAgent) error {
 	return nil
 }
 
+// ConvertFrom use to convert v2alpha1.DatadogAgent to v1alpha1.DatadogAgent
+// The v2alpha1 fields without v1alpha1 equivalent are dropped
+func ConvertFrom(src *v2alpha1.DatadogAgent, dst *DatadogAgent) error {
+	// Copying ObjectMeta as a whole
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// Convert spec
+	if err := convertFromSpec(&src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	// Not converting status, will let the operator generate a new one
+
+	return nil
+}
+
 // Convert the top level structs
 func convertSpec(src *DatadogAgentSpec, dst *v2alpha1.DatadogAgent) error {
 	if src == nil {
@@ -85,6 +106,45 @@ func convertSpec(src *DatadogAgentSpec, dst *v2alpha1.DatadogAgent) error {
 	return nil
 }
 
+// Convert the top level structs from v2alpha1
+func convertFromSpec(src *v2alpha1.DatadogAgentSpec, dst *DatadogAgentSpec) error {
+	if src == nil {
+		return nil
+	}
+
+	if src.Global != nil {
+		if src.Global.Credentials != nil {
+			getV1Credentials(dst).DatadogCredentials = *convertFromCredentials(src.Global.Credentials)
+		}
+
+		if src.Global.ClusterAgentToken != nil {
+			getV1Credentials(dst).Token = *src.Global.ClusterAgentToken
+		}
+
+		if src.Global.ClusterName != nil {
+			dst.ClusterName = *src.Global.ClusterName
+		}
+
+		if src.Global.Site != nil {
+			dst.Site = *src.Global.Site
+		}
+
+		if src.Global.Registry != nil {
+			dst.Registry = src.Global.Registry
+		}
+	}
+
+	// Features are spread between the v1alpha1 features and the components
+	convertFromFeatures(src.Features, &dst.Features)
+
+	// Convert Specs
+	convertFromNodeAgentSpec(src, &dst.Agent)
+	convertFromClusterAgentSpec(src, &dst.ClusterAgent)
+	convertFromCCRSpec(src, &dst.ClusterChecksRunner)
+
+	return nil
+}
+
 // Ad-hoc conversion for major structs
 func convertFeatures(src DatadogFeatures, dst *v2alpha1.DatadogAgent) {
 	dstFeatures := getV2Features(dst)
@@ -143,6 +203,67 @@ func convertFeatures(src DatadogFeatures, dst *v2alpha1.DatadogAgent) {
 	}
 }
 
+// convertFromFeatures only handles the features present in v1alpha1.DatadogFeatures,
+// the others are converted with the component they are configured on in v1alpha1
+func convertFromFeatures(src *v2alpha1.DatadogFeatures, dst *DatadogFeatures) {
+	if src == nil {
+		return
+	}
+
+	if src.OrchestratorExplorer != nil {
+		dst.OrchestratorExplorer = &OrchestratorExplorerConfig{
+			Enabled:   src.OrchestratorExplorer.Enabled,
+			Conf:      convertFromCustomConfig(src.OrchestratorExplorer.Conf),
+			ExtraTags: src.OrchestratorExplorer.ExtraTags,
+		}
+
+		if src.OrchestratorExplorer.ScrubContainers != nil {
+			dst.OrchestratorExplorer.Scrubbing = &Scrubbing{
+				Containers: src.OrchestratorExplorer.ScrubContainers,
+			}
+		}
+
+		if src.OrchestratorExplorer.Endpoint != nil {
+			dst.OrchestratorExplorer.DDUrl = src.OrchestratorExplorer.Endpoint.URL
+		}
+	}
+
+	if src.KubeStateMetricsCore != nil {
+		dst.KubeStateMetricsCore = &KubeStateMetricsCore{
+			Enabled: src.KubeStateMetricsCore.Enabled,
+			Conf:    convertFromCustomConfig(src.KubeStateMetricsCore.Conf),
+		}
+	}
+
+	if src.PrometheusScrape != nil {
+		dst.PrometheusScrape = &PrometheusScrapeConfig{
+			Enabled:           src.PrometheusScrape.Enabled,
+			ServiceEndpoints:  src.PrometheusScrape.EnableServiceEndpoints,
+			AdditionalConfigs: src.PrometheusScrape.AdditionalConfigs,
+		}
+	}
+
+	// The other NPM settings are System Probe settings in v1alpha1
+	if src.NPM != nil {
+		dst.NetworkMonitoring = &NetworkMonitoringConfig{
+			Enabled: src.NPM.Enabled,
+		}
+	}
+
+	if src.LogCollection != nil {
+		dst.LogCollection = &LogCollectionConfig{
+			Enabled:                       src.LogCollection.Enabled,
+			LogsConfigContainerCollectAll: src.LogCollection.ContainerCollectAll,
+			ContainerCollectUsingFiles:    src.LogCollection.ContainerCollectUsingFiles,
+			ContainerLogsPath:             src.LogCollection.ContainerLogsPath,
+			PodLogsPath:                   src.LogCollection.PodLogsPath,
+			ContainerSymlinksPath:         src.LogCollection.ContainerSymlinksPath,
+			TempStoragePath:               src.LogCollection.TempStoragePath,
+			OpenFilesLimit:                src.LogCollection.OpenFilesLimit,
+		}
+	}
+}
+
 // Converting internal structs
 func convertCredentials(src *DatadogCredentials) *v2alpha1.DatadogCredentials {
 	if src == nil {
@@ -203,6 +324,81 @@ func ConvertConfigDirSpec(src *ConfigDirSpec) *v2alpha1.CustomConfig {
 	}
 }
 
+func convertFromCredentials(src *v2alpha1.DatadogCredentials) *DatadogCredentials {
+	if src == nil {
+		return nil
+	}
+
+	creds := &DatadogCredentials{
+		APISecret: src.APISecret,
+		APPSecret: src.AppSecret,
+	}
+
+	if src.APIKey != nil {
+		creds.APIKey = *src.APIKey
+	}
+	if src.AppKey != nil {
+		creds.AppKey = *src.AppKey
+	}
+
+	return creds
+}
+
+func convertFromCustomConfig(src *v2alpha1.CustomConfig) *CustomConfigSpec {
+	if src == nil {
+		return nil
+	}
+
+	dstConfig := &CustomConfigSpec{
+		ConfigData: src.ConfigData,
+	}
+
+	if src.ConfigMap != nil {
+		dstConfig.ConfigMap = &ConfigFileConfigMapSpec{
+			Name: src.ConfigMap.Name,
+		}
+
+		// Only a single file can be referenced in v1alpha1
+		if len(src.ConfigMap.Items) > 0 {
+			dstConfig.ConfigMap.FileKey = src.ConfigMap.Items[0].Key
+		}
+	}
+
+	return dstConfig
+}
+
+// convertFromConfigDir converts v2alpha1.CustomConfig to v1alpha1.ConfigDirSpec,
+// the inlined configuration data cannot be converted
+func convertFromConfigDir(src *v2alpha1.CustomConfig) *ConfigDirSpec {
+	if src == nil {
+		return nil
+	}
+
+	return convertFromConfigMapConfig(src.ConfigMap)
+}
+
+func convertFromConfigMapConfig(src *commonv1.ConfigMapConfig) *ConfigDirSpec {
+	if src == nil {
+		return nil
+	}
+
+	return &ConfigDirSpec{
+		ConfigMapName: src.Name,
+		Items:         src.Items,
+	}
+}
+
+func convertFromRbac(src *v2alpha1.DatadogAgentComponentOverride) *RbacConfig {
+	if src.CreateRbac == nil && src.ServiceAccountName == nil {
+		return nil
+	}
+
+	return &RbacConfig{
+		Create:             src.CreateRbac,
+		ServiceAccountName: src.ServiceAccountName,
+	}
+}
+
 // Accessors
 func getV2GlobalConfig(dst *v2alpha1.DatadogAgent) *v2alpha1.GlobalConfig {
 	if dst.Spec.Global == nil {
@@ -249,6 +445,70 @@ func getV2Container(comp *v2alpha1.DatadogAgentComponentOverride, containerName
 	return cont
 }
 
+func getV1Credentials(dst *DatadogAgentSpec) *AgentCredentials {
+	if dst.Credentials == nil {
+		dst.Credentials = &AgentCredentials{}
+	}
+
+	return dst.Credentials
+}
+
+func getV1NodeAgentConfig(dst *DatadogAgentSpecAgentSpec) *NodeAgentConfig {
+	if dst.Config == nil {
+		dst.Config = &NodeAgentConfig{}
+	}
+
+	return dst.Config
+}
+
+func getV1APMSpec(dst *DatadogAgentSpecAgentSpec) *APMSpec {
+	if dst.Apm == nil {
+		dst.Apm = &APMSpec{}
+	}
+
+	return dst.Apm
+}
+
+func getV1ProcessSpec(dst *DatadogAgentSpecAgentSpec) *ProcessSpec {
+	if dst.Process == nil {
+		dst.Process = &ProcessSpec{}
+	}
+
+	return dst.Process
+}
+
+func getV1SystemProbeSpec(dst *DatadogAgentSpecAgentSpec) *SystemProbeSpec {
+	if dst.SystemProbe == nil {
+		dst.SystemProbe = &SystemProbeSpec{}
+	}
+
+	return dst.SystemProbe
+}
+
+func getV1SecuritySpec(dst *DatadogAgentSpecAgentSpec) *SecuritySpec {
+	if dst.Security == nil {
+		dst.Security = &SecuritySpec{}
+	}
+
+	return dst.Security
+}
+
+func getV1ClusterAgentConfig(dst *DatadogAgentSpecClusterAgentSpec) *ClusterAgentConfig {
+	if dst.Config == nil {
+		dst.Config = &ClusterAgentConfig{}
+	}
+
+	return dst.Config
+}
+
+func getV1CCRConfig(dst *DatadogAgentSpecClusterChecksRunnerSpec) *ClusterChecksRunnerConfig {
+	if dst.Config == nil {
+		dst.Config = &ClusterChecksRunnerConfig{}
+	}
+
+	return dst.Config
+}
+
 // Utils
 func setBooleanPtrOR(src *bool, dst **bool) {
 	if src == nil {
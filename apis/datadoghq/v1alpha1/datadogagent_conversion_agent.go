@@ -415,7 +415,7 @@ func convertSecurityAgentSpec(src *SecuritySpec, dst *v2alpha1.DatadogAgent) {
 
 	features.CSPM.Enabled = src.Compliance.Enabled
 	features.CSPM.CheckInterval = src.Compliance.CheckInterval
-	if features.CSPM.CustomBenchmarks != nil {
+	if src.Compliance.ConfigDir != nil {
 		features.CSPM.CustomBenchmarks = &commonv1.ConfigMapConfig{
 			Name:  src.Compliance.ConfigDir.ConfigMapName,
 			Items: src.Compliance.ConfigDir.Items,
@@ -426,7 +426,7 @@ func convertSecurityAgentSpec(src *SecuritySpec, dst *v2alpha1.DatadogAgent) {
 	if src.Runtime.SyscallMonitor != nil {
 		features.CWS.SyscallMonitorEnabled = src.Runtime.SyscallMonitor.Enabled
 	}
-	if features.CWS.CustomPolicies != nil {
+	if src.Runtime.PoliciesDir != nil {
 		features.CWS.CustomPolicies = &commonv1.ConfigMapConfig{
 			Name:  src.Runtime.PoliciesDir.ConfigMapName,
 			Items: src.Runtime.PoliciesDir.Items,
@@ -453,3 +453,403 @@ func convertSecurityAgentSpec(src *SecuritySpec, dst *v2alpha1.DatadogAgent) {
 		getV2Container(getV2TemplateOverride(&dst.Spec, v2alpha1.NodeAgentComponentName), commonv1.SecurityAgentContainerName).Args = src.Args
 	}
 }
+
+// convertFromNodeAgentSpec converts the node Agent override, as well as the global settings and features configured on the node Agent in v1alpha1
+func convertFromNodeAgentSpec(src *v2alpha1.DatadogAgentSpec, dst *DatadogAgentSpecAgentSpec) {
+	if src.Global != nil {
+		if src.Global.Endpoint != nil && src.Global.Endpoint.URL != nil {
+			getV1NodeAgentConfig(dst).DDUrl = src.Global.Endpoint.URL
+		}
+
+		// Overridden by the core Agent container log level
+		if src.Global.LogLevel != nil {
+			getV1NodeAgentConfig(dst).LogLevel = src.Global.LogLevel
+		}
+
+		if src.Global.Tags != nil {
+			getV1NodeAgentConfig(dst).Tags = src.Global.Tags
+		}
+
+		if src.Global.PodLabelsAsTags != nil {
+			getV1NodeAgentConfig(dst).PodLabelsAsTags = src.Global.PodLabelsAsTags
+		}
+
+		if src.Global.PodAnnotationsAsTags != nil {
+			getV1NodeAgentConfig(dst).PodAnnotationsAsTags = src.Global.PodAnnotationsAsTags
+		}
+
+		if src.Global.CriSocketPath != nil || src.Global.DockerSocketPath != nil {
+			getV1NodeAgentConfig(dst).CriSocket = &CRISocketConfig{
+				CriSocketPath:    src.Global.CriSocketPath,
+				DockerSocketPath: src.Global.DockerSocketPath,
+			}
+		}
+
+		if src.Global.Kubelet != nil {
+			getV1NodeAgentConfig(dst).Kubelet = src.Global.Kubelet
+		}
+
+		if src.Global.NetworkPolicy != nil {
+			dst.NetworkPolicy = &NetworkPolicySpec{
+				Create:               src.Global.NetworkPolicy.Create,
+				Flavor:               NetworkPolicyFlavor(src.Global.NetworkPolicy.Flavor),
+				DNSSelectorEndpoints: src.Global.NetworkPolicy.DNSSelectorEndpoints,
+			}
+		}
+
+		if src.Global.LocalService != nil {
+			dst.LocalService = &LocalService{
+				ForceLocalServiceEnable: src.Global.LocalService.ForceEnableLocalService,
+			}
+
+			if src.Global.LocalService.NameOverride != nil {
+				dst.LocalService.OverrideName = *src.Global.LocalService.NameOverride
+			}
+		}
+	}
+
+	if features := src.Features; features != nil {
+		if features.EventCollection != nil && features.EventCollection.CollectKubernetesEvents != nil {
+			getV1NodeAgentConfig(dst).CollectEvents = features.EventCollection.CollectKubernetesEvents
+		}
+
+		if features.Dogstatsd != nil {
+			dogstatsd := &DogstatsdConfig{
+				DogstatsdOriginDetection: features.Dogstatsd.OriginDetectionEnabled,
+				MapperProfiles:           convertFromCustomConfig(features.Dogstatsd.MapperProfiles),
+			}
+
+			if features.Dogstatsd.UnixDomainSocketConfig != nil {
+				dogstatsd.UnixDomainSocket = &DSDUnixDomainSocketSpec{
+					Enabled:      features.Dogstatsd.UnixDomainSocketConfig.Enabled,
+					HostFilepath: features.Dogstatsd.UnixDomainSocketConfig.Path,
+				}
+			}
+
+			if features.Dogstatsd.HostPortConfig != nil && utils.BoolValue(features.Dogstatsd.HostPortConfig.Enabled) {
+				getV1NodeAgentConfig(dst).HostPort = features.Dogstatsd.HostPortConfig.Port
+			}
+
+			getV1NodeAgentConfig(dst).Dogstatsd = dogstatsd
+		}
+
+		convertFromAPMFeature(features.APM, dst)
+		convertFromProcessFeatures(features, dst)
+		convertFromSystemProbeFeatures(features, dst)
+		convertFromSecurityFeatures(features, dst)
+	}
+
+	override := src.Override[v2alpha1.NodeAgentComponentName]
+	if override == nil {
+		return
+	}
+
+	if utils.BoolValue(override.Disabled) {
+		dst.Enabled = utils.NewBoolPointer(false)
+	}
+
+	if override.Image != nil {
+		dst.Image = override.Image
+	}
+
+	if override.Name != nil {
+		dst.DaemonsetName = *override.Name
+	}
+
+	if override.SecurityContext != nil {
+		getV1NodeAgentConfig(dst).SecurityContext = override.SecurityContext
+	}
+
+	if override.ExtraConfd != nil {
+		getV1NodeAgentConfig(dst).Confd = convertFromConfigDir(override.ExtraConfd)
+	}
+
+	if override.ExtraChecksd != nil {
+		getV1NodeAgentConfig(dst).Checksd = convertFromConfigDir(override.ExtraChecksd)
+	}
+
+	if override.Volumes != nil {
+		getV1NodeAgentConfig(dst).Volumes = override.Volumes
+	}
+
+	if override.Tolerations != nil {
+		getV1NodeAgentConfig(dst).Tolerations = override.Tolerations
+	}
+
+	if rbac := convertFromRbac(override); rbac != nil {
+		dst.Rbac = rbac
+	}
+
+	if override.Annotations != nil {
+		dst.AdditionalAnnotations = override.Annotations
+	}
+
+	if override.Labels != nil {
+		dst.AdditionalLabels = override.Labels
+	}
+
+	if override.PriorityClassName != nil {
+		dst.PriorityClassName = *override.PriorityClassName
+	}
+
+	if override.Env != nil {
+		dst.Env = override.Env
+	}
+
+	if override.HostNetwork != nil {
+		dst.HostNetwork = *override.HostNetwork
+	}
+
+	if override.HostPID != nil {
+		dst.HostPID = *override.HostPID
+	}
+
+	if config, found := override.CustomConfigurations[v2alpha1.AgentGeneralConfigFile]; found {
+		dst.CustomConfig = convertFromCustomConfig(&config)
+	}
+
+	if config, found := override.CustomConfigurations[v2alpha1.SystemProbeConfigFile]; found {
+		getV1SystemProbeSpec(dst).CustomConfig = convertFromCustomConfig(&config)
+	}
+
+	if override.Affinity != nil {
+		dst.Affinity = override.Affinity
+	}
+
+	if override.SecCompRootPath != nil {
+		getV1SystemProbeSpec(dst).SecCompRootPath = *override.SecCompRootPath
+	}
+
+	if override.SecCompCustomProfile != nil && override.SecCompCustomProfile.ConfigMap != nil {
+		getV1SystemProbeSpec(dst).SecCompCustomProfileConfigMap = override.SecCompCustomProfile.ConfigMap.Name
+	}
+
+	if override.SecCompProfileName != nil {
+		getV1SystemProbeSpec(dst).SecCompProfileName = *override.SecCompProfileName
+	}
+
+	if cont := override.Containers[commonv1.CoreAgentContainerName]; cont != nil {
+		config := getV1NodeAgentConfig(dst)
+
+		if cont.LogLevel != nil {
+			config.LogLevel = cont.LogLevel
+		}
+
+		if cont.Env != nil {
+			config.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			config.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.Resources != nil {
+			config.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			config.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			config.Args = cont.Args
+		}
+
+		if cont.LivenessProbe != nil {
+			config.LivenessProbe = cont.LivenessProbe
+		}
+
+		if cont.ReadinessProbe != nil {
+			config.ReadinessProbe = cont.ReadinessProbe
+		}
+
+		if cont.HealthPort != nil {
+			config.HealthPort = cont.HealthPort
+		}
+	}
+
+	if cont := override.Containers[commonv1.TraceAgentContainerName]; cont != nil {
+		apm := getV1APMSpec(dst)
+
+		if cont.Env != nil {
+			apm.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			apm.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.Resources != nil {
+			apm.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			apm.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			apm.Args = cont.Args
+		}
+
+		if cont.LivenessProbe != nil {
+			apm.LivenessProbe = cont.LivenessProbe
+		}
+	}
+
+	if cont := override.Containers[commonv1.ProcessAgentContainerName]; cont != nil {
+		process := getV1ProcessSpec(dst)
+
+		if cont.Env != nil {
+			process.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			process.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.Resources != nil {
+			process.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			process.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			process.Args = cont.Args
+		}
+	}
+
+	if cont := override.Containers[commonv1.SystemProbeContainerName]; cont != nil {
+		systemProbe := getV1SystemProbeSpec(dst)
+
+		if cont.Env != nil {
+			systemProbe.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			systemProbe.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.Resources != nil {
+			systemProbe.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			systemProbe.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			systemProbe.Args = cont.Args
+		}
+
+		if cont.AppArmorProfileName != nil {
+			systemProbe.AppArmorProfileName = *cont.AppArmorProfileName
+		}
+
+		if cont.SecurityContext != nil {
+			systemProbe.SecurityContext = cont.SecurityContext
+		}
+	}
+
+	if cont := override.Containers[commonv1.SecurityAgentContainerName]; cont != nil {
+		security := getV1SecuritySpec(dst)
+
+		if cont.Env != nil {
+			security.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			security.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.Resources != nil {
+			security.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			security.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			security.Args = cont.Args
+		}
+	}
+}
+
+func convertFromAPMFeature(src *v2alpha1.APMFeatureConfig, dst *DatadogAgentSpecAgentSpec) {
+	if src == nil {
+		return
+	}
+
+	apm := getV1APMSpec(dst)
+	apm.Enabled = src.Enabled
+
+	if src.UnixDomainSocketConfig != nil {
+		apm.UnixDomainSocket = &APMUnixDomainSocketSpec{
+			Enabled:      src.UnixDomainSocketConfig.Enabled,
+			HostFilepath: src.UnixDomainSocketConfig.Path,
+		}
+	}
+
+	if src.HostPortConfig != nil && utils.BoolValue(src.HostPortConfig.Enabled) {
+		apm.HostPort = src.HostPortConfig.Port
+	}
+}
+
+func convertFromProcessFeatures(src *v2alpha1.DatadogFeatures, dst *DatadogAgentSpecAgentSpec) {
+	// ScrubProcessArguments and StripProcessArguments have no v1alpha1 equivalent
+	if src.LiveProcessCollection != nil {
+		getV1ProcessSpec(dst).ProcessCollectionEnabled = src.LiveProcessCollection.Enabled
+	}
+
+	if src.LiveContainerCollection != nil {
+		getV1ProcessSpec(dst).Enabled = src.LiveContainerCollection.Enabled
+	}
+}
+
+func convertFromSystemProbeFeatures(src *v2alpha1.DatadogFeatures, dst *DatadogAgentSpecAgentSpec) {
+	// NPM enablement is converted to the NetworkMonitoring feature
+	if src.NPM != nil {
+		if src.NPM.EnableConntrack != nil {
+			getV1SystemProbeSpec(dst).ConntrackEnabled = src.NPM.EnableConntrack
+		}
+
+		if src.NPM.CollectDNSStats != nil {
+			getV1SystemProbeSpec(dst).CollectDNSStats = src.NPM.CollectDNSStats
+		}
+	}
+
+	if src.TCPQueueLength != nil && src.TCPQueueLength.Enabled != nil {
+		getV1SystemProbeSpec(dst).EnableTCPQueueLength = src.TCPQueueLength.Enabled
+	}
+
+	if src.OOMKill != nil && src.OOMKill.Enabled != nil {
+		getV1SystemProbeSpec(dst).EnableOOMKill = src.OOMKill.Enabled
+	}
+}
+
+func convertFromSecurityFeatures(src *v2alpha1.DatadogFeatures, dst *DatadogAgentSpecAgentSpec) {
+	if src.CSPM != nil {
+		getV1SecuritySpec(dst).Compliance = ComplianceSpec{
+			Enabled:       src.CSPM.Enabled,
+			CheckInterval: src.CSPM.CheckInterval,
+			ConfigDir:     convertFromConfigMapConfig(src.CSPM.CustomBenchmarks),
+		}
+	}
+
+	if src.CWS != nil {
+		runtime := RuntimeSecuritySpec{
+			Enabled:     src.CWS.Enabled,
+			PoliciesDir: convertFromConfigMapConfig(src.CWS.CustomPolicies),
+		}
+
+		if src.CWS.SyscallMonitorEnabled != nil {
+			runtime.SyscallMonitor = &SyscallMonitorSpec{
+				Enabled: src.CWS.SyscallMonitorEnabled,
+			}
+		}
+
+		getV1SecuritySpec(dst).Runtime = runtime
+	}
+}
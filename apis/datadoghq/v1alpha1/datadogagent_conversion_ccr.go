@@ -8,6 +8,7 @@ package v1alpha1
 import (
 	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/apis/utils"
 )
 
 func convertCCRSpec(src *DatadogAgentSpecClusterChecksRunnerSpec, dst *v2alpha1.DatadogAgent) {
@@ -114,3 +115,111 @@ func convertCCRSpec(src *DatadogAgentSpecClusterChecksRunnerSpec, dst *v2alpha1.
 
 	// TODO: NetworkPolicy field for CLC? In v2 we only have a single global NetworkPolicy configuration
 }
+
+// convertFromCCRSpec converts the Cluster Checks Runner override and its enablement
+func convertFromCCRSpec(src *v2alpha1.DatadogAgentSpec, dst *DatadogAgentSpecClusterChecksRunnerSpec) {
+	if src.Features != nil && src.Features.ClusterChecks != nil && src.Features.ClusterChecks.UseClusterChecksRunners != nil {
+		dst.Enabled = src.Features.ClusterChecks.UseClusterChecksRunners
+	}
+
+	override := src.Override[v2alpha1.ClusterChecksRunnerComponentName]
+	if override == nil {
+		return
+	}
+
+	if utils.BoolValue(override.Disabled) {
+		dst.Enabled = utils.NewBoolPointer(false)
+	}
+
+	if override.Image != nil {
+		dst.Image = override.Image
+	}
+
+	if override.Name != nil {
+		dst.DeploymentName = *override.Name
+	}
+
+	if override.SecurityContext != nil {
+		getV1CCRConfig(dst).SecurityContext = override.SecurityContext
+	}
+
+	if override.Volumes != nil {
+		getV1CCRConfig(dst).Volumes = override.Volumes
+	}
+
+	if config, found := override.CustomConfigurations[v2alpha1.AgentGeneralConfigFile]; found {
+		dst.CustomConfig = convertFromCustomConfig(&config)
+	}
+
+	if rbac := convertFromRbac(override); rbac != nil {
+		dst.Rbac = rbac
+	}
+
+	if override.Replicas != nil {
+		dst.Replicas = override.Replicas
+	}
+
+	if override.Annotations != nil {
+		dst.AdditionalAnnotations = override.Annotations
+	}
+
+	if override.Labels != nil {
+		dst.AdditionalLabels = override.Labels
+	}
+
+	if override.PriorityClassName != nil {
+		dst.PriorityClassName = *override.PriorityClassName
+	}
+
+	if override.Affinity != nil {
+		dst.Affinity = override.Affinity
+	}
+
+	if override.Tolerations != nil {
+		dst.Tolerations = override.Tolerations
+	}
+
+	if override.NodeSelector != nil {
+		dst.NodeSelector = override.NodeSelector
+	}
+
+	if cont := override.Containers[commonv1.ClusterChecksRunnersContainerName]; cont != nil {
+		config := getV1CCRConfig(dst)
+
+		if cont.LogLevel != nil {
+			config.LogLevel = cont.LogLevel
+		}
+
+		if cont.Resources != nil {
+			config.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			config.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			config.Args = cont.Args
+		}
+
+		if cont.Env != nil {
+			config.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			config.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.LivenessProbe != nil {
+			config.LivenessProbe = cont.LivenessProbe
+		}
+
+		if cont.ReadinessProbe != nil {
+			config.ReadinessProbe = cont.ReadinessProbe
+		}
+
+		if cont.HealthPort != nil {
+			config.HealthPort = cont.HealthPort
+		}
+	}
+}
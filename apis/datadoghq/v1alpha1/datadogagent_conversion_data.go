@@ -0,0 +1,302 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+)
+
+const (
+	// V2alpha1SpecAnnotation is set on a v1alpha1 DatadogAgent converted from a v2alpha1 DatadogAgent
+	// whose spec cannot be fully expressed in v1alpha1. It contains the v2alpha1 spec.
+	V2alpha1SpecAnnotation = "datadoghq.com/v2alpha1-spec"
+	// V1alpha1SpecAnnotation is set on a v2alpha1 DatadogAgent converted from a v1alpha1 DatadogAgent
+	// whose spec cannot be fully expressed in v2alpha1. It contains the v1alpha1 spec.
+	V1alpha1SpecAnnotation = "datadoghq.com/v1alpha1-spec"
+)
+
+// conversionData is the content of the spec annotations
+type conversionData struct {
+	// Spec is the spec of the object before the conversion
+	Spec json.RawMessage `json:"spec"`
+	// Hash is the hash of the converted spec, it is used to know if the converted object was updated since the conversion
+	Hash string `json:"hash"`
+}
+
+// convertToHub converts a v1alpha1 DatadogAgent to v2alpha1, using the spec annotations to avoid losing data:
+// * the v2alpha1 fields without v1alpha1 equivalent are restored from the V2alpha1SpecAnnotation
+// * the v1alpha1 spec is stored in the V1alpha1SpecAnnotation when it cannot be fully converted
+func convertToHub(src *DatadogAgent, dst *v2alpha1.DatadogAgent) error {
+	if err := ConvertTo(src, dst); err != nil {
+		return err
+	}
+
+	data, err := popConversionData(&dst.ObjectMeta, V2alpha1SpecAnnotation)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		if err = restoreV2alpha1Spec(data, src, dst); err != nil {
+			return err
+		}
+	}
+
+	roundTrip := &DatadogAgentSpec{}
+	if err = convertFromSpec(&dst.Spec, roundTrip); err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(&src.Spec, roundTrip) {
+		return nil
+	}
+
+	return setConversionData(&dst.ObjectMeta, V1alpha1SpecAnnotation, &src.Spec, &dst.Spec)
+}
+
+// convertFromHub converts a v2alpha1 DatadogAgent to v1alpha1, using the spec annotations to avoid losing data:
+// * the v1alpha1 fields without v2alpha1 equivalent are restored from the V1alpha1SpecAnnotation
+// * the v2alpha1 spec is stored in the V2alpha1SpecAnnotation when it cannot be fully converted
+func convertFromHub(src *v2alpha1.DatadogAgent, dst *DatadogAgent) error {
+	if err := ConvertFrom(src, dst); err != nil {
+		return err
+	}
+
+	data, err := popConversionData(&dst.ObjectMeta, V1alpha1SpecAnnotation)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		if err = restoreV1alpha1Spec(data, src, dst); err != nil {
+			return err
+		}
+	}
+
+	roundTrip := &v2alpha1.DatadogAgent{}
+	if err = convertSpec(&dst.Spec, roundTrip); err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(&src.Spec, &roundTrip.Spec) {
+		return nil
+	}
+
+	return setConversionData(&dst.ObjectMeta, V2alpha1SpecAnnotation, &src.Spec, &dst.Spec)
+}
+
+// restoreV2alpha1Spec restores the v2alpha1 spec stored on a v1alpha1 DatadogAgent.
+// If the v1alpha1 spec was updated since the conversion, only the fields lost by the conversion are restored.
+func restoreV2alpha1Spec(data *conversionData, src *DatadogAgent, dst *v2alpha1.DatadogAgent) error {
+	stored := v2alpha1.DatadogAgentSpec{}
+	if err := json.Unmarshal(data.Spec, &stored); err != nil {
+		return fmt.Errorf("unable to parse the %s annotation: %w", V2alpha1SpecAnnotation, err)
+	}
+
+	updated, err := isUpdatedSinceConversion(data, &src.Spec)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		dst.Spec = stored
+		return nil
+	}
+
+	// The fields lost by the conversion are the ones that differ after a round trip
+	storedV1 := &DatadogAgentSpec{}
+	if err = convertFromSpec(&stored, storedV1); err != nil {
+		return err
+	}
+	roundTrip := &v2alpha1.DatadogAgent{}
+	if err = convertSpec(storedV1, roundTrip); err != nil {
+		return err
+	}
+
+	merged := v2alpha1.DatadogAgentSpec{}
+	if err = mergeLostFields(&roundTrip.Spec, data.Spec, &dst.Spec, &merged); err != nil {
+		return err
+	}
+	dst.Spec = merged
+
+	return nil
+}
+
+// restoreV1alpha1Spec restores the v1alpha1 spec stored on a v2alpha1 DatadogAgent.
+// If the v2alpha1 spec was updated since the conversion, only the fields lost by the conversion are restored.
+func restoreV1alpha1Spec(data *conversionData, src *v2alpha1.DatadogAgent, dst *DatadogAgent) error {
+	stored := DatadogAgentSpec{}
+	if err := json.Unmarshal(data.Spec, &stored); err != nil {
+		return fmt.Errorf("unable to parse the %s annotation: %w", V1alpha1SpecAnnotation, err)
+	}
+
+	updated, err := isUpdatedSinceConversion(data, &src.Spec)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		dst.Spec = stored
+		return nil
+	}
+
+	// The fields lost by the conversion are the ones that differ after a round trip
+	storedV2 := &v2alpha1.DatadogAgent{}
+	if err = convertSpec(&stored, storedV2); err != nil {
+		return err
+	}
+	roundTrip := &DatadogAgentSpec{}
+	if err = convertFromSpec(&storedV2.Spec, roundTrip); err != nil {
+		return err
+	}
+
+	merged := DatadogAgentSpec{}
+	if err = mergeLostFields(roundTrip, data.Spec, &dst.Spec, &merged); err != nil {
+		return err
+	}
+	dst.Spec = merged
+
+	return nil
+}
+
+// mergeLostFields sets in merged the converted spec, plus the values of the original spec that were lost in the round trip.
+// A value is only restored if the converted spec still has the round trip value, so that updates are not reverted.
+func mergeLostFields(roundTrip interface{}, original json.RawMessage, converted, merged interface{}) error {
+	var roundTripTree, originalTree, convertedTree interface{}
+	if err := toJSONTree(roundTrip, &roundTripTree); err != nil {
+		return err
+	}
+	if err := decodeJSONTree(original, &originalTree); err != nil {
+		return err
+	}
+	if err := toJSONTree(converted, &convertedTree); err != nil {
+		return err
+	}
+
+	mergedJSON, err := json.Marshal(mergeJSONTrees(roundTripTree, originalTree, convertedTree))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(mergedJSON, merged)
+}
+
+// mergeJSONTrees is a three-way merge: it returns current, except for the values that are unchanged compared to base,
+// which are taken from original. Objects are merged key by key while any other value is replaced as a whole.
+func mergeJSONTrees(base, original, current interface{}) interface{} {
+	originalObject, isOriginalObject := original.(map[string]interface{})
+	currentObject, isCurrentObject := current.(map[string]interface{})
+	if isOriginalObject && isCurrentObject {
+		baseObject, _ := base.(map[string]interface{})
+		merged := make(map[string]interface{}, len(currentObject))
+		for key := range originalObject {
+			if value := mergeJSONTrees(baseObject[key], originalObject[key], currentObject[key]); value != nil {
+				merged[key] = value
+			}
+		}
+		for key := range currentObject {
+			if _, found := originalObject[key]; found {
+				continue
+			}
+			if value := mergeJSONTrees(baseObject[key], nil, currentObject[key]); value != nil {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+
+	if reflect.DeepEqual(base, current) {
+		return original
+	}
+
+	return current
+}
+
+func toJSONTree(obj interface{}, tree *interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return decodeJSONTree(data, tree)
+}
+
+// decodeJSONTree keeps the numbers as json.Number to not lose the precision of the int64 values
+func decodeJSONTree(data []byte, tree *interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(tree)
+}
+
+func isUpdatedSinceConversion(data *conversionData, convertedSpec interface{}) (bool, error) {
+	hash, err := hashSpec(convertedSpec)
+	if err != nil {
+		return false, err
+	}
+
+	return hash != data.Hash, nil
+}
+
+// popConversionData returns the content of the annotation and removes it from the object
+func popConversionData(meta *metav1.ObjectMeta, annotation string) (*conversionData, error) {
+	value, found := meta.Annotations[annotation]
+	if !found {
+		return nil, nil
+	}
+
+	delete(meta.Annotations, annotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	data := &conversionData{}
+	if err := json.Unmarshal([]byte(value), data); err != nil {
+		return nil, fmt.Errorf("unable to parse the %s annotation: %w", annotation, err)
+	}
+
+	return data, nil
+}
+
+// setConversionData stores the spec in the annotation, along with the hash of the spec it was converted to
+func setConversionData(meta *metav1.ObjectMeta, annotation string, spec, convertedSpec interface{}) error {
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashSpec(convertedSpec)
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(&conversionData{Spec: specJSON, Hash: hash})
+	if err != nil {
+		return err
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[annotation] = string(value)
+
+	return nil
+}
+
+func hashSpec(spec interface{}) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	/* #nosec */
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:]), nil
+}
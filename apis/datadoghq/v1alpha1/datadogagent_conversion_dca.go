@@ -8,6 +8,7 @@ package v1alpha1
 import (
 	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/apis/utils"
 )
 
 func convertClusterAgentSpec(src *DatadogAgentSpecClusterAgentSpec, dst *v2alpha1.DatadogAgent) {
@@ -184,3 +185,143 @@ func convertClusterAgentAdmissionController(src *AdmissionControllerConfig, dst
 	features.AdmissionController.ServiceName = src.ServiceName
 	features.AdmissionController.AgentCommunicationMode = src.AgentCommunicationMode
 }
+
+// convertFromClusterAgentSpec converts the Cluster Agent override, as well as the features configured on the Cluster Agent in v1alpha1
+func convertFromClusterAgentSpec(src *v2alpha1.DatadogAgentSpec, dst *DatadogAgentSpecClusterAgentSpec) {
+	if features := src.Features; features != nil {
+		if features.ExternalMetricsServer != nil {
+			getV1ClusterAgentConfig(dst).ExternalMetrics = convertFromExternalMetricsServer(features.ExternalMetricsServer)
+		}
+
+		if features.AdmissionController != nil {
+			getV1ClusterAgentConfig(dst).AdmissionController = &AdmissionControllerConfig{
+				Enabled:                features.AdmissionController.Enabled,
+				MutateUnlabelled:       features.AdmissionController.MutateUnlabelled,
+				ServiceName:            features.AdmissionController.ServiceName,
+				AgentCommunicationMode: features.AdmissionController.AgentCommunicationMode,
+			}
+		}
+
+		if features.ClusterChecks != nil && features.ClusterChecks.Enabled != nil {
+			getV1ClusterAgentConfig(dst).ClusterChecksEnabled = features.ClusterChecks.Enabled
+		}
+
+		if features.EventCollection != nil && features.EventCollection.CollectKubernetesEvents != nil {
+			getV1ClusterAgentConfig(dst).CollectEvents = features.EventCollection.CollectKubernetesEvents
+		}
+	}
+
+	override := src.Override[v2alpha1.ClusterAgentComponentName]
+	if override == nil {
+		return
+	}
+
+	if utils.BoolValue(override.Disabled) {
+		dst.Enabled = utils.NewBoolPointer(false)
+	}
+
+	if override.Image != nil {
+		dst.Image = override.Image
+	}
+
+	if override.Name != nil {
+		dst.DeploymentName = *override.Name
+	}
+
+	if override.SecurityContext != nil {
+		getV1ClusterAgentConfig(dst).SecurityContext = override.SecurityContext
+	}
+
+	if override.ExtraConfd != nil {
+		getV1ClusterAgentConfig(dst).Confd = convertFromConfigDir(override.ExtraConfd)
+	}
+
+	if override.Volumes != nil {
+		getV1ClusterAgentConfig(dst).Volumes = override.Volumes
+	}
+
+	if config, found := override.CustomConfigurations[v2alpha1.AgentGeneralConfigFile]; found {
+		dst.CustomConfig = convertFromCustomConfig(&config)
+	}
+
+	if rbac := convertFromRbac(override); rbac != nil {
+		dst.Rbac = rbac
+	}
+
+	if override.Replicas != nil {
+		dst.Replicas = override.Replicas
+	}
+
+	if override.Annotations != nil {
+		dst.AdditionalAnnotations = override.Annotations
+	}
+
+	if override.Labels != nil {
+		dst.AdditionalLabels = override.Labels
+	}
+
+	if override.PriorityClassName != nil {
+		dst.PriorityClassName = *override.PriorityClassName
+	}
+
+	if override.Affinity != nil {
+		dst.Affinity = override.Affinity
+	}
+
+	if override.Tolerations != nil {
+		dst.Tolerations = override.Tolerations
+	}
+
+	if override.NodeSelector != nil {
+		dst.NodeSelector = override.NodeSelector
+	}
+
+	if cont := override.Containers[commonv1.ClusterAgentContainerName]; cont != nil {
+		config := getV1ClusterAgentConfig(dst)
+
+		if cont.LogLevel != nil {
+			config.LogLevel = cont.LogLevel
+		}
+
+		if cont.Resources != nil {
+			config.Resources = cont.Resources
+		}
+
+		if cont.Command != nil {
+			config.Command = cont.Command
+		}
+
+		if cont.Args != nil {
+			config.Args = cont.Args
+		}
+
+		if cont.Env != nil {
+			config.Env = cont.Env
+		}
+
+		if cont.VolumeMounts != nil {
+			config.VolumeMounts = cont.VolumeMounts
+		}
+
+		if cont.HealthPort != nil {
+			config.HealthPort = cont.HealthPort
+		}
+	}
+}
+
+func convertFromExternalMetricsServer(src *v2alpha1.ExternalMetricsServerFeatureConfig) *ExternalMetricsConfig {
+	dst := &ExternalMetricsConfig{
+		Enabled:       src.Enabled,
+		Port:          src.Port,
+		WpaController: utils.BoolValue(src.WPAController),
+		// Only set to false when explicitly disabled, to mirror the conversion to v2alpha1
+		UseDatadogMetrics: src.UseDatadogMetrics == nil || *src.UseDatadogMetrics,
+	}
+
+	if src.Endpoint != nil {
+		dst.Endpoint = src.Endpoint.URL
+		dst.Credentials = convertFromCredentials(src.Endpoint.Credentials)
+	}
+
+	return dst
+}
@@ -7,19 +7,33 @@ package v1alpha1
 
 import (
 	"io/ioutil"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/apis/utils"
 	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/kubernetes/scheme"
 )
 
+const fuzzIterations = 200
+
 func TestDatadogAgentConversion(t *testing.T) {
 	sch := runtime.NewScheme()
 	_ = scheme.AddToScheme(sch)
@@ -73,6 +87,187 @@ func TestDatadogAgentConversion(t *testing.T) {
 	}
 }
 
+func TestDatadogAgentConversionRoundTrip(t *testing.T) {
+	f := newDatadogAgentFuzzer()
+
+	t.Run("v1alpha1 to v2alpha1 to v1alpha1", func(t *testing.T) {
+		for i := 0; i < fuzzIterations; i++ {
+			original := &DatadogAgent{}
+			f.Fuzz(original)
+			jsonRoundTrip(t, original)
+
+			hub := &v2alpha1.DatadogAgent{}
+			require.NoError(t, original.DeepCopy().ConvertTo(hub))
+			jsonRoundTrip(t, hub)
+
+			got := &DatadogAgent{}
+			require.NoError(t, got.ConvertFrom(hub))
+
+			if !apiequality.Semantic.DeepEqual(original.ObjectMeta, got.ObjectMeta) || !apiequality.Semantic.DeepEqual(original.Spec, got.Spec) {
+				t.Fatalf("round trip mismatch (-original +got):\n%s", cmp.Diff(original, got))
+			}
+		}
+	})
+
+	t.Run("v2alpha1 to v1alpha1 to v2alpha1", func(t *testing.T) {
+		for i := 0; i < fuzzIterations; i++ {
+			original := &v2alpha1.DatadogAgent{}
+			f.Fuzz(original)
+			jsonRoundTrip(t, original)
+
+			spoke := &DatadogAgent{}
+			require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+			jsonRoundTrip(t, spoke)
+
+			got := &v2alpha1.DatadogAgent{}
+			require.NoError(t, spoke.ConvertTo(got))
+
+			if !apiequality.Semantic.DeepEqual(original.ObjectMeta, got.ObjectMeta) || !apiequality.Semantic.DeepEqual(original.Spec, got.Spec) {
+				t.Fatalf("round trip mismatch (-original +got):\n%s", cmp.Diff(original, got))
+			}
+		}
+	})
+}
+
+// TestConvertFromReversesConvertTo checks, without the spec annotations, that the v2alpha1 fields
+// set by ConvertTo are converted back to v1alpha1 fields that ConvertTo converts to the same values
+func TestConvertFromReversesConvertTo(t *testing.T) {
+	f := newDatadogAgentFuzzer()
+
+	for i := 0; i < fuzzIterations; i++ {
+		original := &DatadogAgent{}
+		f.Fuzz(original)
+
+		converted := &v2alpha1.DatadogAgent{}
+		require.NoError(t, ConvertTo(original, converted))
+
+		convertedBack := &DatadogAgent{}
+		require.NoError(t, ConvertFrom(converted, convertedBack))
+
+		got := &v2alpha1.DatadogAgent{}
+		require.NoError(t, ConvertTo(convertedBack, got))
+
+		// The conversions do not always distinguish an empty struct from a nil one
+		if diff := cmp.Diff(pruneEmptyObjects(t, converted.Spec), pruneEmptyObjects(t, got.Spec)); diff != "" {
+			t.Fatalf("conversion mismatch (-converted +got):\n%s", diff)
+		}
+	}
+}
+
+func TestDatadogAgentConversionUpdatedSinceConversion(t *testing.T) {
+	t.Run("v2alpha1 fields are restored after a v1alpha1 update", func(t *testing.T) {
+		original := &v2alpha1.DatadogAgent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+			Spec: v2alpha1.DatadogAgentSpec{
+				Global: &v2alpha1.GlobalConfig{
+					ClusterName: utils.NewStringPointer("cluster"),
+				},
+				Features: &v2alpha1.DatadogFeatures{
+					USM: &v2alpha1.USMFeatureConfig{Enabled: utils.NewBoolPointer(true)},
+				},
+			},
+		}
+
+		spoke := &DatadogAgent{}
+		require.NoError(t, spoke.ConvertFrom(original))
+		assert.Contains(t, spoke.Annotations, V2alpha1SpecAnnotation)
+		assert.Equal(t, "cluster", spoke.Spec.ClusterName)
+
+		spoke.Spec.ClusterName = "updated-cluster"
+		got := &v2alpha1.DatadogAgent{}
+		require.NoError(t, spoke.ConvertTo(got))
+
+		assert.NotContains(t, got.Annotations, V2alpha1SpecAnnotation)
+		assert.Equal(t, utils.NewStringPointer("updated-cluster"), got.Spec.Global.ClusterName)
+		assert.Equal(t, utils.NewBoolPointer(true), got.Spec.Features.USM.Enabled)
+	})
+
+	t.Run("v1alpha1 fields are restored after a v2alpha1 update", func(t *testing.T) {
+		original := &DatadogAgent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+			Spec: DatadogAgentSpec{
+				Site: "datadoghq.com",
+				Agent: DatadogAgentSpecAgentSpec{
+					DNSPolicy: "ClusterFirstWithHostNet",
+				},
+			},
+		}
+
+		hub := &v2alpha1.DatadogAgent{}
+		require.NoError(t, original.ConvertTo(hub))
+		assert.Contains(t, hub.Annotations, V1alpha1SpecAnnotation)
+
+		hub.Spec.Global.Site = utils.NewStringPointer("datadoghq.eu")
+		got := &DatadogAgent{}
+		require.NoError(t, got.ConvertFrom(hub))
+
+		assert.NotContains(t, got.Annotations, V1alpha1SpecAnnotation)
+		assert.Equal(t, "datadoghq.eu", got.Spec.Site)
+		assert.Equal(t, original.Spec.Agent.DNSPolicy, got.Spec.Agent.DNSPolicy)
+	})
+}
+
+func newDatadogAgentFuzzer() *fuzz.Fuzzer {
+	funcs := fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, func(codecs runtimeserializer.CodecFactory) []interface{} {
+		return []interface{}{
+			// The status is not converted
+			func(status *DatadogAgentStatus, c fuzz.Continue) {},
+			func(status *v2alpha1.DatadogAgentStatus, c fuzz.Continue) {},
+			// Durations are serialized as strings, keep them in a parsable range
+			func(d *metav1.Duration, c fuzz.Continue) {
+				d.Duration = time.Duration(c.Int63n(int64(time.Hour)))
+			},
+		}
+	})
+
+	return fuzzer.FuzzerFor(funcs, rand.NewSource(rand.Int63()), runtimeserializer.NewCodecFactory(runtime.NewScheme())).
+		NilChance(0.5).
+		NumElements(1, 2)
+}
+
+// jsonRoundTrip serializes and deserializes the object, as the API server does between conversions.
+// It also drops the fuzzed values that cannot be represented in JSON.
+func jsonRoundTrip(t *testing.T, obj interface{}) {
+	data, err := utiljson.Marshal(obj)
+	require.NoError(t, err)
+
+	value := reflect.ValueOf(obj).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	require.NoError(t, utiljson.Unmarshal(data, obj))
+}
+
+func pruneEmptyObjects(t *testing.T, obj interface{}) interface{} {
+	data, err := utiljson.Marshal(obj)
+	require.NoError(t, err)
+
+	var tree interface{}
+	require.NoError(t, utiljson.Unmarshal(data, &tree))
+
+	return pruneEmptyJSONObjects(tree)
+}
+
+func pruneEmptyJSONObjects(tree interface{}) interface{} {
+	switch value := tree.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if pruned := pruneEmptyJSONObjects(child); pruned == nil {
+				delete(value, key)
+			} else {
+				value[key] = pruned
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+	case []interface{}:
+		for i, child := range value {
+			value[i] = pruneEmptyJSONObjects(child)
+		}
+	}
+
+	return tree
+}
+
 func readKubernetesObject(decoder runtime.Decoder, filename string, object runtime.Object) error {
 	data, err := ioutil.ReadFile(getTestFilePath(filename))
 	if err != nil {
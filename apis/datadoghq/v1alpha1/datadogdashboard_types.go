@@ -0,0 +1,193 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogDashboardSpec defines the desired state of DatadogDashboard
+type DatadogDashboardSpec struct {
+	// Title is the dashboard title
+	Title string `json:"title,omitempty"`
+	// Description is the dashboard description
+	Description string `json:"description,omitempty"`
+	// LayoutType is the layout type of the dashboard
+	LayoutType DatadogDashboardLayoutType `json:"layoutType,omitempty"`
+	// ReflowType defines the reflow behavior of an ordered dashboard. It is only used by dashboards with the
+	// `ordered` layout type.
+	ReflowType *DatadogDashboardReflowType `json:"reflowType,omitempty"`
+	// NotifyList is the list of handles of users to notify when changes are made to this dashboard
+	NotifyList []string `json:"notifyList,omitempty"`
+	// RestrictedRoles is a list of role identifiers. Only the author and users associated with at least one of
+	// these roles can edit this dashboard.
+	RestrictedRoles []string `json:"restrictedRoles,omitempty"`
+	// Widgets is the JSON representation of the list of widgets to display on the dashboard, as documented in the
+	// Datadog Dashboards API.
+	Widgets string `json:"widgets,omitempty"`
+	// TemplateVariables is the list of template variables of the dashboard
+	TemplateVariables []DatadogDashboardTemplateVariable `json:"templateVariables,omitempty"`
+	// TemplateVariablePresets is the list of saved views of the template variables
+	TemplateVariablePresets []DatadogDashboardTemplateVariablePreset `json:"templateVariablePresets,omitempty"`
+}
+
+// DatadogDashboardLayoutType defines the layout of a dashboard
+type DatadogDashboardLayoutType string
+
+const (
+	// DatadogDashboardLayoutTypeOrdered is the layout used by timeboards
+	DatadogDashboardLayoutTypeOrdered DatadogDashboardLayoutType = "ordered"
+	// DatadogDashboardLayoutTypeFree is the layout used by screenboards
+	DatadogDashboardLayoutTypeFree DatadogDashboardLayoutType = "free"
+)
+
+// DatadogDashboardReflowType defines the reflow behavior of an ordered dashboard
+type DatadogDashboardReflowType string
+
+const (
+	// DatadogDashboardReflowTypeAuto lets Datadog size the widgets automatically
+	DatadogDashboardReflowTypeAuto DatadogDashboardReflowType = "auto"
+	// DatadogDashboardReflowTypeFixed uses the widget sizes set in the layout
+	DatadogDashboardReflowTypeFixed DatadogDashboardReflowType = "fixed"
+)
+
+// DatadogDashboardTemplateVariable defines a template variable of a dashboard
+type DatadogDashboardTemplateVariable struct {
+	// Name is the name of the variable
+	Name string `json:"name"`
+	// Prefix is the tag prefix associated with the variable. Only tags with this prefix appear in the variable drop-down.
+	Prefix *string `json:"prefix,omitempty"`
+	// Default is the default value of the template variable on dashboard load
+	Default *string `json:"default,omitempty"`
+	// AvailableValues is the list of values that the template variable drop-down is limited to
+	AvailableValues []string `json:"availableValues,omitempty"`
+}
+
+// DatadogDashboardTemplateVariablePreset defines a saved view of the dashboard template variables
+type DatadogDashboardTemplateVariablePreset struct {
+	// Name is the name of the preset
+	Name string `json:"name"`
+	// TemplateVariables is the list of template variable values of the preset
+	TemplateVariables []DatadogDashboardTemplateVariablePresetValue `json:"templateVariables,omitempty"`
+}
+
+// DatadogDashboardTemplateVariablePresetValue defines the value of a template variable in a preset
+type DatadogDashboardTemplateVariablePresetValue struct {
+	// Name is the name of the template variable
+	Name string `json:"name"`
+	// Value is the value of the template variable in this preset
+	Value string `json:"value,omitempty"`
+}
+
+// DatadogDashboardStatus defines the observed state of DatadogDashboard
+type DatadogDashboardStatus struct {
+	// Conditions Represents the latest available observations of a DatadogDashboard's current state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []DatadogDashboardCondition `json:"conditions,omitempty"`
+
+	// ID is the dashboard ID generated in Datadog
+	ID string `json:"id,omitempty"`
+	// URL is the relative URL of the dashboard in the Datadog application
+	URL string `json:"url,omitempty"`
+	// Creator is the handle of the dashboard creator
+	Creator string `json:"creator,omitempty"`
+	// Created is the time the dashboard was created
+	Created *metav1.Time `json:"created,omitempty"`
+	// DashboardLastModifiedTime is the modification time of the dashboard in Datadog the last time
+	// the controller pushed or synced it. A more recent modification time reported by Datadog means
+	// that the dashboard was edited outside Kubernetes.
+	DashboardLastModifiedTime *metav1.Time `json:"dashboardLastModifiedTime,omitempty"`
+	// DashboardLastSyncTime is the last time the dashboard was synced with Datadog
+	DashboardLastSyncTime *metav1.Time `json:"dashboardLastSyncTime,omitempty"`
+	// SyncStatus shows the health of syncing the dashboard with Datadog
+	SyncStatus DatadogDashboardSyncStatusMessage `json:"syncStatus,omitempty"`
+
+	// CurrentHash tracks the hash of the current DatadogDashboardSpec to know
+	// if the Spec has changed and needs an update
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// DatadogDashboardCondition describes the current state of a DatadogDashboard
+// +k8s:openapi-gen=true
+type DatadogDashboardCondition struct {
+	// Type of DatadogDashboard condition
+	Type DatadogDashboardConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Last time the condition was updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DatadogDashboardConditionType represents a DatadogDashboard condition
+type DatadogDashboardConditionType string
+
+const (
+	// DatadogDashboardConditionTypeCreated means the DatadogDashboard is created successfully
+	DatadogDashboardConditionTypeCreated DatadogDashboardConditionType = "Created"
+	// DatadogDashboardConditionTypeActive means the DatadogDashboard is active
+	DatadogDashboardConditionTypeActive DatadogDashboardConditionType = "Active"
+	// DatadogDashboardConditionTypeUpdated means the DatadogDashboard is updated
+	DatadogDashboardConditionTypeUpdated DatadogDashboardConditionType = "Updated"
+	// DatadogDashboardConditionTypeError means the DatadogDashboard has an error
+	DatadogDashboardConditionTypeError DatadogDashboardConditionType = "Error"
+)
+
+// DatadogDashboardSyncStatusMessage is the message reflecting the health of dashboard syncs with Datadog
+type DatadogDashboardSyncStatusMessage string
+
+const (
+	// DatadogDashboardSyncStatusOK means syncing is OK
+	DatadogDashboardSyncStatusOK DatadogDashboardSyncStatusMessage = "OK"
+	// DatadogDashboardSyncStatusCreateError means there is a dashboard creation error
+	DatadogDashboardSyncStatusCreateError DatadogDashboardSyncStatusMessage = "error creating dashboard"
+	// DatadogDashboardSyncStatusUpdateError means there is a dashboard update error
+	DatadogDashboardSyncStatusUpdateError DatadogDashboardSyncStatusMessage = "error updating dashboard"
+	// DatadogDashboardSyncStatusGetError means there is an error getting the dashboard
+	DatadogDashboardSyncStatusGetError DatadogDashboardSyncStatusMessage = "error getting dashboard"
+)
+
+// DatadogDashboard allows to define and manage Dashboards from your Kubernetes Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogdashboards,scope=Namespaced
+// +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="url",type="string",JSONPath=".status.url"
+// +kubebuilder:printcolumn:name="last sync",type="string",format="date",JSONPath=".status.dashboardLastSyncTime"
+// +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogDashboardSpec   `json:"spec,omitempty"`
+	Status DatadogDashboardStatus `json:"status,omitempty"`
+}
+
+// DatadogDashboardList contains a list of DatadogDashboards
+// +kubebuilder:object:root=true
+type DatadogDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogDashboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogDashboard{}, &DatadogDashboardList{})
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// IsValidDatadogDashboard use to check if a DatadogDashboardSpec is valid by checking
+// that the required fields are defined and that the widgets are a JSON list
+func IsValidDatadogDashboard(spec *DatadogDashboardSpec) error {
+	var errs []error
+	if spec.Title == "" {
+		errs = append(errs, fmt.Errorf("spec.Title must be defined"))
+	}
+
+	switch spec.LayoutType {
+	case "":
+		errs = append(errs, fmt.Errorf("spec.LayoutType must be defined"))
+	case DatadogDashboardLayoutTypeOrdered, DatadogDashboardLayoutTypeFree:
+	default:
+		errs = append(errs, fmt.Errorf("spec.LayoutType must be one of %q or %q", DatadogDashboardLayoutTypeOrdered, DatadogDashboardLayoutTypeFree))
+	}
+
+	if spec.ReflowType != nil {
+		if spec.LayoutType != DatadogDashboardLayoutTypeOrdered {
+			errs = append(errs, fmt.Errorf("spec.ReflowType can only be set with the %q layout type", DatadogDashboardLayoutTypeOrdered))
+		}
+		if *spec.ReflowType != DatadogDashboardReflowTypeAuto && *spec.ReflowType != DatadogDashboardReflowTypeFixed {
+			errs = append(errs, fmt.Errorf("spec.ReflowType must be one of %q or %q", DatadogDashboardReflowTypeAuto, DatadogDashboardReflowTypeFixed))
+		}
+	}
+
+	if spec.Widgets != "" {
+		var widgets []map[string]interface{}
+		if err := json.Unmarshal([]byte(spec.Widgets), &widgets); err != nil {
+			errs = append(errs, fmt.Errorf("spec.Widgets must be a JSON list of widgets: %w", err))
+		}
+	}
+
+	for _, tv := range spec.TemplateVariables {
+		if tv.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.TemplateVariables[].Name must be defined"))
+		}
+	}
+
+	for _, preset := range spec.TemplateVariablePresets {
+		if preset.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.TemplateVariablePresets[].Name must be defined"))
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidDatadogDashboard(t *testing.T) {
+	fixed := DatadogDashboardReflowTypeFixed
+	invalidReflow := DatadogDashboardReflowType("foo")
+
+	testCases := []struct {
+		name    string
+		spec    *DatadogDashboardSpec
+		wantErr string
+	}{
+		{
+			name: "minimum valid dashboard",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: DatadogDashboardLayoutTypeOrdered,
+			},
+		},
+		{
+			name: "valid dashboard with widgets and reflow type",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: DatadogDashboardLayoutTypeOrdered,
+				ReflowType: &fixed,
+				Widgets:    `[{"definition": {"type": "note", "content": "hello"}}]`,
+			},
+		},
+		{
+			name: "dashboard missing title",
+			spec: &DatadogDashboardSpec{
+				LayoutType: DatadogDashboardLayoutTypeFree,
+			},
+			wantErr: "spec.Title must be defined",
+		},
+		{
+			name: "dashboard missing layout type",
+			spec: &DatadogDashboardSpec{
+				Title: "Test Dashboard",
+			},
+			wantErr: "spec.LayoutType must be defined",
+		},
+		{
+			name: "dashboard with unknown layout type",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: "foo",
+			},
+			wantErr: `spec.LayoutType must be one of "ordered" or "free"`,
+		},
+		{
+			name: "free dashboard with reflow type",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: DatadogDashboardLayoutTypeFree,
+				ReflowType: &fixed,
+			},
+			wantErr: `spec.ReflowType can only be set with the "ordered" layout type`,
+		},
+		{
+			name: "dashboard with unknown reflow type",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: DatadogDashboardLayoutTypeOrdered,
+				ReflowType: &invalidReflow,
+			},
+			wantErr: `spec.ReflowType must be one of "auto" or "fixed"`,
+		},
+		{
+			name: "dashboard with widgets that are not a list",
+			spec: &DatadogDashboardSpec{
+				Title:      "Test Dashboard",
+				LayoutType: DatadogDashboardLayoutTypeOrdered,
+				Widgets:    `{"definition": {"type": "note"}}`,
+			},
+			wantErr: "spec.Widgets must be a JSON list of widgets: json: cannot unmarshal object into Go value of type []map[string]interface {}",
+		},
+		{
+			name: "dashboard with unnamed template variable",
+			spec: &DatadogDashboardSpec{
+				Title:             "Test Dashboard",
+				LayoutType:        DatadogDashboardLayoutTypeOrdered,
+				TemplateVariables: []DatadogDashboardTemplateVariable{{}},
+			},
+			wantErr: "spec.TemplateVariables[].Name must be defined",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsValidDatadogDashboard(test.spec)
+			if test.wantErr != "" {
+				assert.Error(t, result)
+				assert.EqualError(t, result, test.wantErr)
+			} else {
+				assert.NoError(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogDowntimeSpec defines the desired state of DatadogDowntime
+type DatadogDowntimeSpec struct {
+	// Message is a message to include with the notifications of the downtime
+	Message *string `json:"message,omitempty"`
+	// MonitorRef is a reference to a DatadogMonitor in the same namespace to silence.
+	// Cannot be used together with MonitorTags.
+	MonitorRef *DatadogDowntimeMonitorReference `json:"monitorRef,omitempty"`
+	// MonitorTags is a list of monitor tags. The downtime silences the monitors that have all these tags.
+	// Cannot be used together with MonitorRef.
+	MonitorTags []string `json:"monitorTags,omitempty"`
+	// Scope is the list of scopes to which the downtime applies, for example `env:staging`. Defaults to `*`.
+	Scope []string `json:"scope,omitempty"`
+	// Start is the time the downtime starts. The downtime starts immediately if unset.
+	Start *metav1.Time `json:"start,omitempty"`
+	// End is the time the downtime ends. The downtime continues forever if unset.
+	End *metav1.Time `json:"end,omitempty"`
+	// Timezone is the timezone in which to display the downtime's start and end times in Datadog applications
+	Timezone string `json:"timezone,omitempty"`
+	// Recurrence makes the downtime repeat. Start and End define the first occurrence.
+	Recurrence *DatadogDowntimeRecurrence `json:"recurrence,omitempty"`
+}
+
+// DatadogDowntimeMonitorReference is a reference to a DatadogMonitor in the same namespace
+type DatadogDowntimeMonitorReference struct {
+	// Name is the name of the DatadogMonitor
+	Name string `json:"name"`
+}
+
+// DatadogDowntimeRecurrenceType defines the unit of the repetition of a recurring downtime
+type DatadogDowntimeRecurrenceType string
+
+const (
+	// DatadogDowntimeRecurrenceTypeDays repeats the downtime every Period days
+	DatadogDowntimeRecurrenceTypeDays DatadogDowntimeRecurrenceType = "days"
+	// DatadogDowntimeRecurrenceTypeWeeks repeats the downtime every Period weeks, on the WeekDays
+	DatadogDowntimeRecurrenceTypeWeeks DatadogDowntimeRecurrenceType = "weeks"
+	// DatadogDowntimeRecurrenceTypeMonths repeats the downtime every Period months
+	DatadogDowntimeRecurrenceTypeMonths DatadogDowntimeRecurrenceType = "months"
+	// DatadogDowntimeRecurrenceTypeYears repeats the downtime every Period years
+	DatadogDowntimeRecurrenceTypeYears DatadogDowntimeRecurrenceType = "years"
+	// DatadogDowntimeRecurrenceTypeRRule repeats the downtime according to the RRule
+	DatadogDowntimeRecurrenceTypeRRule DatadogDowntimeRecurrenceType = "rrule"
+)
+
+// DatadogDowntimeRecurrence defines the repetition of a recurring downtime
+type DatadogDowntimeRecurrence struct {
+	// Type is the unit of the repetition, one of `days`, `weeks`, `months`, `years` or `rrule`
+	Type DatadogDowntimeRecurrenceType `json:"type"`
+	// Period is the number of units between repetitions. It is not used with the `rrule` type.
+	Period *int32 `json:"period,omitempty"`
+	// WeekDays is the list of days of the week on which to repeat a `weeks` downtime: `Mon`, `Tue`, `Wed`, `Thu`, `Fri`, `Sat` or `Sun`
+	WeekDays []string `json:"weekDays,omitempty"`
+	// RRule is the recurrence rule (RFC 5545) of a `rrule` downtime, for example `FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE`
+	RRule string `json:"rrule,omitempty"`
+	// UntilDate is the time after which the downtime stops repeating. Cannot be used together with UntilOccurrences.
+	UntilDate *metav1.Time `json:"untilDate,omitempty"`
+	// UntilOccurrences is the number of times the downtime is repeated. Cannot be used together with UntilDate.
+	UntilOccurrences *int32 `json:"untilOccurrences,omitempty"`
+}
+
+// DatadogDowntimeStatus defines the observed state of DatadogDowntime
+type DatadogDowntimeStatus struct {
+	// Conditions Represents the latest available observations of a DatadogDowntime's current state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []DatadogDowntimeCondition `json:"conditions,omitempty"`
+
+	// ID is the downtime ID generated in Datadog
+	ID int `json:"id,omitempty"`
+	// MonitorID is the ID of the monitor referenced by MonitorRef
+	MonitorID int `json:"monitorID,omitempty"`
+	// Active is true when the downtime is currently silencing monitors
+	Active bool `json:"active,omitempty"`
+	// DowntimeLastSyncTime is the last time the downtime was synced with Datadog
+	DowntimeLastSyncTime *metav1.Time `json:"downtimeLastSyncTime,omitempty"`
+	// SyncStatus shows the health of syncing the downtime with Datadog
+	SyncStatus DatadogDowntimeSyncStatusMessage `json:"syncStatus,omitempty"`
+
+	// CurrentHash tracks the hash of the current DatadogDowntimeSpec to know
+	// if the Spec has changed and needs an update
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// DatadogDowntimeCondition describes the current state of a DatadogDowntime
+// +k8s:openapi-gen=true
+type DatadogDowntimeCondition struct {
+	// Type of DatadogDowntime condition
+	Type DatadogDowntimeConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Last time the condition was updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DatadogDowntimeConditionType represents a DatadogDowntime condition
+type DatadogDowntimeConditionType string
+
+const (
+	// DatadogDowntimeConditionTypeCreated means the DatadogDowntime is created successfully
+	DatadogDowntimeConditionTypeCreated DatadogDowntimeConditionType = "Created"
+	// DatadogDowntimeConditionTypeActive means the DatadogDowntime is active
+	DatadogDowntimeConditionTypeActive DatadogDowntimeConditionType = "Active"
+	// DatadogDowntimeConditionTypeUpdated means the DatadogDowntime is updated
+	DatadogDowntimeConditionTypeUpdated DatadogDowntimeConditionType = "Updated"
+	// DatadogDowntimeConditionTypeError means the DatadogDowntime has an error
+	DatadogDowntimeConditionTypeError DatadogDowntimeConditionType = "Error"
+)
+
+// DatadogDowntimeSyncStatusMessage is the message reflecting the health of downtime syncs with Datadog
+type DatadogDowntimeSyncStatusMessage string
+
+const (
+	// DatadogDowntimeSyncStatusOK means syncing is OK
+	DatadogDowntimeSyncStatusOK DatadogDowntimeSyncStatusMessage = "OK"
+	// DatadogDowntimeSyncStatusCreateError means there is a downtime creation error
+	DatadogDowntimeSyncStatusCreateError DatadogDowntimeSyncStatusMessage = "error creating downtime"
+	// DatadogDowntimeSyncStatusUpdateError means there is a downtime update error
+	DatadogDowntimeSyncStatusUpdateError DatadogDowntimeSyncStatusMessage = "error updating downtime"
+	// DatadogDowntimeSyncStatusGetError means there is an error getting the downtime
+	DatadogDowntimeSyncStatusGetError DatadogDowntimeSyncStatusMessage = "error getting downtime"
+	// DatadogDowntimeSyncStatusMonitorRefError means the referenced DatadogMonitor cannot be resolved to a monitor ID
+	DatadogDowntimeSyncStatusMonitorRefError DatadogDowntimeSyncStatusMessage = "error resolving monitor reference"
+)
+
+// DatadogDowntime allows to define and manage Downtimes from your Kubernetes Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogdowntimes,scope=Namespaced
+// +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="active",type="boolean",JSONPath=".status.active"
+// +kubebuilder:printcolumn:name="start",type="string",format="date",JSONPath=".spec.start"
+// +kubebuilder:printcolumn:name="end",type="string",format="date",JSONPath=".spec.end"
+// +kubebuilder:printcolumn:name="last sync",type="string",format="date",JSONPath=".status.downtimeLastSyncTime"
+// +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogDowntime struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogDowntimeSpec   `json:"spec,omitempty"`
+	Status DatadogDowntimeStatus `json:"status,omitempty"`
+}
+
+// DatadogDowntimeList contains a list of DatadogDowntimes
+// +kubebuilder:object:root=true
+type DatadogDowntimeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogDowntime `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogDowntime{}, &DatadogDowntimeList{})
+}
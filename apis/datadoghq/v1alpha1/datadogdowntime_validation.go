@@ -0,0 +1,85 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+var downtimeWeekDays = map[string]bool{
+	"Mon": true,
+	"Tue": true,
+	"Wed": true,
+	"Thu": true,
+	"Fri": true,
+	"Sat": true,
+	"Sun": true,
+}
+
+// IsValidDatadogDowntime use to check if a DatadogDowntimeSpec is valid by checking
+// that the monitor targets are not ambiguous and that the schedule is consistent
+func IsValidDatadogDowntime(spec *DatadogDowntimeSpec) error {
+	var errs []error
+	if spec.MonitorRef != nil {
+		if spec.MonitorRef.Name == "" {
+			errs = append(errs, fmt.Errorf("spec.MonitorRef.Name must be defined"))
+		}
+		if len(spec.MonitorTags) > 0 {
+			errs = append(errs, fmt.Errorf("spec.MonitorRef and spec.MonitorTags cannot be used together"))
+		}
+	}
+
+	if spec.Start != nil && spec.End != nil && !spec.End.After(spec.Start.Time) {
+		errs = append(errs, fmt.Errorf("spec.End must be after spec.Start"))
+	}
+
+	if spec.Recurrence != nil {
+		errs = append(errs, isValidDatadogDowntimeRecurrence(spec)...)
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
+
+func isValidDatadogDowntimeRecurrence(spec *DatadogDowntimeSpec) []error {
+	var errs []error
+	recurrence := spec.Recurrence
+	if spec.Start == nil || spec.End == nil {
+		errs = append(errs, fmt.Errorf("spec.Start and spec.End must be defined for a recurring downtime"))
+	}
+
+	switch recurrence.Type {
+	case DatadogDowntimeRecurrenceTypeDays, DatadogDowntimeRecurrenceTypeWeeks, DatadogDowntimeRecurrenceTypeMonths, DatadogDowntimeRecurrenceTypeYears:
+		if recurrence.Period == nil || *recurrence.Period < 1 {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.Period must be a positive number"))
+		}
+		if recurrence.RRule != "" {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.RRule can only be set with the %q type", DatadogDowntimeRecurrenceTypeRRule))
+		}
+	case DatadogDowntimeRecurrenceTypeRRule:
+		if recurrence.RRule == "" {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.RRule must be defined with the %q type", DatadogDowntimeRecurrenceTypeRRule))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("spec.Recurrence.Type must be one of %q, %q, %q, %q or %q", DatadogDowntimeRecurrenceTypeDays, DatadogDowntimeRecurrenceTypeWeeks, DatadogDowntimeRecurrenceTypeMonths, DatadogDowntimeRecurrenceTypeYears, DatadogDowntimeRecurrenceTypeRRule))
+	}
+
+	if len(recurrence.WeekDays) > 0 && recurrence.Type != DatadogDowntimeRecurrenceTypeWeeks {
+		errs = append(errs, fmt.Errorf("spec.Recurrence.WeekDays can only be set with the %q type", DatadogDowntimeRecurrenceTypeWeeks))
+	}
+	for _, day := range recurrence.WeekDays {
+		if !downtimeWeekDays[day] {
+			errs = append(errs, fmt.Errorf("spec.Recurrence.WeekDays contains an invalid day %q", day))
+		}
+	}
+
+	if recurrence.UntilDate != nil && recurrence.UntilOccurrences != nil {
+		errs = append(errs, fmt.Errorf("spec.Recurrence.UntilDate and spec.Recurrence.UntilOccurrences cannot be used together"))
+	}
+
+	return errs
+}
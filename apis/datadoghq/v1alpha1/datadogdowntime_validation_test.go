@@ -0,0 +1,142 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsValidDatadogDowntime(t *testing.T) {
+	start := metav1.NewTime(time.Date(2021, 10, 1, 22, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(2 * time.Hour))
+	period := int32(1)
+	occurrences := int32(4)
+
+	testCases := []struct {
+		name    string
+		spec    *DatadogDowntimeSpec
+		wantErr string
+	}{
+		{
+			name: "valid downtime with scope only",
+			spec: &DatadogDowntimeSpec{
+				Scope: []string{"env:staging"},
+			},
+		},
+		{
+			name: "valid one-off downtime of a DatadogMonitor",
+			spec: &DatadogDowntimeSpec{
+				MonitorRef: &DatadogDowntimeMonitorReference{Name: "foo"},
+				Start:      &start,
+				End:        &end,
+			},
+		},
+		{
+			name: "valid weekly downtime",
+			spec: &DatadogDowntimeSpec{
+				MonitorTags: []string{"team:foo"},
+				Start:       &start,
+				End:         &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type:             DatadogDowntimeRecurrenceTypeWeeks,
+					Period:           &period,
+					WeekDays:         []string{"Mon", "Wed"},
+					UntilOccurrences: &occurrences,
+				},
+			},
+		},
+		{
+			name: "valid rrule downtime",
+			spec: &DatadogDowntimeSpec{
+				Start: &start,
+				End:   &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type:  DatadogDowntimeRecurrenceTypeRRule,
+					RRule: "FREQ=MONTHLY;BYSETPOS=3;BYDAY=WE",
+				},
+			},
+		},
+		{
+			name: "downtime with monitor reference and monitor tags",
+			spec: &DatadogDowntimeSpec{
+				MonitorRef:  &DatadogDowntimeMonitorReference{},
+				MonitorTags: []string{"team:foo"},
+			},
+			wantErr: "[spec.MonitorRef.Name must be defined, spec.MonitorRef and spec.MonitorTags cannot be used together]",
+		},
+		{
+			name: "downtime ending before it starts",
+			spec: &DatadogDowntimeSpec{
+				Start: &end,
+				End:   &start,
+			},
+			wantErr: "spec.End must be after spec.Start",
+		},
+		{
+			name: "recurring downtime without end",
+			spec: &DatadogDowntimeSpec{
+				Start: &start,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type:   DatadogDowntimeRecurrenceTypeDays,
+					Period: &period,
+				},
+			},
+			wantErr: "spec.Start and spec.End must be defined for a recurring downtime",
+		},
+		{
+			name: "recurring downtime with an unknown type",
+			spec: &DatadogDowntimeSpec{
+				Start: &start,
+				End:   &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type: "hours",
+				},
+			},
+			wantErr: `spec.Recurrence.Type must be one of "days", "weeks", "months", "years" or "rrule"`,
+		},
+		{
+			name: "invalid daily downtime",
+			spec: &DatadogDowntimeSpec{
+				Start: &start,
+				End:   &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type:             DatadogDowntimeRecurrenceTypeDays,
+					RRule:            "FREQ=DAILY",
+					WeekDays:         []string{"Monday"},
+					UntilDate:        &end,
+					UntilOccurrences: &occurrences,
+				},
+			},
+			wantErr: `[spec.Recurrence.Period must be a positive number, spec.Recurrence.RRule can only be set with the "rrule" type, spec.Recurrence.WeekDays can only be set with the "weeks" type, spec.Recurrence.WeekDays contains an invalid day "Monday", spec.Recurrence.UntilDate and spec.Recurrence.UntilOccurrences cannot be used together]`,
+		},
+		{
+			name: "rrule downtime without rule",
+			spec: &DatadogDowntimeSpec{
+				Start: &start,
+				End:   &end,
+				Recurrence: &DatadogDowntimeRecurrence{
+					Type: DatadogDowntimeRecurrenceTypeRRule,
+				},
+			},
+			wantErr: `spec.Recurrence.RRule must be defined with the "rrule" type`,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsValidDatadogDowntime(test.spec)
+			if test.wantErr != "" {
+				assert.Error(t, result)
+				assert.EqualError(t, result, test.wantErr)
+			} else {
+				assert.NoError(t, result)
+			}
+		})
+	}
+}
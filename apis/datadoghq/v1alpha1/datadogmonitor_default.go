@@ -0,0 +1,31 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+// GetDatadogMonitorRequiredTags returns the tags set on all the monitors created from a DatadogMonitor
+func GetDatadogMonitorRequiredTags() []string {
+	return []string{"generated:kubernetes"}
+}
+
+// DefaultDatadogMonitor adds the required tags missing from a DatadogMonitor, and returns whether it was modified
+func DefaultDatadogMonitor(dm *DatadogMonitor) bool {
+	modified := false
+	for _, required := range GetDatadogMonitorRequiredTags() {
+		found := false
+		for _, tag := range dm.Spec.Tags {
+			if tag == required {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dm.Spec.Tags = append(dm.Spec.Tags, required)
+			modified = true
+		}
+	}
+
+	return modified
+}
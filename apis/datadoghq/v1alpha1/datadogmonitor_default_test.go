@@ -0,0 +1,45 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultDatadogMonitor(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tags         []string
+		wantTags     []string
+		wantModified bool
+	}{
+		{
+			name:         "no tags",
+			wantTags:     []string{"generated:kubernetes"},
+			wantModified: true,
+		},
+		{
+			name:         "missing required tag",
+			tags:         []string{"env:prod"},
+			wantTags:     []string{"env:prod", "generated:kubernetes"},
+			wantModified: true,
+		},
+		{
+			name:     "required tag already set",
+			tags:     []string{"generated:kubernetes", "env:prod"},
+			wantTags: []string{"generated:kubernetes", "env:prod"},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			dm := &DatadogMonitor{Spec: DatadogMonitorSpec{Tags: test.tags}}
+			assert.Equal(t, test.wantModified, DefaultDatadogMonitor(dm))
+			assert.Equal(t, test.wantTags, dm.Spec.Tags)
+		})
+	}
+}
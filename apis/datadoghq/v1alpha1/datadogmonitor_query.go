@@ -0,0 +1,438 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxMetricTimeframe is the longest evaluation window of metric monitors
+	maxMetricTimeframe = 30 * 24 * time.Hour
+	// maxEventTimeframe is the longest evaluation window of log, trace-analytics, event-v2 and process monitors,
+	// unless they use a cumulative evaluation window
+	maxEventTimeframe = 48 * time.Hour
+	// maxCumulativeTimeframe is the longest evaluation window of monitors using a cumulative evaluation window
+	maxCumulativeTimeframe = 30 * 24 * time.Hour
+)
+
+var (
+	timeframeRegexp = regexp.MustCompile(`^(\d+)(mo|m|h|d|w)$`)
+	// metricAggregationRegexp matches the time aggregation of metric queries, e.g. `avg(last_5m)`, `percentile(last_5m)`,
+	// or `max(next_1w)` for forecasts
+	metricAggregationRegexp = regexp.MustCompile(`^(avg|sum|min|max|percentile)\((last|next)_(\w+)\)$`)
+	// metricChangeAggregationRegexp matches the time aggregation of change metric queries, e.g. `change(avg(last_5m),last_1h)`
+	metricChangeAggregationRegexp = regexp.MustCompile(`^(change|pct_change)\((avg|sum|min|max)\(last_(\w+)\),\s*last_(\w+)\)$`)
+	// queryCallRegexp matches a function call of a query, e.g. `rollup("count")`
+	queryCallRegexp = regexp.MustCompile(`^([a-z_-]+)\((.*)\)$`)
+
+	closingBrackets = map[byte]byte{'(': ')', '[': ']', '{': '}'}
+	timeframeUnits  = map[string]time.Duration{"m": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour, "mo": 30 * 24 * time.Hour}
+)
+
+// monitorQuery holds the parts of a monitor query that are checked against the monitor options
+type monitorQuery struct {
+	// Comparator and threshold of the query, not set for service checks
+	comparator string
+	threshold  float64
+	// Number of check runs evaluated, only set for service checks
+	checkRuns int
+}
+
+// queryCall is a function call of a query, with its unquoted arguments
+type queryCall struct {
+	name string
+	args []string
+}
+
+// isValidDatadogMonitorQuery lints the query of the monitor types supported locally, and checks that the thresholds
+// of the options are consistent with it
+func isValidDatadogMonitorQuery(spec *DatadogMonitorSpec) []error {
+	var (
+		query *monitorQuery
+		err   error
+	)
+
+	maxTimeframe := maxEventTimeframe
+	if spec.Options.SchedulingOptions != nil && spec.Options.SchedulingOptions.EvaluationWindow != nil {
+		maxTimeframe = maxCumulativeTimeframe
+	}
+
+	switch spec.Type {
+	case DatadogMonitorTypeMetric, DatadogMonitorTypeQuery:
+		query, err = parseMetricQuery(spec.Query)
+	case DatadogMonitorTypeLog:
+		query, err = parseEventQuery(spec.Query, "logs", maxTimeframe)
+	case DatadogMonitorTypeTraceAnalytics:
+		query, err = parseEventQuery(spec.Query, "trace-analytics", maxTimeframe)
+	case DatadogMonitorTypeEventV2:
+		query, err = parseEventQuery(spec.Query, "events", maxTimeframe)
+	case DatadogMonitorTypeProcess:
+		query, err = parseEventQuery(spec.Query, "processes", maxTimeframe)
+	case DatadogMonitorTypeService:
+		query, err = parseServiceCheckQuery(spec.Query)
+	default:
+		return nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("spec.Query is invalid: %w", err)}
+	}
+
+	return isValidThresholds(query, spec.Options.Thresholds)
+}
+
+// parseMetricQuery parses metric queries, e.g. `avg(last_5m):avg:system.cpu.user{*} by {host} > 90`
+func parseMetricQuery(query string) (*monitorQuery, error) {
+	expr, q, err := splitComparison(query)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := strings.Index(expr, ":")
+	if separator < 0 {
+		return nil, fmt.Errorf("missing time aggregation, e.g. avg(last_5m):")
+	}
+	aggregation, metrics := strings.TrimSpace(expr[:separator]), strings.TrimSpace(expr[separator+1:])
+
+	var timeframes []string
+	if matches := metricAggregationRegexp.FindStringSubmatch(aggregation); matches != nil {
+		timeframes = matches[3:]
+		if matches[2] == "next" {
+			// Forecasts predict the metric over the next window, which is not an evaluation window
+			if err = isValidTimeframe(matches[3], 0); err != nil {
+				return nil, err
+			}
+			timeframes = nil
+		}
+	} else if matches := metricChangeAggregationRegexp.FindStringSubmatch(aggregation); matches != nil {
+		timeframes = matches[3:]
+	} else {
+		return nil, fmt.Errorf("invalid time aggregation %q", aggregation)
+	}
+	for _, timeframe := range timeframes {
+		if err = isValidTimeframe(timeframe, maxMetricTimeframe); err != nil {
+			return nil, err
+		}
+	}
+
+	if metrics == "" {
+		return nil, fmt.Errorf("missing metric query")
+	}
+	if !strings.Contains(metrics, "{") {
+		return nil, fmt.Errorf("missing metric scope, e.g. {*}")
+	}
+
+	return q, nil
+}
+
+// parseEventQuery parses the queries of log, trace-analytics, event-v2 and process monitors,
+// e.g. `logs("status:error").index("*").rollup("count").last("5m") > 10`
+func parseEventQuery(query, source string, maxTimeframe time.Duration) (*monitorQuery, error) {
+	expr, q, err := splitComparison(query)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, err := splitCalls(expr)
+	if err != nil {
+		return nil, err
+	}
+	if calls[0].name != source || len(calls[0].args) != 1 {
+		return nil, fmt.Errorf("query must start with %s(\"<search query>\")", source)
+	}
+
+	last := findCall(calls, "last")
+	if last == nil || len(last.args) != 1 {
+		return nil, fmt.Errorf("missing evaluation window, e.g. .last(\"5m\")")
+	}
+	if err = isValidTimeframe(last.args[0], maxTimeframe); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// parseServiceCheckQuery parses service check queries, e.g. `"http.can_connect".over("*").by("host").last(2).count_by_status()`
+func parseServiceCheckQuery(query string) (*monitorQuery, error) {
+	start, _, err := scanQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if start >= 0 {
+		return nil, fmt.Errorf("service check queries cannot have a comparator, thresholds are set in the options")
+	}
+
+	segments := splitTopLevel(strings.TrimSpace(query), '.')
+	if checkName := strings.TrimSpace(segments[0]); unquote(checkName) == checkName || len(segments) < 2 {
+		return nil, fmt.Errorf("query must start with the quoted check name, e.g. \"http.can_connect\"")
+	}
+
+	calls, err := splitCalls(strings.Join(segments[1:], "."))
+	if err != nil {
+		return nil, err
+	}
+	if calls[len(calls)-1].name != "count_by_status" {
+		return nil, fmt.Errorf("query must end with .count_by_status()")
+	}
+
+	last := findCall(calls, "last")
+	if last == nil || len(last.args) != 1 {
+		return nil, fmt.Errorf("missing number of check runs, e.g. .last(3)")
+	}
+	checkRuns, err := strconv.Atoi(last.args[0])
+	if err != nil || checkRuns < 1 {
+		return nil, fmt.Errorf("invalid number of check runs %q, must be a positive integer", last.args[0])
+	}
+
+	return &monitorQuery{checkRuns: checkRuns}, nil
+}
+
+// isValidThresholds checks that the thresholds are numbers consistent with the comparator of the query
+func isValidThresholds(query *monitorQuery, thresholds *DatadogMonitorOptionsThresholds) []error {
+	if thresholds == nil {
+		return nil
+	}
+
+	var errs []error
+	values := map[string]float64{}
+	for _, threshold := range []struct {
+		name  string
+		value *string
+	}{
+		{"Critical", thresholds.Critical},
+		{"CriticalRecovery", thresholds.CriticalRecovery},
+		{"OK", thresholds.OK},
+		{"Unknown", thresholds.Unknown},
+		{"Warning", thresholds.Warning},
+		{"WarningRecovery", thresholds.WarningRecovery},
+	} {
+		if threshold.value == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(*threshold.value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("spec.Options.Thresholds.%s must be a number", threshold.name))
+			continue
+		}
+		values[threshold.name] = value
+	}
+
+	if query.comparator == "" {
+		// Service check thresholds are numbers of consecutive check runs
+		for _, name := range []string{"Critical", "OK", "Unknown", "Warning"} {
+			value, found := values[name]
+			if found && (value <= 0 || value > float64(query.checkRuns)) {
+				errs = append(errs, fmt.Errorf("spec.Options.Thresholds.%s must be positive and at most the %d check runs of the query", name, query.checkRuns))
+			}
+		}
+
+		return errs
+	}
+
+	critical, found := values["Critical"]
+	if found && critical != query.threshold {
+		errs = append(errs, fmt.Errorf("spec.Options.Thresholds.Critical %v must match the query threshold %v", critical, query.threshold))
+	}
+
+	// With the > and >= comparators, the monitor alerts above the thresholds, and recovers below them
+	above := strings.HasPrefix(query.comparator, ">")
+	isLessSevere := func(value, reference float64) bool {
+		if above {
+			return value < reference
+		}
+		return value > reference
+	}
+	direction := "below"
+	if !above {
+		direction = "above"
+	}
+
+	for _, pair := range []struct{ name, reference string }{
+		{"Warning", "Critical"},
+		{"CriticalRecovery", "Critical"},
+		{"WarningRecovery", "Warning"},
+	} {
+		value, found := values[pair.name]
+		reference, referenceFound := values[pair.reference]
+		if pair.reference == "Critical" {
+			reference, referenceFound = query.threshold, true
+		}
+		if found && referenceFound && !isLessSevere(value, reference) {
+			errs = append(errs, fmt.Errorf("spec.Options.Thresholds.%s must be %s the %s threshold with the %s comparator", pair.name, direction, strings.ToLower(pair.reference), query.comparator))
+		}
+	}
+
+	return errs
+}
+
+// isValidTimeframe checks a timeframe such as `5m`, `1h` or `2d`, at most max if it is not 0
+func isValidTimeframe(timeframe string, max time.Duration) error {
+	matches := timeframeRegexp.FindStringSubmatch(timeframe)
+	if matches == nil {
+		return fmt.Errorf("invalid timeframe %q, must be a number followed by one of m, h, d, w or mo", timeframe)
+	}
+
+	value, err := strconv.Atoi(matches[1])
+	if err != nil || value < 1 {
+		return fmt.Errorf("invalid timeframe %q, must be positive", timeframe)
+	}
+	if max > 0 && time.Duration(value)*timeframeUnits[matches[2]] > max {
+		return fmt.Errorf("invalid timeframe %q, must be at most %dd", timeframe, max/(24*time.Hour))
+	}
+
+	return nil
+}
+
+// splitComparison splits a query into its expression and its comparison to the threshold
+func splitComparison(query string) (string, *monitorQuery, error) {
+	start, end, err := scanQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
+	if start < 0 {
+		return "", nil, fmt.Errorf("missing comparison to a threshold, e.g. > 10")
+	}
+
+	q := &monitorQuery{comparator: query[start:end]}
+	switch q.comparator {
+	case ">", ">=", "<", "<=":
+	default:
+		return "", nil, fmt.Errorf("invalid comparator %q, must be one of >, >=, < or <=", q.comparator)
+	}
+
+	expr, threshold := strings.TrimSpace(query[:start]), strings.TrimSpace(query[end:])
+	if expr == "" {
+		return "", nil, fmt.Errorf("missing expression before the comparator")
+	}
+	if q.threshold, err = strconv.ParseFloat(threshold, 64); err != nil {
+		return "", nil, fmt.Errorf("invalid threshold %q, must be a number", threshold)
+	}
+
+	return expr, q, nil
+}
+
+// scanQuery checks that the quotes and brackets of a query are balanced, and returns the position of its last
+// comparator outside quotes and brackets, or -1
+func scanQuery(query string) (start, end int, err error) {
+	var (
+		brackets []byte
+		quote    byte
+	)
+	start, end = -1, -1
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case closingBrackets[c] != 0:
+			brackets = append(brackets, closingBrackets[c])
+		case c == ')' || c == ']' || c == '}':
+			if len(brackets) == 0 || brackets[len(brackets)-1] != c {
+				return -1, -1, fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+			brackets = brackets[:len(brackets)-1]
+		case len(brackets) == 0 && strings.IndexByte("<>=!", c) >= 0:
+			if end != i {
+				start = i
+			}
+			end = i + 1
+		}
+	}
+
+	if quote != 0 {
+		return -1, -1, fmt.Errorf("unterminated string")
+	}
+	if len(brackets) > 0 {
+		return -1, -1, fmt.Errorf("missing %q", brackets[len(brackets)-1])
+	}
+
+	return start, end, nil
+}
+
+// splitCalls splits an expression like `logs("*").rollup("count")` into its function calls
+func splitCalls(expr string) ([]queryCall, error) {
+	var calls []queryCall
+	for _, segment := range splitTopLevel(expr, '.') {
+		segment = strings.TrimSpace(segment)
+		matches := queryCallRegexp.FindStringSubmatch(segment)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid function call %q", segment)
+		}
+
+		call := queryCall{name: matches[1]}
+		if strings.TrimSpace(matches[2]) != "" {
+			for _, arg := range splitTopLevel(matches[2], ',') {
+				call.args = append(call.args, unquote(strings.TrimSpace(arg)))
+			}
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, nil
+}
+
+// splitTopLevel splits a string on a separator found outside quotes and brackets. The quotes and brackets of the
+// string must be balanced.
+func splitTopLevel(s string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		quote byte
+		from  int
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case closingBrackets[c] != 0:
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[from:i])
+			from = i + 1
+		}
+	}
+
+	return append(parts, s[from:])
+}
+
+func findCall(calls []queryCall, name string) *queryCall {
+	for i := range calls {
+		if calls[i].name == name {
+			return &calls[i]
+		}
+	}
+
+	return nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,299 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+
+	"github.com/stretchr/testify/assert"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestIsValidDatadogMonitorQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		monitorType DatadogMonitorType
+		query       string
+		options     DatadogMonitorOptions
+		wantErr     string
+	}{
+		{
+			name:        "metric query",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+		},
+		{
+			name:        "metric query with arithmetic",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "max(last_15m):sum:kubernetes_state.node.status{status:schedulable} by {kube_cluster_name} * 100 / sum:kubernetes_state.node.status{*} by {kube_cluster_name} < 80",
+		},
+		{
+			name:        "change metric query",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "change(sum(last_5m),last_5m):exclude_null(avg:kubernetes.containers.restarts{*} by {pod_name}) > 5",
+		},
+		{
+			name:        "anomaly metric query",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "avg(last_4h):anomalies(avg:system.cpu.user{*}, 'basic', 2) >= 1",
+		},
+		{
+			name:        "forecast metric query",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "max(next_1w):forecast(avg:system.disk.in_use{*} by {host}, 'linear', 1) >= 0.9",
+		},
+		{
+			name:        "forecast metric query with invalid timeframe",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "max(next_1y):forecast(avg:system.disk.in_use{*} by {host}, 'linear', 1) >= 0.9",
+			wantErr:     `spec.Query is invalid: invalid timeframe "1y", must be a number followed by one of m, h, d, w or mo`,
+		},
+		{
+			name:        "percentile metric query",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "percentile(last_5m):p99:trace.http.request{service:web} > 2",
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("2"),
+					Warning:  apiutils.NewStringPointer("1"),
+				},
+			},
+		},
+		{
+			name:        "metric query without time aggregation",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg:system.disk.in_use{*} > 0.5",
+			wantErr:     `spec.Query is invalid: invalid time aggregation "avg"`,
+		},
+		{
+			name:        "metric query with invalid timeframe",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10y):avg:system.disk.in_use{*} > 0.5",
+			wantErr:     `spec.Query is invalid: invalid timeframe "10y", must be a number followed by one of m, h, d, w or mo`,
+		},
+		{
+			name:        "metric query with too long timeframe",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_2mo):avg:system.disk.in_use{*} > 0.5",
+			wantErr:     `spec.Query is invalid: invalid timeframe "2mo", must be at most 30d`,
+		},
+		{
+			name:        "metric query without scope",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use > 0.5",
+			wantErr:     "spec.Query is invalid: missing metric scope, e.g. {*}",
+		},
+		{
+			name:        "metric query with unbalanced brackets",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{* by {host} > 0.5",
+			wantErr:     `spec.Query is invalid: missing '}'`,
+		},
+		{
+			name:        "metric query without threshold",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host}",
+			wantErr:     "spec.Query is invalid: missing comparison to a threshold, e.g. > 10",
+		},
+		{
+			name:        "metric query with invalid comparator",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} == 0.5",
+			wantErr:     `spec.Query is invalid: invalid comparator "==", must be one of >, >=, < or <=`,
+		},
+		{
+			name:        "metric query with invalid threshold",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} > high",
+			wantErr:     `spec.Query is invalid: invalid threshold "high", must be a number`,
+		},
+		{
+			name:        "log query",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup("count").last("1h") > 5`,
+		},
+		{
+			name:        "log query with a cumulative evaluation window",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup("count").last("1w") > 5`,
+			options: DatadogMonitorOptions{
+				SchedulingOptions: &DatadogMonitorOptionsSchedulingOptions{
+					EvaluationWindow: &DatadogMonitorOptionsEvaluationWindow{DayStarts: apiutils.NewStringPointer("04:00")},
+				},
+			},
+		},
+		{
+			name:        "log query with too long timeframe",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup("count").last("1w") > 5`,
+			wantErr:     `spec.Query is invalid: invalid timeframe "1w", must be at most 2d`,
+		},
+		{
+			name:        "log query without evaluation window",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup("count") > 5`,
+			wantErr:     `spec.Query is invalid: missing evaluation window, e.g. .last("5m")`,
+		},
+		{
+			name:        "log query with a syntax error",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup"count").last("1h") > 5`,
+			wantErr:     `spec.Query is invalid: unexpected ')' at position 69`,
+		},
+		{
+			name:        "log query with unterminated string",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error).last("1h") > 5`,
+			wantErr:     "spec.Query is invalid: unterminated string",
+		},
+		{
+			name:        "log monitor with trace-analytics query",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `trace-analytics("env:prod operation_name:pylons.request").rollup("count").by("*").last("5m") > 100`,
+			wantErr:     `spec.Query is invalid: query must start with logs("<search query>")`,
+		},
+		{
+			name:        "trace-analytics query",
+			monitorType: DatadogMonitorTypeTraceAnalytics,
+			query:       `trace-analytics("env:prod operation_name:pylons.request").rollup("count").by("*").last("5m") > 100`,
+		},
+		{
+			name:        "event-v2 query",
+			monitorType: DatadogMonitorTypeEventV2,
+			query:       `events("sources:nagios status:(error OR warning) priority:normal").rollup("count").last("1h") > 10`,
+		},
+		{
+			name:        "process query",
+			monitorType: DatadogMonitorTypeProcess,
+			query:       `processes('java AND elasticsearch').over('*').rollup('count').last('1h') > 5`,
+		},
+		{
+			name:        "service check query",
+			monitorType: DatadogMonitorTypeService,
+			query:       `"kubernetes.kubelet.check".over("*").by("host").last(2).count_by_status()`,
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("2"),
+					Warning:  apiutils.NewStringPointer("1"),
+					OK:       apiutils.NewStringPointer("1"),
+				},
+			},
+		},
+		{
+			name:        "service check query with fractional thresholds",
+			monitorType: DatadogMonitorTypeService,
+			query:       `"kubernetes.kubelet.check".over("*").by("host").last(2).count_by_status()`,
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("0.5"),
+					Warning:  apiutils.NewStringPointer("0.28"),
+				},
+			},
+		},
+		{
+			name:        "service check query with a comparator",
+			monitorType: DatadogMonitorTypeService,
+			query:       `"kubernetes.kubelet.check".over("*").by("host").last(2).count_by_status() > 1`,
+			wantErr:     "spec.Query is invalid: service check queries cannot have a comparator, thresholds are set in the options",
+		},
+		{
+			name:        "service check query without check name",
+			monitorType: DatadogMonitorTypeService,
+			query:       `over("*").by("host").last(2).count_by_status()`,
+			wantErr:     `spec.Query is invalid: query must start with the quoted check name, e.g. "http.can_connect"`,
+		},
+		{
+			name:        "service check query with invalid number of check runs",
+			monitorType: DatadogMonitorTypeService,
+			query:       `"kubernetes.kubelet.check".over("*").by("host").last("5m").count_by_status()`,
+			wantErr:     `spec.Query is invalid: invalid number of check runs "5m", must be a positive integer`,
+		},
+		{
+			name:        "service check thresholds above the number of check runs",
+			monitorType: DatadogMonitorTypeService,
+			query:       `"kubernetes.kubelet.check".over("*").by("host").last(2).count_by_status()`,
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("3"),
+					Warning:  apiutils.NewStringPointer("0"),
+				},
+			},
+			wantErr: "[spec.Options.Thresholds.Critical must be positive and at most the 2 check runs of the query, spec.Options.Thresholds.Warning must be positive and at most the 2 check runs of the query]",
+		},
+		{
+			name:        "thresholds consistent with an above comparator",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical:         apiutils.NewStringPointer("0.5"),
+					CriticalRecovery: apiutils.NewStringPointer("0.4"),
+					Warning:          apiutils.NewStringPointer("0.3"),
+					WarningRecovery:  apiutils.NewStringPointer("0.2"),
+				},
+			},
+		},
+		{
+			name:        "thresholds inconsistent with an above comparator",
+			monitorType: DatadogMonitorTypeMetric,
+			query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical:         apiutils.NewStringPointer("0.6"),
+					CriticalRecovery: apiutils.NewStringPointer("0.7"),
+					Warning:          apiutils.NewStringPointer("0.5"),
+				},
+			},
+			wantErr: "[spec.Options.Thresholds.Critical 0.6 must match the query threshold 0.5, spec.Options.Thresholds.Warning must be below the critical threshold with the > comparator, spec.Options.Thresholds.CriticalRecovery must be below the critical threshold with the > comparator]",
+		},
+		{
+			name:        "thresholds inconsistent with a below comparator",
+			monitorType: DatadogMonitorTypeQuery,
+			query:       "max(last_15m):sum:kubernetes_state.node.status{status:schedulable} by {kube_cluster_name} < 80",
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical:        apiutils.NewStringPointer("80"),
+					Warning:         apiutils.NewStringPointer("90"),
+					WarningRecovery: apiutils.NewStringPointer("85"),
+				},
+			},
+			wantErr: "spec.Options.Thresholds.WarningRecovery must be above the warning threshold with the < comparator",
+		},
+		{
+			name:        "threshold that is not a number",
+			monitorType: DatadogMonitorTypeLog,
+			query:       `logs("source:nagios AND status:error").index("default").rollup("count").last("1h") > 5`,
+			options: DatadogMonitorOptions{
+				Thresholds: &DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("five"),
+				},
+			},
+			wantErr: "spec.Options.Thresholds.Critical must be a number",
+		},
+		{
+			name:        "query of a monitor type not linted",
+			monitorType: DatadogMonitorTypeSLO,
+			query:       `error_budget("slo-hash-id").over("7d") > 10`,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			spec := &DatadogMonitorSpec{
+				Type:    test.monitorType,
+				Query:   test.query,
+				Options: test.options,
+			}
+			result := utilserrors.NewAggregate(isValidDatadogMonitorQuery(spec))
+			if test.wantErr != "" {
+				assert.EqualError(t, result, test.wantErr)
+			} else {
+				assert.NoError(t, result)
+			}
+		})
+	}
+}
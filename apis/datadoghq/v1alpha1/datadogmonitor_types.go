@@ -10,6 +10,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// DatadogMonitorAdoptIDAnnotationKey is the annotation carrying the ID of an existing Datadog monitor, created
+	// outside Kubernetes, that a new DatadogMonitor takes ownership of instead of creating a new monitor
+	DatadogMonitorAdoptIDAnnotationKey = "monitor.datadoghq.com/adopt-id"
+	// DatadogMonitorWorkloadAnnotationPrefix is the prefix of the Deployment and StatefulSet annotations declaring
+	// monitors, like `monitors.datadoghq.com/restarts: "critical>5"`, from which DatadogMonitors are generated
+	DatadogMonitorWorkloadAnnotationPrefix = "monitors.datadoghq.com/"
+	// DatadogMonitorTemplateLabelKey is the label carrying the name of the template a DatadogMonitor is generated from
+	DatadogMonitorTemplateLabelKey = "monitors.datadoghq.com/template"
+	// DatadogMonitorCredentialsSecretName is the name of the Secret providing the Datadog credentials of the
+	// DatadogMonitors of its namespace that do not reference credentials
+	DatadogMonitorCredentialsSecretName = "datadog-monitor-credentials"
+	// DatadogMonitorCredentialsSiteKey is the key of the Datadog site, for example datadoghq.eu, in a credentials Secret
+	DatadogMonitorCredentialsSiteKey = "site"
+	// DatadogMonitorCredentialsURLKey is the key of the Datadog API URL in a credentials Secret, it takes precedence
+	// over the site
+	DatadogMonitorCredentialsURLKey = "url"
+)
+
 // DatadogMonitorSpec defines the desired state of DatadogMonitor
 type DatadogMonitorSpec struct {
 	// Name is the monitor name
@@ -26,8 +45,67 @@ type DatadogMonitorSpec struct {
 	Type DatadogMonitorType `json:"type,omitempty"`
 	// Options are the optional parameters associated with your monitor
 	Options DatadogMonitorOptions `json:"options,omitempty"`
+	// RestrictedRoles is the list of unique role identifiers allowed to edit the monitor. If empty, all the users with
+	// the Monitors Write permission can edit the monitor.
+	RestrictedRoles []string `json:"restrictedRoles,omitempty"`
+	// DriftRemediation defines what to do when the monitor is modified outside Kubernetes, for example in the
+	// Datadog UI: `enforce` overwrites the monitor with the spec, `report` (default) only reports the drift
+	DriftRemediation DatadogMonitorDriftRemediation `json:"driftRemediation,omitempty"`
+	// Credentials references the Secret containing the credentials of the Datadog organization the monitor is
+	// managed in. When not set, the credentials of the datadog-monitor-credentials Secret of the namespace are used
+	// if it exists, otherwise the credentials of the operator. Changing the organization of an existing monitor
+	// is not supported: the monitor is not moved to the new organization.
+	// +optional
+	Credentials *DatadogMonitorCredentials `json:"credentials,omitempty"`
+	// DeletionPolicy defines what happens to the monitor when the DatadogMonitor is deleted: `Delete` deletes it,
+	// `Orphan` keeps it in Datadog without the generated:kubernetes tag, for example to move it to another cluster.
+	// When not set, the default deletion policy of the operator is used.
+	// +optional
+	DeletionPolicy DatadogMonitorDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DatadogMonitorCredentials references a Secret containing the `api_key` and `app_key` keys of a Datadog
+// organization, and optionally its `site` (for example datadoghq.eu) or API `url`
+type DatadogMonitorCredentials struct {
+	// SecretName is the name of the Secret
+	SecretName string `json:"secretName"`
+	// SecretNamespace is the namespace of the Secret, it defaults to the namespace of the DatadogMonitor.
+	// Secrets of other namespaces can only be referenced if the operator allows it.
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+}
+
+// DatadogMonitorCredentialsSource records the credentials a monitor is managed with
+type DatadogMonitorCredentialsSource struct {
+	// Operator is true when the monitor is managed with the credentials of the operator
+	// +optional
+	Operator bool `json:"operator,omitempty"`
+	// Secret references the credentials Secret the monitor is managed with
+	// +optional
+	Secret *DatadogMonitorCredentials `json:"secret,omitempty"`
+}
+
+// DatadogMonitorDriftRemediation defines what to do when a monitor drifts from its DatadogMonitor spec
+type DatadogMonitorDriftRemediation string
+
+const (
+	// DatadogMonitorDriftRemediationEnforce overwrites the monitor with the spec
+	DatadogMonitorDriftRemediationEnforce DatadogMonitorDriftRemediation = "enforce"
+	// DatadogMonitorDriftRemediationReport only reports the drift in the conditions and events
+	DatadogMonitorDriftRemediationReport DatadogMonitorDriftRemediation = "report"
+)
+
+// DatadogMonitorDeletionPolicy defines what happens to a monitor when its DatadogMonitor is deleted
+type DatadogMonitorDeletionPolicy string
+
+const (
+	// DatadogMonitorDeletionPolicyDelete deletes the monitor from Datadog
+	DatadogMonitorDeletionPolicyDelete DatadogMonitorDeletionPolicy = "Delete"
+	// DatadogMonitorDeletionPolicyOrphan keeps the monitor in Datadog, removes its generated:kubernetes tag and tags
+	// it with the DatadogMonitor it was created from
+	DatadogMonitorDeletionPolicyOrphan DatadogMonitorDeletionPolicy = "Orphan"
+)
+
 // DatadogMonitorType defines the type of monitor
 type DatadogMonitorType string
 
@@ -91,8 +169,91 @@ type DatadogMonitorOptions struct {
 	TimeoutH *int64 `json:"timeoutH,omitempty"`
 	// A struct of the different monitor threshold values.
 	Thresholds *DatadogMonitorOptionsThresholds `json:"thresholds,omitempty"`
-	// A struct of the alerting time window options.
+	// A struct of the alerting time window options. Only available for anomaly monitors.
 	ThresholdWindows *DatadogMonitorOptionsThresholdWindows `json:"thresholdWindows,omitempty"`
+	// Controls what granularity a multi alert monitor notifies on: a list of group tags, for example `cluster`,
+	// or `*` to notify once per monitor.
+	NotifyBy []string `json:"notifyBy,omitempty"`
+	// A Boolean indicating whether a log alert monitor triggers a single alert (true), or one alert per group
+	// breaching the threshold. Only available for log monitors.
+	GroupbySimpleMonitor *bool `json:"groupbySimpleMonitor,omitempty"`
+	// Controls how groups or monitors are treated if an evaluation does not return any data point: `default`,
+	// `show_no_data`, `show_and_notify_no_data` or `resolve`. Replaces NotifyNoData and NoDataTimeframe, and is only
+	// available for log, trace-analytics, event-v2, rum and audit monitors.
+	OnMissingData DatadogMonitorOptionsOnMissingData `json:"onMissingData,omitempty"`
+	// The time span after which groups with missing data are dropped from the monitor state, for example `2d`.
+	// Only available for log, trace-analytics, event-v2, rum and audit monitors.
+	GroupRetentionDuration *string `json:"groupRetentionDuration,omitempty"`
+	// Time (in seconds) to skip evaluations for new groups. For example, this option can be used to skip evaluations
+	// for new hosts while they initialize. Must be a non negative integer.
+	NewGroupDelay *int64 `json:"newGroupDelay,omitempty"`
+	// The statuses for which the monitor re-notifies: `alert`, `warn` and/or `no data`. Requires RenotifyInterval.
+	RenotifyStatuses []DatadogMonitorRenotifyStatus `json:"renotifyStatuses,omitempty"`
+	// The number of times re-notification messages are sent on the current status. Requires RenotifyInterval.
+	RenotifyOccurrences *int64 `json:"renotifyOccurrences,omitempty"`
+	// Toggles the display of additional content sent in the monitor notification: `show_all`, `hide_query`,
+	// `hide_handles` or `hide_all`.
+	NotificationPresetName DatadogMonitorNotificationPresetName `json:"notificationPresetName,omitempty"`
+	// Configuration options for scheduling the monitor evaluations.
+	SchedulingOptions *DatadogMonitorOptionsSchedulingOptions `json:"schedulingOptions,omitempty"`
+}
+
+// DatadogMonitorOptionsOnMissingData controls how groups or monitors are treated if an evaluation does not return any data point
+type DatadogMonitorOptionsOnMissingData string
+
+const (
+	// DatadogMonitorOptionsOnMissingDataDefault keeps the monitor default behavior
+	DatadogMonitorOptionsOnMissingDataDefault DatadogMonitorOptionsOnMissingData = "default"
+	// DatadogMonitorOptionsOnMissingDataShowNoData shows the NO DATA state, without notifying
+	DatadogMonitorOptionsOnMissingDataShowNoData DatadogMonitorOptionsOnMissingData = "show_no_data"
+	// DatadogMonitorOptionsOnMissingDataShowAndNotifyNoData shows the NO DATA state and notifies
+	DatadogMonitorOptionsOnMissingDataShowAndNotifyNoData DatadogMonitorOptionsOnMissingData = "show_and_notify_no_data"
+	// DatadogMonitorOptionsOnMissingDataResolve resolves the monitor or group
+	DatadogMonitorOptionsOnMissingDataResolve DatadogMonitorOptionsOnMissingData = "resolve"
+)
+
+// DatadogMonitorRenotifyStatus is a monitor status for which re-notification is supported
+type DatadogMonitorRenotifyStatus string
+
+const (
+	// DatadogMonitorRenotifyStatusAlert re-notifies on the alert status
+	DatadogMonitorRenotifyStatusAlert DatadogMonitorRenotifyStatus = "alert"
+	// DatadogMonitorRenotifyStatusWarn re-notifies on the warn status
+	DatadogMonitorRenotifyStatusWarn DatadogMonitorRenotifyStatus = "warn"
+	// DatadogMonitorRenotifyStatusNoData re-notifies on the no data status
+	DatadogMonitorRenotifyStatusNoData DatadogMonitorRenotifyStatus = "no data"
+)
+
+// DatadogMonitorNotificationPresetName toggles the display of additional content sent in the monitor notification
+type DatadogMonitorNotificationPresetName string
+
+const (
+	// DatadogMonitorNotificationPresetNameShowAll shows all the content
+	DatadogMonitorNotificationPresetNameShowAll DatadogMonitorNotificationPresetName = "show_all"
+	// DatadogMonitorNotificationPresetNameHideQuery hides the query
+	DatadogMonitorNotificationPresetNameHideQuery DatadogMonitorNotificationPresetName = "hide_query"
+	// DatadogMonitorNotificationPresetNameHideHandles hides the notification handles
+	DatadogMonitorNotificationPresetNameHideHandles DatadogMonitorNotificationPresetName = "hide_handles"
+	// DatadogMonitorNotificationPresetNameHideAll hides the query and the notification handles
+	DatadogMonitorNotificationPresetNameHideAll DatadogMonitorNotificationPresetName = "hide_all"
+)
+
+// DatadogMonitorOptionsSchedulingOptions is a struct of the configuration options for scheduling
+type DatadogMonitorOptionsSchedulingOptions struct {
+	// Configuration options for the evaluation window. If set, the monitor evaluates cumulative time windows
+	// instead of rolling ones.
+	EvaluationWindow *DatadogMonitorOptionsEvaluationWindow `json:"evaluationWindow,omitempty"`
+}
+
+// DatadogMonitorOptionsEvaluationWindow is a struct of the configuration options for the evaluation window.
+// Exactly one of the fields must be set, according to the timeframe of the monitor query.
+type DatadogMonitorOptionsEvaluationWindow struct {
+	// The time of the day, formatted as `HH:mm` in UTC, at which a one day cumulative evaluation window starts.
+	DayStarts *string `json:"dayStarts,omitempty"`
+	// The minute of the hour, from 0 to 59, at which a one hour cumulative evaluation window starts.
+	HourStarts *int32 `json:"hourStarts,omitempty"`
+	// The day of the month at which a one month cumulative evaluation window starts. Only 1 is supported.
+	MonthStarts *int32 `json:"monthStarts,omitempty"`
 }
 
 // DatadogMonitorOptionsThresholds is a struct of the different monitor threshold values
@@ -146,12 +307,17 @@ type DatadogMonitorStatus struct {
 	DowntimeStatus DatadogMonitorDowntimeStatus `json:"downtimeStatus,omitempty"`
 
 	// Primary defines whether the monitor is managed by the Kubernetes custom
-	// resource (true) or outside Kubernetes (false)
+	// resource (true) or outside Kubernetes (false). Adopted monitors are managed by
+	// the Kubernetes custom resource.
 	Primary bool `json:"primary,omitempty"`
 
 	// CurrentHash tracks the hash of the current DatadogMonitorSpec to know
 	// if the Spec has changed and needs an update
 	CurrentHash string `json:"currentHash,omitempty"`
+
+	// CredentialsSource records the credentials the monitor was created or adopted with. They are used to manage
+	// the monitor until the DatadogMonitor is deleted, so that it is always deleted from its organization.
+	CredentialsSource *DatadogMonitorCredentialsSource `json:"credentialsSource,omitempty"`
 }
 
 // DatadogMonitorCondition describes the current state of a DatadogMonitor
@@ -185,6 +351,10 @@ const (
 	DatadogMonitorConditionTypeActive DatadogMonitorConditionType = "Active"
 	// DatadogMonitorConditionTypeUpdated means the DatadogMonitor is updated
 	DatadogMonitorConditionTypeUpdated DatadogMonitorConditionType = "Updated"
+	// DatadogMonitorConditionTypeAdopted means the DatadogMonitor took ownership of an existing monitor
+	DatadogMonitorConditionTypeAdopted DatadogMonitorConditionType = "Adopted"
+	// DatadogMonitorConditionTypeDrifted means the monitor was modified outside Kubernetes and differs from the spec
+	DatadogMonitorConditionTypeDrifted DatadogMonitorConditionType = "Drifted"
 	// DatadogMonitorConditionTypeError means the DatadogMonitor has an error
 	DatadogMonitorConditionTypeError DatadogMonitorConditionType = "Error"
 )
@@ -221,6 +391,10 @@ const (
 	SyncStatusUpdateError SyncStatusMessage = "error updating monitor"
 	// SyncStatusGetError means there is an error getting the monitor
 	SyncStatusGetError SyncStatusMessage = "error getting monitor"
+	// SyncStatusMonitorRefError means a DatadogMonitor referenced by a composite monitor cannot be resolved
+	SyncStatusMonitorRefError SyncStatusMessage = "error resolving monitor reference"
+	// SyncStatusCredentialsError means the Datadog credentials of the monitor cannot be resolved
+	SyncStatusCredentialsError SyncStatusMessage = "error getting credentials"
 )
 
 // DatadogMonitorTriggeredState represents the details of a triggering DatadogMonitor
@@ -244,6 +418,7 @@ type DatadogMonitorDowntimeStatus struct {
 // +kubebuilder:resource:path=datadogmonitors,scope=Namespaced
 // +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="monitor state",type="string",JSONPath=".status.monitorState"
+// +kubebuilder:printcolumn:name="downtimed",type="boolean",JSONPath=".status.downtimeStatus.isDowntimed"
 // +kubebuilder:printcolumn:name="last transition",type="string",JSONPath=".status.monitorStateLastTransitionTime"
 // +kubebuilder:printcolumn:name="last sync",type="string",format="date",JSONPath=".status.monitorStateLastUpdateTime"
 // +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
@@ -7,6 +7,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	utilserrors "k8s.io/apimachinery/pkg/util/errors"
 )
@@ -31,5 +33,150 @@ func IsValidDatadogMonitor(spec *DatadogMonitorSpec) error {
 		errs = append(errs, fmt.Errorf("spec.Message must be defined"))
 	}
 
+	switch spec.DriftRemediation {
+	case "", DatadogMonitorDriftRemediationEnforce, DatadogMonitorDriftRemediationReport:
+	default:
+		errs = append(errs, fmt.Errorf("spec.DriftRemediation must be one of %s or %s", DatadogMonitorDriftRemediationEnforce, DatadogMonitorDriftRemediationReport))
+	}
+
+	switch spec.DeletionPolicy {
+	case "", DatadogMonitorDeletionPolicyDelete, DatadogMonitorDeletionPolicyOrphan:
+	default:
+		errs = append(errs, fmt.Errorf("spec.DeletionPolicy must be one of %s or %s", DatadogMonitorDeletionPolicyDelete, DatadogMonitorDeletionPolicyOrphan))
+	}
+
+	if spec.Credentials != nil && spec.Credentials.SecretName == "" {
+		errs = append(errs, fmt.Errorf("spec.Credentials.SecretName must be defined"))
+	}
+
+	if spec.Query != "" {
+		errs = append(errs, isValidDatadogMonitorQuery(spec)...)
+	}
+
+	errs = append(errs, isValidDatadogMonitorOptions(spec)...)
+
 	return utilserrors.NewAggregate(errs)
 }
+
+var dayStartsRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// isValidDatadogMonitorOptions checks the option values, and that options are only set on the monitor types supporting them
+func isValidDatadogMonitorOptions(spec *DatadogMonitorSpec) []error {
+	var errs []error
+	options := spec.Options
+
+	if options.GroupbySimpleMonitor != nil && spec.Type != DatadogMonitorTypeLog {
+		errs = append(errs, fmt.Errorf("spec.Options.GroupbySimpleMonitor is only supported by %s monitors", DatadogMonitorTypeLog))
+	}
+
+	if options.ThresholdWindows != nil {
+		if !isMonitorTypeIn(spec.Type, DatadogMonitorTypeMetric, DatadogMonitorTypeQuery) || !strings.Contains(spec.Query, "anomalies(") {
+			errs = append(errs, fmt.Errorf("spec.Options.ThresholdWindows is only supported by anomaly monitors"))
+		}
+	}
+
+	eventBasedTypes := []DatadogMonitorType{DatadogMonitorTypeLog, DatadogMonitorTypeTraceAnalytics, DatadogMonitorTypeEventV2, DatadogMonitorTypeRUM, DatadogMonitorTypeAudit}
+	if options.OnMissingData != "" {
+		switch options.OnMissingData {
+		case DatadogMonitorOptionsOnMissingDataDefault, DatadogMonitorOptionsOnMissingDataShowNoData, DatadogMonitorOptionsOnMissingDataShowAndNotifyNoData, DatadogMonitorOptionsOnMissingDataResolve:
+		default:
+			errs = append(errs, fmt.Errorf("spec.Options.OnMissingData must be one of %s, %s, %s or %s", DatadogMonitorOptionsOnMissingDataDefault, DatadogMonitorOptionsOnMissingDataShowNoData, DatadogMonitorOptionsOnMissingDataShowAndNotifyNoData, DatadogMonitorOptionsOnMissingDataResolve))
+		}
+		if !isMonitorTypeIn(spec.Type, eventBasedTypes...) {
+			errs = append(errs, fmt.Errorf("spec.Options.OnMissingData is not supported by %s monitors", spec.Type))
+		}
+		if options.NotifyNoData != nil || options.NoDataTimeframe != nil {
+			errs = append(errs, fmt.Errorf("spec.Options.OnMissingData cannot be used with spec.Options.NotifyNoData or spec.Options.NoDataTimeframe"))
+		}
+	}
+
+	if options.GroupRetentionDuration != nil && !isMonitorTypeIn(spec.Type, eventBasedTypes...) {
+		errs = append(errs, fmt.Errorf("spec.Options.GroupRetentionDuration is not supported by %s monitors", spec.Type))
+	}
+
+	if options.NewGroupDelay != nil && *options.NewGroupDelay < 0 {
+		errs = append(errs, fmt.Errorf("spec.Options.NewGroupDelay must be a non negative integer"))
+	}
+
+	for _, notifyBy := range options.NotifyBy {
+		if notifyBy == "" {
+			errs = append(errs, fmt.Errorf("spec.Options.NotifyBy cannot contain empty values"))
+			break
+		}
+		if notifyBy == "*" && len(options.NotifyBy) > 1 {
+			errs = append(errs, fmt.Errorf("spec.Options.NotifyBy cannot combine * with group tags"))
+			break
+		}
+	}
+
+	for _, status := range options.RenotifyStatuses {
+		switch status {
+		case DatadogMonitorRenotifyStatusAlert, DatadogMonitorRenotifyStatusWarn, DatadogMonitorRenotifyStatusNoData:
+		default:
+			errs = append(errs, fmt.Errorf("spec.Options.RenotifyStatuses values must be one of %s, %s or %s", DatadogMonitorRenotifyStatusAlert, DatadogMonitorRenotifyStatusWarn, DatadogMonitorRenotifyStatusNoData))
+		}
+	}
+
+	if (len(options.RenotifyStatuses) > 0 || options.RenotifyOccurrences != nil) && options.RenotifyInterval == nil {
+		errs = append(errs, fmt.Errorf("spec.Options.RenotifyStatuses and spec.Options.RenotifyOccurrences require spec.Options.RenotifyInterval"))
+	}
+
+	switch options.NotificationPresetName {
+	case "", DatadogMonitorNotificationPresetNameShowAll, DatadogMonitorNotificationPresetNameHideQuery, DatadogMonitorNotificationPresetNameHideHandles, DatadogMonitorNotificationPresetNameHideAll:
+	default:
+		errs = append(errs, fmt.Errorf("spec.Options.NotificationPresetName must be one of %s, %s, %s or %s", DatadogMonitorNotificationPresetNameShowAll, DatadogMonitorNotificationPresetNameHideQuery, DatadogMonitorNotificationPresetNameHideHandles, DatadogMonitorNotificationPresetNameHideAll))
+	}
+
+	if options.SchedulingOptions != nil {
+		if !isMonitorTypeIn(spec.Type, append(eventBasedTypes, DatadogMonitorTypeMetric, DatadogMonitorTypeQuery)...) {
+			errs = append(errs, fmt.Errorf("spec.Options.SchedulingOptions is not supported by %s monitors", spec.Type))
+		}
+		if window := options.SchedulingOptions.EvaluationWindow; window != nil {
+			errs = append(errs, isValidEvaluationWindow(window)...)
+		}
+	}
+
+	return errs
+}
+
+func isValidEvaluationWindow(window *DatadogMonitorOptionsEvaluationWindow) []error {
+	var errs []error
+	set := 0
+
+	if window.DayStarts != nil {
+		set++
+		if !dayStartsRegexp.MatchString(*window.DayStarts) {
+			errs = append(errs, fmt.Errorf("spec.Options.SchedulingOptions.EvaluationWindow.DayStarts must be formatted as HH:mm"))
+		}
+	}
+
+	if window.HourStarts != nil {
+		set++
+		if *window.HourStarts < 0 || *window.HourStarts > 59 {
+			errs = append(errs, fmt.Errorf("spec.Options.SchedulingOptions.EvaluationWindow.HourStarts must be between 0 and 59"))
+		}
+	}
+
+	if window.MonthStarts != nil {
+		set++
+		if *window.MonthStarts != 1 {
+			errs = append(errs, fmt.Errorf("spec.Options.SchedulingOptions.EvaluationWindow.MonthStarts must be 1"))
+		}
+	}
+
+	if set != 1 {
+		errs = append(errs, fmt.Errorf("spec.Options.SchedulingOptions.EvaluationWindow must set exactly one of DayStarts, HourStarts or MonthStarts"))
+	}
+
+	return errs
+}
+
+func isMonitorTypeIn(monitorType DatadogMonitorType, types ...DatadogMonitorType) bool {
+	for _, t := range types {
+		if monitorType == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -8,6 +8,8 @@ package v1alpha1
 import (
 	"testing"
 
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,6 +41,118 @@ func TestIsValidDatadogMonitor(t *testing.T) {
 		Type:  "metric alert",
 		Name:  "Test Monitor",
 	}
+	enforceDrift := &DatadogMonitorSpec{
+		Query:            "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:             "metric alert",
+		Name:             "Test Monitor",
+		Message:          "Something is wrong",
+		DriftRemediation: "enforce",
+	}
+	invalidDriftRemediation := &DatadogMonitorSpec{
+		Query:            "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:             "metric alert",
+		Name:             "Test Monitor",
+		Message:          "Something is wrong",
+		DriftRemediation: "ignore",
+	}
+	orphanOnDeletion := &DatadogMonitorSpec{
+		Query:          "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:           "metric alert",
+		Name:           "Test Monitor",
+		Message:        "Something is wrong",
+		DeletionPolicy: "Orphan",
+	}
+	invalidDeletionPolicy := &DatadogMonitorSpec{
+		Query:          "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:           "metric alert",
+		Name:           "Test Monitor",
+		Message:        "Something is wrong",
+		DeletionPolicy: "Retain",
+	}
+	validLogOptions := &DatadogMonitorSpec{
+		Query:   "logs(\"service:foo status:error\").index(\"*\").rollup(\"count\").by(\"env\").last(\"5m\") > 10",
+		Type:    "log alert",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+		Options: DatadogMonitorOptions{
+			NotifyBy:               []string{"env"},
+			GroupbySimpleMonitor:   apiutils.NewBoolPointer(false),
+			OnMissingData:          "show_and_notify_no_data",
+			GroupRetentionDuration: apiutils.NewStringPointer("2d"),
+			NewGroupDelay:          apiutils.NewInt64Pointer(60),
+			RenotifyInterval:       apiutils.NewInt64Pointer(30),
+			RenotifyStatuses:       []DatadogMonitorRenotifyStatus{"alert", "no data"},
+			RenotifyOccurrences:    apiutils.NewInt64Pointer(3),
+			NotificationPresetName: "hide_query",
+			SchedulingOptions: &DatadogMonitorOptionsSchedulingOptions{
+				EvaluationWindow: &DatadogMonitorOptionsEvaluationWindow{
+					DayStarts: apiutils.NewStringPointer("04:00"),
+				},
+			},
+		},
+	}
+	metricEventBasedOptions := &DatadogMonitorSpec{
+		Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:    "metric alert",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+		Options: DatadogMonitorOptions{
+			GroupbySimpleMonitor:   apiutils.NewBoolPointer(true),
+			OnMissingData:          "resolve",
+			GroupRetentionDuration: apiutils.NewStringPointer("2d"),
+			ThresholdWindows: &DatadogMonitorOptionsThresholdWindows{
+				TriggerWindow: apiutils.NewStringPointer("last_15m"),
+			},
+		},
+	}
+	validAnomalyOptions := &DatadogMonitorSpec{
+		Query:   "avg(last_4h):anomalies(avg:system.cpu.user{*}, 'basic', 2) >= 1",
+		Type:    "query alert",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+		Options: DatadogMonitorOptions{
+			ThresholdWindows: &DatadogMonitorOptionsThresholdWindows{
+				TriggerWindow:  apiutils.NewStringPointer("last_15m"),
+				RecoveryWindow: apiutils.NewStringPointer("last_15m"),
+			},
+		},
+	}
+	credentialsWithoutSecretName := &DatadogMonitorSpec{
+		Query:       "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.05",
+		Type:        "metric alert",
+		Name:        "Test Monitor",
+		Message:     "Something is wrong",
+		Credentials: &DatadogMonitorCredentials{SecretNamespace: "datadog"},
+	}
+	invalidOptionValues := &DatadogMonitorSpec{
+		Query:   "logs(\"service:foo status:error\").index(\"*\").rollup(\"count\").last(\"5m\") > 10",
+		Type:    "log alert",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+		Options: DatadogMonitorOptions{
+			NotifyBy:               []string{"*", "env"},
+			OnMissingData:          "ignore",
+			NotifyNoData:           apiutils.NewBoolPointer(true),
+			NewGroupDelay:          apiutils.NewInt64Pointer(-1),
+			RenotifyStatuses:       []DatadogMonitorRenotifyStatus{"ok"},
+			NotificationPresetName: "hide_everything",
+		},
+	}
+	invalidEvaluationWindow := &DatadogMonitorSpec{
+		Query:   "\"http.can_connect\".over(\"*\").by(\"host\").last(3).count_by_status()",
+		Type:    "service check",
+		Name:    "Test Monitor",
+		Message: "Something is wrong",
+		Options: DatadogMonitorOptions{
+			SchedulingOptions: &DatadogMonitorOptionsSchedulingOptions{
+				EvaluationWindow: &DatadogMonitorOptionsEvaluationWindow{
+					DayStarts:   apiutils.NewStringPointer("4am"),
+					HourStarts:  apiutils.NewInt32Pointer(60),
+					MonthStarts: apiutils.NewInt32Pointer(2),
+				},
+			},
+		},
+	}
 
 	testCases := []struct {
 		name    string
@@ -69,6 +183,52 @@ func TestIsValidDatadogMonitor(t *testing.T) {
 			spec:    missingMessage,
 			wantErr: "spec.Message must be defined",
 		},
+		{
+			name: "monitor enforcing drift remediation",
+			spec: enforceDrift,
+		},
+		{
+			name:    "monitor with invalid drift remediation",
+			spec:    invalidDriftRemediation,
+			wantErr: "spec.DriftRemediation must be one of enforce or report",
+		},
+		{
+			name: "monitor orphaned on deletion",
+			spec: orphanOnDeletion,
+		},
+		{
+			name:    "monitor with invalid deletion policy",
+			spec:    invalidDeletionPolicy,
+			wantErr: "spec.DeletionPolicy must be one of Delete or Orphan",
+		},
+		{
+			name: "log monitor with all the options",
+			spec: validLogOptions,
+		},
+		{
+			name:    "metric monitor with options of other monitor types",
+			spec:    metricEventBasedOptions,
+			wantErr: "[spec.Options.GroupbySimpleMonitor is only supported by log alert monitors, spec.Options.ThresholdWindows is only supported by anomaly monitors, spec.Options.OnMissingData is not supported by metric alert monitors, spec.Options.GroupRetentionDuration is not supported by metric alert monitors]",
+		},
+		{
+			name: "anomaly monitor with threshold windows",
+			spec: validAnomalyOptions,
+		},
+		{
+			name:    "monitor with credentials without secret name",
+			spec:    credentialsWithoutSecretName,
+			wantErr: "spec.Credentials.SecretName must be defined",
+		},
+		{
+			name:    "monitor with invalid option values",
+			spec:    invalidOptionValues,
+			wantErr: "[spec.Options.OnMissingData must be one of default, show_no_data, show_and_notify_no_data or resolve, spec.Options.OnMissingData cannot be used with spec.Options.NotifyNoData or spec.Options.NoDataTimeframe, spec.Options.NewGroupDelay must be a non negative integer, spec.Options.NotifyBy cannot combine * with group tags, spec.Options.RenotifyStatuses values must be one of alert, warn or no data, spec.Options.RenotifyStatuses and spec.Options.RenotifyOccurrences require spec.Options.RenotifyInterval, spec.Options.NotificationPresetName must be one of show_all, hide_query, hide_handles or hide_all]",
+		},
+		{
+			name:    "monitor with invalid evaluation window",
+			spec:    invalidEvaluationWindow,
+			wantErr: "[spec.Options.SchedulingOptions is not supported by service check monitors, spec.Options.SchedulingOptions.EvaluationWindow.DayStarts must be formatted as HH:mm, spec.Options.SchedulingOptions.EvaluationWindow.HourStarts must be between 0 and 59, spec.Options.SchedulingOptions.EvaluationWindow.MonthStarts must be 1, spec.Options.SchedulingOptions.EvaluationWindow must set exactly one of DayStarts, HourStarts or MonthStarts]",
+		},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
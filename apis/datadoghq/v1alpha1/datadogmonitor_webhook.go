@@ -0,0 +1,62 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-datadoghq-com-v1alpha1-datadogmonitor,mutating=true,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogmonitors,verbs=create;update,versions=v1alpha1,name=mdatadogmonitor.kb.io,admissionReviewVersions={v1,v1beta1}
+// +kubebuilder:webhook:path=/validate-datadoghq-com-v1alpha1-datadogmonitor,mutating=false,failurePolicy=fail,sideEffects=None,groups=datadoghq.com,resources=datadogmonitors,verbs=create;update,versions=v1alpha1,name=vdatadogmonitor.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var (
+	_ webhook.Defaulter = &DatadogMonitor{}
+	_ webhook.Validator = &DatadogMonitor{}
+)
+
+// SetupWebhookWithManager starts the DatadogMonitor admission webhooks
+func (m *DatadogMonitor) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(m).
+		Complete()
+}
+
+// Default adds the required tags at admission time, instead of updating the DatadogMonitor during reconciliation
+func (m *DatadogMonitor) Default() {
+	DefaultDatadogMonitor(m)
+}
+
+// ValidateCreate rejects DatadogMonitors with an invalid spec
+func (m *DatadogMonitor) ValidateCreate() error {
+	return IsValidDatadogMonitor(&m.Spec)
+}
+
+// ValidateUpdate rejects updates resulting in an invalid spec. Updates of DatadogMonitors being deleted, and updates
+// leaving the spec unchanged, are accepted, so that a DatadogMonitor which became invalid with a stricter validation
+// can still lose its finalizer and get its metadata updated.
+func (m *DatadogMonitor) ValidateUpdate(old runtime.Object) error {
+	if m.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldMonitor, ok := old.(*DatadogMonitor); ok {
+		// The required tags are added to the new spec by the defaulting webhook
+		oldMonitor = oldMonitor.DeepCopy()
+		DefaultDatadogMonitor(oldMonitor)
+		if apiequality.Semantic.DeepEqual(oldMonitor.Spec, m.Spec) {
+			return nil
+		}
+	}
+
+	return IsValidDatadogMonitor(&m.Spec)
+}
+
+// ValidateDelete accepts all deletions, so that invalid DatadogMonitors can be cleaned up
+func (m *DatadogMonitor) ValidateDelete() error {
+	return nil
+}
@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDatadogMonitorWebhook(t *testing.T) {
+	dm := &DatadogMonitor{
+		Spec: DatadogMonitorSpec{
+			Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5",
+			Type:    DatadogMonitorTypeMetric,
+			Name:    "Test Monitor",
+			Message: "Something is wrong",
+			Tags:    []string{"env:prod"},
+		},
+	}
+
+	dm.Default()
+	assert.Equal(t, []string{"env:prod", "generated:kubernetes"}, dm.Spec.Tags)
+	assert.NoError(t, dm.ValidateCreate())
+
+	old := dm.DeepCopy()
+	dm.Spec.Query = "avg(last_10m):avg:system.disk.in_use{*} by {host}"
+	assert.EqualError(t, dm.ValidateUpdate(old), "spec.Query is invalid: missing comparison to a threshold, e.g. > 10")
+	assert.NoError(t, dm.ValidateDelete())
+}
+
+func TestDatadogMonitorWebhook_invalidMonitor(t *testing.T) {
+	// A DatadogMonitor created before its spec was rejected by the validation
+	dm := &DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Finalizers: []string{"finalizer.monitor.datadoghq.com"},
+		},
+		Spec: DatadogMonitorSpec{
+			Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host}",
+			Type:    DatadogMonitorTypeMetric,
+			Name:    "Test Monitor",
+			Message: "Something is wrong",
+		},
+	}
+
+	// Metadata updates are accepted, even when the defaulting webhook adds the required tags
+	updated := dm.DeepCopy()
+	updated.Labels = map[string]string{"team": "web"}
+	updated.Default()
+	assert.NoError(t, updated.ValidateUpdate(dm))
+
+	// Spec updates are validated
+	updated = dm.DeepCopy()
+	updated.Spec.Message = "Something else is wrong"
+	assert.EqualError(t, updated.ValidateUpdate(dm), "spec.Query is invalid: missing comparison to a threshold, e.g. > 10")
+
+	// The finalizer can be removed once the DatadogMonitor is deleted
+	deleted := dm.DeepCopy()
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	updated = deleted.DeepCopy()
+	updated.Finalizers = nil
+	assert.NoError(t, updated.ValidateUpdate(deleted))
+}
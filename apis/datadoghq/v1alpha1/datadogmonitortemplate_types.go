@@ -0,0 +1,151 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DatadogMonitorTemplateNameLabelKey is the label carrying the name of the DatadogMonitorTemplate a DatadogMonitor
+	// is generated from
+	DatadogMonitorTemplateNameLabelKey = "monitors.datadoghq.com/datadogmonitortemplate"
+)
+
+// DatadogMonitorTemplateSpec defines the desired state of DatadogMonitorTemplate
+type DatadogMonitorTemplateSpec struct {
+	// Selector selects the namespaces or workloads a DatadogMonitor is generated for
+	Selector DatadogMonitorTemplateSelector `json:"selector"`
+	// Template is the spec of the generated DatadogMonitors. Its name, message and query are Go templates rendered
+	// with the matched object, for example `{{ .Namespace }}`, `{{ .Name }}` or `{{ index .Labels "team" }}`.
+	Template DatadogMonitorSpec `json:"template"`
+}
+
+// DatadogMonitorTemplateSelector selects the objects a DatadogMonitor is generated for
+type DatadogMonitorTemplateSelector struct {
+	// Kind is the kind of the selected objects: `Namespace` (default), `Deployment` or `StatefulSet`.
+	// A DatadogMonitor is generated in the namespace of each selected object.
+	// +optional
+	Kind DatadogMonitorTemplateTargetKind `json:"kind,omitempty"`
+	// NamespaceSelector selects the namespaces, or the namespaces of the selected workloads. All the namespaces are
+	// selected if unset.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// LabelSelector selects the workloads of the Deployment and StatefulSet kinds. All the workloads of the selected
+	// namespaces are selected if unset. It is not used with the Namespace kind.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// DatadogMonitorTemplateTargetKind is the kind of the objects selected by a DatadogMonitorTemplate
+type DatadogMonitorTemplateTargetKind string
+
+const (
+	// DatadogMonitorTemplateTargetKindNamespace generates a DatadogMonitor per selected namespace
+	DatadogMonitorTemplateTargetKindNamespace DatadogMonitorTemplateTargetKind = "Namespace"
+	// DatadogMonitorTemplateTargetKindDeployment generates a DatadogMonitor per selected Deployment
+	DatadogMonitorTemplateTargetKindDeployment DatadogMonitorTemplateTargetKind = "Deployment"
+	// DatadogMonitorTemplateTargetKindStatefulSet generates a DatadogMonitor per selected StatefulSet
+	DatadogMonitorTemplateTargetKindStatefulSet DatadogMonitorTemplateTargetKind = "StatefulSet"
+)
+
+// GetKind returns the kind of the selected objects, defaulting to Namespace
+func (s *DatadogMonitorTemplateSelector) GetKind() DatadogMonitorTemplateTargetKind {
+	if s.Kind == "" {
+		return DatadogMonitorTemplateTargetKindNamespace
+	}
+	return s.Kind
+}
+
+// DatadogMonitorTemplateStatus defines the observed state of DatadogMonitorTemplate
+type DatadogMonitorTemplateStatus struct {
+	// Conditions Represents the latest available observations of a DatadogMonitorTemplate's current state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []DatadogMonitorTemplateCondition `json:"conditions,omitempty"`
+
+	// Monitors is the list of the DatadogMonitors generated from the template
+	// +listType=atomic
+	Monitors []DatadogMonitorTemplateMonitorStatus `json:"monitors,omitempty"`
+	// MonitorCount is the number of DatadogMonitors generated from the template
+	MonitorCount int32 `json:"monitorCount,omitempty"`
+}
+
+// DatadogMonitorTemplateMonitorStatus is the state of a DatadogMonitor generated from a DatadogMonitorTemplate
+type DatadogMonitorTemplateMonitorStatus struct {
+	// Namespace is the namespace of the DatadogMonitor
+	Namespace string `json:"namespace"`
+	// Name is the name of the DatadogMonitor
+	Name string `json:"name"`
+	// ID is the monitor ID generated in Datadog
+	ID int `json:"id,omitempty"`
+	// MonitorState is the overall state of monitor
+	MonitorState DatadogMonitorState `json:"monitorState,omitempty"`
+	// SyncStatus shows the health of syncing the monitor state to Datadog
+	SyncStatus SyncStatusMessage `json:"syncStatus,omitempty"`
+}
+
+// DatadogMonitorTemplateCondition describes the current state of a DatadogMonitorTemplate
+// +k8s:openapi-gen=true
+type DatadogMonitorTemplateCondition struct {
+	// Type of DatadogMonitorTemplate condition
+	Type DatadogMonitorTemplateConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Last time the condition was updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DatadogMonitorTemplateConditionType represents a DatadogMonitorTemplate condition
+type DatadogMonitorTemplateConditionType string
+
+const (
+	// DatadogMonitorTemplateConditionTypeActive means the DatadogMonitors are generated from the DatadogMonitorTemplate
+	DatadogMonitorTemplateConditionTypeActive DatadogMonitorTemplateConditionType = "Active"
+	// DatadogMonitorTemplateConditionTypeError means the DatadogMonitorTemplate has an error
+	DatadogMonitorTemplateConditionTypeError DatadogMonitorTemplateConditionType = "Error"
+)
+
+// DatadogMonitorTemplate generates DatadogMonitors from a template for the namespaces or workloads matching selectors
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogmonitortemplates,scope=Cluster
+// +kubebuilder:printcolumn:name="kind",type="string",JSONPath=".spec.selector.kind"
+// +kubebuilder:printcolumn:name="monitors",type="integer",JSONPath=".status.monitorCount"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+type DatadogMonitorTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogMonitorTemplateSpec   `json:"spec,omitempty"`
+	Status DatadogMonitorTemplateStatus `json:"status,omitempty"`
+}
+
+// DatadogMonitorTemplateList contains a list of DatadogMonitorTemplates
+// +kubebuilder:object:root=true
+type DatadogMonitorTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogMonitorTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogMonitorTemplate{}, &DatadogMonitorTemplateList{})
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// IsValidDatadogMonitorTemplate use to check if a DatadogMonitorTemplateSpec is valid by checking that the selectors
+// are consistent and that the templated fields are defined and can be parsed. The generated DatadogMonitors are
+// validated once rendered.
+func IsValidDatadogMonitorTemplate(spec *DatadogMonitorTemplateSpec) error {
+	var errs []error
+	switch spec.Selector.GetKind() {
+	case DatadogMonitorTemplateTargetKindNamespace:
+		if spec.Selector.LabelSelector != nil {
+			errs = append(errs, fmt.Errorf("spec.Selector.LabelSelector cannot be used with the %s kind, use spec.Selector.NamespaceSelector", DatadogMonitorTemplateTargetKindNamespace))
+		}
+	case DatadogMonitorTemplateTargetKindDeployment, DatadogMonitorTemplateTargetKindStatefulSet:
+	default:
+		errs = append(errs, fmt.Errorf("spec.Selector.Kind must be one of %s, %s or %s", DatadogMonitorTemplateTargetKindNamespace, DatadogMonitorTemplateTargetKindDeployment, DatadogMonitorTemplateTargetKindStatefulSet))
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(spec.Selector.NamespaceSelector); err != nil {
+		errs = append(errs, fmt.Errorf("spec.Selector.NamespaceSelector is invalid: %w", err))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(spec.Selector.LabelSelector); err != nil {
+		errs = append(errs, fmt.Errorf("spec.Selector.LabelSelector is invalid: %w", err))
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"spec.Template.Name", spec.Template.Name},
+		{"spec.Template.Message", spec.Template.Message},
+		{"spec.Template.Query", spec.Template.Query},
+	} {
+		if field.value == "" {
+			errs = append(errs, fmt.Errorf("%s must be defined", field.name))
+			continue
+		}
+		if _, err := template.New(field.name).Parse(field.value); err != nil {
+			errs = append(errs, fmt.Errorf("%s is not a valid template: %w", field.name, err))
+		}
+	}
+
+	if spec.Template.Type == "" {
+		errs = append(errs, fmt.Errorf("spec.Template.Type must be defined"))
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsValidDatadogMonitorTemplate(t *testing.T) {
+	template := DatadogMonitorSpec{
+		Name:    "Restarts in {{ .Namespace }}",
+		Message: "Pods restart in {{ .Namespace }} @{{ index .Labels \"team\" }}",
+		Query:   "change(sum(last_5m),last_5m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Namespace }}} > 5",
+		Type:    DatadogMonitorTypeQuery,
+	}
+
+	testCases := []struct {
+		name    string
+		spec    *DatadogMonitorTemplateSpec
+		wantErr string
+	}{
+		{
+			name: "valid namespace template",
+			spec: &DatadogMonitorTemplateSpec{
+				Selector: DatadogMonitorTemplateSelector{
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"monitored": "true"}},
+				},
+				Template: template,
+			},
+		},
+		{
+			name: "valid deployment template",
+			spec: &DatadogMonitorTemplateSpec{
+				Selector: DatadogMonitorTemplateSelector{
+					Kind:          DatadogMonitorTemplateTargetKindDeployment,
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+				},
+				Template: template,
+			},
+		},
+		{
+			name: "label selector with the namespace kind",
+			spec: &DatadogMonitorTemplateSpec{
+				Selector: DatadogMonitorTemplateSelector{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+				},
+				Template: template,
+			},
+			wantErr: "spec.Selector.LabelSelector cannot be used with the Namespace kind, use spec.Selector.NamespaceSelector",
+		},
+		{
+			name: "invalid kind",
+			spec: &DatadogMonitorTemplateSpec{
+				Selector: DatadogMonitorTemplateSelector{Kind: "DaemonSet"},
+				Template: template,
+			},
+			wantErr: "spec.Selector.Kind must be one of Namespace, Deployment or StatefulSet",
+		},
+		{
+			name: "invalid namespace selector",
+			spec: &DatadogMonitorTemplateSpec{
+				Selector: DatadogMonitorTemplateSelector{
+					NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Is"}}},
+				},
+				Template: template,
+			},
+			wantErr: `spec.Selector.NamespaceSelector is invalid: "Is" is not a valid pod selector operator`,
+		},
+		{
+			name: "invalid and missing templates",
+			spec: &DatadogMonitorTemplateSpec{
+				Template: DatadogMonitorSpec{
+					Name:  "Restarts in {{ .Namespace }",
+					Query: template.Query,
+				},
+			},
+			wantErr: `[spec.Template.Name is not a valid template: template: spec.Template.Name:1: unexpected "}" in operand, spec.Template.Message must be defined, spec.Template.Type must be defined]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := IsValidDatadogMonitorTemplate(test.spec)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatadogSLOSpec defines the desired state of DatadogSLO
+type DatadogSLOSpec struct {
+	// Name is the name of the service level objective
+	Name string `json:"name,omitempty"`
+	// Description is a user-defined description of the service level objective
+	Description *string `json:"description,omitempty"`
+	// Tags is a list of tags to associate with the service level objective
+	Tags []string `json:"tags,omitempty"`
+	// Type is the type of the service level objective, one of `metric` or `monitor`
+	Type DatadogSLOType `json:"type,omitempty"`
+	// Query is the metric query of good and total events. It is only used by `metric` SLOs.
+	Query *DatadogSLOQuery `json:"query,omitempty"`
+	// MonitorIDs is a list of IDs of monitors defined in Datadog. It is only used by `monitor` SLOs.
+	MonitorIDs []int64 `json:"monitorIDs,omitempty"`
+	// MonitorRefs is a list of references to DatadogMonitors in the same namespace as the DatadogSLO.
+	// Their IDs are added to MonitorIDs once the monitors are created in Datadog. It is only used by `monitor` SLOs.
+	MonitorRefs []DatadogSLOMonitorReference `json:"monitorRefs,omitempty"`
+	// Groups is a list of monitor groups to restrict a `monitor` SLO with a single multi-alert monitor to
+	Groups []string `json:"groups,omitempty"`
+	// Thresholds is the list of targets of the service level objective for each timeframe
+	Thresholds []DatadogSLOThreshold `json:"thresholds,omitempty"`
+}
+
+// DatadogSLOType defines the type of a service level objective
+type DatadogSLOType string
+
+const (
+	// DatadogSLOTypeMetric is the type of SLOs based on the ratio of good events over total events
+	DatadogSLOTypeMetric DatadogSLOType = "metric"
+	// DatadogSLOTypeMonitor is the type of SLOs based on the uptime of monitors
+	DatadogSLOTypeMonitor DatadogSLOType = "monitor"
+)
+
+// DatadogSLOQuery defines the metric queries of a `metric` SLO
+type DatadogSLOQuery struct {
+	// Numerator is the sum of the good events
+	Numerator string `json:"numerator"`
+	// Denominator is the sum of all the events
+	Denominator string `json:"denominator"`
+}
+
+// DatadogSLOMonitorReference is a reference to a DatadogMonitor in the same namespace
+type DatadogSLOMonitorReference struct {
+	// Name is the name of the DatadogMonitor
+	Name string `json:"name"`
+}
+
+// DatadogSLOTimeframe is the time window of a service level objective target
+type DatadogSLOTimeframe string
+
+const (
+	// DatadogSLOTimeframeSevenDays is a rolling window of 7 days
+	DatadogSLOTimeframeSevenDays DatadogSLOTimeframe = "7d"
+	// DatadogSLOTimeframeThirtyDays is a rolling window of 30 days
+	DatadogSLOTimeframeThirtyDays DatadogSLOTimeframe = "30d"
+	// DatadogSLOTimeframeNinetyDays is a rolling window of 90 days
+	DatadogSLOTimeframeNinetyDays DatadogSLOTimeframe = "90d"
+)
+
+// DatadogSLOThreshold defines the target of a service level objective over a timeframe
+type DatadogSLOThreshold struct {
+	// Timeframe is the time window of the target, one of `7d`, `30d` or `90d`
+	Timeframe DatadogSLOTimeframe `json:"timeframe"`
+	// Target is the target value of the service level objective, as a percentage (e.g. `99.9`)
+	Target string `json:"target"`
+	// Warning is an optional warning value of the service level objective, as a percentage
+	Warning *string `json:"warning,omitempty"`
+}
+
+// DatadogSLOStatus defines the observed state of DatadogSLO
+type DatadogSLOStatus struct {
+	// Conditions Represents the latest available observations of a DatadogSLO's current state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []DatadogSLOCondition `json:"conditions,omitempty"`
+
+	// ID is the SLO ID generated in Datadog
+	ID string `json:"id,omitempty"`
+	// Creator is the identity of the SLO creator
+	Creator string `json:"creator,omitempty"`
+	// Created is the time the SLO was created
+	Created *metav1.Time `json:"created,omitempty"`
+	// MonitorIDs is the list of monitor IDs sent to Datadog, including the IDs of the referenced DatadogMonitors
+	MonitorIDs []int64 `json:"monitorIDs,omitempty"`
+
+	// Timeframe is the timeframe of the first threshold, over which SLIValue and ErrorBudgetRemaining are computed
+	Timeframe DatadogSLOTimeframe `json:"timeframe,omitempty"`
+	// SLIValue is the current value of the service level indicator over the timeframe, as a percentage
+	SLIValue string `json:"sliValue,omitempty"`
+	// ErrorBudgetRemaining is the percentage of the error budget that remains over the timeframe.
+	// It is negative when the error budget is exhausted.
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining,omitempty"`
+	// SLOStatusLastSyncTime is the last time the SLI value was synced from Datadog
+	SLOStatusLastSyncTime *metav1.Time `json:"sloStatusLastSyncTime,omitempty"`
+	// SyncStatus shows the health of syncing the SLO with Datadog
+	SyncStatus DatadogSLOSyncStatusMessage `json:"syncStatus,omitempty"`
+
+	// CurrentHash tracks the hash of the current DatadogSLOSpec to know
+	// if the Spec has changed and needs an update
+	CurrentHash string `json:"currentHash,omitempty"`
+}
+
+// DatadogSLOCondition describes the current state of a DatadogSLO
+// +k8s:openapi-gen=true
+type DatadogSLOCondition struct {
+	// Type of DatadogSLO condition
+	Type DatadogSLOConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Last time the condition was updated.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DatadogSLOConditionType represents a DatadogSLO condition
+type DatadogSLOConditionType string
+
+const (
+	// DatadogSLOConditionTypeCreated means the DatadogSLO is created successfully
+	DatadogSLOConditionTypeCreated DatadogSLOConditionType = "Created"
+	// DatadogSLOConditionTypeActive means the DatadogSLO is active
+	DatadogSLOConditionTypeActive DatadogSLOConditionType = "Active"
+	// DatadogSLOConditionTypeUpdated means the DatadogSLO is updated
+	DatadogSLOConditionTypeUpdated DatadogSLOConditionType = "Updated"
+	// DatadogSLOConditionTypeError means the DatadogSLO has an error
+	DatadogSLOConditionTypeError DatadogSLOConditionType = "Error"
+)
+
+// DatadogSLOSyncStatusMessage is the message reflecting the health of SLO syncs with Datadog
+type DatadogSLOSyncStatusMessage string
+
+const (
+	// DatadogSLOSyncStatusOK means syncing is OK
+	DatadogSLOSyncStatusOK DatadogSLOSyncStatusMessage = "OK"
+	// DatadogSLOSyncStatusCreateError means there is an SLO creation error
+	DatadogSLOSyncStatusCreateError DatadogSLOSyncStatusMessage = "error creating SLO"
+	// DatadogSLOSyncStatusUpdateError means there is an SLO update error
+	DatadogSLOSyncStatusUpdateError DatadogSLOSyncStatusMessage = "error updating SLO"
+	// DatadogSLOSyncStatusGetError means there is an error getting the SLO history
+	DatadogSLOSyncStatusGetError DatadogSLOSyncStatusMessage = "error getting SLO history"
+	// DatadogSLOSyncStatusMonitorRefError means a referenced DatadogMonitor cannot be resolved to a monitor ID
+	DatadogSLOSyncStatusMonitorRefError DatadogSLOSyncStatusMessage = "error resolving monitor references"
+)
+
+// DatadogSLO allows to define and manage Service Level Objectives from your Kubernetes Cluster
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=datadogslos,scope=Namespaced
+// +kubebuilder:printcolumn:name="id",type="string",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="sli",type="string",JSONPath=".status.sliValue"
+// +kubebuilder:printcolumn:name="error budget",type="string",JSONPath=".status.errorBudgetRemaining"
+// +kubebuilder:printcolumn:name="timeframe",type="string",JSONPath=".status.timeframe"
+// +kubebuilder:printcolumn:name="last sync",type="string",format="date",JSONPath=".status.sloStatusLastSyncTime"
+// +kubebuilder:printcolumn:name="sync status",type="string",JSONPath=".status.syncStatus"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:openapi-gen=true
+// +genclient
+type DatadogSLO struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatadogSLOSpec   `json:"spec,omitempty"`
+	Status DatadogSLOStatus `json:"status,omitempty"`
+}
+
+// DatadogSLOList contains a list of DatadogSLOs
+// +kubebuilder:object:root=true
+type DatadogSLOList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatadogSLO `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatadogSLO{}, &DatadogSLOList{})
+}
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// IsValidDatadogSLO use to check if a DatadogSLOSpec is valid by checking
+// that the required fields are defined for the SLO type and that the thresholds are percentages
+func IsValidDatadogSLO(spec *DatadogSLOSpec) error {
+	var errs []error
+	if spec.Name == "" {
+		errs = append(errs, fmt.Errorf("spec.Name must be defined"))
+	}
+
+	switch spec.Type {
+	case "":
+		errs = append(errs, fmt.Errorf("spec.Type must be defined"))
+	case DatadogSLOTypeMetric:
+		if spec.Query == nil || spec.Query.Numerator == "" || spec.Query.Denominator == "" {
+			errs = append(errs, fmt.Errorf("spec.Query.Numerator and spec.Query.Denominator must be defined for %q SLOs", DatadogSLOTypeMetric))
+		}
+		if len(spec.MonitorIDs) > 0 || len(spec.MonitorRefs) > 0 {
+			errs = append(errs, fmt.Errorf("spec.MonitorIDs and spec.MonitorRefs can only be set for %q SLOs", DatadogSLOTypeMonitor))
+		}
+	case DatadogSLOTypeMonitor:
+		if len(spec.MonitorIDs) == 0 && len(spec.MonitorRefs) == 0 {
+			errs = append(errs, fmt.Errorf("spec.MonitorIDs or spec.MonitorRefs must be defined for %q SLOs", DatadogSLOTypeMonitor))
+		}
+		if spec.Query != nil {
+			errs = append(errs, fmt.Errorf("spec.Query can only be set for %q SLOs", DatadogSLOTypeMetric))
+		}
+		for _, ref := range spec.MonitorRefs {
+			if ref.Name == "" {
+				errs = append(errs, fmt.Errorf("spec.MonitorRefs[].Name must be defined"))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("spec.Type must be one of %q or %q", DatadogSLOTypeMetric, DatadogSLOTypeMonitor))
+	}
+
+	if len(spec.Thresholds) == 0 {
+		errs = append(errs, fmt.Errorf("spec.Thresholds must be defined"))
+	}
+	for _, threshold := range spec.Thresholds {
+		switch threshold.Timeframe {
+		case DatadogSLOTimeframeSevenDays, DatadogSLOTimeframeThirtyDays, DatadogSLOTimeframeNinetyDays:
+		default:
+			errs = append(errs, fmt.Errorf("spec.Thresholds[].Timeframe must be one of %q, %q or %q", DatadogSLOTimeframeSevenDays, DatadogSLOTimeframeThirtyDays, DatadogSLOTimeframeNinetyDays))
+		}
+		if err := isValidSLOPercentage(threshold.Target); err != nil {
+			errs = append(errs, fmt.Errorf("spec.Thresholds[].Target %w", err))
+		}
+		if threshold.Warning != nil {
+			if err := isValidSLOPercentage(*threshold.Warning); err != nil {
+				errs = append(errs, fmt.Errorf("spec.Thresholds[].Warning %w", err))
+			}
+		}
+	}
+
+	return utilserrors.NewAggregate(errs)
+}
+
+func isValidSLOPercentage(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f <= 0 || f >= 100 {
+		return fmt.Errorf("must be a number strictly between 0 and 100, got %q", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidDatadogSLO(t *testing.T) {
+	validThresholds := []DatadogSLOThreshold{{Timeframe: DatadogSLOTimeframeSevenDays, Target: "99.9"}}
+	validQuery := &DatadogSLOQuery{
+		Numerator:   "sum:requests.success{*}.as_count()",
+		Denominator: "sum:requests.total{*}.as_count()",
+	}
+	invalidWarning := "100"
+
+	testCases := []struct {
+		name    string
+		spec    *DatadogSLOSpec
+		wantErr string
+	}{
+		{
+			name: "valid metric SLO",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       DatadogSLOTypeMetric,
+				Query:      validQuery,
+				Thresholds: validThresholds,
+			},
+		},
+		{
+			name: "valid monitor SLO with references",
+			spec: &DatadogSLOSpec{
+				Name:        "Test SLO",
+				Type:        DatadogSLOTypeMonitor,
+				MonitorIDs:  []int64{12345},
+				MonitorRefs: []DatadogSLOMonitorReference{{Name: "foo"}},
+				Thresholds:  validThresholds,
+			},
+		},
+		{
+			name: "SLO missing name and type",
+			spec: &DatadogSLOSpec{
+				Thresholds: validThresholds,
+			},
+			wantErr: "[spec.Name must be defined, spec.Type must be defined]",
+		},
+		{
+			name: "SLO with unknown type",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       "foo",
+				Thresholds: validThresholds,
+			},
+			wantErr: `spec.Type must be one of "metric" or "monitor"`,
+		},
+		{
+			name: "metric SLO missing denominator",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       DatadogSLOTypeMetric,
+				Query:      &DatadogSLOQuery{Numerator: "sum:requests.success{*}.as_count()"},
+				Thresholds: validThresholds,
+			},
+			wantErr: `spec.Query.Numerator and spec.Query.Denominator must be defined for "metric" SLOs`,
+		},
+		{
+			name: "metric SLO with monitor references",
+			spec: &DatadogSLOSpec{
+				Name:        "Test SLO",
+				Type:        DatadogSLOTypeMetric,
+				Query:       validQuery,
+				MonitorRefs: []DatadogSLOMonitorReference{{Name: "foo"}},
+				Thresholds:  validThresholds,
+			},
+			wantErr: `spec.MonitorIDs and spec.MonitorRefs can only be set for "monitor" SLOs`,
+		},
+		{
+			name: "monitor SLO without monitors",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       DatadogSLOTypeMonitor,
+				Thresholds: validThresholds,
+			},
+			wantErr: `spec.MonitorIDs or spec.MonitorRefs must be defined for "monitor" SLOs`,
+		},
+		{
+			name: "monitor SLO with unnamed reference",
+			spec: &DatadogSLOSpec{
+				Name:        "Test SLO",
+				Type:        DatadogSLOTypeMonitor,
+				MonitorRefs: []DatadogSLOMonitorReference{{}},
+				Thresholds:  validThresholds,
+			},
+			wantErr: "spec.MonitorRefs[].Name must be defined",
+		},
+		{
+			name: "SLO without thresholds",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       DatadogSLOTypeMonitor,
+				MonitorIDs: []int64{12345},
+			},
+			wantErr: "spec.Thresholds must be defined",
+		},
+		{
+			name: "SLO with invalid thresholds",
+			spec: &DatadogSLOSpec{
+				Name:       "Test SLO",
+				Type:       DatadogSLOTypeMonitor,
+				MonitorIDs: []int64{12345},
+				Thresholds: []DatadogSLOThreshold{{Timeframe: "1d", Target: "foo", Warning: &invalidWarning}},
+			},
+			wantErr: `[spec.Thresholds[].Timeframe must be one of "7d", "30d" or "90d", spec.Thresholds[].Target must be a number strictly between 0 and 100, got "foo", spec.Thresholds[].Warning must be a number strictly between 0 and 100, got "100"]`,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsValidDatadogSLO(test.spec)
+			if test.wantErr != "" {
+				assert.Error(t, result)
+				assert.EqualError(t, result, test.wantErr)
+			} else {
+				assert.NoError(t, result)
+			}
+		})
+	}
+}
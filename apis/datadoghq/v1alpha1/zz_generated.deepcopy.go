@@ -1018,67 +1018,1087 @@ func (in *DatadogCredentials) DeepCopy() *DatadogCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboard) DeepCopyInto(out *DatadogDashboard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboard.
+func (in *DatadogDashboard) DeepCopy() *DatadogDashboard {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDashboard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardCondition) DeepCopyInto(out *DatadogDashboardCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardCondition.
+func (in *DatadogDashboardCondition) DeepCopy() *DatadogDashboardCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardList) DeepCopyInto(out *DatadogDashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogDashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardList.
+func (in *DatadogDashboardList) DeepCopy() *DatadogDashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardSpec) DeepCopyInto(out *DatadogDashboardSpec) {
+	*out = *in
+	if in.ReflowType != nil {
+		in, out := &in.ReflowType, &out.ReflowType
+		*out = new(DatadogDashboardReflowType)
+		**out = **in
+	}
+	if in.NotifyList != nil {
+		in, out := &in.NotifyList, &out.NotifyList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestrictedRoles != nil {
+		in, out := &in.RestrictedRoles, &out.RestrictedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateVariables != nil {
+		in, out := &in.TemplateVariables, &out.TemplateVariables
+		*out = make([]DatadogDashboardTemplateVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TemplateVariablePresets != nil {
+		in, out := &in.TemplateVariablePresets, &out.TemplateVariablePresets
+		*out = make([]DatadogDashboardTemplateVariablePreset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardSpec.
+func (in *DatadogDashboardSpec) DeepCopy() *DatadogDashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardStatus) DeepCopyInto(out *DatadogDashboardStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DatadogDashboardCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	if in.DashboardLastModifiedTime != nil {
+		in, out := &in.DashboardLastModifiedTime, &out.DashboardLastModifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DashboardLastSyncTime != nil {
+		in, out := &in.DashboardLastSyncTime, &out.DashboardLastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardStatus.
+func (in *DatadogDashboardStatus) DeepCopy() *DatadogDashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardTemplateVariable) DeepCopyInto(out *DatadogDashboardTemplateVariable) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	if in.AvailableValues != nil {
+		in, out := &in.AvailableValues, &out.AvailableValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardTemplateVariable.
+func (in *DatadogDashboardTemplateVariable) DeepCopy() *DatadogDashboardTemplateVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardTemplateVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardTemplateVariablePreset) DeepCopyInto(out *DatadogDashboardTemplateVariablePreset) {
+	*out = *in
+	if in.TemplateVariables != nil {
+		in, out := &in.TemplateVariables, &out.TemplateVariables
+		*out = make([]DatadogDashboardTemplateVariablePresetValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardTemplateVariablePreset.
+func (in *DatadogDashboardTemplateVariablePreset) DeepCopy() *DatadogDashboardTemplateVariablePreset {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardTemplateVariablePreset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDashboardTemplateVariablePresetValue) DeepCopyInto(out *DatadogDashboardTemplateVariablePresetValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDashboardTemplateVariablePresetValue.
+func (in *DatadogDashboardTemplateVariablePresetValue) DeepCopy() *DatadogDashboardTemplateVariablePresetValue {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDashboardTemplateVariablePresetValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntime) DeepCopyInto(out *DatadogDowntime) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntime.
+func (in *DatadogDowntime) DeepCopy() *DatadogDowntime {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDowntime) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeCondition) DeepCopyInto(out *DatadogDowntimeCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeCondition.
+func (in *DatadogDowntimeCondition) DeepCopy() *DatadogDowntimeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeList) DeepCopyInto(out *DatadogDowntimeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogDowntime, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeList.
+func (in *DatadogDowntimeList) DeepCopy() *DatadogDowntimeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogDowntimeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeMonitorReference) DeepCopyInto(out *DatadogDowntimeMonitorReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeMonitorReference.
+func (in *DatadogDowntimeMonitorReference) DeepCopy() *DatadogDowntimeMonitorReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeMonitorReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeRecurrence) DeepCopyInto(out *DatadogDowntimeRecurrence) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeekDays != nil {
+		in, out := &in.WeekDays, &out.WeekDays
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UntilDate != nil {
+		in, out := &in.UntilDate, &out.UntilDate
+		*out = (*in).DeepCopy()
+	}
+	if in.UntilOccurrences != nil {
+		in, out := &in.UntilOccurrences, &out.UntilOccurrences
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeRecurrence.
+func (in *DatadogDowntimeRecurrence) DeepCopy() *DatadogDowntimeRecurrence {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeRecurrence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeSpec) DeepCopyInto(out *DatadogDowntimeSpec) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.MonitorRef != nil {
+		in, out := &in.MonitorRef, &out.MonitorRef
+		*out = new(DatadogDowntimeMonitorReference)
+		**out = **in
+	}
+	if in.MonitorTags != nil {
+		in, out := &in.MonitorTags, &out.MonitorTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.Recurrence != nil {
+		in, out := &in.Recurrence, &out.Recurrence
+		*out = new(DatadogDowntimeRecurrence)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeSpec.
+func (in *DatadogDowntimeSpec) DeepCopy() *DatadogDowntimeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogDowntimeStatus) DeepCopyInto(out *DatadogDowntimeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DatadogDowntimeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DowntimeLastSyncTime != nil {
+		in, out := &in.DowntimeLastSyncTime, &out.DowntimeLastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogDowntimeStatus.
+func (in *DatadogDowntimeStatus) DeepCopy() *DatadogDowntimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogDowntimeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogFeatures) DeepCopyInto(out *DatadogFeatures) {
 	*out = *in
-	if in.OrchestratorExplorer != nil {
-		in, out := &in.OrchestratorExplorer, &out.OrchestratorExplorer
-		*out = new(OrchestratorExplorerConfig)
+	if in.OrchestratorExplorer != nil {
+		in, out := &in.OrchestratorExplorer, &out.OrchestratorExplorer
+		*out = new(OrchestratorExplorerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeStateMetricsCore != nil {
+		in, out := &in.KubeStateMetricsCore, &out.KubeStateMetricsCore
+		*out = new(KubeStateMetricsCore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusScrape != nil {
+		in, out := &in.PrometheusScrape, &out.PrometheusScrape
+		*out = new(PrometheusScrapeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkMonitoring != nil {
+		in, out := &in.NetworkMonitoring, &out.NetworkMonitoring
+		*out = new(NetworkMonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogCollection != nil {
+		in, out := &in.LogCollection, &out.LogCollection
+		*out = new(LogCollectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogFeatures.
+func (in *DatadogFeatures) DeepCopy() *DatadogFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetric) DeepCopyInto(out *DatadogMetric) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetric.
+func (in *DatadogMetric) DeepCopy() *DatadogMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogMetric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetricCondition) DeepCopyInto(out *DatadogMetricCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricCondition.
+func (in *DatadogMetricCondition) DeepCopy() *DatadogMetricCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetricCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetricList) DeepCopyInto(out *DatadogMetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricList.
+func (in *DatadogMetricList) DeepCopy() *DatadogMetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogMetricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetricSpec) DeepCopyInto(out *DatadogMetricSpec) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricSpec.
+func (in *DatadogMetricSpec) DeepCopy() *DatadogMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMetricStatus) DeepCopyInto(out *DatadogMetricStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DatadogMetricCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricStatus.
+func (in *DatadogMetricStatus) DeepCopy() *DatadogMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitor) DeepCopyInto(out *DatadogMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitor.
+func (in *DatadogMonitor) DeepCopy() *DatadogMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorCondition) DeepCopyInto(out *DatadogMonitorCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorCondition.
+func (in *DatadogMonitorCondition) DeepCopy() *DatadogMonitorCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorCredentials) DeepCopyInto(out *DatadogMonitorCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorCredentials.
+func (in *DatadogMonitorCredentials) DeepCopy() *DatadogMonitorCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorCredentialsSource) DeepCopyInto(out *DatadogMonitorCredentialsSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(DatadogMonitorCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorCredentialsSource.
+func (in *DatadogMonitorCredentialsSource) DeepCopy() *DatadogMonitorCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorDowntimeStatus) DeepCopyInto(out *DatadogMonitorDowntimeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorDowntimeStatus.
+func (in *DatadogMonitorDowntimeStatus) DeepCopy() *DatadogMonitorDowntimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorDowntimeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorList) DeepCopyInto(out *DatadogMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorList.
+func (in *DatadogMonitorList) DeepCopy() *DatadogMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorOptions) DeepCopyInto(out *DatadogMonitorOptions) {
+	*out = *in
+	if in.EscalationMessage != nil {
+		in, out := &in.EscalationMessage, &out.EscalationMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.EvaluationDelay != nil {
+		in, out := &in.EvaluationDelay, &out.EvaluationDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IncludeTags != nil {
+		in, out := &in.IncludeTags, &out.IncludeTags
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Locked != nil {
+		in, out := &in.Locked, &out.Locked
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NewHostDelay != nil {
+		in, out := &in.NewHostDelay, &out.NewHostDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NoDataTimeframe != nil {
+		in, out := &in.NoDataTimeframe, &out.NoDataTimeframe
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NotifyAudit != nil {
+		in, out := &in.NotifyAudit, &out.NotifyAudit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NotifyNoData != nil {
+		in, out := &in.NotifyNoData, &out.NotifyNoData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RenotifyInterval != nil {
+		in, out := &in.RenotifyInterval, &out.RenotifyInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RequireFullWindow != nil {
+		in, out := &in.RequireFullWindow, &out.RequireFullWindow
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeoutH != nil {
+		in, out := &in.TimeoutH, &out.TimeoutH
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = new(DatadogMonitorOptionsThresholds)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.KubeStateMetricsCore != nil {
-		in, out := &in.KubeStateMetricsCore, &out.KubeStateMetricsCore
-		*out = new(KubeStateMetricsCore)
+	if in.ThresholdWindows != nil {
+		in, out := &in.ThresholdWindows, &out.ThresholdWindows
+		*out = new(DatadogMonitorOptionsThresholdWindows)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.PrometheusScrape != nil {
-		in, out := &in.PrometheusScrape, &out.PrometheusScrape
-		*out = new(PrometheusScrapeConfig)
+	if in.NotifyBy != nil {
+		in, out := &in.NotifyBy, &out.NotifyBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupbySimpleMonitor != nil {
+		in, out := &in.GroupbySimpleMonitor, &out.GroupbySimpleMonitor
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GroupRetentionDuration != nil {
+		in, out := &in.GroupRetentionDuration, &out.GroupRetentionDuration
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewGroupDelay != nil {
+		in, out := &in.NewGroupDelay, &out.NewGroupDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RenotifyStatuses != nil {
+		in, out := &in.RenotifyStatuses, &out.RenotifyStatuses
+		*out = make([]DatadogMonitorRenotifyStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RenotifyOccurrences != nil {
+		in, out := &in.RenotifyOccurrences, &out.RenotifyOccurrences
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SchedulingOptions != nil {
+		in, out := &in.SchedulingOptions, &out.SchedulingOptions
+		*out = new(DatadogMonitorOptionsSchedulingOptions)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NetworkMonitoring != nil {
-		in, out := &in.NetworkMonitoring, &out.NetworkMonitoring
-		*out = new(NetworkMonitoringConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptions.
+func (in *DatadogMonitorOptions) DeepCopy() *DatadogMonitorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorOptionsEvaluationWindow) DeepCopyInto(out *DatadogMonitorOptionsEvaluationWindow) {
+	*out = *in
+	if in.DayStarts != nil {
+		in, out := &in.DayStarts, &out.DayStarts
+		*out = new(string)
+		**out = **in
+	}
+	if in.HourStarts != nil {
+		in, out := &in.HourStarts, &out.HourStarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MonthStarts != nil {
+		in, out := &in.MonthStarts, &out.MonthStarts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsEvaluationWindow.
+func (in *DatadogMonitorOptionsEvaluationWindow) DeepCopy() *DatadogMonitorOptionsEvaluationWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorOptionsEvaluationWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorOptionsSchedulingOptions) DeepCopyInto(out *DatadogMonitorOptionsSchedulingOptions) {
+	*out = *in
+	if in.EvaluationWindow != nil {
+		in, out := &in.EvaluationWindow, &out.EvaluationWindow
+		*out = new(DatadogMonitorOptionsEvaluationWindow)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.LogCollection != nil {
-		in, out := &in.LogCollection, &out.LogCollection
-		*out = new(LogCollectionConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsSchedulingOptions.
+func (in *DatadogMonitorOptionsSchedulingOptions) DeepCopy() *DatadogMonitorOptionsSchedulingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorOptionsSchedulingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorOptionsThresholdWindows) DeepCopyInto(out *DatadogMonitorOptionsThresholdWindows) {
+	*out = *in
+	if in.RecoveryWindow != nil {
+		in, out := &in.RecoveryWindow, &out.RecoveryWindow
+		*out = new(string)
+		**out = **in
+	}
+	if in.TriggerWindow != nil {
+		in, out := &in.TriggerWindow, &out.TriggerWindow
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsThresholdWindows.
+func (in *DatadogMonitorOptionsThresholdWindows) DeepCopy() *DatadogMonitorOptionsThresholdWindows {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorOptionsThresholdWindows)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorOptionsThresholds) DeepCopyInto(out *DatadogMonitorOptionsThresholds) {
+	*out = *in
+	if in.Critical != nil {
+		in, out := &in.Critical, &out.Critical
+		*out = new(string)
+		**out = **in
+	}
+	if in.CriticalRecovery != nil {
+		in, out := &in.CriticalRecovery, &out.CriticalRecovery
+		*out = new(string)
+		**out = **in
+	}
+	if in.OK != nil {
+		in, out := &in.OK, &out.OK
+		*out = new(string)
+		**out = **in
+	}
+	if in.Unknown != nil {
+		in, out := &in.Unknown, &out.Unknown
+		*out = new(string)
+		**out = **in
+	}
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(string)
+		**out = **in
+	}
+	if in.WarningRecovery != nil {
+		in, out := &in.WarningRecovery, &out.WarningRecovery
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsThresholds.
+func (in *DatadogMonitorOptionsThresholds) DeepCopy() *DatadogMonitorOptionsThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorOptionsThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorSpec) DeepCopyInto(out *DatadogMonitorSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Options.DeepCopyInto(&out.Options)
+	if in.RestrictedRoles != nil {
+		in, out := &in.RestrictedRoles, &out.RestrictedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(DatadogMonitorCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorSpec.
+func (in *DatadogMonitorSpec) DeepCopy() *DatadogMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorStatus) DeepCopyInto(out *DatadogMonitorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DatadogMonitorCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	if in.MonitorStateLastUpdateTime != nil {
+		in, out := &in.MonitorStateLastUpdateTime, &out.MonitorStateLastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MonitorStateLastTransitionTime != nil {
+		in, out := &in.MonitorStateLastTransitionTime, &out.MonitorStateLastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TriggeredState != nil {
+		in, out := &in.TriggeredState, &out.TriggeredState
+		*out = make([]DatadogMonitorTriggeredState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DowntimeStatus = in.DowntimeStatus
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(DatadogMonitorCredentialsSource)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogFeatures.
-func (in *DatadogFeatures) DeepCopy() *DatadogFeatures {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorStatus.
+func (in *DatadogMonitorStatus) DeepCopy() *DatadogMonitorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogFeatures)
+	out := new(DatadogMonitorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMetric) DeepCopyInto(out *DatadogMetric) {
+func (in *DatadogMonitorTemplate) DeepCopyInto(out *DatadogMonitorTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetric.
-func (in *DatadogMetric) DeepCopy() *DatadogMetric {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplate.
+func (in *DatadogMonitorTemplate) DeepCopy() *DatadogMonitorTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMetric)
+	out := new(DatadogMonitorTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatadogMonitorTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorTemplateCondition) DeepCopyInto(out *DatadogMonitorTemplateCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateCondition.
+func (in *DatadogMonitorTemplateCondition) DeepCopy() *DatadogMonitorTemplateCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorTemplateCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogMonitorTemplateList) DeepCopyInto(out *DatadogMonitorTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatadogMonitorTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateList.
+func (in *DatadogMonitorTemplateList) DeepCopy() *DatadogMonitorTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DatadogMetric) DeepCopyObject() runtime.Object {
+func (in *DatadogMonitorTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1086,94 +2106,107 @@ func (in *DatadogMetric) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMetricCondition) DeepCopyInto(out *DatadogMetricCondition) {
+func (in *DatadogMonitorTemplateMonitorStatus) DeepCopyInto(out *DatadogMonitorTemplateMonitorStatus) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricCondition.
-func (in *DatadogMetricCondition) DeepCopy() *DatadogMetricCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateMonitorStatus.
+func (in *DatadogMonitorTemplateMonitorStatus) DeepCopy() *DatadogMonitorTemplateMonitorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMetricCondition)
+	out := new(DatadogMonitorTemplateMonitorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMetricList) DeepCopyInto(out *DatadogMetricList) {
+func (in *DatadogMonitorTemplateSelector) DeepCopyInto(out *DatadogMonitorTemplateSelector) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DatadogMetric, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricList.
-func (in *DatadogMetricList) DeepCopy() *DatadogMetricList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateSelector.
+func (in *DatadogMonitorTemplateSelector) DeepCopy() *DatadogMonitorTemplateSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMetricList)
+	out := new(DatadogMonitorTemplateSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DatadogMetricList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMetricSpec) DeepCopyInto(out *DatadogMetricSpec) {
+func (in *DatadogMonitorTemplateSpec) DeepCopyInto(out *DatadogMonitorTemplateSpec) {
 	*out = *in
-	out.MaxAge = in.MaxAge
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricSpec.
-func (in *DatadogMetricSpec) DeepCopy() *DatadogMetricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateSpec.
+func (in *DatadogMonitorTemplateSpec) DeepCopy() *DatadogMonitorTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMetricSpec)
+	out := new(DatadogMonitorTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMetricStatus) DeepCopyInto(out *DatadogMetricStatus) {
+func (in *DatadogMonitorTemplateStatus) DeepCopyInto(out *DatadogMonitorTemplateStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DatadogMetricCondition, len(*in))
+		*out = make([]DatadogMonitorTemplateCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = make([]DatadogMonitorTemplateMonitorStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMetricStatus.
-func (in *DatadogMetricStatus) DeepCopy() *DatadogMetricStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTemplateStatus.
+func (in *DatadogMonitorTemplateStatus) DeepCopy() *DatadogMonitorTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMetricStatus)
+	out := new(DatadogMonitorTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitor) DeepCopyInto(out *DatadogMonitor) {
+func (in *DatadogMonitorTriggeredState) DeepCopyInto(out *DatadogMonitorTriggeredState) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTriggeredState.
+func (in *DatadogMonitorTriggeredState) DeepCopy() *DatadogMonitorTriggeredState {
+	if in == nil {
+		return nil
+	}
+	out := new(DatadogMonitorTriggeredState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatadogSLO) DeepCopyInto(out *DatadogSLO) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1181,18 +2214,18 @@ func (in *DatadogMonitor) DeepCopyInto(out *DatadogMonitor) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitor.
-func (in *DatadogMonitor) DeepCopy() *DatadogMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLO.
+func (in *DatadogSLO) DeepCopy() *DatadogSLO {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitor)
+	out := new(DatadogSLO)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DatadogMonitor) DeepCopyObject() runtime.Object {
+func (in *DatadogSLO) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1200,63 +2233,48 @@ func (in *DatadogMonitor) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorCondition) DeepCopyInto(out *DatadogMonitorCondition) {
+func (in *DatadogSLOCondition) DeepCopyInto(out *DatadogSLOCondition) {
 	*out = *in
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorCondition.
-func (in *DatadogMonitorCondition) DeepCopy() *DatadogMonitorCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DatadogMonitorCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorDowntimeStatus) DeepCopyInto(out *DatadogMonitorDowntimeStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorDowntimeStatus.
-func (in *DatadogMonitorDowntimeStatus) DeepCopy() *DatadogMonitorDowntimeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOCondition.
+func (in *DatadogSLOCondition) DeepCopy() *DatadogSLOCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorDowntimeStatus)
+	out := new(DatadogSLOCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorList) DeepCopyInto(out *DatadogMonitorList) {
+func (in *DatadogSLOList) DeepCopyInto(out *DatadogSLOList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DatadogMonitor, len(*in))
+		*out = make([]DatadogSLO, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorList.
-func (in *DatadogMonitorList) DeepCopy() *DatadogMonitorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOList.
+func (in *DatadogSLOList) DeepCopy() *DatadogSLOList {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorList)
+	out := new(DatadogSLOList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DatadogMonitorList) DeepCopyObject() runtime.Object {
+func (in *DatadogSLOList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1264,182 +2282,93 @@ func (in *DatadogMonitorList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorOptions) DeepCopyInto(out *DatadogMonitorOptions) {
+func (in *DatadogSLOMonitorReference) DeepCopyInto(out *DatadogSLOMonitorReference) {
 	*out = *in
-	if in.EscalationMessage != nil {
-		in, out := &in.EscalationMessage, &out.EscalationMessage
-		*out = new(string)
-		**out = **in
-	}
-	if in.EvaluationDelay != nil {
-		in, out := &in.EvaluationDelay, &out.EvaluationDelay
-		*out = new(int64)
-		**out = **in
-	}
-	if in.IncludeTags != nil {
-		in, out := &in.IncludeTags, &out.IncludeTags
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Locked != nil {
-		in, out := &in.Locked, &out.Locked
-		*out = new(bool)
-		**out = **in
-	}
-	if in.NewHostDelay != nil {
-		in, out := &in.NewHostDelay, &out.NewHostDelay
-		*out = new(int64)
-		**out = **in
-	}
-	if in.NoDataTimeframe != nil {
-		in, out := &in.NoDataTimeframe, &out.NoDataTimeframe
-		*out = new(int64)
-		**out = **in
-	}
-	if in.NotifyAudit != nil {
-		in, out := &in.NotifyAudit, &out.NotifyAudit
-		*out = new(bool)
-		**out = **in
-	}
-	if in.NotifyNoData != nil {
-		in, out := &in.NotifyNoData, &out.NotifyNoData
-		*out = new(bool)
-		**out = **in
-	}
-	if in.RenotifyInterval != nil {
-		in, out := &in.RenotifyInterval, &out.RenotifyInterval
-		*out = new(int64)
-		**out = **in
-	}
-	if in.RequireFullWindow != nil {
-		in, out := &in.RequireFullWindow, &out.RequireFullWindow
-		*out = new(bool)
-		**out = **in
-	}
-	if in.TimeoutH != nil {
-		in, out := &in.TimeoutH, &out.TimeoutH
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Thresholds != nil {
-		in, out := &in.Thresholds, &out.Thresholds
-		*out = new(DatadogMonitorOptionsThresholds)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ThresholdWindows != nil {
-		in, out := &in.ThresholdWindows, &out.ThresholdWindows
-		*out = new(DatadogMonitorOptionsThresholdWindows)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptions.
-func (in *DatadogMonitorOptions) DeepCopy() *DatadogMonitorOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOMonitorReference.
+func (in *DatadogSLOMonitorReference) DeepCopy() *DatadogSLOMonitorReference {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorOptions)
+	out := new(DatadogSLOMonitorReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorOptionsThresholdWindows) DeepCopyInto(out *DatadogMonitorOptionsThresholdWindows) {
+func (in *DatadogSLOQuery) DeepCopyInto(out *DatadogSLOQuery) {
 	*out = *in
-	if in.RecoveryWindow != nil {
-		in, out := &in.RecoveryWindow, &out.RecoveryWindow
-		*out = new(string)
-		**out = **in
-	}
-	if in.TriggerWindow != nil {
-		in, out := &in.TriggerWindow, &out.TriggerWindow
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsThresholdWindows.
-func (in *DatadogMonitorOptionsThresholdWindows) DeepCopy() *DatadogMonitorOptionsThresholdWindows {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOQuery.
+func (in *DatadogSLOQuery) DeepCopy() *DatadogSLOQuery {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorOptionsThresholdWindows)
+	out := new(DatadogSLOQuery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorOptionsThresholds) DeepCopyInto(out *DatadogMonitorOptionsThresholds) {
+func (in *DatadogSLOSpec) DeepCopyInto(out *DatadogSLOSpec) {
 	*out = *in
-	if in.Critical != nil {
-		in, out := &in.Critical, &out.Critical
-		*out = new(string)
-		**out = **in
-	}
-	if in.CriticalRecovery != nil {
-		in, out := &in.CriticalRecovery, &out.CriticalRecovery
-		*out = new(string)
-		**out = **in
-	}
-	if in.OK != nil {
-		in, out := &in.OK, &out.OK
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
 		*out = new(string)
 		**out = **in
 	}
-	if in.Unknown != nil {
-		in, out := &in.Unknown, &out.Unknown
-		*out = new(string)
-		**out = **in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Warning != nil {
-		in, out := &in.Warning, &out.Warning
-		*out = new(string)
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(DatadogSLOQuery)
 		**out = **in
 	}
-	if in.WarningRecovery != nil {
-		in, out := &in.WarningRecovery, &out.WarningRecovery
-		*out = new(string)
-		**out = **in
+	if in.MonitorIDs != nil {
+		in, out := &in.MonitorIDs, &out.MonitorIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorOptionsThresholds.
-func (in *DatadogMonitorOptionsThresholds) DeepCopy() *DatadogMonitorOptionsThresholds {
-	if in == nil {
-		return nil
+	if in.MonitorRefs != nil {
+		in, out := &in.MonitorRefs, &out.MonitorRefs
+		*out = make([]DatadogSLOMonitorReference, len(*in))
+		copy(*out, *in)
 	}
-	out := new(DatadogMonitorOptionsThresholds)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorSpec) DeepCopyInto(out *DatadogMonitorSpec) {
-	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.Options.DeepCopyInto(&out.Options)
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]DatadogSLOThreshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorSpec.
-func (in *DatadogMonitorSpec) DeepCopy() *DatadogMonitorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOSpec.
+func (in *DatadogSLOSpec) DeepCopy() *DatadogSLOSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorSpec)
+	out := new(DatadogSLOSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorStatus) DeepCopyInto(out *DatadogMonitorStatus) {
+func (in *DatadogSLOStatus) DeepCopyInto(out *DatadogSLOStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DatadogMonitorCondition, len(*in))
+		*out = make([]DatadogSLOCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1448,46 +2377,43 @@ func (in *DatadogMonitorStatus) DeepCopyInto(out *DatadogMonitorStatus) {
 		in, out := &in.Created, &out.Created
 		*out = (*in).DeepCopy()
 	}
-	if in.MonitorStateLastUpdateTime != nil {
-		in, out := &in.MonitorStateLastUpdateTime, &out.MonitorStateLastUpdateTime
-		*out = (*in).DeepCopy()
+	if in.MonitorIDs != nil {
+		in, out := &in.MonitorIDs, &out.MonitorIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
 	}
-	if in.MonitorStateLastTransitionTime != nil {
-		in, out := &in.MonitorStateLastTransitionTime, &out.MonitorStateLastTransitionTime
+	if in.SLOStatusLastSyncTime != nil {
+		in, out := &in.SLOStatusLastSyncTime, &out.SLOStatusLastSyncTime
 		*out = (*in).DeepCopy()
 	}
-	if in.TriggeredState != nil {
-		in, out := &in.TriggeredState, &out.TriggeredState
-		*out = make([]DatadogMonitorTriggeredState, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	out.DowntimeStatus = in.DowntimeStatus
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorStatus.
-func (in *DatadogMonitorStatus) DeepCopy() *DatadogMonitorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOStatus.
+func (in *DatadogSLOStatus) DeepCopy() *DatadogSLOStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorStatus)
+	out := new(DatadogSLOStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatadogMonitorTriggeredState) DeepCopyInto(out *DatadogMonitorTriggeredState) {
+func (in *DatadogSLOThreshold) DeepCopyInto(out *DatadogSLOThreshold) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogMonitorTriggeredState.
-func (in *DatadogMonitorTriggeredState) DeepCopy() *DatadogMonitorTriggeredState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogSLOThreshold.
+func (in *DatadogSLOThreshold) DeepCopy() *DatadogSLOThreshold {
 	if in == nil {
 		return nil
 	}
-	out := new(DatadogMonitorTriggeredState)
+	out := new(DatadogSLOThreshold)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -19,7 +19,7 @@ func UpdateDatadogAgentStatusConditionsFailure(status *DatadogAgentStatus, now m
 func UpdateDatadogAgentStatusConditions(status *DatadogAgentStatus, now metav1.Time, t string, conditionStatus metav1.ConditionStatus, reason, message string, writeFalseIfNotExist bool) {
 	idConditionComplete := getIndexForConditionType(status, t)
 	if idConditionComplete >= 0 {
-		UpdateDatadogAgentStatusCondition(status.Conditions[idConditionComplete], now, t, conditionStatus, reason, message)
+		status.Conditions[idConditionComplete] = UpdateDatadogAgentStatusCondition(status.Conditions[idConditionComplete], now, t, conditionStatus, reason, message)
 	} else if conditionStatus == metav1.ConditionTrue || writeFalseIfNotExist {
 		// Only add if the condition is True
 		status.Conditions = append(status.Conditions, NewDatadogAgentStatusCondition(t, conditionStatus, now, reason, message))
@@ -32,7 +32,6 @@ func UpdateDatadogAgentStatusCondition(condition metav1.Condition, now metav1.Ti
 		condition.LastTransitionTime = now
 		condition.Status = conditionStatus
 	}
-	condition.LastTransitionTime = now
 	condition.Message = message
 	condition.Reason = reason
 
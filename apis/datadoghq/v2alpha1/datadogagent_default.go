@@ -110,7 +110,7 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 	}
 
 	// LogsCollection Feature
-	if ddaSpec.Features.LogCollection != nil && *ddaSpec.Features.LogCollection.Enabled {
+	if ddaSpec.Features.LogCollection != nil && apiutils.BoolValue(ddaSpec.Features.LogCollection.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.LogCollection.ContainerCollectUsingFiles, defaultLogContainerCollectUsingFiles)
 
 		apiutils.DefaultStringIfUnset(&ddaSpec.Features.LogCollection.ContainerLogsPath, defaultLogContainerLogsPath)
@@ -130,7 +130,7 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 	}
 
 	// APM Feature
-	if ddaSpec.Features.APM != nil && *ddaSpec.Features.APM.Enabled {
+	if ddaSpec.Features.APM != nil && apiutils.BoolValue(ddaSpec.Features.APM.Enabled) {
 		if ddaSpec.Features.APM.HostPortConfig == nil {
 			ddaSpec.Features.APM.HostPortConfig = &HostPortConfig{}
 		}
@@ -149,12 +149,12 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 	}
 
 	// CWS (Cloud Workload Security) Feature
-	if ddaSpec.Features.CWS != nil && *ddaSpec.Features.CWS.Enabled {
+	if ddaSpec.Features.CWS != nil && apiutils.BoolValue(ddaSpec.Features.CWS.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.CWS.SyscallMonitorEnabled, defaultCWSSyscallMonitorEnabled)
 	}
 
 	// NPM (Network Performance Monitoring) Feature
-	if ddaSpec.Features.NPM != nil && *ddaSpec.Features.NPM.Enabled {
+	if ddaSpec.Features.NPM != nil && apiutils.BoolValue(ddaSpec.Features.NPM.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.NPM.EnableConntrack, defaultNPMEnableConntrack)
 
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.NPM.CollectDNSStats, defaultNPMCollectDNSStats)
@@ -173,7 +173,7 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 		}
 	}
 
-	if *ddaSpec.Features.Dogstatsd.HostPortConfig.Enabled {
+	if apiutils.BoolValue(ddaSpec.Features.Dogstatsd.HostPortConfig.Enabled) {
 		apiutils.DefaultInt32IfUnset(&ddaSpec.Features.Dogstatsd.HostPortConfig.Port, defaultDogstatsdPort)
 	}
 
@@ -201,6 +201,8 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 		}
 	}
 
+	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.OrchestratorExplorer.Enabled, defaultOrchestratorExplorerEnabled)
+
 	if *ddaSpec.Features.OrchestratorExplorer.Enabled {
 		if ddaSpec.Features.OrchestratorExplorer.Conf == nil {
 			ddaSpec.Features.OrchestratorExplorer.Conf = &CustomConfig{
@@ -216,6 +218,8 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 		}
 	}
 
+	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.KubeStateMetricsCore.Enabled, defaultKubeStateMetricsCoreEnabled)
+
 	if *ddaSpec.Features.KubeStateMetricsCore.Enabled {
 		if ddaSpec.Features.KubeStateMetricsCore.Conf == nil {
 			ddaSpec.Features.KubeStateMetricsCore.Conf = &CustomConfig{
@@ -225,12 +229,12 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 	}
 
 	// AdmissionController Feature
-	if ddaSpec.Features.AdmissionController != nil && *ddaSpec.Features.AdmissionController.Enabled {
+	if ddaSpec.Features.AdmissionController != nil && apiutils.BoolValue(ddaSpec.Features.AdmissionController.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.AdmissionController.MutateUnlabelled, defaultAdmissionControllerMutateUnlabelled)
 	}
 
 	// ExternalMetricsServer Feature
-	if ddaSpec.Features.ExternalMetricsServer != nil && *ddaSpec.Features.ExternalMetricsServer.Enabled {
+	if ddaSpec.Features.ExternalMetricsServer != nil && apiutils.BoolValue(ddaSpec.Features.ExternalMetricsServer.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.ExternalMetricsServer.UseDatadogMetrics, defaultDatadogMetricsEnabled)
 
 		apiutils.DefaultInt32IfUnset(&ddaSpec.Features.ExternalMetricsServer.Port, defaultMetricsProviderPort)
@@ -243,12 +247,14 @@ func defaultFeaturesConfig(ddaSpec *DatadogAgentSpec) {
 		}
 	}
 
+	apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.ClusterChecks.Enabled, defaultClusterChecksEnabled)
+
 	if *ddaSpec.Features.ClusterChecks.Enabled {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.ClusterChecks.UseClusterChecksRunners, defaultUseClusterChecksRunners)
 	}
 
 	// PrometheusScrape Feature
-	if ddaSpec.Features.PrometheusScrape != nil && *ddaSpec.Features.PrometheusScrape.Enabled {
+	if ddaSpec.Features.PrometheusScrape != nil && apiutils.BoolValue(ddaSpec.Features.PrometheusScrape.Enabled) {
 		apiutils.DefaultBooleanIfUnset(&ddaSpec.Features.PrometheusScrape.EnableServiceEndpoints, defaultPrometheusScrapeEnableServiceEndpoints)
 	}
 }
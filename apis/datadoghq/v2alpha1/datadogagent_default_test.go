@@ -434,6 +434,64 @@ func Test_defaultFeatures(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "features are configured without enabled",
+			ddaSpec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					LogCollection: &LogCollectionFeatureConfig{
+						ContainerCollectAll: apiutils.NewBoolPointer(valueTrue),
+					},
+					APM: &APMFeatureConfig{},
+					Dogstatsd: &DogstatsdFeatureConfig{
+						HostPortConfig: &HostPortConfig{},
+					},
+					OrchestratorExplorer: &OrchestratorExplorerFeatureConfig{
+						ScrubContainers: apiutils.NewBoolPointer(valueFalse),
+					},
+					KubeStateMetricsCore: &KubeStateMetricsCoreFeatureConfig{},
+					ClusterChecks:        &ClusterChecksFeatureConfig{},
+				},
+			},
+			want: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					LogCollection: &LogCollectionFeatureConfig{
+						ContainerCollectAll: apiutils.NewBoolPointer(valueTrue),
+					},
+					LiveContainerCollection: &LiveContainerCollectionFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(defaultLiveContainerCollectionEnabled),
+					},
+					APM: &APMFeatureConfig{},
+					Dogstatsd: &DogstatsdFeatureConfig{
+						OriginDetectionEnabled: apiutils.NewBoolPointer(defaultDogstatsdOriginDetectionEnabled),
+						HostPortConfig:         &HostPortConfig{},
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{
+							Enabled: apiutils.NewBoolPointer(defaultDogstatsdUseSocketVolume),
+							Path:    apiutils.NewStringPointer(defaultDogstatsdSocketPath),
+						},
+					},
+					EventCollection: &EventCollectionFeatureConfig{
+						CollectKubernetesEvents: apiutils.NewBoolPointer(defaultCollectKubernetesEvents),
+					},
+					OrchestratorExplorer: &OrchestratorExplorerFeatureConfig{
+						Enabled:         apiutils.NewBoolPointer(defaultOrchestratorExplorerEnabled),
+						ScrubContainers: apiutils.NewBoolPointer(valueFalse),
+						Conf: &CustomConfig{
+							ConfigData: apiutils.NewStringPointer(DefaultOrchestratorExplorerConf),
+						},
+					},
+					KubeStateMetricsCore: &KubeStateMetricsCoreFeatureConfig{
+						Enabled: apiutils.NewBoolPointer(defaultKubeStateMetricsCoreEnabled),
+						Conf: &CustomConfig{
+							ConfigData: apiutils.NewStringPointer(defaultKubeStateMetricsCoreConf),
+						},
+					},
+					ClusterChecks: &ClusterChecksFeatureConfig{
+						Enabled:                 apiutils.NewBoolPointer(defaultClusterChecksEnabled),
+						UseClusterChecksRunners: apiutils.NewBoolPointer(defaultUseClusterChecksRunners),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
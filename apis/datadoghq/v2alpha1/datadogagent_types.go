@@ -170,6 +170,16 @@ type LiveProcessCollectionFeatureConfig struct {
 	// Default: false
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// ScrubProcessArguments enables scrubbing of sensitive data in process command-lines (passwords, tokens, etc. ).
+	// Default: true
+	// +optional
+	ScrubProcessArguments *bool `json:"scrubProcessArguments,omitempty"`
+
+	// StripProcessArguments enables stripping of all process arguments.
+	// Default: false
+	// +optional
+	StripProcessArguments *bool `json:"stripProcessArguments,omitempty"`
 }
 
 // LiveContainerCollectionFeatureConfig contains Container Collection configuration.
@@ -840,17 +850,43 @@ type DatadogAgentStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// The actual state of the Agent as a daemonset.
+	// +optional
+	Agent *commonv1.DaemonSetStatus `json:"agent,omitempty"`
+
+	// The actual state of the Cluster Agent as a deployment.
+	// +optional
+	ClusterAgent *commonv1.DeploymentStatus `json:"clusterAgent,omitempty"`
+
+	// The actual state of the Cluster Checks Runner as a deployment.
+	// +optional
+	ClusterChecksRunner *commonv1.DeploymentStatus `json:"clusterChecksRunner,omitempty"`
+
+	// CurrentHash is the hash of the DatadogAgent spec last reconciled.
+	// +optional
+	CurrentHash string `json:"currentHash,omitempty"`
+
+	// EnabledFeatures is the list of the features enabled by the DatadogAgent spec.
+	// +optional
+	// +listType=set
+	EnabledFeatures []string `json:"enabledFeatures,omitempty"`
+
+	// ClusterAgentTokenRef references the secret containing the token used by the Agents to communicate with the Cluster Agent.
+	// +optional
+	ClusterAgentTokenRef *commonv1.SecretConfig `json:"clusterAgentTokenRef,omitempty"`
 }
 
 // DatadogAgent Deployment with the Datadog Operator.
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:unservedversion
-// +kubebuilder:resource:path=datadogagents,shortName=dd
+// +kubebuilder:resource:path=datadogagents,shortName=dd;dda
 // +kubebuilder:printcolumn:name="active",type="string",JSONPath=".status.conditions[?(@.type=='Active')].status"
 // +kubebuilder:printcolumn:name="agent",type="string",JSONPath=".status.agent.status"
 // +kubebuilder:printcolumn:name="cluster-agent",type="string",JSONPath=".status.clusterAgent.status"
 // +kubebuilder:printcolumn:name="cluster-checks-runner",type="string",JSONPath=".status.clusterChecksRunner.status"
+// +kubebuilder:printcolumn:name="features",type="string",JSONPath=".status.enabledFeatures",priority=1
 // +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
 // +k8s:openapi-gen=true
 // +genclient
@@ -0,0 +1,200 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"path/filepath"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/utils"
+)
+
+// componentContainerNames lists the containers that can be overridden for each component
+var componentContainerNames = map[ComponentName][]commonv1.AgentContainerName{
+	NodeAgentComponentName: {
+		commonv1.CoreAgentContainerName,
+		commonv1.TraceAgentContainerName,
+		commonv1.ProcessAgentContainerName,
+		commonv1.SecurityAgentContainerName,
+		commonv1.SystemProbeContainerName,
+		commonv1.SeccompSetupContainerName,
+		commonv1.InitVolumeContainerName,
+	},
+	ClusterAgentComponentName: {
+		commonv1.ClusterAgentContainerName,
+	},
+	ClusterChecksRunnerComponentName: {
+		commonv1.ClusterChecksRunnersContainerName,
+	},
+}
+
+// IsValidDatadogAgent use to check if a DatadogAgentSpec is valid
+func IsValidDatadogAgent(spec *DatadogAgentSpec) error {
+	return ValidateDatadogAgentSpec(spec, field.NewPath("spec")).ToAggregate()
+}
+
+// ValidateDatadogAgentSpec returns the list of errors found in a DatadogAgentSpec, with their field path
+func ValidateDatadogAgentSpec(spec *DatadogAgentSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec == nil {
+		return errs
+	}
+
+	errs = append(errs, validateFeatures(spec, fldPath.Child("features"))...)
+	errs = append(errs, validateOverride(spec.Override, fldPath.Child("override"))...)
+
+	return errs
+}
+
+func validateFeatures(spec *DatadogAgentSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	features := spec.Features
+	if features == nil {
+		return errs
+	}
+
+	if features.APM != nil && utils.BoolValue(features.APM.Enabled) {
+		errs = append(errs, validateUnixDomainSocketConfig(features.APM.UnixDomainSocketConfig, fldPath.Child("apm", "unixDomainSocketConfig"))...)
+	}
+	if features.Dogstatsd != nil {
+		errs = append(errs, validateUnixDomainSocketConfig(features.Dogstatsd.UnixDomainSocketConfig, fldPath.Child("dogstatsd", "unixDomainSocketConfig"))...)
+		errs = append(errs, validateCustomConfig(features.Dogstatsd.MapperProfiles, fldPath.Child("dogstatsd", "mapperProfiles"))...)
+	}
+	if features.OrchestratorExplorer != nil {
+		errs = append(errs, validateCustomConfig(features.OrchestratorExplorer.Conf, fldPath.Child("orchestratorExplorer", "conf"))...)
+	}
+	if features.KubeStateMetricsCore != nil {
+		errs = append(errs, validateCustomConfig(features.KubeStateMetricsCore.Conf, fldPath.Child("kubeStateMetricsCore", "conf"))...)
+	}
+
+	// The features running in the System Probe can only be enabled with the node Agent
+	if isComponentDisabled(spec.Override, NodeAgentComponentName) {
+		nodeAgentFeatures := []struct {
+			name    string
+			enabled bool
+		}{
+			{name: "npm", enabled: features.NPM != nil && utils.BoolValue(features.NPM.Enabled)},
+			{name: "usm", enabled: features.USM != nil && utils.BoolValue(features.USM.Enabled)},
+			{name: "cws", enabled: features.CWS != nil && utils.BoolValue(features.CWS.Enabled)},
+			{name: "oomKill", enabled: features.OOMKill != nil && utils.BoolValue(features.OOMKill.Enabled)},
+			{name: "tcpQueueLength", enabled: features.TCPQueueLength != nil && utils.BoolValue(features.TCPQueueLength.Enabled)},
+		}
+		for _, feat := range nodeAgentFeatures {
+			if feat.enabled {
+				errs = append(errs, field.Invalid(fldPath.Child(feat.name, "enabled"), true, "the feature requires the node Agent, which is disabled in spec.override.nodeAgent"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateUnixDomainSocketConfig(config *UnixDomainSocketConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	// The path is defaulted when not set, so only an explicitly empty or relative path is invalid
+	if config == nil || !utils.BoolValue(config.Enabled) || config.Path == nil {
+		return errs
+	}
+
+	switch {
+	case *config.Path == "":
+		errs = append(errs, field.Required(fldPath.Child("path"), "the host path of the socket is required when the unix domain socket is enabled"))
+	case !filepath.IsAbs(*config.Path):
+		errs = append(errs, field.Invalid(fldPath.Child("path"), *config.Path, "the host path of the socket must be absolute"))
+	}
+
+	return errs
+}
+
+func validateCustomConfig(config *CustomConfig, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if config != nil && config.ConfigData != nil && config.ConfigMap != nil {
+		errs = append(errs, field.Forbidden(fldPath, "'configData' and 'configMap' should not be set at the same time"))
+	}
+
+	return errs
+}
+
+func validateOverride(override map[ComponentName]*DatadogAgentComponentOverride, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	// sort the keys to always return the errors in the same order
+	componentNames := make([]string, 0, len(override))
+	for componentName := range override {
+		componentNames = append(componentNames, string(componentName))
+	}
+	sort.Strings(componentNames)
+
+	for _, name := range componentNames {
+		componentName := ComponentName(name)
+		componentPath := fldPath.Key(name)
+		validContainerNames, found := componentContainerNames[componentName]
+		if !found {
+			errs = append(errs, field.NotSupported(componentPath, name, []string{string(NodeAgentComponentName), string(ClusterAgentComponentName), string(ClusterChecksRunnerComponentName)}))
+			continue
+		}
+
+		componentOverride := override[componentName]
+		if componentOverride == nil {
+			continue
+		}
+
+		for _, containerName := range sortedContainerNames(componentOverride.Containers) {
+			if !isValidContainerName(containerName, validContainerNames) {
+				supported := make([]string, 0, len(validContainerNames))
+				for _, validName := range validContainerNames {
+					supported = append(supported, string(validName))
+				}
+				errs = append(errs, field.NotSupported(componentPath.Child("containers").Key(string(containerName)), string(containerName), supported))
+			}
+		}
+
+		fileNames := make([]string, 0, len(componentOverride.CustomConfigurations))
+		for fileName := range componentOverride.CustomConfigurations {
+			fileNames = append(fileNames, string(fileName))
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			config := componentOverride.CustomConfigurations[AgentConfigFileName(fileName)]
+			errs = append(errs, validateCustomConfig(&config, componentPath.Child("customConfigurations").Key(fileName))...)
+		}
+		errs = append(errs, validateCustomConfig(componentOverride.ExtraConfd, componentPath.Child("extraConfd"))...)
+		errs = append(errs, validateCustomConfig(componentOverride.ExtraChecksd, componentPath.Child("extraChecksd"))...)
+		errs = append(errs, validateCustomConfig(componentOverride.SecCompCustomProfile, componentPath.Child("secCompCustomProfile"))...)
+	}
+
+	return errs
+}
+
+func sortedContainerNames(containers map[commonv1.AgentContainerName]*DatadogAgentGenericContainer) []commonv1.AgentContainerName {
+	names := make([]commonv1.AgentContainerName, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+func isValidContainerName(name commonv1.AgentContainerName, validNames []commonv1.AgentContainerName) bool {
+	for _, validName := range validNames {
+		if name == validName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isComponentDisabled(override map[ComponentName]*DatadogAgentComponentOverride, componentName ComponentName) bool {
+	componentOverride, found := override[componentName]
+	return found && componentOverride != nil && utils.BoolValue(componentOverride.Disabled)
+}
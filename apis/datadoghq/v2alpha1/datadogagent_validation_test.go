@@ -0,0 +1,145 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	commonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func TestValidateDatadogAgentSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       *DatadogAgentSpec
+		wantFields []string
+	}{
+		{
+			name: "nil spec",
+			spec: nil,
+		},
+		{
+			name: "empty spec",
+			spec: &DatadogAgentSpec{},
+		},
+		{
+			name: "valid spec",
+			spec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					APM: &APMFeatureConfig{
+						Enabled:                utils.NewBoolPointer(true),
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{Enabled: utils.NewBoolPointer(true)},
+					},
+					NPM: &NPMFeatureConfig{Enabled: utils.NewBoolPointer(true)},
+				},
+				Override: map[ComponentName]*DatadogAgentComponentOverride{
+					NodeAgentComponentName: {
+						Containers: map[commonv1.AgentContainerName]*DatadogAgentGenericContainer{
+							commonv1.SystemProbeContainerName: {},
+						},
+					},
+					ClusterChecksRunnerComponentName: {Disabled: utils.NewBoolPointer(true)},
+				},
+			},
+		},
+		{
+			name: "APM unix domain socket without host path",
+			spec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					APM: &APMFeatureConfig{
+						Enabled: utils.NewBoolPointer(true),
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{
+							Enabled: utils.NewBoolPointer(true),
+							Path:    utils.NewStringPointer(""),
+						},
+					},
+				},
+			},
+			wantFields: []string{"spec.features.apm.unixDomainSocketConfig.path"},
+		},
+		{
+			name: "dogstatsd unix domain socket with relative host path",
+			spec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					Dogstatsd: &DogstatsdFeatureConfig{
+						UnixDomainSocketConfig: &UnixDomainSocketConfig{
+							Enabled: utils.NewBoolPointer(true),
+							Path:    utils.NewStringPointer("dsd.socket"),
+						},
+					},
+				},
+			},
+			wantFields: []string{"spec.features.dogstatsd.unixDomainSocketConfig.path"},
+		},
+		{
+			name: "NPM with the node Agent disabled",
+			spec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					NPM:     &NPMFeatureConfig{Enabled: utils.NewBoolPointer(true)},
+					OOMKill: &OOMKillFeatureConfig{Enabled: utils.NewBoolPointer(false)},
+				},
+				Override: map[ComponentName]*DatadogAgentComponentOverride{
+					NodeAgentComponentName: {Disabled: utils.NewBoolPointer(true)},
+				},
+			},
+			wantFields: []string{"spec.features.npm.enabled"},
+		},
+		{
+			name: "configData and configMap set together",
+			spec: &DatadogAgentSpec{
+				Features: &DatadogFeatures{
+					KubeStateMetricsCore: &KubeStateMetricsCoreFeatureConfig{
+						Conf: &CustomConfig{
+							ConfigData: utils.NewStringPointer("foo: bar"),
+							ConfigMap:  &commonv1.ConfigMapConfig{Name: "foo"},
+						},
+					},
+				},
+			},
+			wantFields: []string{"spec.features.kubeStateMetricsCore.conf"},
+		},
+		{
+			name: "invalid override component and container names",
+			spec: &DatadogAgentSpec{
+				Override: map[ComponentName]*DatadogAgentComponentOverride{
+					"agent": {},
+					ClusterAgentComponentName: {
+						Containers: map[commonv1.AgentContainerName]*DatadogAgentGenericContainer{
+							commonv1.ClusterAgentContainerName: {},
+							commonv1.CoreAgentContainerName:    {},
+						},
+						CustomConfigurations: map[AgentConfigFileName]CustomConfig{
+							AgentGeneralConfigFile: {
+								ConfigData: utils.NewStringPointer("foo: bar"),
+								ConfigMap:  &commonv1.ConfigMapConfig{Name: "foo"},
+							},
+						},
+					},
+				},
+			},
+			wantFields: []string{
+				"spec.override[agent]",
+				"spec.override[clusterAgent].containers[agent]",
+				"spec.override[clusterAgent].customConfigurations[datadog.yaml]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateDatadogAgentSpec(tt.spec, field.NewPath("spec"))
+			var gotFields []string
+			for _, err := range errs {
+				gotFields = append(gotFields, err.Field)
+			}
+			assert.Equal(t, tt.wantFields, gotFields)
+		})
+	}
+}
@@ -6,12 +6,55 @@
 package v2alpha1
 
 import (
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
-// SetupWebhookWithManager starts the conversion webhook
+// matchPolicy is Exact as the v1alpha1 DatadogAgents are validated by the reconciler
+// +kubebuilder:webhook:path=/validate-datadoghq-com-v2alpha1-datadogagent,mutating=false,failurePolicy=fail,sideEffects=None,matchPolicy=Exact,groups=datadoghq.com,resources=datadogagents,verbs=create;update,versions=v2alpha1,name=vdatadogagent.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DatadogAgent{}
+
+// SetupWebhookWithManager starts the conversion and validating webhooks
 func (r *DatadogAgent) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
+
+// ValidateCreate rejects DatadogAgents with an invalid spec
+func (r *DatadogAgent) ValidateCreate() error {
+	return r.validate()
+}
+
+// ValidateUpdate rejects updates resulting in an invalid spec. Updates of DatadogAgents being deleted, and updates
+// leaving the spec unchanged, are accepted, so that a DatadogAgent which became invalid with a stricter validation
+// can still lose its finalizer and get its metadata updated.
+func (r *DatadogAgent) ValidateUpdate(old runtime.Object) error {
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	if oldAgent, ok := old.(*DatadogAgent); ok && apiequality.Semantic.DeepEqual(oldAgent.Spec, r.Spec) {
+		return nil
+	}
+
+	return r.validate()
+}
+
+// ValidateDelete accepts all deletions, so that invalid DatadogAgents can be cleaned up
+func (r *DatadogAgent) ValidateDelete() error {
+	return nil
+}
+
+func (r *DatadogAgent) validate() error {
+	errs := ValidateDatadogAgentSpec(&r.Spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("DatadogAgent").GroupKind(), r.Name, errs)
+}
@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDatadogAgentWebhook(t *testing.T) {
+	dda := &DatadogAgent{}
+	dda.Name = "foo"
+	assert.NoError(t, dda.ValidateCreate())
+
+	old := dda.DeepCopy()
+	dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{"agent": {}}
+	assert.EqualError(t, dda.ValidateUpdate(old), `DatadogAgent.datadoghq.com "foo" is invalid: spec.override[agent]: Unsupported value: "agent": supported values: "nodeAgent", "clusterAgent", "clusterChecksRunner"`)
+	assert.EqualError(t, IsValidDatadogAgent(&dda.Spec), `spec.override[agent]: Unsupported value: "agent": supported values: "nodeAgent", "clusterAgent", "clusterChecksRunner"`)
+	assert.NoError(t, dda.ValidateDelete())
+}
+
+func TestDatadogAgentWebhook_invalidAgent(t *testing.T) {
+	// A DatadogAgent created before its spec was rejected by the validation
+	dda := &DatadogAgent{}
+	dda.Name = "foo"
+	dda.Finalizers = []string{"finalizer.agent.datadoghq.com"}
+	dda.Spec.Override = map[ComponentName]*DatadogAgentComponentOverride{"agent": {}}
+
+	// Metadata updates are accepted
+	updated := dda.DeepCopy()
+	updated.Labels = map[string]string{"team": "web"}
+	assert.NoError(t, updated.ValidateUpdate(dda))
+
+	// Spec updates are validated
+	updated = dda.DeepCopy()
+	updated.Spec.Override["nodeAgent"] = &DatadogAgentComponentOverride{}
+	assert.Error(t, updated.ValidateUpdate(dda))
+
+	// The finalizer can be removed once the DatadogAgent is deleted
+	deleted := dda.DeepCopy()
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+	updated = deleted.DeepCopy()
+	updated.Finalizers = nil
+	assert.NoError(t, updated.ValidateUpdate(deleted))
+}
@@ -40,6 +40,11 @@ func GetClusterChecksRunnerServiceAccount(dda *DatadogAgent) string {
 	return saDefault
 }
 
+// GetClusterAgentTokenSecretName return the name of the secret containing the Cluster Agent token
+func GetClusterAgentTokenSecretName(dda *DatadogAgent) string {
+	return fmt.Sprintf("%s-%s", dda.Name, common.DefaultClusterAgentTokenResourceSuffix)
+}
+
 // ConvertCustomConfig use to convert a CustomConfig to a common.CustomConfig.
 func ConvertCustomConfig(config *CustomConfig) *commonv1.CustomConfig {
 	if config == nil {
@@ -539,6 +539,31 @@ func (in *DatadogAgentStatus) DeepCopyInto(out *DatadogAgentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(commonv1.DaemonSetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAgent != nil {
+		in, out := &in.ClusterAgent, &out.ClusterAgent
+		*out = new(commonv1.DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterChecksRunner != nil {
+		in, out := &in.ClusterChecksRunner, &out.ClusterChecksRunner
+		*out = new(commonv1.DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnabledFeatures != nil {
+		in, out := &in.EnabledFeatures, &out.EnabledFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterAgentTokenRef != nil {
+		in, out := &in.ClusterAgentTokenRef, &out.ClusterAgentTokenRef
+		*out = new(commonv1.SecretConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatadogAgentStatus.
@@ -1008,6 +1033,16 @@ func (in *LiveProcessCollectionFeatureConfig) DeepCopyInto(out *LiveProcessColle
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ScrubProcessArguments != nil {
+		in, out := &in.ScrubProcessArguments, &out.ScrubProcessArguments
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StripProcessArguments != nil {
+		in, out := &in.StripProcessArguments, &out.StripProcessArguments
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiveProcessCollectionFeatureConfig.
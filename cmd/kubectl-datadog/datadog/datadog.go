@@ -11,6 +11,7 @@ import (
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/flare"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/get"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/metrics"
+	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/migrate"
 	"github.com/DataDog/datadog-operator/cmd/kubectl-datadog/validate/validate"
 
 	"github.com/spf13/cobra"
@@ -41,6 +42,7 @@ func NewCmd(streams genericclioptions.IOStreams) *cobra.Command {
 	cmd.AddCommand(get.New(streams))
 	cmd.AddCommand(flare.New(streams))
 	cmd.AddCommand(validate.New(streams))
+	cmd.AddCommand(migrate.New(streams))
 
 	// Agent commands
 	cmd.AddCommand(agent.New(streams))
@@ -0,0 +1,275 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package migrate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+)
+
+// conversion is the result of the conversion of a v1alpha1 DatadogAgent
+type conversion struct {
+	// manifest is the v2alpha1 DatadogAgent manifest, without the defaulted fields
+	manifest []byte
+	// warnings lists the v1alpha1 fields dropped or approximated by the conversion
+	warnings []string
+}
+
+// convertDatadogAgent converts a v1alpha1 DatadogAgent to a clean v2alpha1 manifest
+func convertDatadogAgent(src *v1alpha1.DatadogAgent) (*conversion, error) {
+	spec, err := convertSpec(&src.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := conversionWarnings(&src.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanSpec, err := stripDefaults(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": v2alpha1.GroupVersion.String(),
+		"kind":       "DatadogAgent",
+		"metadata":   cleanMetadata(&src.ObjectMeta),
+		"spec":       cleanSpec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the v2alpha1 DatadogAgent: %w", err)
+	}
+
+	return &conversion{manifest: manifest, warnings: warnings}, nil
+}
+
+// convertSpec converts a v1alpha1 spec to its v2alpha1 equivalent, as an unstructured object
+func convertSpec(spec *v1alpha1.DatadogAgentSpec) (map[string]interface{}, error) {
+	src := &v1alpha1.DatadogAgent{Spec: *spec}
+	dst := &v2alpha1.DatadogAgent{}
+	if err := v1alpha1.ConvertTo(src, dst); err != nil {
+		return nil, fmt.Errorf("unable to convert the DatadogAgent to v2alpha1: %w", err)
+	}
+
+	return toUnstructured(&dst.Spec)
+}
+
+// convertUnstructuredSpec converts an unstructured v1alpha1 spec to its v2alpha1 equivalent
+func convertUnstructuredSpec(tree map[string]interface{}) (map[string]interface{}, error) {
+	spec := v1alpha1.DatadogAgentSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(tree, &spec); err != nil {
+		return nil, err
+	}
+
+	return convertSpec(&spec)
+}
+
+// conversionWarnings returns a warning for each v1alpha1 field that is dropped or approximated by the conversion.
+// A field is dropped when removing it does not change the v2alpha1 spec. It is approximated when its value
+// is not found again at the same place when converting the v2alpha1 spec back to v1alpha1.
+func conversionWarnings(spec *v1alpha1.DatadogAgentSpec) ([]string, error) {
+	original, err := toUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertUnstructuredSpec(original)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedSpec := v2alpha1.DatadogAgentSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(converted, &convertedSpec); err != nil {
+		return nil, err
+	}
+	back := &v1alpha1.DatadogAgent{}
+	if err = v1alpha1.ConvertFrom(&v2alpha1.DatadogAgent{Spec: convertedSpec}, back); err != nil {
+		return nil, fmt.Errorf("unable to convert the DatadogAgent back to v1alpha1: %w", err)
+	}
+	roundTrip, err := toUnstructured(&back.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, path := range leafPaths(original) {
+		candidateTree := runtime.DeepCopyJSON(original)
+		removeField(candidateTree, path)
+		candidate, err := convertUnstructuredSpec(candidateTree)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldPath := "spec." + strings.Join(path, ".")
+		if reflect.DeepEqual(candidate, converted) {
+			warnings = append(warnings, fmt.Sprintf("%s is dropped: it has no effect on the v2alpha1 DatadogAgent", fieldPath))
+			continue
+		}
+		if !reflect.DeepEqual(nestedField(roundTrip, path), nestedField(original, path)) {
+			warnings = append(warnings, fmt.Sprintf("%s is approximated: check its v2alpha1 equivalent", fieldPath))
+		}
+	}
+
+	return warnings, nil
+}
+
+// stripDefaults removes from the v2alpha1 spec the fields that have the value the operator defaults them to
+func stripDefaults(spec map[string]interface{}) (map[string]interface{}, error) {
+	stripped := runtime.DeepCopyJSON(spec)
+	defaulted, err := defaultSpec(stripped)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range leafPaths(spec) {
+		candidate := runtime.DeepCopyJSON(stripped)
+		removeField(candidate, path)
+		candidateDefaulted, err := defaultSpec(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(candidateDefaulted, defaulted) {
+			stripped = candidate
+		}
+	}
+
+	return stripped, nil
+}
+
+// defaultSpec returns the spec with the defaults set by the operator
+func defaultSpec(spec map[string]interface{}) (map[string]interface{}, error) {
+	dda := &v2alpha1.DatadogAgent{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &dda.Spec); err != nil {
+		return nil, err
+	}
+	v2alpha1.DefaultDatadogAgent(dda)
+
+	return toUnstructured(&dda.Spec)
+}
+
+// cleanMetadata only keeps the metadata that should be part of a manifest
+func cleanMetadata(meta *metav1.ObjectMeta) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"name": meta.Name,
+	}
+	if meta.Namespace != "" {
+		metadata["namespace"] = meta.Namespace
+	}
+	if len(meta.Labels) > 0 {
+		metadata["labels"] = meta.Labels
+	}
+
+	annotations := map[string]string{}
+	for key, value := range meta.Annotations {
+		switch key {
+		case corev1.LastAppliedConfigAnnotation, v1alpha1.V1alpha1SpecAnnotation, v1alpha1.V2alpha1SpecAnnotation:
+			continue
+		}
+		annotations[key] = value
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	return metadata
+}
+
+// toUnstructured returns the unstructured representation of an object, without its null values and empty objects
+func toUnstructured(obj interface{}) (map[string]interface{}, error) {
+	tree, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	pruneEmptyObjects(tree)
+
+	return tree, nil
+}
+
+// pruneEmptyObjects recursively removes the fields whose value is null or an empty object
+func pruneEmptyObjects(tree map[string]interface{}) {
+	for key, value := range tree {
+		if value == nil {
+			delete(tree, key)
+			continue
+		}
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			continue
+		}
+		pruneEmptyObjects(object)
+		if len(object) == 0 {
+			delete(tree, key)
+		}
+	}
+}
+
+// leafPaths returns the sorted paths of the fields that are not objects
+func leafPaths(tree map[string]interface{}) [][]string {
+	var paths [][]string
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		object, isObject := tree[key].(map[string]interface{})
+		if !isObject {
+			paths = append(paths, []string{key})
+			continue
+		}
+		for _, path := range leafPaths(object) {
+			paths = append(paths, append([]string{key}, path...))
+		}
+	}
+
+	return paths
+}
+
+// nestedField returns the value at the path, or nil if it is not set
+func nestedField(tree map[string]interface{}, path []string) interface{} {
+	var value interface{} = tree
+	for _, key := range path {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil
+		}
+		value = object[key]
+	}
+
+	return value
+}
+
+// removeField removes the value at the path, along with the objects left empty
+func removeField(tree map[string]interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	if len(path) == 1 {
+		delete(tree, path[0])
+		return
+	}
+
+	object, isObject := tree[path[0]].(map[string]interface{})
+	if !isObject {
+		return
+	}
+	removeField(object, path[1:])
+	if len(object) == 0 {
+		delete(tree, path[0])
+	}
+}
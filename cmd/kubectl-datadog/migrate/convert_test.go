@@ -0,0 +1,155 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func Test_convertDatadogAgent(t *testing.T) {
+	tests := []struct {
+		name         string
+		dda          *v1alpha1.DatadogAgent
+		wantManifest string
+		wantWarnings []string
+	}{
+		{
+			name: "empty spec",
+			dda: &v1alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "datadog"},
+			},
+			wantManifest: `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+spec: {}
+`,
+		},
+		{
+			name: "defaulted fields and server metadata are stripped",
+			dda: &v1alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "datadog",
+					Namespace:       "monitoring",
+					ResourceVersion: "42",
+					UID:             "0a1b2c",
+					Labels:          map[string]string{"team": "containers"},
+					Annotations: map[string]string{
+						corev1.LastAppliedConfigAnnotation: "{}",
+						v1alpha1.V2alpha1SpecAnnotation:    "{}",
+					},
+				},
+				Spec: v1alpha1.DatadogAgentSpec{
+					ClusterName: "my-cluster",
+					Site:        "datadoghq.com",
+					Registry:    apiutils.NewStringPointer("gcr.io/datadoghq"),
+					Features: v1alpha1.DatadogFeatures{
+						OrchestratorExplorer: &v1alpha1.OrchestratorExplorerConfig{Enabled: apiutils.NewBoolPointer(true)},
+						KubeStateMetricsCore: &v1alpha1.KubeStateMetricsCore{Enabled: apiutils.NewBoolPointer(true)},
+					},
+				},
+				Status: v1alpha1.DatadogAgentStatus{DefaultOverride: &v1alpha1.DatadogAgentSpec{ClusterName: "foo"}},
+			},
+			wantManifest: `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  labels:
+    team: containers
+  name: datadog
+  namespace: monitoring
+spec:
+  global:
+    clusterName: my-cluster
+`,
+		},
+		{
+			name: "dropped and approximated fields",
+			dda: &v1alpha1.DatadogAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "datadog"},
+				Spec: v1alpha1.DatadogAgentSpec{
+					Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+						DNSPolicy: corev1.DNSClusterFirst,
+						Log:       &v1alpha1.LogCollectionConfig{Enabled: apiutils.NewBoolPointer(true)},
+						Config: &v1alpha1.NodeAgentConfig{
+							Tags: []string{"env:prod"},
+						},
+					},
+				},
+			},
+			wantManifest: `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+spec:
+  features:
+    logCollection:
+      enabled: true
+  global:
+    tags:
+    - env:prod
+`,
+			wantWarnings: []string{
+				"spec.agent.dnsPolicy is dropped: it has no effect on the v2alpha1 DatadogAgent",
+				"spec.agent.log.enabled is approximated: check its v2alpha1 equivalent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertDatadogAgent(tt.dda)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantManifest, string(got.manifest))
+			assert.Equal(t, tt.wantWarnings, got.warnings)
+		})
+	}
+}
+
+func Test_stripDefaults(t *testing.T) {
+	spec := map[string]interface{}{
+		"global": map[string]interface{}{
+			"site":     "datadoghq.com",
+			"logLevel": "debug",
+		},
+		"features": map[string]interface{}{
+			"apm": map[string]interface{}{
+				"enabled": true,
+				"hostPortConfig": map[string]interface{}{
+					"enabled": false,
+					"port":    int64(8126),
+				},
+			},
+			"npm": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}
+
+	got, err := stripDefaults(spec)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"global": map[string]interface{}{
+			"logLevel": "debug",
+		},
+		"features": map[string]interface{}{
+			"apm": map[string]interface{}{
+				"enabled": true,
+			},
+			// the operator does not default the NPM enabled field
+			"npm": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}, got)
+}
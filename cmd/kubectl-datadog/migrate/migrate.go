@@ -0,0 +1,370 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/plugin/common"
+)
+
+const documentSeparator = "---\n"
+
+var migrateExample = `
+  # print the v2alpha1 version of the DatadogAgent manifests of a directory
+  %[1]s migrate -f ./manifests
+
+  # convert the DatadogAgent manifests of a directory and its subdirectories in place
+  %[1]s migrate -f ./manifests -R --in-place
+
+  # fail if v1alpha1 DatadogAgent manifests remain, for instance in a CI job
+  %[1]s migrate -f ./manifests -R --check
+
+  # print the v2alpha1 version of the DatadogAgent foo deployed in the cluster
+  %[1]s migrate foo
+`
+
+// options provides information required by Datadog migrate command.
+type options struct {
+	genericclioptions.IOStreams
+	common.Options
+	args                 []string
+	userDatadogAgentName string
+	filenames            []string
+	recursive            bool
+	inPlace              bool
+	check                bool
+}
+
+// newOptions provides an instance of options with default values.
+func newOptions(streams genericclioptions.IOStreams) *options {
+	o := &options{
+		IOStreams: streams,
+	}
+	o.SetConfigFlags()
+	return o
+}
+
+// New provides a cobra command wrapping options for "migrate" sub command.
+func New(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newOptions(streams)
+	cmd := &cobra.Command{
+		Use:          "migrate [DatadogAgent name]",
+		Short:        "Convert v1alpha1 DatadogAgent manifests or deployments to v2alpha1",
+		Example:      fmt.Sprintf(migrateExample, "kubectl datadog"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.filenames, "filename", "f", nil, "The manifest files or directories to migrate, instead of the DatadogAgents deployed in the cluster")
+	cmd.Flags().BoolVarP(&o.recursive, "recursive", "R", false, "Process the directories used in -f, --filename recursively")
+	cmd.Flags().BoolVarP(&o.inPlace, "in-place", "", false, "Replace the v1alpha1 DatadogAgents in the manifest files instead of printing the v2alpha1 DatadogAgents")
+	cmd.Flags().BoolVarP(&o.check, "check", "", false, "Only list the v1alpha1 DatadogAgents of the manifest files, and fail if there is any")
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// complete sets all information required for processing the command.
+func (o *options) complete(cmd *cobra.Command, args []string) error {
+	o.args = args
+	if len(args) > 0 {
+		o.userDatadogAgentName = args[0]
+	}
+
+	// The manifest files are migrated offline
+	if len(o.filenames) > 0 || o.inPlace || o.check {
+		return nil
+	}
+
+	return o.Init(cmd)
+}
+
+// validate ensures that all required arguments and flag values are provided.
+func (o *options) validate() error {
+	if len(o.args) > 1 {
+		return errors.New("either one or no arguments are allowed")
+	}
+	if len(o.filenames) > 0 && len(o.args) > 0 {
+		return errors.New("a DatadogAgent name cannot be used with --filename")
+	}
+	if len(o.filenames) == 0 && (o.inPlace || o.check) {
+		return errors.New("--in-place and --check require --filename")
+	}
+	if o.inPlace && o.check {
+		return errors.New("--in-place and --check cannot be used together")
+	}
+	return nil
+}
+
+// run runs the migrate command.
+func (o *options) run() error {
+	if len(o.filenames) == 0 {
+		return o.migrateDatadogAgents()
+	}
+
+	paths, err := o.manifestPaths()
+	if err != nil {
+		return err
+	}
+
+	if o.check {
+		return o.checkFiles(paths)
+	}
+
+	return o.migrateFiles(paths)
+}
+
+// migrateDatadogAgents prints the v2alpha1 version of the DatadogAgents deployed in the cluster
+func (o *options) migrateDatadogAgents() error {
+	ddList := &v1alpha1.DatadogAgentList{}
+	if o.userDatadogAgentName == "" {
+		if err := o.Client.List(context.TODO(), ddList, &client.ListOptions{Namespace: o.UserNamespace}); err != nil {
+			return fmt.Errorf("unable to list DatadogAgent: %w", err)
+		}
+	} else {
+		dd := &v1alpha1.DatadogAgent{}
+		err := o.Client.Get(context.TODO(), client.ObjectKey{Namespace: o.UserNamespace, Name: o.userDatadogAgentName}, dd)
+		if err != nil && apierrors.IsNotFound(err) {
+			return fmt.Errorf("DatadogAgent %s/%s not found", o.UserNamespace, o.userDatadogAgentName)
+		} else if err != nil {
+			return fmt.Errorf("unable to get DatadogAgent: %w", err)
+		}
+		ddList.Items = append(ddList.Items, *dd)
+	}
+
+	for i, item := range ddList.Items {
+		converted, err := convertDatadogAgent(&item)
+		if err != nil {
+			return fmt.Errorf("unable to migrate DatadogAgent %s/%s: %w", item.Namespace, item.Name, err)
+		}
+		for _, warning := range converted.warnings {
+			fmt.Fprintf(o.ErrOut, "Warning: DatadogAgent %s/%s: %s\n", item.Namespace, item.Name, warning)
+		}
+		if i > 0 {
+			fmt.Fprint(o.Out, documentSeparator)
+		}
+		if _, err = o.Out.Write(converted.manifest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateFiles prints the v2alpha1 version of the DatadogAgents of the manifest files, or replaces them in place
+func (o *options) migrateFiles(paths []string) error {
+	printed := 0
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		documents, err := splitDocuments(data)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+
+		migrated := 0
+		for i, document := range documents {
+			dda, err := parseV1alpha1DatadogAgent(document)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+			if dda == nil {
+				continue
+			}
+
+			converted, err := convertDatadogAgent(dda)
+			if err != nil {
+				return fmt.Errorf("unable to migrate DatadogAgent %s in %s: %w", dda.Name, path, err)
+			}
+			for _, warning := range converted.warnings {
+				fmt.Fprintf(o.ErrOut, "Warning: %s: DatadogAgent %s: %s\n", path, dda.Name, warning)
+			}
+			documents[i] = converted.manifest
+			migrated++
+
+			if o.inPlace {
+				continue
+			}
+			if printed > 0 {
+				fmt.Fprint(o.Out, documentSeparator)
+			}
+			if _, err = o.Out.Write(converted.manifest); err != nil {
+				return err
+			}
+			printed++
+		}
+
+		if !o.inPlace || migrated == 0 {
+			continue
+		}
+		if err = writeDocuments(path, documents); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "%s: %d DatadogAgent(s) migrated to v2alpha1\n", path, migrated)
+	}
+
+	return nil
+}
+
+// checkFiles lists the v1alpha1 DatadogAgents of the manifest files, and returns an error if there is any
+func (o *options) checkFiles(paths []string) error {
+	found := 0
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", path, err)
+		}
+		documents, err := splitDocuments(data)
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+
+		for _, document := range documents {
+			dda, err := parseV1alpha1DatadogAgent(document)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+			if dda == nil {
+				continue
+			}
+			fmt.Fprintf(o.Out, "%s: DatadogAgent %s is a v1alpha1 DatadogAgent\n", path, dda.Name)
+			found++
+		}
+	}
+
+	if found > 0 {
+		return fmt.Errorf("%d v1alpha1 DatadogAgent(s) to migrate, run 'kubectl datadog migrate --in-place' to migrate them", found)
+	}
+
+	return nil
+}
+
+// manifestPaths returns the manifest files to process: the files given with --filename,
+// and the YAML files of the directories given with --filename
+func (o *options) manifestPaths() ([]string, error) {
+	var paths []string
+	for _, filename := range o.filenames {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, filename)
+			continue
+		}
+
+		err = filepath.Walk(filename, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if path != filename && !o.recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch filepath.Ext(path) {
+			case ".yaml", ".yml":
+				paths = append(paths, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// splitDocuments returns the documents of a YAML stream
+func splitDocuments(data []byte) ([][]byte, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	var documents [][]byte
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+}
+
+// parseV1alpha1DatadogAgent returns the DatadogAgent defined in the document, or nil if it is not a v1alpha1 DatadogAgent
+func parseV1alpha1DatadogAgent(document []byte) (*v1alpha1.DatadogAgent, error) {
+	typeMeta := &metav1.TypeMeta{}
+	// Documents that are not Kubernetes objects, like templates, are ignored
+	if err := yaml.Unmarshal(document, typeMeta); err != nil {
+		return nil, nil
+	}
+	if typeMeta.APIVersion != v1alpha1.GroupVersion.String() || typeMeta.Kind != "DatadogAgent" {
+		return nil, nil
+	}
+
+	dda := &v1alpha1.DatadogAgent{}
+	if err := yaml.Unmarshal(document, dda); err != nil {
+		return nil, err
+	}
+
+	return dda, nil
+}
+
+// writeDocuments replaces the content of the file with the documents
+func writeDocuments(path string, documents [][]byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	for i, document := range documents {
+		if i > 0 {
+			buffer.WriteString(documentSeparator)
+		}
+		buffer.Write(document)
+		if !bytes.HasSuffix(document, []byte("\n")) {
+			buffer.WriteString("\n")
+		}
+	}
+
+	if err = ioutil.WriteFile(path, buffer.Bytes(), info.Mode()); err != nil {
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+
+	return nil
+}
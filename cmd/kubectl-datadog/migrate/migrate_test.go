@@ -0,0 +1,195 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package migrate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const (
+	configMapManifest = `# not a DatadogAgent
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+data:
+  foo: bar
+`
+	v1alpha1Manifest = `apiVersion: datadoghq.com/v1alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+spec:
+  clusterName: my-cluster
+  agent:
+    dnsPolicy: ClusterFirst
+`
+	v2alpha1Manifest = `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: datadog
+spec:
+  global:
+    clusterName: my-cluster
+`
+)
+
+func Test_options_files(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "datadog.yaml"), configMapManifest+documentSeparator+v1alpha1Manifest)
+	writeFile(t, filepath.Join(dir, "migrated.yml"), v2alpha1Manifest)
+	writeFile(t, filepath.Join(dir, "README.md"), v1alpha1Manifest)
+	writeFile(t, filepath.Join(dir, "nested", "datadog.yaml"), v1alpha1Manifest)
+
+	// --check lists the v1alpha1 DatadogAgents and fails
+	o, out, _ := newTestOptions()
+	o.filenames = []string{dir}
+	o.recursive = true
+	o.check = true
+	require.NoError(t, o.validate())
+	assert.Error(t, o.run())
+	assert.Equal(t, filepath.Join(dir, "datadog.yaml")+": DatadogAgent datadog is a v1alpha1 DatadogAgent\n"+
+		filepath.Join(dir, "nested", "datadog.yaml")+": DatadogAgent datadog is a v1alpha1 DatadogAgent\n", out.String())
+
+	// without --recursive, the nested directories are ignored
+	o, out, errOut := newTestOptions()
+	o.filenames = []string{dir}
+	require.NoError(t, o.validate())
+	require.NoError(t, o.run())
+	assert.Equal(t, v2alpha1Manifest, out.String())
+	assert.Equal(t, "Warning: "+filepath.Join(dir, "datadog.yaml")+": DatadogAgent datadog: spec.agent.dnsPolicy is dropped: it has no effect on the v2alpha1 DatadogAgent\n", errOut.String())
+
+	// --in-place only replaces the v1alpha1 DatadogAgents
+	o, _, _ = newTestOptions()
+	o.filenames = []string{dir}
+	o.recursive = true
+	o.inPlace = true
+	require.NoError(t, o.validate())
+	require.NoError(t, o.run())
+	assert.Equal(t, configMapManifest+documentSeparator+v2alpha1Manifest, readFile(t, filepath.Join(dir, "datadog.yaml")))
+	assert.Equal(t, v2alpha1Manifest, readFile(t, filepath.Join(dir, "nested", "datadog.yaml")))
+	assert.Equal(t, v1alpha1Manifest, readFile(t, filepath.Join(dir, "README.md")))
+
+	// --check succeeds once everything is migrated
+	o, out, _ = newTestOptions()
+	o.filenames = []string{dir}
+	o.recursive = true
+	o.check = true
+	require.NoError(t, o.run())
+	assert.Empty(t, out.String())
+}
+
+func Test_options_datadogAgents(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(s))
+
+	o, out, _ := newTestOptions()
+	o.UserNamespace = "monitoring"
+	o.Client = fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&v1alpha1.DatadogAgent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "foo"},
+			Spec:       v1alpha1.DatadogAgentSpec{ClusterName: "foo"},
+		},
+		&v1alpha1.DatadogAgent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "bar"},
+			Spec:       v1alpha1.DatadogAgentSpec{ClusterName: "bar"},
+		},
+	).Build()
+
+	require.NoError(t, o.validate())
+	require.NoError(t, o.run())
+	assert.Equal(t, `apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: bar
+  namespace: monitoring
+spec:
+  global:
+    clusterName: bar
+---
+apiVersion: datadoghq.com/v2alpha1
+kind: DatadogAgent
+metadata:
+  name: foo
+  namespace: monitoring
+spec:
+  global:
+    clusterName: foo
+`, out.String())
+
+	o.userDatadogAgentName = "baz"
+	assert.EqualError(t, o.run(), "DatadogAgent monitoring/baz not found")
+}
+
+func Test_options_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       *options
+		wantErr bool
+	}{
+		{
+			name: "live DatadogAgent",
+			o:    &options{args: []string{"foo"}},
+		},
+		{
+			name:    "too many arguments",
+			o:       &options{args: []string{"foo", "bar"}},
+			wantErr: true,
+		},
+		{
+			name:    "name with filename",
+			o:       &options{args: []string{"foo"}, filenames: []string{"foo.yaml"}},
+			wantErr: true,
+		},
+		{
+			name:    "check without filename",
+			o:       &options{check: true},
+			wantErr: true,
+		},
+		{
+			name:    "check and in-place",
+			o:       &options{filenames: []string{"foo.yaml"}, check: true, inPlace: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("options.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newTestOptions() (*options, *bytes.Buffer, *bytes.Buffer) {
+	streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+	return newOptions(streams), out, errOut
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
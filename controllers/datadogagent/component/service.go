@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package component
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// GetLocalAgentServiceName return the name of the internal traffic policy service targeting
+// the Agent running on the local node
+func GetLocalAgentServiceName(dda *v2alpha1.DatadogAgent) string {
+	if dda.Spec.Global != nil && dda.Spec.Global.LocalService != nil && dda.Spec.Global.LocalService.NameOverride != nil {
+		return *dda.Spec.Global.LocalService.NameOverride
+	}
+
+	return GetAgentServiceName(dda)
+}
+
+// AddPortToLocalAgentService adds a port to the internal traffic policy service targeting
+// the Agent running on the local node. The service is shared between features,
+// so an existing service in the store is completed instead of being replaced.
+func AddPortToLocalAgentService(store dependencies.StoreClient, owner metav1.Object, serviceName string, port corev1.ServicePort) error {
+	obj, found := store.GetOrCreate(kubernetes.ServicesKind, owner.GetNamespace(), serviceName)
+	service, ok := obj.(*corev1.Service)
+	if !ok {
+		return fmt.Errorf("unable to get the local service %s/%s from the store", owner.GetNamespace(), serviceName)
+	}
+
+	if !found {
+		internalTrafficPolicy := corev1.ServiceInternalTrafficPolicyLocal
+		service.Labels = object.GetDefaultLabels(owner, apicommon.DefaultAgentResourceSuffix, GetAgentVersion(owner))
+		service.Annotations = object.GetDefaultAnnotations(owner)
+		service.Spec = corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeClusterIP,
+			Selector:              GetAgentPodSelector(owner).MatchLabels,
+			SessionAffinity:       corev1.ServiceAffinityNone,
+			InternalTrafficPolicy: &internalTrafficPolicy,
+		}
+	}
+
+	updated := false
+	for id := range service.Spec.Ports {
+		if service.Spec.Ports[id].Name == port.Name {
+			service.Spec.Ports[id] = port
+			updated = true
+		}
+	}
+	if !updated {
+		service.Spec.Ports = append(service.Spec.Ports, port)
+	}
+
+	store.AddOrUpdate(kubernetes.ServicesKind, service)
+
+	return nil
+}
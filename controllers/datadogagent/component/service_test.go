@@ -0,0 +1,61 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_AddPortToLocalAgentService(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+	}
+	apmPort := corev1.ServicePort{Name: "traceport", Protocol: corev1.ProtocolTCP, Port: 8126, TargetPort: intstr.FromInt(8126)}
+	dsdPort := corev1.ServicePort{Name: "dogstatsdport", Protocol: corev1.ProtocolUDP, Port: 8125, TargetPort: intstr.FromInt(8125)}
+	newAPMPort := corev1.ServicePort{Name: "traceport", Protocol: corev1.ProtocolTCP, Port: 8127, TargetPort: intstr.FromInt(8127)}
+
+	store := dependencies.NewStore(nil)
+	require.NoError(t, AddPortToLocalAgentService(store, dda, "foo-agent", apmPort))
+	require.NoError(t, AddPortToLocalAgentService(store, dda, "foo-agent", dsdPort))
+	// A port with the same name is replaced
+	require.NoError(t, AddPortToLocalAgentService(store, dda, "foo-agent", newAPMPort))
+
+	obj, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+	require.True(t, found)
+	service, ok := obj.(*corev1.Service)
+	require.True(t, ok)
+	assert.Equal(t, []corev1.ServicePort{newAPMPort, dsdPort}, service.Spec.Ports)
+	assert.Equal(t, GetAgentPodSelector(dda).MatchLabels, service.Spec.Selector)
+	assert.Equal(t, corev1.ServiceInternalTrafficPolicyLocal, *service.Spec.InternalTrafficPolicy)
+}
+
+func Test_GetLocalAgentServiceName(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	assert.Equal(t, "foo-agent", GetLocalAgentServiceName(dda))
+
+	nameOverride := "local-agent"
+	dda.Spec.Global = &v2alpha1.GlobalConfig{
+		LocalService: &v2alpha1.LocalService{NameOverride: &nameOverride},
+	}
+	assert.Equal(t, "local-agent", GetLocalAgentServiceName(dda))
+}
@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 )
 
 // GetVolumeForConfig return the volume that contains the agent config
@@ -170,3 +171,37 @@ func GetAgentVersion(dda metav1.Object) string {
 func GetClusterChecksRunnerName(dda metav1.Object) string {
 	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.DefaultClusterChecksRunnerResourceSuffix)
 }
+
+// GetAgentServiceName return the Agent service name based on the DatadogAgent name
+func GetAgentServiceName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-%s", dda.GetName(), apicommon.DefaultAgentResourceSuffix)
+}
+
+// GetAgentPodSelector return the label selector matching the Agent pods of the DatadogAgent
+func GetAgentPodSelector(dda metav1.Object) metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			apicommon.AgentDeploymentNameLabelKey:      dda.GetName(),
+			apicommon.AgentDeploymentComponentLabelKey: apicommon.DefaultAgentResourceSuffix,
+		},
+	}
+}
+
+// AddHostPortToContainer exposes a port on the host from the given container,
+// if the container is already present in the pod template. A port with the same name is replaced.
+func AddHostPortToContainer(tpl *corev1.PodTemplateSpec, containerName apicommonv1.AgentContainerName, port corev1.ContainerPort) {
+	for id := range tpl.Spec.Containers {
+		container := &tpl.Spec.Containers[id]
+		if container.Name != string(containerName) {
+			continue
+		}
+
+		for portID := range container.Ports {
+			if container.Ports[portID].Name == port.Name {
+				container.Ports[portID] = port
+				return
+			}
+		}
+		container.Ports = append(container.Ports, port)
+	}
+}
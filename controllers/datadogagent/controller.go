@@ -32,13 +32,23 @@ import (
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
 
 	// Use to register features
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/admissioncontroller"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/apm"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/clusterchecks"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/cspm"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/cws"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/dogstatsd"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/dummy"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/enabledefault"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/eventcollection"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/externalmetrics"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/kubernetesstatecore"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/livecontainer"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/liveprocess"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/logcollection"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/npm"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/oom_kill"
+	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/orchestratorexplorer"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/prometheus_scrape"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/tcp_queue_length"
 	_ "github.com/DataDog/datadog-operator/controllers/datadogagent/feature/usm"
@@ -143,17 +153,22 @@ func (r *Reconciler) internalReconcile(ctx context.Context, request reconcile.Re
 	return r.reconcileInstance(ctx, reqLogger, instance)
 }
 
-func reconcilerOptionsToFeatureOptions(opts *ReconcilerOptions, logger logr.Logger) *feature.Options {
-	return &feature.Options{
+func reconcilerOptionsToFeatureOptions(opts *ReconcilerOptions, versionInfo *version.Info, logger logr.Logger) *feature.Options {
+	featOpts := &feature.Options{
 		SupportExtendedDaemonset: opts.SupportExtendedDaemonset,
 		Logger:                   logger,
 	}
+	if versionInfo != nil {
+		featOpts.KubernetesVersion = versionInfo.GitVersion
+	}
+
+	return featOpts
 }
 
 func (r *Reconciler) reconcileInstance(ctx context.Context, logger logr.Logger, instance *datadoghqv1alpha1.DatadogAgent) (reconcile.Result, error) {
 	var result reconcile.Result
 
-	features, requiredComponents, err := feature.BuildFeaturesV1(instance, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	features, requiredComponents, err := feature.BuildFeaturesV1(instance, reconcilerOptionsToFeatureOptions(&r.options, r.versionInfo, logger))
 	if err != nil {
 		return result, fmt.Errorf("unable to build features, err: %w", err)
 	}
@@ -170,7 +185,7 @@ func (r *Reconciler) reconcileInstance(ctx context.Context, logger logr.Logger,
 	resourcesManager := feature.NewResourceManagers(depsStore)
 	var errs []error
 	for _, feat := range features {
-		if featErr := feat.ManageDependencies(resourcesManager, requiredComponents); err != nil {
+		if featErr := feat.ManageDependencies(resourcesManager, requiredComponents); featErr != nil {
 			errs = append(errs, featErr)
 		}
 	}
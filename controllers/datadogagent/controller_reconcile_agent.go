@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -27,7 +28,7 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, features []feature.Fea
 	podManagers := feature.NewPodTemplateManagers(&daemonset.Spec.Template)
 
 	// Set Global setting on the default deployment
-	daemonset.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global)
+	daemonset.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global, datadoghqv2alpha1.NodeAgentComponentName)
 
 	// Apply features changes on the Deployment.Spec.Template
 	for _, feat := range features {
@@ -36,19 +37,20 @@ func (r *Reconciler) reconcileV2Agent(logger logr.Logger, features []feature.Fea
 		}
 	}
 
-	// If Override is define for the cluster-checks-runner component, apply the override on the PodTemplateSpec, it will cascade to container.
-	if _, ok := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]; ok {
-		_, err = override.PodTemplateSpec(podManagers, dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName])
+	// If Override is define for the node agent component, apply the override on the PodTemplateSpec, it will cascade to container.
+	if componentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.NodeAgentComponentName]; ok {
+		_, err = override.PodTemplateSpec(podManagers, componentOverride, datadoghqv2alpha1.NodeAgentComponentName, dda.Name)
 		if err != nil {
 			return result, err
 		}
+		override.DaemonSet(daemonset, componentOverride)
 	}
 
 	daemonsetLogger := logger.WithValues("component", datadoghqv2alpha1.NodeAgentComponentName)
 	return r.createOrUpdateDaemonset(daemonsetLogger, dda, daemonset, newStatus, updateStatusV2WithAgent)
 }
 
-func updateStatusV2WithAgent(newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
-	// TODO(operator-ga): update status with DCA deployment information
+func updateStatusV2WithAgent(daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
+	newStatus.Agent = updateDaemonSetStatusV2(daemonset, newStatus.Agent, &updateTime)
 	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, updateTime, datadoghqv2alpha1.AgentReconcileConditionType, status, reason, message, true)
 }
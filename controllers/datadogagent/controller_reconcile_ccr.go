@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -25,7 +26,7 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, features
 	podManagers := feature.NewPodTemplateManagers(&deployment.Spec.Template)
 
 	// Set Global setting on the default deployment
-	deployment.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global)
+	deployment.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global, datadoghqv2alpha1.ClusterChecksRunnerComponentName)
 
 	// Apply features changes on the Deployment.Spec.Template
 	for _, feat := range features {
@@ -35,18 +36,19 @@ func (r *Reconciler) reconcileV2ClusterChecksRunner(logger logr.Logger, features
 	}
 
 	// If Override is define for the cluster-checks-runner component, apply the override on the PodTemplateSpec, it will cascade to container.
-	if _, ok := dda.Spec.Override[datadoghqv2alpha1.ClusterChecksRunnerComponentName]; ok {
-		_, err = override.PodTemplateSpec(podManagers, dda.Spec.Override[datadoghqv2alpha1.ClusterChecksRunnerComponentName])
+	if componentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.ClusterChecksRunnerComponentName]; ok {
+		_, err = override.PodTemplateSpec(podManagers, componentOverride, datadoghqv2alpha1.ClusterChecksRunnerComponentName, dda.Name)
 		if err != nil {
 			return result, err
 		}
+		override.Deployment(deployment, componentOverride)
 	}
 
 	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterChecksRunnerReconcileConditionType)
 	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterChecksRunner)
 }
 
-func updateStatusV2WithClusterChecksRunner(newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
-	// TODO(operator-ga): update status with DCA deployment information
+func updateStatusV2WithClusterChecksRunner(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
+	newStatus.ClusterChecksRunner = updateDeploymentStatusV2(deployment, newStatus.ClusterChecksRunner, &updateTime)
 	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, updateTime, datadoghqv2alpha1.ClusterChecksRunnerReconcileConditionType, status, reason, message, true)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/override"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -25,7 +26,7 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, features []feat
 	podManagers := feature.NewPodTemplateManagers(&deployment.Spec.Template)
 
 	// Set Global setting on the default deployment
-	deployment.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global)
+	deployment.Spec.Template = *override.ApplyGlobalSettings(podManagers, dda.Spec.Global, datadoghqv2alpha1.ClusterAgentComponentName)
 
 	// Apply features changes on the Deployment.Spec.Template
 	for _, feat := range features {
@@ -35,18 +36,19 @@ func (r *Reconciler) reconcileV2ClusterAgent(logger logr.Logger, features []feat
 	}
 
 	// If Override is define for the cluster-agent component, apply the override on the PodTemplateSpec, it will cascade to container.
-	if _, ok := dda.Spec.Override[datadoghqv2alpha1.ClusterAgentComponentName]; ok {
-		_, err = override.PodTemplateSpec(podManagers, dda.Spec.Override[datadoghqv2alpha1.ClusterAgentComponentName])
+	if componentOverride, ok := dda.Spec.Override[datadoghqv2alpha1.ClusterAgentComponentName]; ok {
+		_, err = override.PodTemplateSpec(podManagers, componentOverride, datadoghqv2alpha1.ClusterAgentComponentName, dda.Name)
 		if err != nil {
 			return result, err
 		}
+		override.Deployment(deployment, componentOverride)
 	}
 
 	deploymentLogger := logger.WithValues("component", datadoghqv2alpha1.ClusterAgentComponentName)
 	return r.createOrUpdateDeployment(deploymentLogger, dda, deployment, newStatus, updateStatusV2WithClusterAgent)
 }
 
-func updateStatusV2WithClusterAgent(newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
-	// TODO(operator-ga): update status with DCA deployment information
+func updateStatusV2WithClusterAgent(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string) {
+	newStatus.ClusterAgent = updateDeploymentStatusV2(deployment, newStatus.ClusterAgent, &updateTime)
 	datadoghqv2alpha1.UpdateDatadogAgentStatusConditions(newStatus, updateTime, datadoghqv2alpha1.ClusterAgentReconcileConditionType, status, reason, message, true)
 }
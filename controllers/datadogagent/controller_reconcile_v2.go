@@ -12,17 +12,24 @@ import (
 
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/override"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 )
 
 func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -68,13 +75,11 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 		return result, err
 	}
 
-	// TODO check if IsValideDatadogAgent function is needed for v2
-	/*
-		if err = datadoghqv2alpha1.IsValidDatadogAgent(&instance.Spec); err != nil {
-			reqLogger.V(1).Info("Invalid spec", "error", err)
-			return r.updateStatusIfNeeded(reqLogger, instance, &instance.Status, result, err)
-		}
-	*/
+	// The validating webhook may be disabled, so the spec is also validated here
+	if err = datadoghqv2alpha1.IsValidDatadogAgent(&instance.Spec); err != nil {
+		reqLogger.V(1).Info("Invalid spec", "error", err)
+		return r.updateStatusIfNeededV2(reqLogger, instance, instance.Status.DeepCopy(), result, err)
+	}
 
 	return r.reconcileInstanceV2(ctx, reqLogger, instance)
 }
@@ -82,12 +87,20 @@ func (r *Reconciler) internalReconcileV2(ctx context.Context, request reconcile.
 func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger, instance *datadoghqv2alpha1.DatadogAgent) (reconcile.Result, error) {
 	var result reconcile.Result
 
-	features, requiredComponents, err := feature.BuildFeatures(instance, reconcilerOptionsToFeatureOptions(&r.options, logger))
+	features, requiredComponents, err := feature.BuildFeatures(instance, reconcilerOptionsToFeatureOptions(&r.options, r.versionInfo, logger))
 	if err != nil {
 		return result, fmt.Errorf("unable to build features, err: %w", err)
 	}
+	// Components disabled in the override are never deployed, whatever the features require
+	override.RequiredComponents(&requiredComponents, instance.Spec.Override)
 	logger.Info("requiredComponents status:", "agent", requiredComponents.Agent.IsEnabled(), "cluster-agent", requiredComponents.ClusterAgent.IsEnabled(), "cluster-checks-runner", requiredComponents.ClusterChecksRunner.IsEnabled())
 
+	newStatus := instance.Status.DeepCopy()
+	newStatus.EnabledFeatures = getEnabledFeatureNames(features)
+	if newStatus.CurrentHash, err = comparison.GenerateMD5ForSpec(instance.Spec); err != nil {
+		return result, fmt.Errorf("unable to generate the DatadogAgent spec hash, err: %w", err)
+	}
+
 	// -----------------------
 	// Manage dependencies
 	// -----------------------
@@ -98,12 +111,18 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	depsStore := dependencies.NewStore(storeOptions)
 	resourcesManager := feature.NewResourceManagers(depsStore)
 	var errs []error
-	for id, feat := range features {
-		logger.Info("Dependency ManageDependencies", "featureID", id)
-		if featErr := feat.ManageDependencies(resourcesManager, requiredComponents); err != nil {
+	if tokenErr := r.manageClusterAgentToken(ctx, instance, resourcesManager, newStatus); tokenErr != nil {
+		errs = append(errs, tokenErr)
+	}
+	for _, feat := range features {
+		logger.Info("Dependency ManageDependencies", "featureID", feat.ID())
+		if featErr := feat.ManageDependencies(resourcesManager, requiredComponents); featErr != nil {
 			errs = append(errs, featErr)
 		}
 	}
+	for componentName, componentOverride := range instance.Spec.Override {
+		errs = append(errs, override.Dependencies(resourcesManager, componentOverride, componentName, instance)...)
+	}
 	// Now create/update dependencies
 	errs = append(errs, depsStore.Apply(ctx, r.client)...)
 	if len(errs) > 0 {
@@ -114,8 +133,6 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	// Start reconcile Components
 	// -----------------------------
 
-	newStatus := instance.Status.DeepCopy()
-
 	if requiredComponents.ClusterAgent.IsEnabled() {
 		logger.Info("ClusterAgent enabled")
 		result, err = r.reconcileV2ClusterAgent(logger, features, instance, newStatus)
@@ -153,6 +170,46 @@ func (r *Reconciler) reconcileInstanceV2(ctx context.Context, logger logr.Logger
 	return r.updateStatusIfNeededV2(logger, instance, newStatus, result, err)
 }
 
+// manageClusterAgentToken adds the secret containing the token used by the Agents to communicate with the Cluster Agent.
+// The token from the spec is used if set, else the previously generated token is kept to avoid restarting all the Agents.
+func (r *Reconciler) manageClusterAgentToken(ctx context.Context, dda *datadoghqv2alpha1.DatadogAgent, managers feature.ResourceManagers, newStatus *datadoghqv2alpha1.DatadogAgentStatus) error {
+	secretName := datadoghqv2alpha1.GetClusterAgentTokenSecretName(dda)
+
+	var token string
+	if dda.Spec.Global != nil && dda.Spec.Global.ClusterAgentToken != nil && *dda.Spec.Global.ClusterAgentToken != "" {
+		token = *dda.Spec.Global.ClusterAgentToken
+	} else {
+		currentSecret := &corev1.Secret{}
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: dda.Namespace, Name: secretName}, currentSecret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		token = string(currentSecret.Data[apicommon.DefaultTokenKey])
+		if token == "" {
+			token = apiutils.GenerateRandomString(32)
+		}
+	}
+
+	if err := managers.SecretManager().AddSecret(dda.Namespace, secretName, apicommon.DefaultTokenKey, token); err != nil {
+		return err
+	}
+
+	newStatus.ClusterAgentTokenRef = &apicommonv1.SecretConfig{
+		SecretName: secretName,
+		KeyName:    apicommon.DefaultTokenKey,
+	}
+	return nil
+}
+
+// getEnabledFeatureNames returns the names of the enabled features, in the order they were built.
+func getEnabledFeatureNames(features []feature.Feature) []string {
+	names := make([]string, 0, len(features))
+	for _, feat := range features {
+		names = append(names, feat.ID().String())
+	}
+	return names
+}
+
 func (r *Reconciler) updateStatusIfNeededV2(logger logr.Logger, agentdeployment *datadoghqv2alpha1.DatadogAgent, newStatus *datadoghqv2alpha1.DatadogAgentStatus, result reconcile.Result, currentError error) (reconcile.Result, error) {
 	now := metav1.NewTime(time.Now())
 	if currentError == nil {
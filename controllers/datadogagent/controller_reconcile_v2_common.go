@@ -7,8 +7,11 @@ package datadogagent
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
@@ -16,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -23,9 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-type updateStatusComponentFunc func(newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
+type updateDepStatusComponentFunc func(deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
+type updateDSStatusComponentFunc func(daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateTime metav1.Time, status metav1.ConditionStatus, reason, message string)
 
-func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, deployment *appsv1.Deployment, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDepStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("deployment.Namespace", deployment.Namespace, "deployment.Name", deployment.Name)
 
 	var result reconcile.Result
@@ -68,7 +73,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 		if !needUpdate {
 			// no need to update to stop here the process
 			now := metav1.NewTime(time.Now())
-			updateStatusFunc(newStatus, now, metav1.ConditionTrue, "deployment_up_to_date", "Deployment up-to-date")
+			updateStatusFunc(currentDeployment, newStatus, now, metav1.ConditionTrue, "deployment_up_to_date", "Deployment up-to-date")
 			return reconcile.Result{}, nil
 		}
 
@@ -88,23 +93,23 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 		now := metav1.NewTime(time.Now())
 		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateDeployment, currentDeployment.ObjectMeta)
 		if err != nil {
-			updateStatusFunc(newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to Update Deployment")
+			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to Update Deployment")
 			return reconcile.Result{}, err
 		}
 		event := buildEventInfo(updateDeployment.Name, updateDeployment.Namespace, deploymentKind, datadog.UpdateEvent)
 		r.recordEvent(dda, event)
-		updateStatusFunc(newStatus, now, metav1.ConditionTrue, "deployment_updated", "Deployment updated")
+		updateStatusFunc(updateDeployment, newStatus, now, metav1.ConditionTrue, "deployment_updated", "Deployment updated")
 	} else {
 		now := metav1.NewTime(time.Now())
 
 		err = r.client.Create(context.TODO(), deployment)
 		if err != nil {
-			updateStatusFunc(newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to create Deployment")
+			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to create Deployment")
 			return reconcile.Result{}, err
 		}
 		event := buildEventInfo(deployment.Name, deployment.Namespace, deploymentKind, datadog.CreationEvent)
 		r.recordEvent(dda, event)
-		updateStatusFunc(newStatus, now, metav1.ConditionTrue, "create_succeed", "Deployment created")
+		updateStatusFunc(deployment, newStatus, now, metav1.ConditionTrue, "create_succeed", "Deployment created")
 	}
 
 	logger.Info("Creating Deployment")
@@ -112,7 +117,7 @@ func (r *Reconciler) createOrUpdateDeployment(parentLogger logr.Logger, dda *dat
 	return result, err
 }
 
-func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateStatusComponentFunc) (reconcile.Result, error) {
+func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *datadoghqv2alpha1.DatadogAgent, daemonset *appsv1.DaemonSet, newStatus *datadoghqv2alpha1.DatadogAgentStatus, updateStatusFunc updateDSStatusComponentFunc) (reconcile.Result, error) {
 	logger := parentLogger.WithValues("daemonset.Namespace", daemonset.Namespace, "daemonset.Name", daemonset.Name)
 
 	var result reconcile.Result
@@ -154,6 +159,8 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 		needUpdate := !comparison.IsSameSpecMD5Hash(hash, currentDaemonset.GetAnnotations())
 		if !needUpdate {
 			// no need to update to stop here the process
+			now := metav1.NewTime(time.Now())
+			updateStatusFunc(currentDaemonset, newStatus, now, metav1.ConditionTrue, "daemonset_up_to_date", "Daemonset up-to-date")
 			return reconcile.Result{}, nil
 		}
 
@@ -172,25 +179,113 @@ func (r *Reconciler) createOrUpdateDaemonset(parentLogger logr.Logger, dda *data
 		now := metav1.NewTime(time.Now())
 		err = kubernetes.UpdateFromObject(context.TODO(), r.client, updateDaemonset, currentDaemonset.ObjectMeta)
 		if err != nil {
+			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, "update_failed", "Unable to update Daemonset")
 			return reconcile.Result{}, err
 		}
 		event := buildEventInfo(updateDaemonset.Name, updateDaemonset.Namespace, deploymentKind, datadog.UpdateEvent)
 		r.recordEvent(dda, event)
-		updateStatusFunc(newStatus, now, metav1.ConditionTrue, "Daemonset_updated", "Daemonset updated")
+		updateStatusFunc(updateDaemonset, newStatus, now, metav1.ConditionTrue, "Daemonset_updated", "Daemonset updated")
 	} else {
 		now := metav1.NewTime(time.Now())
 
 		err = r.client.Create(context.TODO(), daemonset)
 		if err != nil {
-			updateStatusFunc(newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to create Daemonset")
+			updateStatusFunc(nil, newStatus, now, metav1.ConditionFalse, "create_failed", "Unable to create Daemonset")
 			return reconcile.Result{}, err
 		}
 		event := buildEventInfo(daemonset.Name, daemonset.Namespace, daemonSetKind, datadog.CreationEvent)
 		r.recordEvent(dda, event)
-		updateStatusFunc(newStatus, now, metav1.ConditionTrue, "create_success", "Daemonset created")
+		updateStatusFunc(daemonset, newStatus, now, metav1.ConditionTrue, "create_success", "Daemonset created")
 	}
 
 	logger.Info("Creating Daemonset")
 
 	return result, err
 }
+
+func updateDaemonSetStatusV2(ds *appsv1.DaemonSet, dsStatus *apicommonv1.DaemonSetStatus, updateTime *metav1.Time) *apicommonv1.DaemonSetStatus {
+	if dsStatus == nil {
+		dsStatus = &apicommonv1.DaemonSetStatus{}
+	}
+	if ds == nil {
+		dsStatus.LastUpdate = updateTime
+		dsStatus.State = string(datadoghqv1alpha1.DatadogAgentStateFailed)
+		dsStatus.Status = string(datadoghqv1alpha1.DatadogAgentStateFailed)
+		return dsStatus
+	}
+
+	// Only track the last time the spec was changed, to avoid updating the status at each reconcile
+	hash := getHashAnnotation(ds.Annotations)
+	if updateTime != nil && (dsStatus.LastUpdate == nil || dsStatus.CurrentHash != hash) {
+		dsStatus.LastUpdate = updateTime
+	}
+	dsStatus.CurrentHash = hash
+	dsStatus.Desired = ds.Status.DesiredNumberScheduled
+	dsStatus.Current = ds.Status.CurrentNumberScheduled
+	dsStatus.Ready = ds.Status.NumberReady
+	dsStatus.Available = ds.Status.NumberAvailable
+	dsStatus.UpToDate = ds.Status.UpdatedNumberScheduled
+
+	var deploymentState datadoghqv1alpha1.DatadogAgentState
+	switch {
+	case dsStatus.UpToDate != dsStatus.Desired:
+		deploymentState = datadoghqv1alpha1.DatadogAgentStateUpdating
+	case dsStatus.Ready == 0:
+		deploymentState = datadoghqv1alpha1.DatadogAgentStateProgressing
+	default:
+		deploymentState = datadoghqv1alpha1.DatadogAgentStateRunning
+	}
+
+	dsStatus.State = string(deploymentState)
+	dsStatus.Status = fmt.Sprintf("%v (%d/%d/%d)", deploymentState, dsStatus.Desired, dsStatus.Ready, dsStatus.UpToDate)
+	dsStatus.DaemonsetName = ds.ObjectMeta.Name
+	return dsStatus
+}
+
+func updateDeploymentStatusV2(dep *appsv1.Deployment, depStatus *apicommonv1.DeploymentStatus, updateTime *metav1.Time) *apicommonv1.DeploymentStatus {
+	if depStatus == nil {
+		depStatus = &apicommonv1.DeploymentStatus{}
+	}
+	if dep == nil {
+		depStatus.LastUpdate = updateTime
+		depStatus.State = string(datadoghqv1alpha1.DatadogAgentStateFailed)
+		depStatus.Status = string(datadoghqv1alpha1.DatadogAgentStateFailed)
+		return depStatus
+	}
+
+	// Only track the last time the spec was changed, to avoid updating the status at each reconcile
+	hash := getHashAnnotation(dep.Annotations)
+	if updateTime != nil && (depStatus.LastUpdate == nil || depStatus.CurrentHash != hash) {
+		depStatus.LastUpdate = updateTime
+	}
+	depStatus.CurrentHash = hash
+	depStatus.Replicas = dep.Status.Replicas
+	depStatus.UpdatedReplicas = dep.Status.UpdatedReplicas
+	depStatus.AvailableReplicas = dep.Status.AvailableReplicas
+	depStatus.UnavailableReplicas = dep.Status.UnavailableReplicas
+	depStatus.ReadyReplicas = dep.Status.ReadyReplicas
+
+	// Deciding on deployment status based on Deployment status
+	var deploymentState datadoghqv1alpha1.DatadogAgentState
+	for _, condition := range dep.Status.Conditions {
+		if condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue {
+			deploymentState = datadoghqv1alpha1.DatadogAgentStateFailed
+		}
+	}
+
+	if deploymentState == "" {
+		switch {
+		case depStatus.UpdatedReplicas != depStatus.Replicas:
+			deploymentState = datadoghqv1alpha1.DatadogAgentStateUpdating
+		case depStatus.ReadyReplicas == 0:
+			deploymentState = datadoghqv1alpha1.DatadogAgentStateProgressing
+		default:
+			deploymentState = datadoghqv1alpha1.DatadogAgentStateRunning
+		}
+	}
+
+	depStatus.State = string(deploymentState)
+	depStatus.Status = fmt.Sprintf("%v (%d/%d/%d)", deploymentState, depStatus.Replicas, depStatus.ReadyReplicas, depStatus.UpdatedReplicas)
+	depStatus.DeploymentName = dep.ObjectMeta.Name
+	return depStatus
+}
@@ -0,0 +1,189 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+)
+
+func Test_updateDaemonSetStatusV2(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	before := metav1.NewTime(now.Add(-time.Hour))
+
+	newDaemonSet := func(hash string, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo-agent",
+				Annotations: map[string]string{apicommon.MD5AgentDeploymentAnnotationKey: hash},
+			},
+			Status: status,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		ds       *appsv1.DaemonSet
+		dsStatus *apicommonv1.DaemonSetStatus
+		want     *apicommonv1.DaemonSetStatus
+	}{
+		{
+			name: "daemonset failed",
+			ds:   nil,
+			want: &apicommonv1.DaemonSetStatus{
+				State:      "Failed",
+				Status:     "Failed",
+				LastUpdate: &now,
+			},
+		},
+		{
+			name: "daemonset running",
+			ds:   newDaemonSet("hash", appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, CurrentNumberScheduled: 2, NumberReady: 2, NumberAvailable: 2, UpdatedNumberScheduled: 2}),
+			want: &apicommonv1.DaemonSetStatus{
+				Desired:       2,
+				Current:       2,
+				Ready:         2,
+				Available:     2,
+				UpToDate:      2,
+				State:         "Running",
+				Status:        "Running (2/2/2)",
+				LastUpdate:    &now,
+				CurrentHash:   "hash",
+				DaemonsetName: "foo-agent",
+			},
+		},
+		{
+			name: "daemonset updating",
+			ds:   newDaemonSet("hash", appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, CurrentNumberScheduled: 2, NumberReady: 2, NumberAvailable: 2, UpdatedNumberScheduled: 1}),
+			want: &apicommonv1.DaemonSetStatus{
+				Desired:       2,
+				Current:       2,
+				Ready:         2,
+				Available:     2,
+				UpToDate:      1,
+				State:         "Updating",
+				Status:        "Updating (2/2/1)",
+				LastUpdate:    &now,
+				CurrentHash:   "hash",
+				DaemonsetName: "foo-agent",
+			},
+		},
+		{
+			name:     "same hash keeps the last update",
+			ds:       newDaemonSet("hash", appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1}),
+			dsStatus: &apicommonv1.DaemonSetStatus{CurrentHash: "hash", LastUpdate: &before},
+			want: &apicommonv1.DaemonSetStatus{
+				Desired:       1,
+				UpToDate:      1,
+				State:         "Progressing",
+				Status:        "Progressing (1/0/1)",
+				LastUpdate:    &before,
+				CurrentHash:   "hash",
+				DaemonsetName: "foo-agent",
+			},
+		},
+		{
+			name:     "new hash updates the last update",
+			ds:       newDaemonSet("new-hash", appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, NumberReady: 1, UpdatedNumberScheduled: 1}),
+			dsStatus: &apicommonv1.DaemonSetStatus{CurrentHash: "hash", LastUpdate: &before},
+			want: &apicommonv1.DaemonSetStatus{
+				Desired:       1,
+				Ready:         1,
+				UpToDate:      1,
+				State:         "Running",
+				Status:        "Running (1/1/1)",
+				LastUpdate:    &now,
+				CurrentHash:   "new-hash",
+				DaemonsetName: "foo-agent",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := updateDaemonSetStatusV2(tt.ds, tt.dsStatus, &now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_updateDeploymentStatusV2(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+
+	newDeployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo-cluster-agent",
+				Annotations: map[string]string{apicommon.MD5AgentDeploymentAnnotationKey: "hash"},
+			},
+			Status: status,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		dep        *appsv1.Deployment
+		wantState  string
+		wantStatus string
+	}{
+		{
+			name:       "deployment failed to be created",
+			dep:        nil,
+			wantState:  "Failed",
+			wantStatus: "Failed",
+		},
+		{
+			name:       "deployment progressing",
+			dep:        newDeployment(appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1}),
+			wantState:  "Progressing",
+			wantStatus: "Progressing (1/0/1)",
+		},
+		{
+			name:       "deployment updating",
+			dep:        newDeployment(appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 2}),
+			wantState:  "Updating",
+			wantStatus: "Updating (2/2/1)",
+		},
+		{
+			name:       "deployment running",
+			dep:        newDeployment(appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2}),
+			wantState:  "Running",
+			wantStatus: "Running (2/2/2)",
+		},
+		{
+			name: "deployment replica failure",
+			dep: newDeployment(appsv1.DeploymentStatus{
+				Replicas:   1,
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue}},
+			}),
+			wantState:  "Failed",
+			wantStatus: "Failed (1/0/0)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := updateDeploymentStatusV2(tt.dep, nil, &now)
+			assert.Equal(t, tt.wantState, got.State)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, &now, got.LastUpdate)
+			if tt.dep != nil {
+				assert.Equal(t, "hash", got.CurrentHash)
+				assert.Equal(t, tt.dep.Name, got.DeploymentName)
+				assert.Equal(t, tt.dep.Status.Replicas, got.Replicas)
+				assert.Equal(t, tt.dep.Status.ReadyReplicas, got.ReadyReplicas)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogagent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func Test_manageClusterAgentToken(t *testing.T) {
+	newDDA := func(token *string) *datadoghqv2alpha1.DatadogAgent {
+		return &datadoghqv2alpha1.DatadogAgent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+			Spec: datadoghqv2alpha1.DatadogAgentSpec{
+				Global: &datadoghqv2alpha1.GlobalConfig{ClusterAgentToken: token},
+			},
+		}
+	}
+	existingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo-token"},
+		Data:       map[string][]byte{apicommon.DefaultTokenKey: []byte("previously-generated-token")},
+	}
+
+	tests := []struct {
+		name      string
+		dda       *datadoghqv2alpha1.DatadogAgent
+		objects   []client.Object
+		wantToken string
+	}{
+		{
+			name:      "token from the spec",
+			dda:       newDDA(apiutils.NewStringPointer("spec-token")),
+			objects:   []client.Object{existingSecret},
+			wantToken: "spec-token",
+		},
+		{
+			name:      "keep the previously generated token",
+			dda:       newDDA(nil),
+			objects:   []client.Object{existingSecret},
+			wantToken: "previously-generated-token",
+		},
+		{
+			name: "generate a new token",
+			dda:  newDDA(nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{client: fake.NewClientBuilder().WithObjects(tt.objects...).Build()}
+			store := dependencies.NewStore(&dependencies.StoreOptions{})
+			newStatus := &datadoghqv2alpha1.DatadogAgentStatus{}
+
+			err := r.manageClusterAgentToken(context.TODO(), tt.dda, feature.NewResourceManagers(store), newStatus)
+			require.NoError(t, err)
+
+			assert.Equal(t, &apicommonv1.SecretConfig{SecretName: "foo-token", KeyName: apicommon.DefaultTokenKey}, newStatus.ClusterAgentTokenRef)
+
+			obj, found := store.Get(kubernetes.SecretsKind, "bar", "foo-token")
+			require.True(t, found)
+			token := string(obj.(*corev1.Secret).Data[apicommon.DefaultTokenKey])
+			if tt.wantToken == "" {
+				assert.Len(t, token, 32)
+			} else {
+				assert.Equal(t, tt.wantToken, token)
+			}
+		})
+	}
+}
+
+func Test_getEnabledFeatureNames(t *testing.T) {
+	dda := &datadoghqv2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "bar", Name: "foo"},
+		Spec: datadoghqv2alpha1.DatadogAgentSpec{
+			Features: &datadoghqv2alpha1.DatadogFeatures{
+				OOMKill: &datadoghqv2alpha1.OOMKillFeatureConfig{Enabled: apiutils.NewBoolPointer(true)},
+			},
+		},
+	}
+
+	features, _, err := feature.BuildFeatures(dda, &feature.Options{})
+	require.NoError(t, err)
+
+	// Dogstatsd is enabled by default
+	assert.Equal(t, []string{"default", "oom_kill", "dogstatsd"}, getEnabledFeatureNames(features))
+}
@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	admissionControllerRBACPrefix    = "admission-controller"
+	admissionControllerWebhookSuffix = "webhook"
+)
+
+// GetAdmissionControllerRBACResourceName return the RBAC resources name
+func GetAdmissionControllerRBACResourceName(owner metav1.Object, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s-%s", owner.GetNamespace(), owner.GetName(), admissionControllerRBACPrefix, suffix)
+}
+
+// getWebhookName return the name of the MutatingWebhookConfiguration managed by the Cluster Agent
+func getWebhookName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", owner.GetName(), admissionControllerWebhookSuffix)
+}
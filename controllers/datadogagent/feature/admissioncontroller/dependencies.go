@@ -0,0 +1,44 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+)
+
+// buildWebhookService returns the service targeting the Cluster Agent webhook server,
+// it is referenced by the MutatingWebhookConfiguration managed by the Cluster Agent.
+func (f *admissionControllerFeature) buildWebhookService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        f.serviceName,
+			Namespace:   f.owner.GetNamespace(),
+			Labels:      object.GetDefaultLabels(f.owner, apicommon.DefaultClusterAgentResourceSuffix, component.GetAgentVersion(f.owner)),
+			Annotations: object.GetDefaultAnnotations(f.owner),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:      f.owner.GetName(),
+				apicommon.AgentDeploymentComponentLabelKey: apicommon.DefaultClusterAgentResourceSuffix,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(apicommon.DefaultAdmissionControllerTargetPort),
+					Port:       apicommon.DefaultAdmissionControllerServicePort,
+				},
+			},
+			SessionAffinity: corev1.ServiceAffinityNone,
+		},
+	}
+}
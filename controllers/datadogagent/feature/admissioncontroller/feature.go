@@ -0,0 +1,147 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	common "github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func init() {
+	err := feature.Register(feature.AdmissionControllerIDType, buildAdmissionControllerFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildAdmissionControllerFeature(options *feature.Options) feature.Feature {
+	admissionControllerFeat := &admissionControllerFeature{}
+
+	return admissionControllerFeat
+}
+
+type admissionControllerFeature struct {
+	mutateUnlabelled       bool
+	serviceName            string
+	webhookName            string
+	agentCommunicationMode string
+	localServiceName       string
+
+	serviceAccountName string
+
+	owner metav1.Object
+}
+
+// ID returns the ID of the Feature
+func (f *admissionControllerFeature) ID() feature.IDType {
+	return feature.AdmissionControllerIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *admissionControllerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	ac := dda.Spec.Features.AdmissionController
+
+	if ac != nil && apiutils.BoolValue(ac.Enabled) {
+		f.mutateUnlabelled = apiutils.BoolValue(ac.MutateUnlabelled)
+		f.serviceName = apicommon.DefaultAdmissionServiceName
+		if ac.ServiceName != nil && *ac.ServiceName != "" {
+			f.serviceName = *ac.ServiceName
+		}
+		if ac.AgentCommunicationMode != nil {
+			f.agentCommunicationMode = *ac.AgentCommunicationMode
+		}
+		f.webhookName = getWebhookName(dda)
+		f.localServiceName = component.GetLocalAgentServiceName(dda)
+		f.serviceAccountName = v2alpha1.GetClusterAgentServiceAccount(dda)
+
+		reqComp = feature.RequiredComponents{
+			ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+		}
+	}
+
+	return reqComp
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// The Admission Controller is still configured by the v1alpha1 reconcile logic (Cluster Agent
+// env vars, service and RBAC), so the feature stays disabled to avoid managing the same resources twice.
+func (f *admissionControllerFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *admissionControllerFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	// The MutatingWebhookConfiguration is not added to the store: the Cluster Agent creates it
+	// and keeps its webhooks and CA bundle up to date, so the operator only provides the service
+	// and the RBAC needed to manage the configuration named after DD_ADMISSION_CONTROLLER_WEBHOOK_NAME.
+	managers.Store().AddOrUpdate(kubernetes.ServicesKind, f.buildWebhookService())
+
+	rbacName := GetAdmissionControllerRBACResourceName(f.owner, common.ClusterAgentSuffix)
+
+	return managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACClusterPolicyRules())
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *admissionControllerFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDAdmissionControllerEnabled,
+		Value: "true",
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDAdmissionControllerMutateUnlabelled,
+		Value: apiutils.BoolToString(&f.mutateUnlabelled),
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDAdmissionControllerServiceName,
+		Value: f.serviceName,
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDAdmissionControllerWebhookName,
+		Value: f.webhookName,
+	})
+
+	if f.agentCommunicationMode != "" {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDAdmissionControllerInjectConfigMode,
+			Value: f.agentCommunicationMode,
+		})
+	}
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDAdmissionControllerLocalServiceName,
+		Value: f.localServiceName,
+	})
+
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *admissionControllerFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *admissionControllerFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
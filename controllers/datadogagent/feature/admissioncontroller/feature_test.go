@@ -0,0 +1,192 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package admissioncontroller
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_admissionControllerFeature_Configure(t *testing.T) {
+	ddav1AdmissionController := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			ClusterAgent: v1alpha1.DatadogAgentSpecClusterAgentSpec{
+				Config: &v1alpha1.ClusterAgentConfig{
+					AdmissionController: &v1alpha1.AdmissionControllerConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+					},
+				},
+			},
+		},
+	}
+
+	ddav2AdmissionControllerDisabled := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				AdmissionController: &v2alpha1.AdmissionControllerFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2AdmissionControllerEnabled := ddav2AdmissionControllerDisabled.DeepCopy()
+	{
+		ddav2AdmissionControllerEnabled.Spec.Features.AdmissionController.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2AdmissionControllerCustom := ddav2AdmissionControllerEnabled.DeepCopy()
+	{
+		ddav2AdmissionControllerCustom.Spec.Features.AdmissionController.MutateUnlabelled = apiutils.NewBoolPointer(true)
+		ddav2AdmissionControllerCustom.Spec.Features.AdmissionController.ServiceName = apiutils.NewStringPointer("custom-webhook-svc")
+		ddav2AdmissionControllerCustom.Spec.Features.AdmissionController.AgentCommunicationMode = apiutils.NewStringPointer("socket")
+		ddav2AdmissionControllerCustom.Spec.Global = &v2alpha1.GlobalConfig{
+			LocalService: &v2alpha1.LocalService{
+				NameOverride: apiutils.NewStringPointer("custom-agent-svc"),
+			},
+		}
+	}
+
+	admissionControllerClusterAgentWantFunc := func(mutateUnlabelled, serviceName, mode, localServiceName string) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+			dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+
+			want := []*corev1.EnvVar{
+				{
+					Name:  apicommon.DDAdmissionControllerEnabled,
+					Value: "true",
+				},
+				{
+					Name:  apicommon.DDAdmissionControllerMutateUnlabelled,
+					Value: mutateUnlabelled,
+				},
+				{
+					Name:  apicommon.DDAdmissionControllerServiceName,
+					Value: serviceName,
+				},
+				{
+					Name:  apicommon.DDAdmissionControllerWebhookName,
+					Value: "foo-webhook",
+				},
+			}
+			if mode != "" {
+				want = append(want, &corev1.EnvVar{
+					Name:  apicommon.DDAdmissionControllerInjectConfigMode,
+					Value: mode,
+				})
+			}
+			want = append(want, &corev1.EnvVar{
+				Name:  apicommon.DDAdmissionControllerLocalServiceName,
+				Value: localServiceName,
+			})
+			assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
+		}
+	}
+
+	admissionControllerWantDependenciesFunc := func(serviceName string) func(testing.TB, dependencies.StoreClient) {
+		return func(t testing.TB, store dependencies.StoreClient) {
+			obj, found := store.Get(kubernetes.ServicesKind, "bar", serviceName)
+			if assert.True(t, found, "Service %s should be in the store", serviceName) {
+				service := obj.(*corev1.Service)
+				if assert.Len(t, service.Spec.Ports, 1) {
+					assert.Equal(t, int32(apicommon.DefaultAdmissionControllerServicePort), service.Spec.Ports[0].Port)
+					assert.Equal(t, apicommon.DefaultAdmissionControllerTargetPort, service.Spec.Ports[0].TargetPort.IntValue())
+				}
+				assert.Equal(t, apicommon.DefaultClusterAgentResourceSuffix, service.Spec.Selector[apicommon.AgentDeploymentComponentLabelKey])
+			}
+
+			rbacName := "bar-foo-admission-controller-dca"
+			obj, found = store.Get(kubernetes.ClusterRolesKind, "", rbacName)
+			if assert.True(t, found, "ClusterRole %s should be in the store", rbacName) {
+				clusterRole := obj.(*rbacv1.ClusterRole)
+				if assert.Len(t, clusterRole.Rules, 5) {
+					assert.Equal(t, []string{rbac.MutatingConfigResource}, clusterRole.Rules[0].Resources)
+				}
+			}
+
+			obj, found = store.Get(kubernetes.ClusterRoleBindingKind, "", rbacName)
+			if assert.True(t, found, "ClusterRoleBinding %s should be in the store", rbacName) {
+				clusterRoleBinding := obj.(*rbacv1.ClusterRoleBinding)
+				if assert.Len(t, clusterRoleBinding.Subjects, 1) {
+					assert.Equal(t, "foo-cluster-agent", clusterRoleBinding.Subjects[0].Name)
+					assert.Equal(t, "bar", clusterRoleBinding.Subjects[0].Namespace)
+				}
+			}
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 admission controller enabled",
+			DDAv1:         ddav1AdmissionController.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 admission controller not enabled",
+			DDAv2:         ddav2AdmissionControllerDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:                 "v2alpha1 admission controller enabled",
+			DDAv2:                ddav2AdmissionControllerEnabled,
+			WantConfigure:        true,
+			WantDependenciesFunc: admissionControllerWantDependenciesFunc(apicommon.DefaultAdmissionServiceName),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   admissionControllerClusterAgentWantFunc("false", apicommon.DefaultAdmissionServiceName, "", "foo-agent"),
+			},
+		},
+		{
+			Name:                 "v2alpha1 admission controller custom config",
+			DDAv2:                ddav2AdmissionControllerCustom,
+			WantConfigure:        true,
+			WantDependenciesFunc: admissionControllerWantDependenciesFunc("custom-webhook-svc"),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   admissionControllerClusterAgentWantFunc("true", "custom-webhook-svc", "socket", "custom-agent-svc"),
+			},
+		},
+	}
+
+	tests.Run(t, buildAdmissionControllerFeature)
+}
@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+)
+
+const (
+	defaultAPMSocketHostFilepath = "/var/run/datadog/apm.sock"
+
+	apmPortName                = "traceport"
+	apmNetworkPolicySuffix     = "apm"
+	apmCiliumPolicyDescription = "Ingress for APM trace"
+)
+
+// getAPMNetworkPolicyName return the name of the network policy allowing the APM traffic to the agent
+func getAPMNetworkPolicyName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s-%s", owner.GetName(), apicommon.DefaultAgentResourceSuffix, apmNetworkPolicySuffix)
+}
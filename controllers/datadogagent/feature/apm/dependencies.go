@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+	cilium "github.com/DataDog/datadog-operator/pkg/cilium/v1"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// manageLocalService adds the APM port to the local service of the agent.
+func (f *apmFeature) manageLocalService(store dependencies.StoreClient) error {
+	apmPort := corev1.ServicePort{
+		Protocol:   corev1.ProtocolTCP,
+		TargetPort: intstr.FromInt(int(f.hostPort)),
+		Port:       f.hostPort,
+		Name:       apicommon.DefaultApmPortName,
+	}
+
+	return component.AddPortToLocalAgentService(store, f.owner, f.localServiceName, apmPort)
+}
+
+// manageKubernetesNetworkPolicy allows the APM traffic to reach the agent pods.
+func (f *apmFeature) manageKubernetesNetworkPolicy(store dependencies.StoreClient) {
+	name := getAPMNetworkPolicyName(f.owner)
+	protocolTCP := corev1.ProtocolTCP
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: f.owner.GetNamespace(),
+			Labels:    object.GetDefaultLabels(f.owner, name, component.GetAgentVersion(f.owner)),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: component.GetAgentPodSelector(f.owner),
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: []networkingv1.NetworkPolicyPort{
+						{
+							Port: &intstr.IntOrString{
+								Type:   intstr.Int,
+								IntVal: f.hostPort,
+							},
+							Protocol: &protocolTCP,
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+		},
+	}
+
+	store.AddOrUpdate(kubernetes.NetworkPoliciesKind, policy)
+}
+
+// manageCiliumNetworkPolicy allows the APM traffic to reach the agent pods when Cilium is used.
+func (f *apmFeature) manageCiliumNetworkPolicy(store dependencies.StoreClient) error {
+	name := getAPMNetworkPolicyName(f.owner)
+
+	policy := &cilium.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: f.owner.GetNamespace(),
+			Labels:    object.GetDefaultLabels(f.owner, name, component.GetAgentVersion(f.owner)),
+		},
+		Specs: []cilium.NetworkPolicySpec{
+			{
+				Description:      apmCiliumPolicyDescription,
+				EndpointSelector: component.GetAgentPodSelector(f.owner),
+				Ingress: []cilium.IngressRule{
+					{
+						FromEndpoints: []metav1.LabelSelector{
+							{},
+						},
+						ToPorts: []cilium.PortRule{
+							{
+								Ports: []cilium.PortProtocol{
+									{
+										Port:     strconv.Itoa(int(f.hostPort)),
+										Protocol: cilium.ProtocolTCP,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	unstructuredPolicy := cilium.EmptyCiliumUnstructuredPolicy()
+	var err error
+	unstructuredPolicy.Object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return err
+	}
+	unstructuredPolicy.SetGroupVersionKind(cilium.GroupVersionCiliumNetworkPolicyKind())
+
+	store.AddOrUpdate(kubernetes.CiliumNetworkPoliciesKind, unstructuredPolicy)
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"path/filepath"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/utils"
+	"github.com/go-logr/logr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
+)
+
+func init() {
+	err := feature.Register(feature.APMIDType, buildAPMFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildAPMFeature(options *feature.Options) feature.Feature {
+	apmFeat := &apmFeature{}
+
+	if options != nil {
+		apmFeat.kubernetesVersion = options.KubernetesVersion
+		apmFeat.logger = options.Logger
+	}
+
+	return apmFeat
+}
+
+type apmFeature struct {
+	hostPortEnabled bool
+	hostPort        int32
+
+	udsEnabled      bool
+	udsHostFilepath string
+
+	owner metav1.Object
+
+	localServiceName        string
+	forceEnableLocalService bool
+	kubernetesVersion       string
+
+	createKubernetesNetworkPolicy bool
+	createCiliumNetworkPolicy     bool
+
+	logger logr.Logger
+}
+
+// ID returns the ID of the Feature
+func (f *apmFeature) ID() feature.IDType {
+	return feature.APMIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *apmFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	apm := dda.Spec.Features.APM
+	if apm == nil || !apiutils.BoolValue(apm.Enabled) {
+		return reqComp
+	}
+
+	f.hostPort = apicommon.DefaultApmPort
+	if apm.HostPortConfig != nil {
+		f.hostPortEnabled = apiutils.BoolValue(apm.HostPortConfig.Enabled)
+		if apm.HostPortConfig.Port != nil {
+			f.hostPort = *apm.HostPortConfig.Port
+		}
+	}
+	if apm.UnixDomainSocketConfig != nil && apiutils.BoolValue(apm.UnixDomainSocketConfig.Enabled) {
+		f.udsEnabled = true
+		f.udsHostFilepath = defaultAPMSocketHostFilepath
+		if apm.UnixDomainSocketConfig.Path != nil {
+			f.udsHostFilepath = *apm.UnixDomainSocketConfig.Path
+		}
+	}
+
+	f.localServiceName = component.GetLocalAgentServiceName(dda)
+	if dda.Spec.Global != nil {
+		if dda.Spec.Global.LocalService != nil {
+			f.forceEnableLocalService = apiutils.BoolValue(dda.Spec.Global.LocalService.ForceEnableLocalService)
+		}
+		if dda.Spec.Global.NetworkPolicy != nil && apiutils.BoolValue(dda.Spec.Global.NetworkPolicy.Create) {
+			switch dda.Spec.Global.NetworkPolicy.Flavor {
+			case v2alpha1.NetworkPolicyFlavorCilium:
+				f.createCiliumNetworkPolicy = true
+			default:
+				f.createKubernetesNetworkPolicy = true
+			}
+		}
+	}
+
+	return getRequiredComponents()
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// APM is still configured by the v1alpha1 reconcile logic (trace-agent container, agent
+// local service and network policy), so the feature stays disabled to avoid managing
+// the same resources twice.
+func (f *apmFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+func getRequiredComponents() feature.RequiredComponents {
+	return feature.RequiredComponents{
+		Agent: feature.RequiredComponent{
+			IsRequired: apiutils.NewBoolPointer(true),
+			Containers: []apicommonv1.AgentContainerName{
+				apicommonv1.CoreAgentContainerName,
+				apicommonv1.TraceAgentContainerName,
+			},
+		},
+	}
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *apmFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	// Service Internal Traffic Policy exists in Kube 1.21 but it is enabled by default since 1.22
+	if utils.IsAboveMinVersion(f.kubernetesVersion, "1.22-0") || f.forceEnableLocalService {
+		if err := f.manageLocalService(managers.Store()); err != nil {
+			return err
+		}
+	}
+
+	if f.createKubernetesNetworkPolicy {
+		f.manageKubernetesNetworkPolicy(managers.Store())
+	} else if f.createCiliumNetworkPolicy {
+		return f.manageCiliumNetworkPolicy(managers.Store())
+	}
+
+	return nil
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *apmFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *apmFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.TraceAgentContainerName, &corev1.EnvVar{
+		Name:  apicommon.DDAPMEnabled,
+		Value: "true",
+	})
+
+	// host port
+	if f.hostPortEnabled {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.TraceAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMReceiverPort,
+			Value: strconv.Itoa(int(f.hostPort)),
+		})
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.TraceAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMNonLocalTraffic,
+			Value: "true",
+		})
+		component.AddHostPortToContainer(managers.PodTemplateSpec(), apicommonv1.TraceAgentContainerName, corev1.ContainerPort{
+			Name:          apmPortName,
+			ContainerPort: f.hostPort,
+			HostPort:      f.hostPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+
+	// uds
+	if f.udsEnabled {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.TraceAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDAPMReceiverSocket,
+			Value: filepath.Join(apicommon.APMSocketVolumePath, filepath.Base(f.udsHostFilepath)),
+		})
+
+		socketVol, socketVolMount := volume.GetVolumes(apicommon.APMSocketVolumeName, filepath.Dir(f.udsHostFilepath), apicommon.APMSocketVolumePath, false)
+		volumeType := corev1.HostPathDirectoryOrCreate
+		socketVol.HostPath.Type = &volumeType
+		managers.Volume().AddVolumeToContainer(&socketVol, &socketVolMount, apicommonv1.TraceAgentContainerName)
+	}
+
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *apmFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
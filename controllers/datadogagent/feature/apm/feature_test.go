@@ -0,0 +1,402 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package apm
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func createTraceAgentFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	mgr.Tpl.Spec.Containers = []corev1.Container{
+		{
+			Name: string(apicommonv1.CoreAgentContainerName),
+		},
+		{
+			Name: string(apicommonv1.TraceAgentContainerName),
+		},
+	}
+	return mgr
+}
+
+func Test_apmFeature_Configure(t *testing.T) {
+	ddav1APMDisabled := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+				Apm: &v1alpha1.APMSpec{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav1APMEnabled := ddav1APMDisabled.DeepCopy()
+	{
+		ddav1APMEnabled.Spec.Agent.Apm.Enabled = apiutils.NewBoolPointer(true)
+		ddav1APMEnabled.Spec.Agent.Apm.HostPort = apiutils.NewInt32Pointer(8126)
+	}
+
+	ddav2APMDisabled := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				APM: &v2alpha1.APMFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2APMEnabled := ddav2APMDisabled.DeepCopy()
+	{
+		ddav2APMEnabled.Spec.Features.APM.Enabled = apiutils.NewBoolPointer(true)
+		ddav2APMEnabled.Spec.Features.APM.HostPortConfig = &v2alpha1.HostPortConfig{
+			Enabled: apiutils.NewBoolPointer(false),
+			Port:    apiutils.NewInt32Pointer(8126),
+		}
+		ddav2APMEnabled.Spec.Features.APM.UnixDomainSocketConfig = &v2alpha1.UnixDomainSocketConfig{
+			Enabled: apiutils.NewBoolPointer(true),
+			Path:    apiutils.NewStringPointer("/var/run/datadog/apm.sock"),
+		}
+	}
+
+	ddav2APMHostPort := ddav2APMEnabled.DeepCopy()
+	{
+		ddav2APMHostPort.Spec.Features.APM.HostPortConfig = &v2alpha1.HostPortConfig{
+			Enabled: apiutils.NewBoolPointer(true),
+			Port:    apiutils.NewInt32Pointer(8127),
+		}
+		ddav2APMHostPort.Spec.Features.APM.UnixDomainSocketConfig.Enabled = apiutils.NewBoolPointer(false)
+	}
+
+	ddav2APMHostPortAndUDS := ddav2APMEnabled.DeepCopy()
+	{
+		ddav2APMHostPortAndUDS.Spec.Features.APM.HostPortConfig.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2APMNetworkPolicy := ddav2APMEnabled.DeepCopy()
+	{
+		ddav2APMNetworkPolicy.Spec.Global = &v2alpha1.GlobalConfig{
+			NetworkPolicy: &v2alpha1.NetworkPolicyConfig{
+				Create: apiutils.NewBoolPointer(true),
+				Flavor: v2alpha1.NetworkPolicyFlavorKubernetes,
+			},
+		}
+	}
+
+	ddav2APMCiliumNetworkPolicy := ddav2APMEnabled.DeepCopy()
+	{
+		ddav2APMCiliumNetworkPolicy.Spec.Global = &v2alpha1.GlobalConfig{
+			NetworkPolicy: &v2alpha1.NetworkPolicyConfig{
+				Create: apiutils.NewBoolPointer(true),
+				Flavor: v2alpha1.NetworkPolicyFlavorCilium,
+			},
+		}
+	}
+
+	wantUDSVolumeFunc := func(t testing.TB, mgr *fake.PodTemplateManagers) {
+		volumeType := corev1.HostPathDirectoryOrCreate
+		wantVolumes := []corev1.Volume{
+			{
+				Name: apicommon.APMSocketVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: "/var/run/datadog",
+						Type: &volumeType,
+					},
+				},
+			},
+		}
+		volumes := mgr.VolumeMgr.Volumes
+		assert.True(t, apiutils.IsEqualStruct(volumes, wantVolumes), "Volumes \ndiff = %s", cmp.Diff(volumes, wantVolumes))
+
+		wantVolumeMounts := []corev1.VolumeMount{
+			{
+				Name:      apicommon.APMSocketVolumeName,
+				MountPath: apicommon.APMSocketVolumePath,
+				ReadOnly:  false,
+			},
+		}
+		traceAgentMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.TraceAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(traceAgentMounts, wantVolumeMounts), "Trace Agent volume mounts \ndiff = %s", cmp.Diff(traceAgentMounts, wantVolumeMounts))
+	}
+
+	apmUDSAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantUDSVolumeFunc(t, mgr)
+
+		wantEnvVars := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDAPMEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDAPMReceiverSocket,
+				Value: "/var/run/datadog/apm/apm.sock",
+			},
+		}
+		traceAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.TraceAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(traceAgentEnvVars, wantEnvVars), "Trace Agent envvars \ndiff = %s", cmp.Diff(traceAgentEnvVars, wantEnvVars))
+	}
+
+	apmHostPortAndUDSAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantUDSVolumeFunc(t, mgr)
+
+		wantEnvVars := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDAPMEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDAPMReceiverPort,
+				Value: "8126",
+			},
+			{
+				Name:  apicommon.DDAPMNonLocalTraffic,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDAPMReceiverSocket,
+				Value: "/var/run/datadog/apm/apm.sock",
+			},
+		}
+		traceAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.TraceAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(traceAgentEnvVars, wantEnvVars), "Trace Agent envvars \ndiff = %s", cmp.Diff(traceAgentEnvVars, wantEnvVars))
+	}
+
+	apmHostPortAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDAPMEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDAPMReceiverPort,
+				Value: "8127",
+			},
+			{
+				Name:  apicommon.DDAPMNonLocalTraffic,
+				Value: "true",
+			},
+		}
+		traceAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.TraceAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(traceAgentEnvVars, wantEnvVars), "Trace Agent envvars \ndiff = %s", cmp.Diff(traceAgentEnvVars, wantEnvVars))
+
+		assert.Empty(t, mgr.VolumeMgr.Volumes, "Volumes should be empty")
+
+		wantPorts := []corev1.ContainerPort{
+			{
+				Name:          apmPortName,
+				ContainerPort: 8127,
+				HostPort:      8127,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		}
+		for _, container := range mgr.Tpl.Spec.Containers {
+			if container.Name == string(apicommonv1.TraceAgentContainerName) {
+				assert.True(t, apiutils.IsEqualStruct(container.Ports, wantPorts), "Trace Agent ports \ndiff = %s", cmp.Diff(container.Ports, wantPorts))
+			} else {
+				assert.Empty(t, container.Ports, "%s ports should be empty", container.Name)
+			}
+		}
+	}
+
+	wantLocalServiceFunc := func(name string, port int32) func(testing.TB, dependencies.StoreClient) {
+		return func(t testing.TB, store dependencies.StoreClient) {
+			obj, found := store.Get(kubernetes.ServicesKind, "bar", name)
+			if !assert.True(t, found, "local service %s should be in the store", name) {
+				return
+			}
+			service := obj.(*corev1.Service)
+			assert.Equal(t, corev1.ServiceInternalTrafficPolicyLocal, *service.Spec.InternalTrafficPolicy)
+			wantSelector := map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:      "foo",
+				apicommon.AgentDeploymentComponentLabelKey: apicommon.DefaultAgentResourceSuffix,
+			}
+			assert.Equal(t, wantSelector, service.Spec.Selector)
+			if assert.Len(t, service.Spec.Ports, 1) {
+				assert.Equal(t, apicommon.DefaultApmPortName, service.Spec.Ports[0].Name)
+				assert.Equal(t, corev1.ProtocolTCP, service.Spec.Ports[0].Protocol)
+				assert.Equal(t, port, service.Spec.Ports[0].Port)
+			}
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v1alpha1 APM not enabled",
+			DDAv1:         ddav1APMDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 APM enabled",
+			DDAv1:         ddav1APMEnabled,
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 APM not enabled",
+			DDAv2:         ddav2APMDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:                 "v2alpha1 APM enabled",
+			DDAv2:                ddav2APMEnabled,
+			Options:              &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantLocalServiceFunc("foo-agent", 8126),
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   apmUDSAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 APM enabled, local service not supported",
+			DDAv2:         ddav2APMEnabled,
+			Options:       &test.Options{KubernetesVersion: "v1.21.0"},
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				_, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+				assert.False(t, found, "local service should not be created before Kubernetes 1.22")
+			},
+		},
+		{
+			Name:                 "v2alpha1 APM enabled, host port enabled",
+			DDAv2:                ddav2APMHostPort,
+			Options:              &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantLocalServiceFunc("foo-agent", 8127),
+			Agent: &test.ComponentTest{
+				CreateFunc: createTraceAgentFakeManager,
+				WantFunc:   apmHostPortAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 APM enabled, host port and unix domain socket enabled",
+			DDAv2:         ddav2APMHostPortAndUDS,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   apmHostPortAndUDSAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 APM enabled, local service shared with another feature",
+			DDAv2:         ddav2APMEnabled,
+			Options:       &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure: true,
+			StoreInitFunc: func(store dependencies.StoreClient) {
+				store.AddOrUpdate(kubernetes.ServicesKind, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-agent",
+						Namespace: "bar",
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{
+							{
+								Name:     apicommon.DefaultDogstatsdPortName,
+								Protocol: corev1.ProtocolUDP,
+								Port:     apicommon.DefaultDogstatsdPort,
+							},
+						},
+					},
+				})
+			},
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+				if !assert.True(t, found, "local service should be in the store") {
+					return
+				}
+				ports := obj.(*corev1.Service).Spec.Ports
+				if assert.Len(t, ports, 2) {
+					assert.Equal(t, apicommon.DefaultDogstatsdPortName, ports[0].Name)
+					assert.Equal(t, apicommon.DefaultApmPortName, ports[1].Name)
+				}
+			},
+		},
+		{
+			Name:          "v2alpha1 APM enabled, kubernetes network policy",
+			DDAv2:         ddav2APMNetworkPolicy,
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.NetworkPoliciesKind, "bar", "foo-agent-apm")
+				if !assert.True(t, found, "network policy should be in the store") {
+					return
+				}
+				policy := obj.(*networkingv1.NetworkPolicy)
+				assert.Equal(t, "foo", policy.Spec.PodSelector.MatchLabels[apicommon.AgentDeploymentNameLabelKey])
+				if assert.Len(t, policy.Spec.Ingress, 1) && assert.Len(t, policy.Spec.Ingress[0].Ports, 1) {
+					assert.Equal(t, int32(8126), policy.Spec.Ingress[0].Ports[0].Port.IntVal)
+					assert.Equal(t, corev1.ProtocolTCP, *policy.Spec.Ingress[0].Ports[0].Protocol)
+				}
+
+				_, found = store.Get(kubernetes.CiliumNetworkPoliciesKind, "bar", "foo-agent-apm")
+				assert.False(t, found, "cilium network policy should not be in the store")
+			},
+		},
+		{
+			Name:          "v2alpha1 APM enabled, cilium network policy",
+			DDAv2:         ddav2APMCiliumNetworkPolicy,
+			WantConfigure: true,
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.CiliumNetworkPoliciesKind, "bar", "foo-agent-apm")
+				if !assert.True(t, found, "cilium network policy should be in the store") {
+					return
+				}
+				policy := obj.(*unstructured.Unstructured)
+				specs, _, _ := unstructured.NestedSlice(policy.Object, "specs")
+				if assert.Len(t, specs, 1) {
+					assert.Equal(t, apmCiliumPolicyDescription, specs[0].(map[string]interface{})["description"])
+				}
+
+				_, found = store.Get(kubernetes.NetworkPoliciesKind, "bar", "foo-agent-apm")
+				assert.False(t, found, "kubernetes network policy should not be in the store")
+			},
+		},
+	}
+
+	tests.Run(t, buildAPMFeature)
+}
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusterchecks
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/merger"
+)
+
+func init() {
+	err := feature.Register(feature.ClusterChecksIDType, buildClusterChecksFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildClusterChecksFeature(options *feature.Options) feature.Feature {
+	clusterChecksFeat := &clusterChecksFeature{}
+
+	return clusterChecksFeat
+}
+
+type clusterChecksFeature struct {
+	useClusterCheckRunners bool
+
+	owner metav1.Object
+}
+
+// ID returns the ID of the Feature
+func (f *clusterChecksFeature) ID() feature.IDType {
+	return feature.ClusterChecksIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+// When Cluster Checks are enabled, the Cluster Checks Runner is required if
+// `useClusterChecksRunners` is set, and explicitly disabled otherwise.
+func (f *clusterChecksFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	clusterChecks := dda.Spec.Features.ClusterChecks
+
+	if clusterChecks != nil && apiutils.BoolValue(clusterChecks.Enabled) {
+		f.useClusterCheckRunners = apiutils.BoolValue(clusterChecks.UseClusterChecksRunners)
+
+		reqComp = feature.RequiredComponents{
+			ClusterAgent:        feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+			ClusterChecksRunner: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(f.useClusterCheckRunners)},
+		}
+		if f.useClusterCheckRunners {
+			reqComp.ClusterChecksRunner.Containers = []apicommonv1.AgentContainerName{
+				apicommonv1.ClusterChecksRunnersContainerName,
+			}
+		}
+	}
+
+	return reqComp
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// Cluster Checks are still configured by the v1alpha1 reconcile logic (Cluster Agent, Agent
+// and Cluster Checks Runner env vars), so the feature stays disabled to avoid configuring them twice.
+func (f *clusterChecksFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *clusterChecksFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	return nil
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterChecksFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDClusterChecksEnabled,
+		Value: "true",
+	})
+
+	if err := managers.EnvVar().AddEnvVarWithMergeFunc(&corev1.EnvVar{
+		Name:  apicommon.DDExtraConfigProviders,
+		Value: apicommon.KubeServicesAndEndpointsConfigProviders,
+	}, merger.AppendToValueEnvVarMergeFunction); err != nil {
+		return err
+	}
+
+	return managers.EnvVar().AddEnvVarWithMergeFunc(&corev1.EnvVar{
+		Name:  apicommon.DDExtraListeners,
+		Value: apicommon.KubeServicesAndEndpointsListeners,
+	}, merger.AppendToValueEnvVarMergeFunction)
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+// Without Cluster Checks Runners, the Node Agents run both the cluster checks and the endpoints checks.
+func (f *clusterChecksFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	configProviders := apicommon.ClusterAndEndpointsConfigProviders
+	if f.useClusterCheckRunners {
+		configProviders = apicommon.EndpointsChecksConfigProvider
+	}
+
+	return managers.EnvVar().AddEnvVarToContainerWithMergeFunc(apicommonv1.CoreAgentContainerName, &corev1.EnvVar{
+		Name:  apicommon.DDExtraConfigProviders,
+		Value: configProviders,
+	}, merger.AppendToValueEnvVarMergeFunction)
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *clusterChecksFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return managers.EnvVar().AddEnvVarToContainerWithMergeFunc(apicommonv1.ClusterChecksRunnersContainerName, &corev1.EnvVar{
+		Name:  apicommon.DDExtraConfigProviders,
+		Value: apicommon.ClusterChecksConfigProvider,
+	}, merger.AppendToValueEnvVarMergeFunction)
+}
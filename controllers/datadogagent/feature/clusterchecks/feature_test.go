@@ -0,0 +1,200 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package clusterchecks
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func newV2ClusterChecksAgent(enabled bool, useRunners *bool) *v2alpha1.DatadogAgent {
+	return &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				ClusterChecks: &v2alpha1.ClusterChecksFeatureConfig{
+					Enabled:                 apiutils.NewBoolPointer(enabled),
+					UseClusterChecksRunners: useRunners,
+				},
+			},
+		},
+	}
+}
+
+func Test_clusterChecksFeature_Configure(t *testing.T) {
+	ddav1ClusterChecks := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			ClusterAgent: v1alpha1.DatadogAgentSpecClusterAgentSpec{
+				Config: &v1alpha1.ClusterAgentConfig{
+					ClusterChecksEnabled: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	clusterChecksClusterAgentWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+		dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+
+		want := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDClusterChecksEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExtraConfigProviders,
+				Value: apicommon.KubeServicesAndEndpointsConfigProviders,
+			},
+			{
+				Name:  apicommon.DDExtraListeners,
+				Value: apicommon.KubeServicesAndEndpointsListeners,
+			},
+		}
+		assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
+	}
+
+	clusterChecksNodeAgentWantFunc := func(configProviders string) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+			agentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+
+			want := []*corev1.EnvVar{
+				{
+					Name:  apicommon.DDExtraConfigProviders,
+					Value: configProviders,
+				},
+			}
+			assert.True(t, apiutils.IsEqualStruct(agentEnvVars, want), "Agent envvars \ndiff = %s", cmp.Diff(agentEnvVars, want))
+		}
+	}
+
+	clusterChecksRunnerWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+		ccrEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.ClusterChecksRunnersContainerName]
+
+		want := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDExtraConfigProviders,
+				Value: apicommon.ClusterChecksConfigProvider,
+			},
+		}
+		assert.True(t, apiutils.IsEqualStruct(ccrEnvVars, want), "Cluster Checks Runner envvars \ndiff = %s", cmp.Diff(ccrEnvVars, want))
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 cluster checks enabled",
+			DDAv1:         ddav1ClusterChecks.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 cluster checks not enabled",
+			DDAv2:         newV2ClusterChecksAgent(false, nil),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v2alpha1 cluster checks enabled, without runners",
+			DDAv2:         newV2ClusterChecksAgent(true, apiutils.NewBoolPointer(false)),
+			WantConfigure: true,
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   clusterChecksClusterAgentWantFunc,
+			},
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   clusterChecksNodeAgentWantFunc(apicommon.ClusterAndEndpointsConfigProviders),
+			},
+		},
+		{
+			Name:          "v2alpha1 cluster checks enabled, with runners",
+			DDAv2:         newV2ClusterChecksAgent(true, apiutils.NewBoolPointer(true)),
+			WantConfigure: true,
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   clusterChecksClusterAgentWantFunc,
+			},
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   clusterChecksNodeAgentWantFunc(apicommon.EndpointsChecksConfigProvider),
+			},
+			ClusterChecksRunner: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   clusterChecksRunnerWantFunc,
+			},
+		},
+	}
+
+	tests.Run(t, buildClusterChecksFeature)
+}
+
+func Test_clusterChecksFeature_RequiredComponents(t *testing.T) {
+	tests := []struct {
+		name             string
+		dda              *v2alpha1.DatadogAgent
+		wantClusterAgent bool
+		wantRunner       *bool
+	}{
+		{
+			name:       "cluster checks disabled",
+			dda:        newV2ClusterChecksAgent(false, apiutils.NewBoolPointer(true)),
+			wantRunner: nil,
+		},
+		{
+			name:             "cluster checks enabled, runners not set",
+			dda:              newV2ClusterChecksAgent(true, nil),
+			wantClusterAgent: true,
+			wantRunner:       apiutils.NewBoolPointer(false),
+		},
+		{
+			name:             "cluster checks enabled, with runners",
+			dda:              newV2ClusterChecksAgent(true, apiutils.NewBoolPointer(true)),
+			wantClusterAgent: true,
+			wantRunner:       apiutils.NewBoolPointer(true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := buildClusterChecksFeature(&feature.Options{})
+			reqComp := f.Configure(tt.dda)
+
+			assert.Equal(t, tt.wantClusterAgent, reqComp.ClusterAgent.IsEnabled())
+			assert.Equal(t, tt.wantRunner, reqComp.ClusterChecksRunner.IsRequired)
+			assert.Equal(t, apiutils.BoolValue(tt.wantRunner), reqComp.ClusterChecksRunner.IsEnabled())
+		})
+	}
+}
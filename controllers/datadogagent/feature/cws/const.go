@@ -0,0 +1,19 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package cws
+
+const (
+	cwsConfigVolumeName = "runtimepoliciesdir"
+	cwsConfigVolumePath = "/etc/datadog-agent/runtime-security.d"
+	cwsSocketFileName   = "runtime-security.sock"
+
+	// the custom policies ConfigMap is copied with the bundled policies into the cwsConfigVolumeName emptyDir by the
+	// init-volume init container
+	cwsCustomPoliciesVolumeName  = "customruntimepolicies"
+	cwsCustomPoliciesVolumePath  = "/etc/datadog-agent-runtime-policies"
+	cwsInitConfigVolumeMountPath = "/opt/datadog-agent/runtime-security.d"
+	cwsCopyCustomPoliciesCommand = "cp -v " + cwsConfigVolumePath + "/* " + cwsInitConfigVolumeMountPath + "/;cp -v " + cwsCustomPoliciesVolumePath + "/* " + cwsInitConfigVolumeMountPath + "/"
+)
@@ -0,0 +1,277 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package cws
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/merger"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+)
+
+func init() {
+	err := feature.Register(feature.CWSIDType, buildCWSFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildCWSFeature(options *feature.Options) feature.Feature {
+	cwsFeat := &cwsFeature{}
+
+	return cwsFeat
+}
+
+type cwsFeature struct {
+	syscallMonitorEnabled bool
+	configMapConfig       *apicommonv1.ConfigMapConfig
+	configMapName         string
+
+	owner metav1.Object
+}
+
+// ID returns the ID of the Feature
+func (f *cwsFeature) ID() feature.IDType {
+	return feature.CWSIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *cwsFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+
+	if dda.Spec.Features.CWS != nil && apiutils.BoolValue(dda.Spec.Features.CWS.Enabled) {
+		f.syscallMonitorEnabled = apiutils.BoolValue(dda.Spec.Features.CWS.SyscallMonitorEnabled)
+
+		if dda.Spec.Features.CWS.CustomPolicies != nil {
+			f.configMapName = dda.Spec.Features.CWS.CustomPolicies.Name
+			f.configMapConfig = dda.Spec.Features.CWS.CustomPolicies
+		}
+
+		reqComp = feature.RequiredComponents{
+			Agent: feature.RequiredComponent{
+				IsRequired: apiutils.NewBoolPointer(true),
+				Containers: []apicommonv1.AgentContainerName{
+					apicommonv1.CoreAgentContainerName,
+					apicommonv1.SecurityAgentContainerName,
+					apicommonv1.SystemProbeContainerName,
+				},
+			},
+		}
+	}
+
+	return reqComp
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+func (f *cwsFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+
+	if dda.Spec.Agent.Security != nil && apiutils.BoolValue(dda.Spec.Agent.Security.Runtime.Enabled) {
+		if dda.Spec.Agent.Security.Runtime.SyscallMonitor != nil {
+			f.syscallMonitorEnabled = apiutils.BoolValue(dda.Spec.Agent.Security.Runtime.SyscallMonitor.Enabled)
+		}
+
+		if dda.Spec.Agent.Security.Runtime.PoliciesDir != nil {
+			f.configMapName = dda.Spec.Agent.Security.Runtime.PoliciesDir.ConfigMapName
+			f.configMapConfig = v1alpha1.ConvertConfigDirSpec(dda.Spec.Agent.Security.Runtime.PoliciesDir).ConfigMap
+		}
+
+		reqComp = feature.RequiredComponents{
+			Agent: feature.RequiredComponent{
+				IsRequired: apiutils.NewBoolPointer(true),
+				Containers: []apicommonv1.AgentContainerName{
+					apicommonv1.CoreAgentContainerName,
+					apicommonv1.SecurityAgentContainerName,
+					apicommonv1.SystemProbeContainerName,
+				},
+			},
+		}
+	}
+
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *cwsFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	return nil
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *cwsFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *cwsFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	// annotations
+	managers.Annotation().AddAnnotation(apicommon.SystemProbeAppArmorAnnotationKey, apicommon.SystemProbeAppArmorAnnotationValue)
+
+	// security context capabilities
+	capabilities := []corev1.Capability{
+		"SYS_ADMIN",
+		"SYS_RESOURCE",
+		"SYS_PTRACE",
+		"NET_ADMIN",
+		"NET_BROADCAST",
+		"NET_RAW",
+		"IPC_LOCK",
+		"CHOWN",
+	}
+	managers.SecurityContext().AddCapabilitiesToContainer(capabilities, apicommonv1.SystemProbeContainerName)
+
+	// custom policies volume mounts: the ConfigMap is copied next to the bundled policies by an init container, into
+	// an emptyDir mounted in place of the policies directory
+	if f.configMapConfig != nil && f.configMapName != "" {
+		addInitVolumeContainer(managers.PodTemplateSpec(), cwsCopyCustomPoliciesCommand)
+
+		policiesVol, policiesVolMount := volume.GetVolumesEmptyDir(cwsConfigVolumeName, cwsConfigVolumePath)
+		managers.Volume().AddVolumeToContainers(
+			&policiesVol,
+			&policiesVolMount,
+			[]apicommonv1.AgentContainerName{
+				apicommonv1.SecurityAgentContainerName,
+				apicommonv1.SystemProbeContainerName,
+			})
+
+		initPoliciesVolMount := corev1.VolumeMount{
+			Name:      cwsConfigVolumeName,
+			MountPath: cwsInitConfigVolumeMountPath,
+		}
+		if err := managers.Volume().AddVolumeToContainerWithMergeFunc(&policiesVol, &initPoliciesVolMount, apicommonv1.InitVolumeContainerName, merger.DefaultVolumeMergeFunction, merger.DefaultVolumeMountMergeFunction); err != nil {
+			return err
+		}
+
+		cmVol, cmVolMount := volume.GetConfigMapVolumes(
+			f.configMapConfig,
+			f.configMapName,
+			cwsCustomPoliciesVolumeName,
+			cwsCustomPoliciesVolumePath,
+		)
+		managers.Volume().AddVolumeToContainer(&cmVol, &cmVolMount, apicommonv1.InitVolumeContainerName)
+	}
+
+	// debugfs volume mount
+	debugfsVol, debugfsVolMount := volume.GetVolumes(apicommon.DebugfsVolumeName, apicommon.DebugfsPath, apicommon.DebugfsPath, false)
+	managers.Volume().AddVolumeToContainer(&debugfsVol, &debugfsVolMount, apicommonv1.SystemProbeContainerName)
+
+	// tracefs volume mount
+	tracefsVol, tracefsVolMount := volume.GetVolumes(apicommon.TracefsVolumeName, apicommon.TracefsPath, apicommon.TracefsPath, false)
+	managers.Volume().AddVolumeToContainer(&tracefsVol, &tracefsVolMount, apicommonv1.SystemProbeContainerName)
+
+	// kernel headers volume mounts
+	modulesVol, modulesVolMount := volume.GetVolumes(apicommon.ModulesVolumeName, apicommon.ModulesVolumePath, apicommon.ModulesVolumePath, true)
+	managers.Volume().AddVolumeToContainer(&modulesVol, &modulesVolMount, apicommonv1.SystemProbeContainerName)
+
+	srcVol, srcVolMount := volume.GetVolumes(apicommon.SrcVolumeName, apicommon.SrcVolumePath, apicommon.SrcVolumePath, true)
+	managers.Volume().AddVolumeToContainer(&srcVol, &srcVolMount, apicommonv1.SystemProbeContainerName)
+
+	osReleaseVol, osReleaseVolMount := volume.GetVolumes(apicommon.OSReleaseVolumeName, apicommon.OSReleaseHostPath, apicommon.OSReleaseMountPath, true)
+	managers.Volume().AddVolumeToContainer(&osReleaseVol, &osReleaseVolMount, apicommonv1.SystemProbeContainerName)
+
+	// procdir volume mount
+	procdirVol, procdirVolMount := volume.GetVolumes(apicommon.ProcdirVolumeName, apicommon.ProcdirHostPath, apicommon.ProcdirMountPath, true)
+	managers.Volume().AddVolumeToContainer(&procdirVol, &procdirVolMount, apicommonv1.SystemProbeContainerName)
+
+	// socket volume mount
+	socketVol, socketVolMount := volume.GetVolumesEmptyDir(apicommon.SystemProbeSocketVolumeName, apicommon.SystemProbeSocketVolumePath)
+	managers.Volume().AddVolumeToContainers(
+		&socketVol,
+		&socketVolMount,
+		[]apicommonv1.AgentContainerName{
+			apicommonv1.CoreAgentContainerName,
+			apicommonv1.SecurityAgentContainerName,
+			apicommonv1.SystemProbeContainerName,
+		})
+
+	// env vars
+	enabledEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDRuntimeSecurityConfigEnabled,
+		Value: "true",
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SecurityAgentContainerName, enabledEnvVar)
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, enabledEnvVar)
+
+	socketEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDRuntimeSecurityConfigSocket,
+		Value: filepath.Join(apicommon.SystemProbeSocketVolumePath, cwsSocketFileName),
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SecurityAgentContainerName, socketEnvVar)
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, socketEnvVar)
+
+	syscallMonitorEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDRuntimeSecurityConfigSyscallMonitor,
+		Value: strconv.FormatBool(f.syscallMonitorEnabled),
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SecurityAgentContainerName, syscallMonitorEnvVar)
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, syscallMonitorEnvVar)
+
+	policiesDirEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDRuntimeSecurityConfigPoliciesDir,
+		Value: cwsConfigVolumePath,
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SecurityAgentContainerName, policiesDirEnvVar)
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, policiesDirEnvVar)
+
+	// env vars for System Probe only
+	sysProbeEnableEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDSystemProbeEnabledEnvVar,
+		Value: "true",
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, sysProbeEnableEnvVar)
+
+	// For now don't expose the remote_tagger setting to user, since it is an implementation detail.
+	remoteTaggerEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDRuntimeSecurityConfigRemoteTagger,
+		Value: "true",
+	}
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.SystemProbeContainerName, remoteTaggerEnvVar)
+
+	return nil
+}
+
+// addInitVolumeContainer adds a command to the init-volume init container, creating it if needed
+func addInitVolumeContainer(podTemplate *corev1.PodTemplateSpec, command string) {
+	for id := range podTemplate.Spec.InitContainers {
+		container := &podTemplate.Spec.InitContainers[id]
+		if container.Name == string(apicommonv1.InitVolumeContainerName) {
+			if len(container.Args) == 0 {
+				container.Args = []string{command}
+			} else if !strings.Contains(container.Args[0], command) {
+				container.Args[0] = container.Args[0] + ";" + command
+			}
+			return
+		}
+	}
+
+	podTemplate.Spec.InitContainers = append(podTemplate.Spec.InitContainers, corev1.Container{
+		Name:    string(apicommonv1.InitVolumeContainerName),
+		Image:   defaulting.GetLatestAgentImage(),
+		Command: []string{"bash", "-c"},
+		Args:    []string{command},
+	})
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *cwsFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -0,0 +1,369 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package cws
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_cwsFeature_Configure(t *testing.T) {
+	ddav1CWSDisabled := v1alpha1.DatadogAgent{
+		Spec: v1alpha1.DatadogAgentSpec{
+			Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+				Security: &v1alpha1.SecuritySpec{
+					Runtime: v1alpha1.RuntimeSecuritySpec{
+						Enabled: apiutils.NewBoolPointer(false),
+					},
+				},
+			},
+		},
+	}
+
+	ddav1CWSEnabled := ddav1CWSDisabled.DeepCopy()
+	{
+		ddav1CWSEnabled.Spec.Agent.Security.Runtime.Enabled = apiutils.NewBoolPointer(true)
+		ddav1CWSEnabled.Spec.Agent.Security.Runtime.PoliciesDir = &v1alpha1.ConfigDirSpec{
+			ConfigMapName: "custom_test",
+		}
+		ddav1CWSEnabled.Spec.Agent.Security.Runtime.SyscallMonitor = &v1alpha1.SyscallMonitorSpec{
+			Enabled: apiutils.NewBoolPointer(true),
+		}
+	}
+
+	ddav2CWSDisabled := v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				CWS: &v2alpha1.CWSFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+	ddav2CWSEnabled := ddav2CWSDisabled.DeepCopy()
+	{
+		ddav2CWSEnabled.Spec.Features.CWS.Enabled = apiutils.NewBoolPointer(true)
+		ddav2CWSEnabled.Spec.Features.CWS.CustomPolicies = &apicommonv1.ConfigMapConfig{
+			Name: "custom_test",
+		}
+		ddav2CWSEnabled.Spec.Features.CWS.SyscallMonitorEnabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2CWSDefault := ddav2CWSDisabled.DeepCopy()
+	{
+		ddav2CWSDefault.Spec.Features.CWS.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	wantEnvVarsFunc := func(syscallMonitor string) ([]*corev1.EnvVar, []*corev1.EnvVar) {
+		securityAgentWant := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDRuntimeSecurityConfigEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDRuntimeSecurityConfigSocket,
+				Value: "/var/run/sysprobe/runtime-security.sock",
+			},
+			{
+				Name:  apicommon.DDRuntimeSecurityConfigSyscallMonitor,
+				Value: syscallMonitor,
+			},
+			{
+				Name:  apicommon.DDRuntimeSecurityConfigPoliciesDir,
+				Value: cwsConfigVolumePath,
+			},
+		}
+		sysProbeWant := append([]*corev1.EnvVar{}, securityAgentWant...)
+		sysProbeWant = append(sysProbeWant,
+			&corev1.EnvVar{
+				Name:  apicommon.DDSystemProbeEnabledEnvVar,
+				Value: "true",
+			},
+			&corev1.EnvVar{
+				Name:  apicommon.DDRuntimeSecurityConfigRemoteTagger,
+				Value: "true",
+			},
+		)
+		return securityAgentWant, sysProbeWant
+	}
+
+	sysProbeWantVolumeMounts := []corev1.VolumeMount{
+		{
+			Name:      apicommon.DebugfsVolumeName,
+			MountPath: apicommon.DebugfsPath,
+			ReadOnly:  false,
+		},
+		{
+			Name:      apicommon.TracefsVolumeName,
+			MountPath: apicommon.TracefsPath,
+			ReadOnly:  false,
+		},
+		{
+			Name:      apicommon.ModulesVolumeName,
+			MountPath: apicommon.ModulesVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      apicommon.SrcVolumeName,
+			MountPath: apicommon.SrcVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      apicommon.OSReleaseVolumeName,
+			MountPath: apicommon.OSReleaseMountPath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      apicommon.ProcdirVolumeName,
+			MountPath: apicommon.ProcdirMountPath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      apicommon.SystemProbeSocketVolumeName,
+			MountPath: apicommon.SystemProbeSocketVolumePath,
+			ReadOnly:  true,
+		},
+	}
+
+	sysProbeWantVolumes := []corev1.Volume{
+		{
+			Name: apicommon.DebugfsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.DebugfsPath,
+				},
+			},
+		},
+		{
+			Name: apicommon.TracefsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.TracefsPath,
+				},
+			},
+		},
+		{
+			Name: apicommon.ModulesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.ModulesVolumePath,
+				},
+			},
+		},
+		{
+			Name: apicommon.SrcVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.SrcVolumePath,
+				},
+			},
+		},
+		{
+			Name: apicommon.OSReleaseVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.OSReleaseHostPath,
+				},
+			},
+		},
+		{
+			Name: apicommon.ProcdirVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: apicommon.ProcdirHostPath,
+				},
+			},
+		},
+		{
+			Name: apicommon.SystemProbeSocketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+
+	policiesVolumeMount := corev1.VolumeMount{
+		Name:      cwsConfigVolumeName,
+		MountPath: cwsConfigVolumePath,
+		ReadOnly:  true,
+	}
+
+	policiesVolume := corev1.Volume{
+		Name: cwsConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+
+	customPoliciesVolume := corev1.Volume{
+		Name: cwsCustomPoliciesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "custom_test",
+				},
+			},
+		},
+	}
+
+	initVolumeWantVolumeMounts := []corev1.VolumeMount{
+		{
+			Name:      cwsConfigVolumeName,
+			MountPath: "/opt/datadog-agent/runtime-security.d",
+		},
+		{
+			Name:      cwsCustomPoliciesVolumeName,
+			MountPath: "/etc/datadog-agent-runtime-policies",
+			ReadOnly:  true,
+		},
+	}
+
+	cwsAgentNodeWantFunc := func(customPolicies bool, syscallMonitor string) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			// check annotations
+			wantAnnotations := map[string]string{
+				apicommon.SystemProbeAppArmorAnnotationKey: apicommon.SystemProbeAppArmorAnnotationValue,
+			}
+			annotations := mgr.AnnotationMgr.Annotations
+			assert.True(t, apiutils.IsEqualStruct(annotations, wantAnnotations), "Annotations \ndiff = %s", cmp.Diff(annotations, wantAnnotations))
+
+			// check security context capabilities
+			wantCapabilities := []corev1.Capability{
+				"SYS_ADMIN",
+				"SYS_RESOURCE",
+				"SYS_PTRACE",
+				"NET_ADMIN",
+				"NET_BROADCAST",
+				"NET_RAW",
+				"IPC_LOCK",
+				"CHOWN",
+			}
+			sysProbeCapabilities := mgr.SecurityContextMgr.CapabilitiesByC[apicommonv1.SystemProbeContainerName]
+			assert.True(t, apiutils.IsEqualStruct(sysProbeCapabilities, wantCapabilities), "System Probe security context capabilities \ndiff = %s", cmp.Diff(sysProbeCapabilities, wantCapabilities))
+
+			// check volume mounts
+			wantSysProbeMounts := sysProbeWantVolumeMounts
+			wantSecurityAgentMounts := []corev1.VolumeMount{
+				{
+					Name:      apicommon.SystemProbeSocketVolumeName,
+					MountPath: apicommon.SystemProbeSocketVolumePath,
+					ReadOnly:  true,
+				},
+			}
+			wantVolumes := sysProbeWantVolumes
+			var wantInitVolumeMounts []corev1.VolumeMount
+			var wantInitContainers []corev1.Container
+			if customPolicies {
+				wantSysProbeMounts = append([]corev1.VolumeMount{policiesVolumeMount}, wantSysProbeMounts...)
+				wantSecurityAgentMounts = append([]corev1.VolumeMount{policiesVolumeMount}, wantSecurityAgentMounts...)
+				wantVolumes = append([]corev1.Volume{policiesVolume, customPoliciesVolume}, wantVolumes...)
+				wantInitVolumeMounts = initVolumeWantVolumeMounts
+				wantInitContainers = []corev1.Container{
+					{
+						Name:    "init-volume",
+						Image:   defaulting.GetLatestAgentImage(),
+						Command: []string{"bash", "-c"},
+						Args:    []string{"cp -v /etc/datadog-agent/runtime-security.d/* /opt/datadog-agent/runtime-security.d/;cp -v /etc/datadog-agent-runtime-policies/* /opt/datadog-agent/runtime-security.d/"},
+					},
+				}
+			}
+
+			// check the init container copying the custom policies next to the bundled ones
+			initContainers := mgr.Tpl.Spec.InitContainers
+			assert.True(t, apiutils.IsEqualStruct(initContainers, wantInitContainers), "Init containers \ndiff = %s", cmp.Diff(initContainers, wantInitContainers))
+
+			initVolumeMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.InitVolumeContainerName]
+			assert.True(t, apiutils.IsEqualStruct(initVolumeMounts, wantInitVolumeMounts), "Init volume mounts \ndiff = %s", cmp.Diff(initVolumeMounts, wantInitVolumeMounts))
+
+			sysProbeMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.SystemProbeContainerName]
+			assert.True(t, apiutils.IsEqualStruct(sysProbeMounts, wantSysProbeMounts), "System Probe volume mounts \ndiff = %s", cmp.Diff(sysProbeMounts, wantSysProbeMounts))
+
+			securityAgentMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.SecurityAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(securityAgentMounts, wantSecurityAgentMounts), "Security Agent volume mounts \ndiff = %s", cmp.Diff(securityAgentMounts, wantSecurityAgentMounts))
+
+			// check volumes
+			volumes := mgr.VolumeMgr.Volumes
+			assert.True(t, apiutils.IsEqualStruct(volumes, wantVolumes), "Volumes \ndiff = %s", cmp.Diff(volumes, wantVolumes))
+
+			// check env vars
+			securityAgentWantEnvVars, sysProbeWantEnvVars := wantEnvVarsFunc(syscallMonitor)
+
+			securityAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.SecurityAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(securityAgentEnvVars, securityAgentWantEnvVars), "Security Agent envvars \ndiff = %s", cmp.Diff(securityAgentEnvVars, securityAgentWantEnvVars))
+
+			sysProbeEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.SystemProbeContainerName]
+			assert.True(t, apiutils.IsEqualStruct(sysProbeEnvVars, sysProbeWantEnvVars), "System Probe envvars \ndiff = %s", cmp.Diff(sysProbeEnvVars, sysProbeWantEnvVars))
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v1alpha1 CWS not enabled",
+			DDAv1:         ddav1CWSDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v1alpha1 CWS enabled",
+			DDAv1:         ddav1CWSEnabled,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   cwsAgentNodeWantFunc(true, "true"),
+			},
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 CWS not enabled",
+			DDAv2:         ddav2CWSDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v2alpha1 CWS enabled",
+			DDAv2:         ddav2CWSEnabled,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   cwsAgentNodeWantFunc(true, "true"),
+			},
+		},
+		{
+			Name:          "v2alpha1 CWS enabled without custom policies",
+			DDAv2:         ddav2CWSDefault,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   cwsAgentNodeWantFunc(false, "false"),
+			},
+		},
+	}
+
+	tests.Run(t, buildCWSFeature)
+}
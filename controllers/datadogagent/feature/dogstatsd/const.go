@@ -0,0 +1,15 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dogstatsd
+
+const (
+	defaultDogstatsdSocketHostFilepath = "/var/run/datadog/dsd.socket"
+
+	dogstatsdPortName = "dogstatsdport"
+
+	// defaultMapperProfilesConfigMapKey is the ConfigMap key used when no item is specified in the mapper profiles ConfigMap
+	defaultMapperProfilesConfigMapKey = "mapper-profiles"
+)
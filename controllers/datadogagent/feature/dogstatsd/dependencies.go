@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dogstatsd
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+)
+
+// manageLocalService adds the dogstatsd port to the local service of the agent.
+func (f *dogstatsdFeature) manageLocalService(store dependencies.StoreClient) error {
+	dsdPort := corev1.ServicePort{
+		Protocol:   corev1.ProtocolUDP,
+		TargetPort: intstr.FromInt(apicommon.DefaultDogstatsdPort),
+		Port:       apicommon.DefaultDogstatsdPort,
+		Name:       apicommon.DefaultDogstatsdPortName,
+	}
+
+	return component.AddPortToLocalAgentService(store, f.owner, f.localServiceName, dsdPort)
+}
@@ -0,0 +1,234 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dogstatsd
+
+import (
+	"path/filepath"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/utils"
+	"github.com/go-logr/logr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
+)
+
+func init() {
+	err := feature.Register(feature.DogstatsdIDType, buildDogstatsdFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildDogstatsdFeature(options *feature.Options) feature.Feature {
+	dogstatsdFeat := &dogstatsdFeature{}
+
+	if options != nil {
+		dogstatsdFeat.kubernetesVersion = options.KubernetesVersion
+		dogstatsdFeat.logger = options.Logger
+	}
+
+	return dogstatsdFeat
+}
+
+type dogstatsdFeature struct {
+	originDetectionEnabled bool
+
+	hostPortEnabled bool
+	hostPort        int32
+
+	udsEnabled      bool
+	udsHostFilepath string
+
+	mapperProfiles *v2alpha1.CustomConfig
+
+	owner metav1.Object
+
+	localServiceName        string
+	forceEnableLocalService bool
+	kubernetesVersion       string
+
+	logger logr.Logger
+}
+
+// ID returns the ID of the Feature
+func (f *dogstatsdFeature) ID() feature.IDType {
+	return feature.DogstatsdIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+// Dogstatsd is always running in the core agent, a nil configuration means the default one.
+func (f *dogstatsdFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	f.hostPort = apicommon.DefaultDogstatsdPort
+	f.udsHostFilepath = defaultDogstatsdSocketHostFilepath
+
+	if dsd := dda.Spec.Features.Dogstatsd; dsd != nil {
+		f.originDetectionEnabled = apiutils.BoolValue(dsd.OriginDetectionEnabled)
+		if dsd.HostPortConfig != nil {
+			f.hostPortEnabled = apiutils.BoolValue(dsd.HostPortConfig.Enabled)
+			if dsd.HostPortConfig.Port != nil {
+				f.hostPort = *dsd.HostPortConfig.Port
+			}
+		}
+		if dsd.UnixDomainSocketConfig != nil {
+			f.udsEnabled = apiutils.BoolValue(dsd.UnixDomainSocketConfig.Enabled)
+			if dsd.UnixDomainSocketConfig.Path != nil {
+				f.udsHostFilepath = *dsd.UnixDomainSocketConfig.Path
+			}
+		}
+		f.mapperProfiles = dsd.MapperProfiles
+	}
+
+	f.localServiceName = component.GetLocalAgentServiceName(dda)
+	if dda.Spec.Global != nil && dda.Spec.Global.LocalService != nil {
+		f.forceEnableLocalService = apiutils.BoolValue(dda.Spec.Global.LocalService.ForceEnableLocalService)
+	}
+
+	return feature.RequiredComponents{
+		Agent: feature.RequiredComponent{
+			IsRequired: apiutils.NewBoolPointer(true),
+			Containers: []apicommonv1.AgentContainerName{
+				apicommonv1.CoreAgentContainerName,
+			},
+		},
+	}
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// Dogstatsd is still configured by the v1alpha1 reconcile logic (agent container port,
+// socket volume and agent local service), so the feature stays disabled to avoid managing
+// the same resources twice.
+func (f *dogstatsdFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *dogstatsdFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	// When the host port is enabled, the applications reach dogstatsd through the host IP.
+	if f.hostPortEnabled {
+		return nil
+	}
+
+	// Service Internal Traffic Policy exists in Kube 1.21 but it is enabled by default since 1.22
+	if utils.IsAboveMinVersion(f.kubernetesVersion, "1.22-0") || f.forceEnableLocalService {
+		return f.manageLocalService(managers.Store())
+	}
+
+	return nil
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *dogstatsdFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *dogstatsdFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	// dogstatsd receives traffic from the other pods, through the host port or the local service
+	managers.EnvVar().AddEnvVarToContainer(apicommonv1.CoreAgentContainerName, &corev1.EnvVar{
+		Name:  apicommon.DDDogstatsdNonLocalTraffic,
+		Value: "true",
+	})
+
+	// host port
+	if f.hostPortEnabled {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.CoreAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDDogstatsdPort,
+			Value: strconv.Itoa(int(f.hostPort)),
+		})
+		component.AddHostPortToContainer(managers.PodTemplateSpec(), apicommonv1.CoreAgentContainerName, corev1.ContainerPort{
+			Name:          dogstatsdPortName,
+			ContainerPort: f.hostPort,
+			HostPort:      f.hostPort,
+			Protocol:      corev1.ProtocolUDP,
+		})
+	}
+
+	// uds
+	if f.udsEnabled {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.CoreAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDDogstatsdSocket,
+			Value: filepath.Join(apicommon.DogstatsdSocketVolumePath, filepath.Base(f.udsHostFilepath)),
+		})
+
+		socketVol, socketVolMount := volume.GetVolumes(apicommon.DogstatsdSocketVolumeName, filepath.Dir(f.udsHostFilepath), apicommon.DogstatsdSocketVolumePath, false)
+		volumeType := corev1.HostPathDirectoryOrCreate
+		socketVol.HostPath.Type = &volumeType
+		managers.Volume().AddVolumeToContainer(&socketVol, &socketVolMount, apicommonv1.CoreAgentContainerName)
+	}
+
+	// origin detection
+	if f.originDetectionEnabled {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.CoreAgentContainerName, &corev1.EnvVar{
+			Name:  apicommon.DDDogstatsdOriginDetection,
+			Value: "true",
+		})
+		// the agent needs to see the client processes to resolve their container
+		managers.PodTemplateSpec().Spec.HostPID = true
+	}
+
+	// mapper profiles
+	if envVar := f.getMapperProfilesEnvVar(); envVar != nil {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.CoreAgentContainerName, envVar)
+	}
+
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *dogstatsdFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+func (f *dogstatsdFeature) getMapperProfilesEnvVar() *corev1.EnvVar {
+	if f.mapperProfiles == nil {
+		return nil
+	}
+
+	if f.mapperProfiles.ConfigData != nil {
+		if f.mapperProfiles.ConfigMap != nil {
+			f.logger.Info("configData and configMap cannot be set simultaneously for dogstatsd mapper profiles, ignoring the config map")
+		}
+		jsonValue := apiutils.YAMLToJSONString(*f.mapperProfiles.ConfigData)
+		if jsonValue == "" {
+			f.logger.Info("Invalid dogstatsd mapper profiles config, ignoring it")
+			return nil
+		}
+		return &corev1.EnvVar{
+			Name:  apicommon.DDDogstatsdMapperProfiles,
+			Value: jsonValue,
+		}
+	}
+
+	if f.mapperProfiles.ConfigMap != nil {
+		cmSelector := corev1.ConfigMapKeySelector{}
+		cmSelector.Name = f.mapperProfiles.ConfigMap.Name
+		cmSelector.Key = defaultMapperProfilesConfigMapKey
+		if len(f.mapperProfiles.ConfigMap.Items) > 0 {
+			cmSelector.Key = f.mapperProfiles.ConfigMap.Items[0].Key
+		}
+		return &corev1.EnvVar{
+			Name:      apicommon.DDDogstatsdMapperProfiles,
+			ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &cmSelector},
+		}
+	}
+
+	return nil
+}
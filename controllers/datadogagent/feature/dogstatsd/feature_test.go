@@ -0,0 +1,396 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package dogstatsd
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func createCoreAgentFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	mgr.Tpl.Spec.Containers = []corev1.Container{
+		{
+			Name: string(apicommonv1.CoreAgentContainerName),
+		},
+	}
+	return mgr
+}
+
+func Test_dogstatsdFeature_Configure(t *testing.T) {
+	ddav1Dogstatsd := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+				Config: &v1alpha1.NodeAgentConfig{
+					Dogstatsd: &v1alpha1.DogstatsdConfig{
+						DogstatsdOriginDetection: apiutils.NewBoolPointer(true),
+					},
+				},
+			},
+		},
+	}
+
+	ddav2Default := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{},
+		},
+	}
+
+	ddav2UDS := ddav2Default.DeepCopy()
+	{
+		ddav2UDS.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{
+			UnixDomainSocketConfig: &v2alpha1.UnixDomainSocketConfig{
+				Enabled: apiutils.NewBoolPointer(true),
+				Path:    apiutils.NewStringPointer("/var/run/datadog/dsd.socket"),
+			},
+		}
+	}
+
+	ddav2HostPort := ddav2Default.DeepCopy()
+	{
+		ddav2HostPort.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{
+			HostPortConfig: &v2alpha1.HostPortConfig{
+				Enabled: apiutils.NewBoolPointer(true),
+				Port:    apiutils.NewInt32Pointer(8130),
+			},
+		}
+	}
+
+	ddav2OriginDetection := ddav2Default.DeepCopy()
+	{
+		ddav2OriginDetection.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{
+			OriginDetectionEnabled: apiutils.NewBoolPointer(true),
+		}
+	}
+
+	ddav2MapperProfilesData := ddav2Default.DeepCopy()
+	{
+		ddav2MapperProfilesData.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{
+			MapperProfiles: &v2alpha1.CustomConfig{
+				ConfigData: apiutils.NewStringPointer("- name: foo\n  prefix: \"foo.\"\n"),
+			},
+		}
+	}
+
+	ddav2MapperProfilesConfigMap := ddav2Default.DeepCopy()
+	{
+		ddav2MapperProfilesConfigMap.Spec.Features.Dogstatsd = &v2alpha1.DogstatsdFeatureConfig{
+			MapperProfiles: &v2alpha1.CustomConfig{
+				ConfigMap: &apicommonv1.ConfigMapConfig{
+					Name: "mapper-profiles-cm",
+					Items: []corev1.KeyToPath{
+						{
+							Key:  "profiles.json",
+							Path: "profiles.json",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	wantNonLocalTrafficEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDDogstatsdNonLocalTraffic,
+		Value: "true",
+	}
+
+	defaultAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{wantNonLocalTrafficEnvVar}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+
+		assert.Empty(t, mgr.VolumeMgr.Volumes, "Volumes should be empty")
+		assert.False(t, mgr.Tpl.Spec.HostPID, "HostPID should not be enabled")
+	}
+
+	udsAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		volumeType := corev1.HostPathDirectoryOrCreate
+		wantVolumes := []corev1.Volume{
+			{
+				Name: apicommon.DogstatsdSocketVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: "/var/run/datadog",
+						Type: &volumeType,
+					},
+				},
+			},
+		}
+		volumes := mgr.VolumeMgr.Volumes
+		assert.True(t, apiutils.IsEqualStruct(volumes, wantVolumes), "Volumes \ndiff = %s", cmp.Diff(volumes, wantVolumes))
+
+		wantVolumeMounts := []corev1.VolumeMount{
+			{
+				Name:      apicommon.DogstatsdSocketVolumeName,
+				MountPath: apicommon.DogstatsdSocketVolumePath,
+				ReadOnly:  false,
+			},
+		}
+		coreAgentMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentMounts, wantVolumeMounts), "Core Agent volume mounts \ndiff = %s", cmp.Diff(coreAgentMounts, wantVolumeMounts))
+
+		wantEnvVars := []*corev1.EnvVar{
+			wantNonLocalTrafficEnvVar,
+			{
+				Name:  apicommon.DDDogstatsdSocket,
+				Value: "/var/run/datadog/statsd/dsd.socket",
+			},
+		}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+	}
+
+	hostPortAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{
+			wantNonLocalTrafficEnvVar,
+			{
+				Name:  apicommon.DDDogstatsdPort,
+				Value: "8130",
+			},
+		}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+
+		wantPorts := []corev1.ContainerPort{
+			{
+				Name:          dogstatsdPortName,
+				ContainerPort: 8130,
+				HostPort:      8130,
+				Protocol:      corev1.ProtocolUDP,
+			},
+		}
+		if assert.Len(t, mgr.Tpl.Spec.Containers, 1) {
+			ports := mgr.Tpl.Spec.Containers[0].Ports
+			assert.True(t, apiutils.IsEqualStruct(ports, wantPorts), "Core Agent ports \ndiff = %s", cmp.Diff(ports, wantPorts))
+		}
+	}
+
+	originDetectionAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{
+			wantNonLocalTrafficEnvVar,
+			{
+				Name:  apicommon.DDDogstatsdOriginDetection,
+				Value: "true",
+			},
+		}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+
+		assert.True(t, mgr.Tpl.Spec.HostPID, "HostPID should be enabled")
+	}
+
+	mapperProfilesDataAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{
+			wantNonLocalTrafficEnvVar,
+			{
+				Name:  apicommon.DDDogstatsdMapperProfiles,
+				Value: `[{"name":"foo","prefix":"foo."}]`,
+			},
+		}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+	}
+
+	mapperProfilesConfigMapAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		wantEnvVars := []*corev1.EnvVar{
+			wantNonLocalTrafficEnvVar,
+			{
+				Name: apicommon.DDDogstatsdMapperProfiles,
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "mapper-profiles-cm",
+						},
+						Key: "profiles.json",
+					},
+				},
+			},
+		}
+		coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, wantEnvVars), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, wantEnvVars))
+	}
+
+	wantLocalServiceFunc := func(t testing.TB, store dependencies.StoreClient) {
+		obj, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+		if !assert.True(t, found, "local service should be in the store") {
+			return
+		}
+		service := obj.(*corev1.Service)
+		assert.Equal(t, corev1.ServiceInternalTrafficPolicyLocal, *service.Spec.InternalTrafficPolicy)
+		wantSelector := map[string]string{
+			apicommon.AgentDeploymentNameLabelKey:      "foo",
+			apicommon.AgentDeploymentComponentLabelKey: apicommon.DefaultAgentResourceSuffix,
+		}
+		assert.Equal(t, wantSelector, service.Spec.Selector)
+		if assert.Len(t, service.Spec.Ports, 1) {
+			assert.Equal(t, apicommon.DefaultDogstatsdPortName, service.Spec.Ports[0].Name)
+			assert.Equal(t, corev1.ProtocolUDP, service.Spec.Ports[0].Protocol)
+			assert.Equal(t, int32(apicommon.DefaultDogstatsdPort), service.Spec.Ports[0].Port)
+		}
+	}
+
+	wantNoLocalServiceFunc := func(t testing.TB, store dependencies.StoreClient) {
+		_, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+		assert.False(t, found, "local service should not be in the store")
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 dogstatsd",
+			DDAv1:         ddav1Dogstatsd.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:                 "v2alpha1 dogstatsd default config",
+			DDAv2:                ddav2Default.DeepCopy(),
+			Options:              &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantLocalServiceFunc,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   defaultAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:                 "v2alpha1 dogstatsd default config, local service not supported",
+			DDAv2:                ddav2Default.DeepCopy(),
+			Options:              &test.Options{KubernetesVersion: "v1.21.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantNoLocalServiceFunc,
+		},
+		{
+			Name:                 "v2alpha1 dogstatsd unix domain socket enabled",
+			DDAv2:                ddav2UDS,
+			Options:              &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantLocalServiceFunc,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   udsAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:                 "v2alpha1 dogstatsd host port enabled",
+			DDAv2:                ddav2HostPort,
+			Options:              &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure:        true,
+			WantDependenciesFunc: wantNoLocalServiceFunc,
+			Agent: &test.ComponentTest{
+				CreateFunc: createCoreAgentFakeManager,
+				WantFunc:   hostPortAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 dogstatsd origin detection enabled",
+			DDAv2:         ddav2OriginDetection,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   originDetectionAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 dogstatsd mapper profiles from config data",
+			DDAv2:         ddav2MapperProfilesData,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   mapperProfilesDataAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 dogstatsd mapper profiles from a config map",
+			DDAv2:         ddav2MapperProfilesConfigMap,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   mapperProfilesConfigMapAgentNodeWantFunc,
+			},
+		},
+		{
+			Name:          "v2alpha1 dogstatsd local service shared with another feature",
+			DDAv2:         ddav2Default.DeepCopy(),
+			Options:       &test.Options{KubernetesVersion: "v1.22.0"},
+			WantConfigure: true,
+			StoreInitFunc: func(store dependencies.StoreClient) {
+				store.AddOrUpdate(kubernetes.ServicesKind, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-agent",
+						Namespace: "bar",
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{
+							{
+								Name:     apicommon.DefaultApmPortName,
+								Protocol: corev1.ProtocolTCP,
+								Port:     apicommon.DefaultApmPort,
+							},
+						},
+					},
+				})
+			},
+			WantDependenciesFunc: func(t testing.TB, store dependencies.StoreClient) {
+				obj, found := store.Get(kubernetes.ServicesKind, "bar", "foo-agent")
+				if !assert.True(t, found, "local service should be in the store") {
+					return
+				}
+				ports := obj.(*corev1.Service).Spec.Ports
+				if assert.Len(t, ports, 2) {
+					assert.Equal(t, apicommon.DefaultApmPortName, ports[0].Name)
+					assert.Equal(t, apicommon.DefaultDogstatsdPortName, ports[1].Name)
+				}
+			},
+		},
+	}
+
+	tests.Run(t, buildDogstatsdFeature)
+}
@@ -29,6 +29,11 @@ const (
 
 type dummyFeature struct{}
 
+// ID returns the ID of the Feature
+func (f *dummyFeature) ID() feature.IDType {
+	return feature.DummyIDType
+}
+
 func (f *dummyFeature) Configure(dda *v2alpha1.DatadogAgent) feature.RequiredComponents {
 	return feature.RequiredComponents{}
 }
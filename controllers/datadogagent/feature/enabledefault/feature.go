@@ -6,6 +6,9 @@
 package enabledefault
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
@@ -22,9 +25,19 @@ func buildDefaultFeature(options *feature.Options) feature.Feature {
 	return &defaultFeature{}
 }
 
-type defaultFeature struct{}
+type defaultFeature struct {
+	clusterAgentTokenSecretName string
+}
+
+// ID returns the ID of the Feature
+func (f *defaultFeature) ID() feature.IDType {
+	return feature.DefaultIDType
+}
 
 func (f *defaultFeature) Configure(dda *v2alpha1.DatadogAgent) feature.RequiredComponents {
+	// The token secret itself is managed by the reconciler, as it needs to read the previously generated token
+	f.clusterAgentTokenSecretName = v2alpha1.GetClusterAgentTokenSecretName(dda)
+
 	trueValue := true
 	return feature.RequiredComponents{
 		ClusterAgent: feature.RequiredComponent{
@@ -57,15 +70,40 @@ func (f *defaultFeature) ManageDependencies(managers feature.ResourceManagers, c
 // ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *defaultFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	f.addClusterAgentTokenEnvVar(managers)
 	return nil
 }
 
 // ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
-func (f *defaultFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error { return nil }
+func (f *defaultFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	f.addClusterAgentTokenEnvVar(managers)
+	return nil
+}
 
 // ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunnerAgent's corev1.PodTemplateSpec
 // It should do nothing if the feature doesn't need to configure it.
 func (f *defaultFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	f.addClusterAgentTokenEnvVar(managers)
 	return nil
 }
+
+// addClusterAgentTokenEnvVar configures the token used to communicate with the Cluster Agent.
+// It does nothing when the feature has only been configured from a v1alpha1.DatadogAgent.
+func (f *defaultFeature) addClusterAgentTokenEnvVar(managers feature.PodTemplateManagers) {
+	if f.clusterAgentTokenSecretName == "" {
+		return
+	}
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name: apicommon.DDClusterAgentAuthToken,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: f.clusterAgentTokenSecretName,
+				},
+				Key: apicommon.DefaultTokenKey,
+			},
+		},
+	})
+}
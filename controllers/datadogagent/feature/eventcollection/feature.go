@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventcollection
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	common "github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func init() {
+	err := feature.Register(feature.EventCollectionIDType, buildEventCollectionFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildEventCollectionFeature(options *feature.Options) feature.Feature {
+	eventCollectionFeat := &eventCollectionFeature{}
+
+	return eventCollectionFeat
+}
+
+type eventCollectionFeature struct {
+	serviceAccountName string
+
+	owner metav1.Object
+}
+
+// ID returns the ID of the Feature
+func (f *eventCollectionFeature) ID() feature.IDType {
+	return feature.EventCollectionIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *eventCollectionFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+
+	if dda.Spec.Features.EventCollection != nil && apiutils.BoolValue(dda.Spec.Features.EventCollection.CollectKubernetesEvents) {
+		f.serviceAccountName = v2alpha1.GetClusterAgentServiceAccount(dda)
+
+		reqComp = feature.RequiredComponents{
+			ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+		}
+	}
+
+	return reqComp
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// Event collection is still configured by the v1alpha1 reconcile logic (Cluster Agent
+// env vars and RBAC), so the feature stays disabled to avoid managing the same resources twice.
+func (f *eventCollectionFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *eventCollectionFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	rbacName := GetEventCollectionRBACResourceName(f.owner, common.ClusterAgentSuffix)
+
+	// Leader election and event token ConfigMaps
+	if err := managers.RBACManager().AddPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACPolicyRules(f.owner)); err != nil {
+		return err
+	}
+
+	// Kubernetes events
+	return managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACClusterPolicyRules())
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *eventCollectionFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDCollectKubernetesEvents,
+		Value: "true",
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDLeaderElection,
+		Value: "true",
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDLeaderLeaseName,
+		Value: utils.GetDatadogLeaderElectionResourceName(f.owner),
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDClusterAgentTokenName,
+		Value: utils.GetDatadogTokenResourceName(f.owner),
+	})
+
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *eventCollectionFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *eventCollectionFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
@@ -0,0 +1,159 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventcollection
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_eventCollectionFeature_Configure(t *testing.T) {
+	ddav1EventCollection := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			ClusterAgent: v1alpha1.DatadogAgentSpecClusterAgentSpec{
+				Config: &v1alpha1.ClusterAgentConfig{
+					CollectEvents: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	ddav2EventCollectionDisabled := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				EventCollection: &v2alpha1.EventCollectionFeatureConfig{
+					CollectKubernetesEvents: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2EventCollectionEnabled := ddav2EventCollectionDisabled.DeepCopy()
+	{
+		ddav2EventCollectionEnabled.Spec.Features.EventCollection.CollectKubernetesEvents = apiutils.NewBoolPointer(true)
+	}
+
+	eventCollectionClusterAgentWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+		dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+
+		want := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDCollectKubernetesEvents,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDLeaderElection,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDLeaderLeaseName,
+				Value: "foo-leader-election",
+			},
+			{
+				Name:  apicommon.DDClusterAgentTokenName,
+				Value: "footoken",
+			},
+		}
+		assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
+	}
+
+	eventCollectionWantDependenciesFunc := func(t testing.TB, store dependencies.StoreClient) {
+		rbacName := "bar-foo-event-dca"
+
+		obj, found := store.Get(kubernetes.RolesKind, "bar", rbacName)
+		if assert.True(t, found, "Role %s should be in the store", rbacName) {
+			role := obj.(*rbacv1.Role)
+			if assert.Len(t, role.Rules, 2) {
+				assert.Contains(t, role.Rules[0].ResourceNames, "foo-leader-election")
+				assert.Contains(t, role.Rules[0].ResourceNames, "footoken")
+				assert.Equal(t, []string{rbac.CreateVerb}, role.Rules[1].Verbs)
+			}
+		}
+
+		obj, found = store.Get(kubernetes.RoleBindingKind, "bar", rbacName)
+		if assert.True(t, found, "RoleBinding %s should be in the store", rbacName) {
+			roleBinding := obj.(*rbacv1.RoleBinding)
+			assert.Equal(t, rbac.RoleKind, roleBinding.RoleRef.Kind)
+			if assert.Len(t, roleBinding.Subjects, 1) {
+				assert.Equal(t, "foo-cluster-agent", roleBinding.Subjects[0].Name)
+				assert.Equal(t, "bar", roleBinding.Subjects[0].Namespace)
+			}
+		}
+
+		obj, found = store.Get(kubernetes.ClusterRolesKind, "", rbacName)
+		if assert.True(t, found, "ClusterRole %s should be in the store", rbacName) {
+			clusterRole := obj.(*rbacv1.ClusterRole)
+			if assert.Len(t, clusterRole.Rules, 1) {
+				assert.Equal(t, []string{rbac.EventsResource}, clusterRole.Rules[0].Resources)
+			}
+		}
+
+		_, found = store.Get(kubernetes.ClusterRoleBindingKind, "", rbacName)
+		assert.True(t, found, "ClusterRoleBinding %s should be in the store", rbacName)
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 event collection enabled",
+			DDAv1:         ddav1EventCollection.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 event collection not enabled",
+			DDAv2:         ddav2EventCollectionDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:                 "v2alpha1 event collection enabled",
+			DDAv2:                ddav2EventCollectionEnabled,
+			WantConfigure:        true,
+			WantDependenciesFunc: eventCollectionWantDependenciesFunc,
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   eventCollectionClusterAgentWantFunc,
+			},
+		},
+	}
+
+	tests.Run(t, buildEventCollectionFeature)
+}
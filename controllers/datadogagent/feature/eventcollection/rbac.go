@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package eventcollection
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+)
+
+// getRBACPolicyRules generates the namespaced rules needed by the Cluster Agent to elect a leader
+// and to store the event collection token, both are stored in ConfigMaps.
+func getRBACPolicyRules(owner metav1.Object) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		// Leader election and event token
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{rbac.ConfigMapsResource},
+			ResourceNames: []string{
+				common.DatadogLeaderElectionOldResourceName, // Kept for backward compatibility with agent <7.37.0
+				utils.GetDatadogLeaderElectionResourceName(owner),
+				common.DatadogTokenOldResourceName, // Kept for backward compatibility with agent <7.37.0
+				utils.GetDatadogTokenResourceName(owner),
+			},
+			Verbs: []string{rbac.GetVerb, rbac.UpdateVerb},
+		},
+		// The ConfigMaps are created by the Cluster Agent when missing
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{rbac.ConfigMapsResource},
+			Verbs:     []string{rbac.CreateVerb},
+		},
+	}
+}
+
+// getRBACClusterPolicyRules generates the cluster role required to watch the Kubernetes events.
+func getRBACClusterPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{rbac.EventsResource},
+			Verbs:     []string{rbac.GetVerb, rbac.ListVerb, rbac.WatchVerb},
+		},
+	}
+}
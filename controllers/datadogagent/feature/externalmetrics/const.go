@@ -0,0 +1,53 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package externalmetrics
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+)
+
+const (
+	externalMetricsRBACPrefix = "external-metrics"
+
+	authDelegatorRBACSuffix = "auth-delegator"
+	metricsReaderRBACSuffix = "reader"
+
+	authDelegatorClusterRoleName = "system:auth-delegator"
+	hpaServiceAccountName        = "horizontal-pod-autoscaler"
+
+	externalMetricsAPIGroup   = "external.metrics.k8s.io"
+	externalMetricsAPIVersion = "v1beta1"
+
+	metricsProviderPortName = "metricsapi"
+)
+
+// GetExternalMetricsRBACResourceName return the RBAC resources name
+func GetExternalMetricsRBACResourceName(owner metav1.Object, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s-%s", owner.GetNamespace(), owner.GetName(), externalMetricsRBACPrefix, suffix)
+}
+
+func getMetricsServerServiceName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", owner.GetName(), apicommon.DefaultMetricsServerResourceSuffix)
+}
+
+func getMetricsServerAPIServiceName() string {
+	return fmt.Sprintf("%s.%s", externalMetricsAPIVersion, externalMetricsAPIGroup)
+}
+
+// getDefaultExternalMetricsSecretName return the name of the secret storing the
+// External Metrics Server endpoint credentials when they are provided in plain text.
+func getDefaultExternalMetricsSecretName(owner metav1.Object) string {
+	return fmt.Sprintf("%s-%s", owner.GetName(), "metrics-server")
+}
+
+// getDefaultCredentialsSecretName return the name of the secret storing the global credentials
+func getDefaultCredentialsSecretName(owner metav1.Object) string {
+	return owner.GetName()
+}
@@ -0,0 +1,69 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package externalmetrics
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+)
+
+// buildMetricsServerService returns the service targeting the External Metrics Server running in the Cluster Agent
+func (f *externalMetricsFeature) buildMetricsServerService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getMetricsServerServiceName(f.owner),
+			Namespace:   f.owner.GetNamespace(),
+			Labels:      object.GetDefaultLabels(f.owner, apicommon.DefaultClusterAgentResourceSuffix, component.GetAgentVersion(f.owner)),
+			Annotations: object.GetDefaultAnnotations(f.owner),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				apicommon.AgentDeploymentNameLabelKey:      f.owner.GetName(),
+				apicommon.AgentDeploymentComponentLabelKey: apicommon.DefaultClusterAgentResourceSuffix,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(int(f.port)),
+					Port:       apicommon.DefaultMetricsServerServicePort,
+				},
+			},
+			SessionAffinity: corev1.ServiceAffinityNone,
+		},
+	}
+}
+
+// buildMetricsServerAPIService returns the APIService registering the External Metrics Server
+// as the provider of the `external.metrics.k8s.io` API group.
+func (f *externalMetricsFeature) buildMetricsServerAPIService() *apiregistrationv1.APIService {
+	port := int32(apicommon.DefaultMetricsServerServicePort)
+	return &apiregistrationv1.APIService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getMetricsServerAPIServiceName(),
+			Labels:      object.GetDefaultLabels(f.owner, apicommon.DefaultClusterAgentResourceSuffix, component.GetAgentVersion(f.owner)),
+			Annotations: object.GetDefaultAnnotations(f.owner),
+		},
+		Spec: apiregistrationv1.APIServiceSpec{
+			Service: &apiregistrationv1.ServiceReference{
+				Name:      getMetricsServerServiceName(f.owner),
+				Namespace: f.owner.GetNamespace(),
+				Port:      &port,
+			},
+			Version:               externalMetricsAPIVersion,
+			InsecureSkipTLSVerify: true,
+			Group:                 externalMetricsAPIGroup,
+			GroupPriorityMinimum:  100,
+			VersionPriority:       100,
+		},
+	}
+}
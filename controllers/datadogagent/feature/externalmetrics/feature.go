@@ -0,0 +1,272 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package externalmetrics
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	common "github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func init() {
+	err := feature.Register(feature.ExternalMetricsIDType, buildExternalMetricsFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildExternalMetricsFeature(options *feature.Options) feature.Feature {
+	externalMetricsFeat := &externalMetricsFeature{}
+
+	return externalMetricsFeat
+}
+
+type externalMetricsFeature struct {
+	useDDM bool
+	useWPA bool
+	port   int32
+	url    string
+
+	// appKeySecret references the application key used by the Cluster Agent to query Datadog.
+	appKeySecret secretKeyRef
+	// endpoint credentials, only set when they are configured on the External Metrics Server endpoint.
+	endpointAPIKeySecret *secretKeyRef
+	endpointAPIKey       string
+	endpointAppKeySecret *secretKeyRef
+	endpointAppKey       string
+
+	serviceAccountName string
+
+	owner metav1.Object
+}
+
+// secretKeyRef references a key inside a Secret
+type secretKeyRef struct {
+	name string
+	key  string
+}
+
+// ID returns the ID of the Feature
+func (f *externalMetricsFeature) ID() feature.IDType {
+	return feature.ExternalMetricsIDType
+}
+
+// Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *externalMetricsFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	em := dda.Spec.Features.ExternalMetricsServer
+
+	if em != nil && apiutils.BoolValue(em.Enabled) {
+		f.useDDM = apiutils.BoolValue(em.UseDatadogMetrics)
+		f.useWPA = apiutils.BoolValue(em.WPAController)
+		f.port = apicommon.DefaultMetricsProviderPort
+		if em.Port != nil {
+			f.port = *em.Port
+		}
+
+		var globalCreds *v2alpha1.DatadogCredentials
+		if dda.Spec.Global != nil {
+			globalCreds = dda.Spec.Global.Credentials
+		}
+		f.appKeySecret = secretKeyRef{name: getDefaultCredentialsSecretName(dda), key: apicommon.DefaultAPPKeyKey}
+		if globalCreds != nil && globalCreds.AppSecret != nil {
+			f.appKeySecret = getSecretKeyRef(globalCreds.AppSecret, apicommon.DefaultAPPKeyKey)
+		}
+
+		if em.Endpoint != nil {
+			if em.Endpoint.URL != nil {
+				f.url = *em.Endpoint.URL
+			}
+			if creds := em.Endpoint.Credentials; creds != nil {
+				defaultSecretName := getDefaultExternalMetricsSecretName(dda)
+				if creds.APISecret != nil {
+					ref := getSecretKeyRef(creds.APISecret, apicommon.DefaultAPIKeyKey)
+					f.endpointAPIKeySecret = &ref
+				} else if creds.APIKey != nil && *creds.APIKey != "" {
+					f.endpointAPIKeySecret = &secretKeyRef{name: defaultSecretName, key: apicommon.DefaultAPIKeyKey}
+					f.endpointAPIKey = *creds.APIKey
+				}
+				if creds.AppSecret != nil {
+					ref := getSecretKeyRef(creds.AppSecret, apicommon.DefaultAPPKeyKey)
+					f.endpointAppKeySecret = &ref
+				} else if creds.AppKey != nil && *creds.AppKey != "" {
+					f.endpointAppKeySecret = &secretKeyRef{name: defaultSecretName, key: apicommon.DefaultAPPKeyKey}
+					f.endpointAppKey = *creds.AppKey
+				}
+			}
+		}
+		f.serviceAccountName = v2alpha1.GetClusterAgentServiceAccount(dda)
+
+		reqComp = feature.RequiredComponents{
+			ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+		}
+	}
+
+	return reqComp
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// The External Metrics Server is still configured by the v1alpha1 reconcile logic (Cluster Agent
+// env vars, services, APIService and RBAC), so the feature stays disabled to avoid managing the same resources twice.
+func (f *externalMetricsFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *externalMetricsFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	// Metrics provider service and its registration in the API aggregation layer
+	managers.Store().AddOrUpdate(kubernetes.ServicesKind, f.buildMetricsServerService())
+	managers.Store().AddOrUpdate(kubernetes.APIServiceKind, f.buildMetricsServerAPIService())
+
+	// Endpoint credentials provided in plain text
+	if f.endpointAPIKey != "" {
+		if err := managers.SecretManager().AddSecret(f.owner.GetNamespace(), f.endpointAPIKeySecret.name, f.endpointAPIKeySecret.key, f.endpointAPIKey); err != nil {
+			return err
+		}
+	}
+	if f.endpointAppKey != "" {
+		if err := managers.SecretManager().AddSecret(f.owner.GetNamespace(), f.endpointAppKeySecret.name, f.endpointAppKeySecret.key, f.endpointAppKey); err != nil {
+			return err
+		}
+	}
+
+	// Cluster Agent RBAC
+	rbacName := GetExternalMetricsRBACResourceName(f.owner, common.ClusterAgentSuffix)
+	if err := managers.RBACManager().AddPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACPolicyRules()); err != nil {
+		return err
+	}
+	if err := managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACClusterPolicyRules(f.useDDM, f.useWPA)); err != nil {
+		return err
+	}
+
+	// Auth delegation to the Kubernetes API server
+	managers.Store().AddOrUpdate(kubernetes.ClusterRoleBindingKind, buildAuthDelegatorClusterRoleBinding(f.owner, f.serviceAccountName))
+
+	// External metrics read access for the HPA controller
+	readerName := GetExternalMetricsRBACResourceName(f.owner, metricsReaderRBACSuffix)
+	return managers.RBACManager().AddClusterPolicyRules(common.KubeSystemResourceName, readerName, hpaServiceAccountName, getMetricsReaderPolicyRules())
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *externalMetricsFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDExternalMetricsProviderEnabled,
+		Value: "true",
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDExternalMetricsProviderPort,
+		Value: strconv.Itoa(int(f.port)),
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:      apicommon.DDAppKey,
+		ValueFrom: buildEnvVarFromSecret(f.appKeySecret),
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDExternalMetricsProviderUseDatadogMetric,
+		Value: apiutils.BoolToString(&f.useDDM),
+	})
+
+	managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  apicommon.DDExternalMetricsProviderWPAController,
+		Value: apiutils.BoolToString(&f.useWPA),
+	})
+
+	if f.url != "" {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDExternalMetricsProviderEndpoint,
+			Value: f.url,
+		})
+	}
+
+	if f.endpointAPIKeySecret != nil {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:      apicommon.DDExternalMetricsProviderAPIKey,
+			ValueFrom: buildEnvVarFromSecret(*f.endpointAPIKeySecret),
+		})
+	}
+
+	if f.endpointAppKeySecret != nil {
+		managers.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:      apicommon.DDExternalMetricsProviderAppKey,
+			ValueFrom: buildEnvVarFromSecret(*f.endpointAppKeySecret),
+		})
+	}
+
+	addPortToContainer(managers.PodTemplateSpec(), apicommonv1.ClusterAgentContainerName, f.port)
+
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *externalMetricsFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunner's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *externalMetricsFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	return nil
+}
+
+func getSecretKeyRef(secret *apicommonv1.SecretConfig, defaultKey string) secretKeyRef {
+	ref := secretKeyRef{name: secret.SecretName, key: defaultKey}
+	if secret.KeyName != "" {
+		ref.key = secret.KeyName
+	}
+	return ref
+}
+
+func buildEnvVarFromSecret(ref secretKeyRef) *corev1.EnvVarSource {
+	return &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: ref.name,
+			},
+			Key: ref.key,
+		},
+	}
+}
+
+// addPortToContainer exposes the metrics provider port from the given container,
+// if the container is already present in the pod template.
+func addPortToContainer(tpl *corev1.PodTemplateSpec, containerName apicommonv1.AgentContainerName, port int32) {
+	for id := range tpl.Spec.Containers {
+		container := &tpl.Spec.Containers[id]
+		if container.Name != string(containerName) {
+			continue
+		}
+
+		metricsPort := corev1.ContainerPort{
+			Name:          metricsProviderPortName,
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		}
+		for portID := range container.Ports {
+			if container.Ports[portID].Name == metricsProviderPortName {
+				container.Ports[portID] = metricsPort
+				return
+			}
+		}
+		container.Ports = append(container.Ports, metricsPort)
+	}
+}
@@ -0,0 +1,302 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package externalmetrics
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+)
+
+func createClusterAgentFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	mgr.Tpl.Spec.Containers = []corev1.Container{
+		{
+			Name: string(apicommonv1.ClusterAgentContainerName),
+		},
+	}
+	return mgr
+}
+
+func Test_externalMetricsFeature_Configure(t *testing.T) {
+	ddav1ExternalMetrics := v1alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1alpha1.DatadogAgentSpec{
+			ClusterAgent: v1alpha1.DatadogAgentSpecClusterAgentSpec{
+				Config: &v1alpha1.ClusterAgentConfig{
+					ExternalMetrics: &v1alpha1.ExternalMetricsConfig{
+						Enabled: apiutils.NewBoolPointer(true),
+					},
+				},
+			},
+		},
+	}
+
+	ddav2ExternalMetricsDisabled := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				ExternalMetricsServer: &v2alpha1.ExternalMetricsServerFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2ExternalMetricsEnabled := ddav2ExternalMetricsDisabled.DeepCopy()
+	{
+		ddav2ExternalMetricsEnabled.Spec.Features.ExternalMetricsServer.Enabled = apiutils.NewBoolPointer(true)
+		ddav2ExternalMetricsEnabled.Spec.Features.ExternalMetricsServer.UseDatadogMetrics = apiutils.NewBoolPointer(true)
+		ddav2ExternalMetricsEnabled.Spec.Features.ExternalMetricsServer.Port = apiutils.NewInt32Pointer(8443)
+	}
+
+	ddav2ExternalMetricsEndpoint := ddav2ExternalMetricsEnabled.DeepCopy()
+	{
+		ddav2ExternalMetricsEndpoint.Spec.Features.ExternalMetricsServer.WPAController = apiutils.NewBoolPointer(true)
+		ddav2ExternalMetricsEndpoint.Spec.Features.ExternalMetricsServer.Endpoint = &v2alpha1.Endpoint{
+			URL: apiutils.NewStringPointer("https://app.datadoghq.eu"),
+			Credentials: &v2alpha1.DatadogCredentials{
+				APIKey: apiutils.NewStringPointer("0123456789abcdef0123456789abcdef"),
+				AppSecret: &apicommonv1.SecretConfig{
+					SecretName: "endpoint-secret",
+					KeyName:    "endpoint-app-key",
+				},
+			},
+		}
+		ddav2ExternalMetricsEndpoint.Spec.Global = &v2alpha1.GlobalConfig{
+			Credentials: &v2alpha1.DatadogCredentials{
+				AppSecret: &apicommonv1.SecretConfig{
+					SecretName: "global-secret",
+				},
+			},
+		}
+	}
+
+	secretEnvVarSource := func(name, key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}
+	}
+
+	externalMetricsClusterAgentWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+		dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+
+		want := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDExternalMetricsProviderEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderPort,
+				Value: "8443",
+			},
+			{
+				Name:      apicommon.DDAppKey,
+				ValueFrom: secretEnvVarSource("foo", apicommon.DefaultAPPKeyKey),
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderUseDatadogMetric,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderWPAController,
+				Value: "false",
+			},
+		}
+		assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
+
+		if assert.Len(t, mgr.Tpl.Spec.Containers, 1) {
+			wantPorts := []corev1.ContainerPort{
+				{
+					Name:          metricsProviderPortName,
+					ContainerPort: 8443,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			}
+			ports := mgr.Tpl.Spec.Containers[0].Ports
+			assert.True(t, apiutils.IsEqualStruct(ports, wantPorts), "DCA ports \ndiff = %s", cmp.Diff(ports, wantPorts))
+		}
+	}
+
+	externalMetricsEndpointClusterAgentWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+		dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+
+		want := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDExternalMetricsProviderEnabled,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderPort,
+				Value: "8443",
+			},
+			{
+				Name:      apicommon.DDAppKey,
+				ValueFrom: secretEnvVarSource("global-secret", apicommon.DefaultAPPKeyKey),
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderUseDatadogMetric,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderWPAController,
+				Value: "true",
+			},
+			{
+				Name:  apicommon.DDExternalMetricsProviderEndpoint,
+				Value: "https://app.datadoghq.eu",
+			},
+			{
+				Name:      apicommon.DDExternalMetricsProviderAPIKey,
+				ValueFrom: secretEnvVarSource("foo-metrics-server", apicommon.DefaultAPIKeyKey),
+			},
+			{
+				Name:      apicommon.DDExternalMetricsProviderAppKey,
+				ValueFrom: secretEnvVarSource("endpoint-secret", "endpoint-app-key"),
+			},
+		}
+		assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, want), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, want))
+	}
+
+	externalMetricsWantDependenciesFunc := func(wantWPA bool, wantSecret bool) func(testing.TB, dependencies.StoreClient) {
+		return func(t testing.TB, store dependencies.StoreClient) {
+			obj, found := store.Get(kubernetes.ServicesKind, "bar", "foo-cluster-agent-metrics-server")
+			if assert.True(t, found, "metrics server Service should be in the store") {
+				service := obj.(*corev1.Service)
+				if assert.Len(t, service.Spec.Ports, 1) {
+					assert.Equal(t, int32(apicommon.DefaultMetricsServerServicePort), service.Spec.Ports[0].Port)
+					assert.Equal(t, 8443, service.Spec.Ports[0].TargetPort.IntValue())
+				}
+			}
+
+			obj, found = store.Get(kubernetes.APIServiceKind, "", "v1beta1.external.metrics.k8s.io")
+			if assert.True(t, found, "APIService should be in the store") {
+				apiService := obj.(*apiregistrationv1.APIService)
+				if assert.NotNil(t, apiService.Spec.Service) {
+					assert.Equal(t, "foo-cluster-agent-metrics-server", apiService.Spec.Service.Name)
+					assert.Equal(t, "bar", apiService.Spec.Service.Namespace)
+				}
+				assert.Equal(t, "external.metrics.k8s.io", apiService.Spec.Group)
+			}
+
+			rbacName := "bar-foo-external-metrics-dca"
+			_, found = store.Get(kubernetes.RolesKind, "bar", rbacName)
+			assert.True(t, found, "Role %s should be in the store", rbacName)
+
+			obj, found = store.Get(kubernetes.ClusterRolesKind, "", rbacName)
+			if assert.True(t, found, "ClusterRole %s should be in the store", rbacName) {
+				clusterRole := obj.(*rbacv1.ClusterRole)
+				hasWPA := false
+				for _, rule := range clusterRole.Rules {
+					for _, resource := range rule.Resources {
+						if resource == rbac.WpaResource {
+							hasWPA = true
+						}
+					}
+				}
+				assert.Equal(t, wantWPA, hasWPA, "ClusterRole %s watermarkpodautoscalers rule", rbacName)
+			}
+
+			authDelegatorName := "bar-foo-external-metrics-auth-delegator"
+			obj, found = store.Get(kubernetes.ClusterRoleBindingKind, "", authDelegatorName)
+			if assert.True(t, found, "ClusterRoleBinding %s should be in the store", authDelegatorName) {
+				clusterRoleBinding := obj.(*rbacv1.ClusterRoleBinding)
+				assert.Equal(t, "system:auth-delegator", clusterRoleBinding.RoleRef.Name)
+				if assert.Len(t, clusterRoleBinding.Subjects, 1) {
+					assert.Equal(t, "foo-cluster-agent", clusterRoleBinding.Subjects[0].Name)
+					assert.Equal(t, "bar", clusterRoleBinding.Subjects[0].Namespace)
+				}
+			}
+
+			readerName := "bar-foo-external-metrics-reader"
+			obj, found = store.Get(kubernetes.ClusterRoleBindingKind, "", readerName)
+			if assert.True(t, found, "ClusterRoleBinding %s should be in the store", readerName) {
+				clusterRoleBinding := obj.(*rbacv1.ClusterRoleBinding)
+				if assert.Len(t, clusterRoleBinding.Subjects, 1) {
+					assert.Equal(t, "horizontal-pod-autoscaler", clusterRoleBinding.Subjects[0].Name)
+					assert.Equal(t, "kube-system", clusterRoleBinding.Subjects[0].Namespace)
+				}
+			}
+
+			obj, found = store.Get(kubernetes.SecretsKind, "bar", "foo-metrics-server")
+			if assert.Equal(t, wantSecret, found, "endpoint credentials Secret presence") && found {
+				secret := obj.(*corev1.Secret)
+				assert.Equal(t, "0123456789abcdef0123456789abcdef", string(secret.Data[apicommon.DefaultAPIKeyKey]))
+				assert.NotContains(t, secret.Data, apicommon.DefaultAPPKeyKey)
+			}
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			// still managed by the v1alpha1 reconcile logic
+			Name:          "v1alpha1 external metrics enabled",
+			DDAv1:         ddav1ExternalMetrics.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 external metrics not enabled",
+			DDAv2:         ddav2ExternalMetricsDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:                 "v2alpha1 external metrics enabled",
+			DDAv2:                ddav2ExternalMetricsEnabled,
+			WantConfigure:        true,
+			WantDependenciesFunc: externalMetricsWantDependenciesFunc(false, false),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createClusterAgentFakeManager,
+				WantFunc:   externalMetricsClusterAgentWantFunc,
+			},
+		},
+		{
+			Name:                 "v2alpha1 external metrics with custom endpoint",
+			DDAv2:                ddav2ExternalMetricsEndpoint,
+			WantConfigure:        true,
+			WantDependenciesFunc: externalMetricsWantDependenciesFunc(true, true),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createClusterAgentFakeManager,
+				WantFunc:   externalMetricsEndpointClusterAgentWantFunc,
+			},
+		},
+	}
+
+	tests.Run(t, buildExternalMetricsFeature)
+}
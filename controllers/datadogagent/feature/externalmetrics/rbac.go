@@ -0,0 +1,112 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package externalmetrics
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/component"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+)
+
+// getRBACPolicyRules generates the role required by the Cluster Agent to store the custom metrics
+func getRBACPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{rbac.CoreAPIGroup},
+			Resources:     []string{rbac.ConfigMapsResource},
+			ResourceNames: []string{common.DatadogCustomMetricsResourceName},
+			Verbs:         []string{rbac.GetVerb, rbac.UpdateVerb},
+		},
+	}
+}
+
+// getRBACClusterPolicyRules generates the cluster role required by the Cluster Agent to run the External Metrics Server
+func getRBACClusterPolicyRules(useDDM, useWPA bool) []rbacv1.PolicyRule {
+	rules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{rbac.CoreAPIGroup},
+			Resources:     []string{rbac.ConfigMapsResource},
+			ResourceNames: []string{common.ExtensionAPIServerAuthResourceName},
+			Verbs:         []string{rbac.GetVerb, rbac.ListVerb, rbac.WatchVerb},
+		},
+		{
+			APIGroups: []string{rbac.AuthorizationAPIGroup},
+			Resources: []string{rbac.SubjectAccessReviewResource},
+			Verbs:     []string{rbac.CreateVerb, rbac.GetVerb},
+		},
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{rbac.EventsResource},
+			Verbs:     []string{rbac.CreateVerb},
+		},
+	}
+
+	if useDDM {
+		rules = append(rules,
+			rbacv1.PolicyRule{
+				APIGroups: []string{rbac.DatadogAPIGroup},
+				Resources: []string{rbac.DatadogMetricsResource},
+				Verbs:     []string{rbac.ListVerb, rbac.WatchVerb, rbac.CreateVerb, rbac.DeleteVerb},
+			},
+			// Specific update rule for status subresource
+			rbacv1.PolicyRule{
+				APIGroups: []string{rbac.DatadogAPIGroup},
+				Resources: []string{rbac.DatadogMetricsStatusResource},
+				Verbs:     []string{rbac.UpdateVerb},
+			},
+		)
+	}
+
+	if useWPA {
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{rbac.DatadogAPIGroup},
+			Resources: []string{rbac.WpaResource},
+			Verbs:     []string{rbac.ListVerb, rbac.WatchVerb, rbac.GetVerb},
+		})
+	}
+
+	return rules
+}
+
+// getMetricsReaderPolicyRules generates the cluster role allowing the HPA controller to read external metrics
+func getMetricsReaderPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{externalMetricsAPIGroup},
+			Resources: []string{"*"},
+			Verbs:     []string{rbac.GetVerb, rbac.ListVerb, rbac.WatchVerb},
+		},
+	}
+}
+
+// buildAuthDelegatorClusterRoleBinding binds the Cluster Agent service account to the
+// `system:auth-delegator` ClusterRole, so the External Metrics Server can delegate
+// authentication and authorization decisions to the Kubernetes API server.
+func buildAuthDelegatorClusterRoleBinding(owner metav1.Object, serviceAccountName string) *rbacv1.ClusterRoleBinding {
+	name := GetExternalMetricsRBACResourceName(owner, authDelegatorRBACSuffix)
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: object.GetDefaultLabels(owner, name, component.GetAgentVersion(owner)),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbac.RbacAPIGroup,
+			Kind:     rbac.ClusterRoleKind,
+			Name:     authDelegatorClusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbac.ServiceAccountKind,
+				Name:      serviceAccountName,
+				Namespace: owner.GetNamespace(),
+			},
+		},
+	}
+}
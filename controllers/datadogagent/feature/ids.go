@@ -5,6 +5,8 @@
 
 package feature
 
+import "fmt"
+
 // IDType use to identify a Feature
 type IDType int
 
@@ -29,6 +31,55 @@ const (
 	PrometheusScrapeIDType
 	// TCPQueueLengthIDType TCP Queue length check feature
 	TCPQueueLengthIDType
+	// APMIDType APM feature
+	APMIDType
+	// CWSIDType CWS feature
+	CWSIDType
+	// DogstatsdIDType Dogstatsd feature
+	DogstatsdIDType
+	// EventCollectionIDType Event Collection feature
+	EventCollectionIDType
+	// AdmissionControllerIDType Admission Controller feature
+	AdmissionControllerIDType
+	// ExternalMetricsIDType External Metrics Server feature
+	ExternalMetricsIDType
+	// ClusterChecksIDType Cluster Checks feature
+	ClusterChecksIDType
+	// LiveProcessIDType Live Process feature
+	LiveProcessIDType
+	// LiveContainerIDType Live Container feature
+	LiveContainerIDType
 	// DummyIDType Dummy feature.
 	DummyIDType
 )
+
+var idNames = map[IDType]string{
+	DefaultIDType:              "default",
+	KubernetesStateCoreIDType:  "kubernetes_state_core",
+	OrchestratorExplorerIDType: "orchestrator_explorer",
+	LogCollectionIDType:        "log_collection",
+	NPMIDType:                  "npm",
+	CSPMIDType:                 "cspm",
+	USMIDType:                  "usm",
+	OOMKillIDType:              "oom_kill",
+	PrometheusScrapeIDType:     "prometheus_scrape",
+	TCPQueueLengthIDType:       "tcp_queue_length",
+	APMIDType:                  "apm",
+	CWSIDType:                  "cws",
+	DogstatsdIDType:            "dogstatsd",
+	EventCollectionIDType:      "event_collection",
+	AdmissionControllerIDType:  "admission_controller",
+	ExternalMetricsIDType:      "external_metrics",
+	ClusterChecksIDType:        "cluster_checks",
+	LiveProcessIDType:          "live_process",
+	LiveContainerIDType:        "live_container",
+	DummyIDType:                "dummy",
+}
+
+// String returns the name of the Feature, as reported in the DatadogAgent status.
+func (id IDType) String() string {
+	if name, found := idNames[id]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown_%d", int(id))
+}
@@ -0,0 +1,28 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDTypeString(t *testing.T) {
+	assert.Equal(t, "kubernetes_state_core", KubernetesStateCoreIDType.String())
+	assert.Equal(t, "unknown_1000", IDType(1000).String())
+
+	// every feature must have a unique name, as it is reported in the DatadogAgent status
+	names := map[string]IDType{}
+	for id := DefaultIDType; id <= DummyIDType; id++ {
+		name, found := idNames[id]
+		assert.True(t, found, "missing name for feature ID %d", id)
+		if previous, duplicated := names[name]; duplicated {
+			t.Errorf("feature IDs %d and %d have the same name %q", previous, id, name)
+		}
+		names[name] = id
+	}
+}
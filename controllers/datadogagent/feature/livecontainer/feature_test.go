@@ -0,0 +1,127 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package livecontainer
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_liveContainerFeature_Configure(t *testing.T) {
+	ddav1ProcessAgentDisabled := v1alpha1.DatadogAgent{
+		Spec: v1alpha1.DatadogAgentSpec{
+			Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+				Process: &v1alpha1.ProcessSpec{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav1ProcessAgentEnabled := ddav1ProcessAgentDisabled.DeepCopy()
+	{
+		ddav1ProcessAgentEnabled.Spec.Agent.Process.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2LiveContainerDisabled := v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				LiveContainerCollection: &v2alpha1.LiveContainerCollectionFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2LiveContainerEnabled := ddav2LiveContainerDisabled.DeepCopy()
+	{
+		ddav2LiveContainerEnabled.Spec.Features.LiveContainerCollection.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	liveContainerAgentNodeWantFunc := func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+		mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+		// check volume mounts
+		wantVolumeMounts := []*corev1.VolumeMount{
+			{
+				Name:      apicommon.CgroupsVolumeName,
+				MountPath: apicommon.CgroupsMountPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      apicommon.ProcdirVolumeName,
+				MountPath: apicommon.ProcdirMountPath,
+				ReadOnly:  true,
+			},
+		}
+		processAgentMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.ProcessAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(processAgentMounts, wantVolumeMounts), "Process Agent volume mounts \ndiff = %s", cmp.Diff(processAgentMounts, wantVolumeMounts))
+
+		// check env vars
+		wantEnvVars := []*corev1.EnvVar{
+			{
+				Name:  apicommon.DDProcessConfigContainerCollectionEnabled,
+				Value: "true",
+			},
+		}
+		processAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.ProcessAgentContainerName]
+		assert.True(t, apiutils.IsEqualStruct(processAgentEnvVars, wantEnvVars), "Process Agent envvars \ndiff = %s", cmp.Diff(processAgentEnvVars, wantEnvVars))
+
+		// the process collection is left to the live process feature
+		assert.Empty(t, mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName])
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v1alpha1 process agent not enabled",
+			DDAv1:         ddav1ProcessAgentDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v1alpha1 process agent enabled",
+			DDAv1:         ddav1ProcessAgentEnabled,
+			WantConfigure: true,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 live container collection not enabled",
+			DDAv2:         ddav2LiveContainerDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v2alpha1 live container collection enabled",
+			DDAv2:         ddav2LiveContainerEnabled,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   liveContainerAgentNodeWantFunc,
+			},
+		},
+	}
+
+	tests.Run(t, buildLiveContainerFeature)
+}
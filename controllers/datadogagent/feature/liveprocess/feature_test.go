@@ -0,0 +1,207 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package liveprocess
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_liveProcessFeature_Configure(t *testing.T) {
+	ddav1LiveProcessDisabled := v1alpha1.DatadogAgent{
+		Spec: v1alpha1.DatadogAgentSpec{
+			Agent: v1alpha1.DatadogAgentSpecAgentSpec{
+				Process: &v1alpha1.ProcessSpec{
+					Enabled:                  apiutils.NewBoolPointer(true),
+					ProcessCollectionEnabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav1LiveProcessEnabled := ddav1LiveProcessDisabled.DeepCopy()
+	{
+		ddav1LiveProcessEnabled.Spec.Agent.Process.ProcessCollectionEnabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2LiveProcessDisabled := v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2LiveProcessEnabled := ddav2LiveProcessDisabled.DeepCopy()
+	{
+		ddav2LiveProcessEnabled.Spec.Features.LiveProcessCollection.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2LiveProcessScrubbing := ddav2LiveProcessEnabled.DeepCopy()
+	{
+		ddav2LiveProcessScrubbing.Spec.Features.LiveProcessCollection.ScrubProcessArguments = apiutils.NewBoolPointer(false)
+		ddav2LiveProcessScrubbing.Spec.Features.LiveProcessCollection.StripProcessArguments = apiutils.NewBoolPointer(true)
+	}
+
+	liveProcessAgentNodeWantFunc := func(extraEnvVars ...*corev1.EnvVar) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			assert.True(t, mgr.Tpl.Spec.HostPID, "Host PID should be enabled")
+
+			// check volume mounts
+			wantVolumeMounts := []*corev1.VolumeMount{
+				{
+					Name:      apicommon.PasswdVolumeName,
+					MountPath: apicommon.PasswdMountPath,
+					ReadOnly:  true,
+				},
+				{
+					Name:      apicommon.CgroupsVolumeName,
+					MountPath: apicommon.CgroupsMountPath,
+					ReadOnly:  true,
+				},
+				{
+					Name:      apicommon.ProcdirVolumeName,
+					MountPath: apicommon.ProcdirMountPath,
+					ReadOnly:  true,
+				},
+			}
+			processAgentMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.ProcessAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(processAgentMounts, wantVolumeMounts), "Process Agent volume mounts \ndiff = %s", cmp.Diff(processAgentMounts, wantVolumeMounts))
+
+			// check volumes
+			wantVolumes := []*corev1.Volume{
+				{
+					Name: apicommon.PasswdVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: apicommon.PasswdHostPath,
+						},
+					},
+				},
+				{
+					Name: apicommon.CgroupsVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: apicommon.CgroupsHostPath,
+						},
+					},
+				},
+				{
+					Name: apicommon.ProcdirVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: apicommon.ProcdirHostPath,
+						},
+					},
+				},
+			}
+			volumes := mgr.VolumeMgr.Volumes
+			assert.True(t, apiutils.IsEqualStruct(volumes, wantVolumes), "Volumes \ndiff = %s", cmp.Diff(volumes, wantVolumes))
+
+			// check env vars
+			enableEnvVar := &corev1.EnvVar{
+				Name:  apicommon.DDProcessAgentEnabledEnvVar,
+				Value: "true",
+			}
+			coreAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.CoreAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(coreAgentEnvVars, []*corev1.EnvVar{enableEnvVar}), "Core Agent envvars \ndiff = %s", cmp.Diff(coreAgentEnvVars, []*corev1.EnvVar{enableEnvVar}))
+
+			wantProcessEnvVars := append([]*corev1.EnvVar{enableEnvVar}, extraEnvVars...)
+			processAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.ProcessAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(processAgentEnvVars, wantProcessEnvVars), "Process Agent envvars \ndiff = %s", cmp.Diff(processAgentEnvVars, wantProcessEnvVars))
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v1alpha1 live process collection not enabled",
+			DDAv1:         ddav1LiveProcessDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v1alpha1 live process collection enabled",
+			DDAv1:         ddav1LiveProcessEnabled,
+			WantConfigure: true,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 live process collection not enabled",
+			DDAv2:         ddav2LiveProcessDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:          "v2alpha1 live process collection enabled",
+			DDAv2:         ddav2LiveProcessEnabled,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   liveProcessAgentNodeWantFunc(),
+			},
+		},
+		{
+			Name:          "v2alpha1 live process collection enabled with scrubbing settings",
+			DDAv2:         ddav2LiveProcessScrubbing,
+			WantConfigure: true,
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc: liveProcessAgentNodeWantFunc(
+					&corev1.EnvVar{
+						Name:  apicommon.DDProcessConfigScrubArgs,
+						Value: "false",
+					},
+					&corev1.EnvVar{
+						Name:  apicommon.DDProcessConfigStripArgs,
+						Value: "true",
+					},
+				),
+			},
+		},
+	}
+
+	tests.Run(t, buildLiveProcessFeature)
+}
+
+func Test_liveProcessFeature_RequiredComponents(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				LiveProcessCollection: &v2alpha1.LiveProcessCollectionFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	reqComp := buildLiveProcessFeature(&feature.Options{}).Configure(dda)
+	assert.Equal(t, []apicommonv1.AgentContainerName{apicommonv1.CoreAgentContainerName, apicommonv1.ProcessAgentContainerName}, reqComp.Agent.Containers)
+	assert.False(t, reqComp.ClusterAgent.IsEnabled())
+	assert.False(t, reqComp.ClusterChecksRunner.IsEnabled())
+}
@@ -0,0 +1,251 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package orchestratorexplorer
+
+import (
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func init() {
+	err := feature.Register(feature.OrchestratorExplorerIDType, buildOrchestratorExplorerFeature)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func buildOrchestratorExplorerFeature(options *feature.Options) feature.Feature {
+	orchestratorExplorerFeat := &orchestratorExplorerFeature{
+		rbacSuffix: common.ClusterAgentSuffix,
+	}
+
+	if options != nil {
+		orchestratorExplorerFeat.logger = options.Logger
+	}
+
+	return orchestratorExplorerFeat
+}
+
+type orchestratorExplorerFeature struct {
+	clusterChecksEnabled bool
+
+	rbacSuffix                     string
+	serviceAccountName             string
+	clusterAgentServiceAccountName string
+
+	owner               metav1.Object
+	customConfig        *apicommonv1.CustomConfig
+	configConfigMapName string
+
+	scrubContainers     bool
+	extraTags           []string
+	ddURL               string
+	additionalEndpoints string
+
+	logger logr.Logger
+}
+
+// ID returns the ID of the Feature
+func (f *orchestratorExplorerFeature) ID() feature.IDType {
+	return feature.OrchestratorExplorerIDType
+}
+
+// Configure use to configure the feature from a v2alpha1.DatadogAgent instance.
+func (f *orchestratorExplorerFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	f.owner = dda
+	orchestratorExplorer := dda.Spec.Features.OrchestratorExplorer
+
+	if orchestratorExplorer != nil && apiutils.BoolValue(orchestratorExplorer.Enabled) {
+		reqComp = feature.RequiredComponents{
+			ClusterAgent: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+			Agent: feature.RequiredComponent{
+				IsRequired: apiutils.NewBoolPointer(true),
+				Containers: []apicommonv1.AgentContainerName{
+					apicommonv1.CoreAgentContainerName,
+					apicommonv1.ProcessAgentContainerName,
+				},
+			},
+		}
+
+		if orchestratorExplorer.Conf != nil {
+			f.customConfig = v2alpha1.ConvertCustomConfig(orchestratorExplorer.Conf)
+		}
+		f.configConfigMapName = apicommonv1.GetConfName(dda, f.customConfig, apicommon.DefaultOrchestratorExplorerConf)
+
+		// Scrubbing is enabled unless explicitly disabled
+		f.scrubContainers = orchestratorExplorer.ScrubContainers == nil || *orchestratorExplorer.ScrubContainers
+		f.extraTags = orchestratorExplorer.ExtraTags
+		f.configureEndpoint(orchestratorExplorer.Endpoint)
+
+		f.clusterAgentServiceAccountName = v2alpha1.GetClusterAgentServiceAccount(dda)
+		f.serviceAccountName = f.clusterAgentServiceAccountName
+
+		// The check only runs in the Cluster Checks Runners when they are deployed,
+		// otherwise it is run by the Cluster Agent itself.
+		if dda.Spec.Features.ClusterChecks != nil && apiutils.BoolValue(dda.Spec.Features.ClusterChecks.Enabled) && apiutils.BoolValue(dda.Spec.Features.ClusterChecks.UseClusterChecksRunners) {
+			f.clusterChecksEnabled = true
+			f.rbacSuffix = common.ChecksRunnerSuffix
+			f.serviceAccountName = v2alpha1.GetClusterChecksRunnerServiceAccount(dda)
+			reqComp.ClusterChecksRunner.IsRequired = apiutils.NewBoolPointer(true)
+		}
+	}
+
+	return reqComp
+}
+
+// configureEndpoint configures the Datadog endpoint of the Orchestrator Explorer.
+// The Cluster Agent can only override the URL of the main endpoint, so an endpoint
+// with its own API key is configured as an additional endpoint.
+func (f *orchestratorExplorerFeature) configureEndpoint(endpoint *v2alpha1.Endpoint) {
+	if endpoint == nil || endpoint.URL == nil || *endpoint.URL == "" {
+		return
+	}
+
+	creds := endpoint.Credentials
+	if creds == nil || (creds.APIKey == nil && creds.APISecret == nil) {
+		f.ddURL = *endpoint.URL
+		return
+	}
+
+	if creds.APIKey == nil || *creds.APIKey == "" {
+		if f.logger != nil {
+			f.logger.Info("The Orchestrator Explorer endpoint credentials can only be set with an API key, ignoring the endpoint")
+		}
+		return
+	}
+
+	// Marshalling a map[string][]string can't fail
+	endpoints, _ := json.Marshal(map[string][]string{*endpoint.URL: {*creds.APIKey}})
+	f.additionalEndpoints = string(endpoints)
+}
+
+// ConfigureV1 use to configure the feature from a v1alpha1.DatadogAgent instance.
+// The Orchestrator Explorer is still configured by the v1alpha1 reconcile logic (check ConfigMap,
+// env vars and RBAC), so the feature stays disabled to avoid managing the same resources twice.
+func (f *orchestratorExplorerFeature) ConfigureV1(dda *v1alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
+	return reqComp
+}
+
+// ManageDependencies allows a feature to manage its dependencies.
+// Feature's dependencies should be added in the store.
+func (f *orchestratorExplorerFeature) ManageDependencies(managers feature.ResourceManagers, components feature.RequiredComponents) error {
+	// Manage the Check Configuration in a configmap
+	configCM, err := f.buildOrchestratorExplorerConfigMap()
+	if err != nil {
+		return err
+	}
+	if configCM != nil {
+		managers.Store().AddOrUpdate(kubernetes.ConfigMapKind, configCM)
+	}
+
+	// The Cluster Agent generates the cluster ID, even if the check runs in the Cluster Checks Runners
+	if f.clusterChecksEnabled {
+		clusterAgentRBACName := GetOrchestratorExplorerRBACResourceName(f.owner, common.ClusterAgentSuffix)
+		if err := managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), clusterAgentRBACName, f.clusterAgentServiceAccountName, getClusterIDPolicyRules()); err != nil {
+			return err
+		}
+	}
+
+	// Manage RBAC permission of the component running the check
+	rbacName := GetOrchestratorExplorerRBACResourceName(f.owner, f.rbacSuffix)
+
+	return managers.RBACManager().AddClusterPolicyRules(f.owner.GetNamespace(), rbacName, f.serviceAccountName, getRBACPolicyRules())
+}
+
+// ManageClusterAgent allows a feature to configure the ClusterAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *orchestratorExplorerFeature) ManageClusterAgent(managers feature.PodTemplateManagers) error {
+	// Manage the check config in configmap
+	vol, volMount := volume.GetCustomConfigSpecVolumes(
+		f.customConfig,
+		apicommon.OrchestratorExplorerVolumeName,
+		f.configConfigMapName,
+		orchestratorExplorerCheckFolderName,
+	)
+
+	managers.Volume().AddVolumeToContainer(&vol, &volMount, apicommonv1.ClusterAgentContainerName)
+
+	for _, env := range f.getEnvVars() {
+		managers.EnvVar().AddEnvVar(env)
+	}
+
+	return nil
+}
+
+// ManageNodeAgent allows a feature to configure the Node Agent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *orchestratorExplorerFeature) ManageNodeAgent(managers feature.PodTemplateManagers) error {
+	for _, env := range f.getEnvVars() {
+		managers.EnvVar().AddEnvVarToContainer(apicommonv1.ProcessAgentContainerName, env)
+	}
+
+	return nil
+}
+
+// ManageClusterChecksRunner allows a feature to configure the ClusterChecksRunnerAgent's corev1.PodTemplateSpec
+// It should do nothing if the feature doesn't need to configure it.
+func (f *orchestratorExplorerFeature) ManageClusterChecksRunner(managers feature.PodTemplateManagers) error {
+	if !f.clusterChecksEnabled {
+		return nil
+	}
+
+	for _, env := range f.getEnvVars() {
+		managers.EnvVar().AddEnvVar(env)
+	}
+
+	return nil
+}
+
+func (f *orchestratorExplorerFeature) getEnvVars() []*corev1.EnvVar {
+	envVars := []*corev1.EnvVar{
+		{
+			Name:  apicommon.DDOrchestratorExplorerEnabled,
+			Value: "true",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerContainerScrubbing,
+			Value: apiutils.BoolToString(&f.scrubContainers),
+		},
+	}
+
+	if f.ddURL != "" {
+		envVars = append(envVars, &corev1.EnvVar{
+			Name:  apicommon.DDOrchestratorExplorerDDUrl,
+			Value: f.ddURL,
+		})
+	}
+
+	if f.additionalEndpoints != "" {
+		envVars = append(envVars, &corev1.EnvVar{
+			Name:  apicommon.DDOrchestratorExplorerAdditionalEndpoints,
+			Value: f.additionalEndpoints,
+		})
+	}
+
+	if len(f.extraTags) > 0 {
+		// Marshalling a []string can't fail
+		tags, _ := json.Marshal(f.extraTags)
+		envVars = append(envVars, &corev1.EnvVar{
+			Name:  apicommon.DDOrchestratorExplorerExtraTags,
+			Value: string(tags),
+		})
+	}
+
+	return envVars
+}
@@ -0,0 +1,314 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package orchestratorexplorer
+
+import (
+	"testing"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/fake"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature/test"
+	mergerfake "github.com/DataDog/datadog-operator/controllers/datadogagent/merger/fake"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createEmptyFakeManager(t testing.TB) feature.PodTemplateManagers {
+	mgr := fake.NewPodTemplateManagers(t)
+	return mgr
+}
+
+func Test_orchestratorExplorerFeature_Configure(t *testing.T) {
+	ddav1OrchestratorExplorerEnabled := v1alpha1.DatadogAgent{
+		Spec: v1alpha1.DatadogAgentSpec{
+			Features: v1alpha1.DatadogFeatures{
+				OrchestratorExplorer: &v1alpha1.OrchestratorExplorerConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	ddav2OrchestratorExplorerDisabled := v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				OrchestratorExplorer: &v2alpha1.OrchestratorExplorerFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(false),
+				},
+			},
+		},
+	}
+
+	ddav2OrchestratorExplorerEnabled := ddav2OrchestratorExplorerDisabled.DeepCopy()
+	{
+		ddav2OrchestratorExplorerEnabled.Spec.Features.OrchestratorExplorer.Enabled = apiutils.NewBoolPointer(true)
+	}
+
+	ddav2OrchestratorExplorerSettings := ddav2OrchestratorExplorerEnabled.DeepCopy()
+	{
+		ddav2OrchestratorExplorerSettings.Spec.Features.OrchestratorExplorer.ScrubContainers = apiutils.NewBoolPointer(false)
+		ddav2OrchestratorExplorerSettings.Spec.Features.OrchestratorExplorer.ExtraTags = []string{"a:b", "c:d"}
+		ddav2OrchestratorExplorerSettings.Spec.Features.OrchestratorExplorer.Endpoint = &v2alpha1.Endpoint{
+			URL: apiutils.NewStringPointer("https://orchestrator.datadoghq.eu"),
+		}
+	}
+
+	ddav2OrchestratorExplorerAdditionalEndpoint := ddav2OrchestratorExplorerEnabled.DeepCopy()
+	{
+		ddav2OrchestratorExplorerAdditionalEndpoint.Spec.Features.OrchestratorExplorer.Endpoint = &v2alpha1.Endpoint{
+			URL: apiutils.NewStringPointer("https://orchestrator.datadoghq.eu"),
+			Credentials: &v2alpha1.DatadogCredentials{
+				APIKey: apiutils.NewStringPointer("0123456789abcdef0123456789abcdef"),
+			},
+		}
+	}
+
+	ddav2OrchestratorExplorerClusterChecks := ddav2OrchestratorExplorerEnabled.DeepCopy()
+	{
+		ddav2OrchestratorExplorerClusterChecks.Spec.Features.ClusterChecks = &v2alpha1.ClusterChecksFeatureConfig{
+			Enabled:                 apiutils.NewBoolPointer(true),
+			UseClusterChecksRunners: apiutils.NewBoolPointer(true),
+		}
+	}
+
+	defaultEnvVars := []*corev1.EnvVar{
+		{
+			Name:  apicommon.DDOrchestratorExplorerEnabled,
+			Value: "true",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerContainerScrubbing,
+			Value: "true",
+		},
+	}
+
+	settingsEnvVars := []*corev1.EnvVar{
+		{
+			Name:  apicommon.DDOrchestratorExplorerEnabled,
+			Value: "true",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerContainerScrubbing,
+			Value: "false",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerDDUrl,
+			Value: "https://orchestrator.datadoghq.eu",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerExtraTags,
+			Value: `["a:b","c:d"]`,
+		},
+	}
+
+	additionalEndpointEnvVars := []*corev1.EnvVar{
+		{
+			Name:  apicommon.DDOrchestratorExplorerEnabled,
+			Value: "true",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerContainerScrubbing,
+			Value: "true",
+		},
+		{
+			Name:  apicommon.DDOrchestratorExplorerAdditionalEndpoints,
+			Value: `{"https://orchestrator.datadoghq.eu":["0123456789abcdef0123456789abcdef"]}`,
+		},
+	}
+
+	orchestratorExplorerClusterAgentWantFunc := func(wantEnvVars []*corev1.EnvVar) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			dcaEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+			assert.True(t, apiutils.IsEqualStruct(dcaEnvVars, wantEnvVars), "DCA envvars \ndiff = %s", cmp.Diff(dcaEnvVars, wantEnvVars))
+
+			wantVolumeMounts := []*corev1.VolumeMount{
+				{
+					Name:      apicommon.OrchestratorExplorerVolumeName,
+					MountPath: "/etc/datadog-agent/conf.d/orchestrator.d",
+					ReadOnly:  true,
+				},
+			}
+			dcaVolumeMounts := mgr.VolumeMgr.VolumeMountByC[apicommonv1.ClusterAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(dcaVolumeMounts, wantVolumeMounts), "DCA volume mounts \ndiff = %s", cmp.Diff(dcaVolumeMounts, wantVolumeMounts))
+
+			wantVolumes := []*corev1.Volume{
+				{
+					Name: apicommon.OrchestratorExplorerVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "foo-orchestrator-explorer-config",
+							},
+						},
+					},
+				},
+			}
+			dcaVolumes := mgr.VolumeMgr.Volumes
+			assert.True(t, apiutils.IsEqualStruct(dcaVolumes, wantVolumes), "DCA volumes \ndiff = %s", cmp.Diff(dcaVolumes, wantVolumes))
+		}
+	}
+
+	orchestratorExplorerAgentNodeWantFunc := func(wantEnvVars []*corev1.EnvVar) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			processAgentEnvVars := mgr.EnvVarMgr.EnvVarsByC[apicommonv1.ProcessAgentContainerName]
+			assert.True(t, apiutils.IsEqualStruct(processAgentEnvVars, wantEnvVars), "Process Agent envvars \ndiff = %s", cmp.Diff(processAgentEnvVars, wantEnvVars))
+		}
+	}
+
+	orchestratorExplorerClusterChecksRunnerWantFunc := func(wantEnvVars []*corev1.EnvVar) func(testing.TB, feature.PodTemplateManagers) {
+		return func(t testing.TB, mgrInterface feature.PodTemplateManagers) {
+			mgr := mgrInterface.(*fake.PodTemplateManagers)
+
+			ccrEnvVars := mgr.EnvVarMgr.EnvVarsByC[mergerfake.AllContainers]
+			assert.True(t, apiutils.IsEqualStruct(ccrEnvVars, wantEnvVars), "CCR envvars \ndiff = %s", cmp.Diff(ccrEnvVars, wantEnvVars))
+		}
+	}
+
+	orchestratorExplorerWantDependenciesFunc := func(wantClusterCheck bool) func(testing.TB, dependencies.StoreClient) {
+		return func(t testing.TB, store dependencies.StoreClient) {
+			obj, found := store.Get(kubernetes.ConfigMapKind, "bar", "foo-orchestrator-explorer-config")
+			if assert.True(t, found, "check ConfigMap should be in the store") {
+				configMap := obj.(*corev1.ConfigMap)
+				assert.Equal(t, orchestratorExplorerCheckConfig(wantClusterCheck), configMap.Data[orchestratorExplorerCheckName])
+			}
+
+			checkRunnerRBACName, checkRunnerSAName := "bar-foo-orch-exp-dca", "foo-cluster-agent"
+			if wantClusterCheck {
+				checkRunnerRBACName, checkRunnerSAName = "bar-foo-orch-exp-ccr", "foo-cluster-checks-runner"
+			}
+
+			obj, found = store.Get(kubernetes.ClusterRolesKind, "", checkRunnerRBACName)
+			if assert.True(t, found, "ClusterRole %s should be in the store", checkRunnerRBACName) {
+				clusterRole := obj.(*rbacv1.ClusterRole)
+				assert.Equal(t, getRBACPolicyRules(), clusterRole.Rules)
+			}
+
+			obj, found = store.Get(kubernetes.ClusterRoleBindingKind, "", checkRunnerRBACName)
+			if assert.True(t, found, "ClusterRoleBinding %s should be in the store", checkRunnerRBACName) {
+				clusterRoleBinding := obj.(*rbacv1.ClusterRoleBinding)
+				if assert.Len(t, clusterRoleBinding.Subjects, 1) {
+					assert.Equal(t, checkRunnerSAName, clusterRoleBinding.Subjects[0].Name)
+					assert.Equal(t, "bar", clusterRoleBinding.Subjects[0].Namespace)
+				}
+			}
+
+			if wantClusterCheck {
+				obj, found = store.Get(kubernetes.ClusterRolesKind, "", "bar-foo-orch-exp-dca")
+				if assert.True(t, found, "Cluster Agent ClusterRole should be in the store") {
+					clusterRole := obj.(*rbacv1.ClusterRole)
+					assert.Equal(t, getClusterIDPolicyRules(), clusterRole.Rules)
+				}
+			}
+		}
+	}
+
+	tests := test.FeatureTestSuite{
+		///////////////////////////
+		// v1alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v1alpha1 orchestrator explorer is managed by the v1alpha1 reconcile",
+			DDAv1:         ddav1OrchestratorExplorerEnabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		///////////////////////////
+		// v2alpha1.DatadogAgent //
+		///////////////////////////
+		{
+			Name:          "v2alpha1 orchestrator explorer not enabled",
+			DDAv2:         ddav2OrchestratorExplorerDisabled.DeepCopy(),
+			WantConfigure: false,
+		},
+		{
+			Name:                 "v2alpha1 orchestrator explorer enabled",
+			DDAv2:                ddav2OrchestratorExplorerEnabled,
+			WantConfigure:        true,
+			WantDependenciesFunc: orchestratorExplorerWantDependenciesFunc(false),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerClusterAgentWantFunc(defaultEnvVars),
+			},
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerAgentNodeWantFunc(defaultEnvVars),
+			},
+		},
+		{
+			Name:                 "v2alpha1 orchestrator explorer with scrubbing, tags and endpoint",
+			DDAv2:                ddav2OrchestratorExplorerSettings,
+			WantConfigure:        true,
+			WantDependenciesFunc: orchestratorExplorerWantDependenciesFunc(false),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerClusterAgentWantFunc(settingsEnvVars),
+			},
+			Agent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerAgentNodeWantFunc(settingsEnvVars),
+			},
+		},
+		{
+			Name:          "v2alpha1 orchestrator explorer with an additional endpoint",
+			DDAv2:         ddav2OrchestratorExplorerAdditionalEndpoint,
+			WantConfigure: true,
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerClusterAgentWantFunc(additionalEndpointEnvVars),
+			},
+		},
+		{
+			Name:                 "v2alpha1 orchestrator explorer in the cluster checks runners",
+			DDAv2:                ddav2OrchestratorExplorerClusterChecks,
+			WantConfigure:        true,
+			WantDependenciesFunc: orchestratorExplorerWantDependenciesFunc(true),
+			ClusterAgent: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerClusterAgentWantFunc(defaultEnvVars),
+			},
+			ClusterChecksRunner: &test.ComponentTest{
+				CreateFunc: createEmptyFakeManager,
+				WantFunc:   orchestratorExplorerClusterChecksRunnerWantFunc(defaultEnvVars),
+			},
+		},
+	}
+
+	tests.Run(t, buildOrchestratorExplorerFeature)
+}
+
+func Test_orchestratorExplorerFeature_RequiredComponents(t *testing.T) {
+	dda := &v2alpha1.DatadogAgent{
+		Spec: v2alpha1.DatadogAgentSpec{
+			Features: &v2alpha1.DatadogFeatures{
+				OrchestratorExplorer: &v2alpha1.OrchestratorExplorerFeatureConfig{
+					Enabled: apiutils.NewBoolPointer(true),
+				},
+			},
+		},
+	}
+
+	reqComp := buildOrchestratorExplorerFeature(&feature.Options{}).Configure(dda)
+	assert.True(t, reqComp.ClusterAgent.IsEnabled())
+	assert.Equal(t, []apicommonv1.AgentContainerName{apicommonv1.CoreAgentContainerName, apicommonv1.ProcessAgentContainerName}, reqComp.Agent.Containers)
+	assert.False(t, reqComp.ClusterChecksRunner.IsEnabled())
+}
@@ -0,0 +1,106 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package orchestratorexplorer
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/common"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes/rbac"
+)
+
+// getClusterIDPolicyRules returns the rules required to generate the cluster ID.
+// The Cluster Agent always needs them: it creates the cluster-id ConfigMap shared with the other components.
+func getClusterIDPolicyRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		// To get the kube-system namespace UID and generate a cluster ID
+		{
+			APIGroups:     []string{rbac.CoreAPIGroup},
+			Resources:     []string{rbac.NamespaceResource},
+			ResourceNames: []string{common.KubeSystemResourceName},
+			Verbs:         []string{rbac.GetVerb},
+		},
+		// To create the cluster-id configmap
+		{
+			APIGroups:     []string{rbac.CoreAPIGroup},
+			Resources:     []string{rbac.ConfigMapsResource},
+			ResourceNames: []string{common.DatadogClusterIDResourceName},
+			Verbs: []string{
+				rbac.GetVerb,
+				rbac.CreateVerb,
+				rbac.UpdateVerb,
+			},
+		},
+	}
+}
+
+// getRBACPolicyRules generates the cluster role required for the Orchestrator Explorer check
+// to list and watch the collected Kubernetes resources.
+func getRBACPolicyRules() []rbacv1.PolicyRule {
+	rbacRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{
+				rbac.PodsResource,
+				rbac.ServicesResource,
+				rbac.NodesResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.AppsAPIGroup},
+			Resources: []string{
+				rbac.DeploymentsResource,
+				rbac.ReplicasetsResource,
+				rbac.DaemonsetsResource,
+				rbac.StatefulsetsResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.BatchAPIGroup},
+			Resources: []string{
+				rbac.JobsResource,
+				rbac.CronjobsResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{
+				rbac.PersistentVolumesResource,
+				rbac.PersistentVolumeClaimsResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.CoreAPIGroup},
+			Resources: []string{
+				rbac.ServiceAccountResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.RbacAPIGroup},
+			Resources: []string{
+				rbac.RoleResource,
+				rbac.RoleBindingResource,
+				rbac.ClusterRoleResource,
+				rbac.ClusterRoleBindingResource,
+			},
+		},
+		{
+			APIGroups: []string{rbac.NetworkingAPIGroup},
+			Resources: []string{rbac.IngressesResource},
+		},
+	}
+
+	commonVerbs := []string{
+		rbac.ListVerb,
+		rbac.WatchVerb,
+	}
+
+	for i := range rbacRules {
+		rbacRules[i].Verbs = commonVerbs
+	}
+
+	return append(getClusterIDPolicyRules(), rbacRules...)
+}
@@ -37,6 +37,11 @@ type prometheusScrapeFeature struct {
 	additionalConfigs      string
 }
 
+// ID returns the ID of the Feature
+func (f *prometheusScrapeFeature) ID() feature.IDType {
+	return feature.PrometheusScrapeIDType
+}
+
 // Configure is used to configure the feature from a v2alpha1.DatadogAgent instance.
 func (f *prometheusScrapeFeature) Configure(dda *v2alpha1.DatadogAgent) (reqComp feature.RequiredComponents) {
 	prometheusScrape := dda.Spec.Features.PrometheusScrape
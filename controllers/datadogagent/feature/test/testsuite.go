@@ -42,7 +42,9 @@ type FeatureTest struct {
 }
 
 // Options use to provide some option to the test.
-type Options struct{}
+type Options struct {
+	KubernetesVersion string
+}
 
 // ComponentTest use to configure how to test a component (Cluster-Agent, Agent, ClusterChecksRunner)
 type ComponentTest struct {
@@ -61,9 +63,13 @@ func runTest(t *testing.T, tt FeatureTest, buildFunc feature.BuildFunc) {
 	logf.SetLogger(zap.New(zap.UseDevMode(true)))
 	logger := logf.Log.WithName(tt.Name)
 
-	f := buildFunc(&feature.Options{
+	options := &feature.Options{
 		Logger: logger,
-	})
+	}
+	if tt.Options != nil {
+		options.KubernetesVersion = tt.Options.KubernetesVersion
+	}
+	f := buildFunc(options)
 
 	// check feature Configure function
 	var gotConfigure feature.RequiredComponents
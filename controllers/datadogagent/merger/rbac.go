@@ -54,7 +54,7 @@ func (m *rbacManagerImpl) AddPolicyRules(namespace string, roleName string, saNa
 
 	roleBinding.RoleRef = rbacv1.RoleRef{
 		APIGroup: rbac.RbacAPIGroup,
-		Kind:     rbac.ClusterRoleKind,
+		Kind:     rbac.RoleKind,
 		Name:     roleName,
 	}
 	found := false
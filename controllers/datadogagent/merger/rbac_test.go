@@ -84,9 +84,14 @@ func TestRBACManager_AddPolicyRules(t *testing.T) {
 					t.Errorf("missing Role %s/%s", ns, name+"role")
 				}
 
-				if _, found := store.Get(kubernetes.RoleBindingKind, ns, name+"role"); !found {
+				obj, found := store.Get(kubernetes.RoleBindingKind, ns, name+"role")
+				if !found {
 					t.Errorf("missing RoleBinding %s/%s", ns, name+"role")
 				}
+				roleBinding, ok := obj.(*rbacv1.RoleBinding)
+				if !ok || roleBinding.RoleRef.Kind != "Role" {
+					t.Errorf("RoleBinding %s/%s should reference a Role", ns, name+"role")
+				}
 			},
 		},
 		{
@@ -98,6 +98,15 @@ func (impl *volumeManagerImpl) AddVolumeToContainerWithMergeFunc(volume *corev1.
 			return nil
 		}
 	}
+	for id := range impl.podTmpl.Spec.InitContainers {
+		if impl.podTmpl.Spec.InitContainers[id].Name == string(containerName) {
+			_, err = AddVolumeMountToContainer(&impl.podTmpl.Spec.InitContainers[id], volumeMount, DefaultVolumeMountMergeFunction)
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+	}
 	return nil
 }
 
@@ -6,14 +6,111 @@
 package override
 
 import (
+	"fmt"
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/merger"
 )
 
+// appArmorAnnotationKeyFormat is the format of the pod annotation setting the AppArmor profile of a container
+const appArmorAnnotationKeyFormat = "container.apparmor.security.beta.kubernetes.io/%s"
+
 // Container use to override a corev1.Container with a 2alpha1.DatadogAgentGenericContainer.
-func Container(manager feature.PodTemplateManagers, override *v2alpha1.DatadogAgentGenericContainer) (*corev1.Container, error) {
-	// TODO(operator-ga): implement OverrideContainer
-	return nil, nil
+// The container is looked up by name in the PodTemplateSpec; nothing is done if it is not present.
+func Container(manager feature.PodTemplateManagers, containerName apicommonv1.AgentContainerName, override *v2alpha1.DatadogAgentGenericContainer) (*corev1.Container, error) {
+	if override == nil {
+		return nil, nil
+	}
+
+	container := getContainer(manager.PodTemplateSpec(), string(containerName))
+	if container == nil {
+		return nil, nil
+	}
+
+	if override.LogLevel != nil {
+		if _, err := merger.AddEnvVarToContainer(container, &corev1.EnvVar{Name: apicommon.DDLogLevel, Value: *override.LogLevel}, merger.OverrideCurrentEnvVarMergeFunction); err != nil {
+			return nil, err
+		}
+	}
+
+	for id := range override.Env {
+		if _, err := merger.AddEnvVarToContainer(container, &override.Env[id], merger.OverrideCurrentEnvVarMergeFunction); err != nil {
+			return nil, err
+		}
+	}
+
+	for id := range override.VolumeMounts {
+		if _, err := merger.AddVolumeMountToContainer(container, &override.VolumeMounts[id], merger.OverrideCurrentVolumeMountMergeFunction); err != nil {
+			return nil, err
+		}
+	}
+
+	if override.Resources != nil {
+		container.Resources = *override.Resources.DeepCopy()
+	}
+
+	if override.Command != nil {
+		container.Command = override.Command
+	}
+
+	if override.Args != nil {
+		container.Args = override.Args
+	}
+
+	if override.ReadinessProbe != nil {
+		container.ReadinessProbe = override.ReadinessProbe.DeepCopy()
+	}
+
+	if override.LivenessProbe != nil {
+		container.LivenessProbe = override.LivenessProbe.DeepCopy()
+	}
+
+	if override.HealthPort != nil {
+		if err := overrideHealthPort(container, *override.HealthPort); err != nil {
+			return nil, err
+		}
+	}
+
+	if override.SecurityContext != nil {
+		container.SecurityContext = override.SecurityContext.DeepCopy()
+	}
+
+	if override.AppArmorProfileName != nil {
+		addAnnotation(manager.PodTemplateSpec(), fmt.Sprintf(appArmorAnnotationKeyFormat, container.Name), *override.AppArmorProfileName)
+	}
+
+	return container, nil
+}
+
+// overrideHealthPort sets the health port of the container, and updates the probes using it.
+func overrideHealthPort(container *corev1.Container, healthPort int32) error {
+	healthPortEnvVar := &corev1.EnvVar{
+		Name:  apicommon.DDHealthPort,
+		Value: strconv.Itoa(int(healthPort)),
+	}
+	if _, err := merger.AddEnvVarToContainer(container, healthPortEnvVar, merger.OverrideCurrentEnvVarMergeFunction); err != nil {
+		return err
+	}
+
+	for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe} {
+		if probe != nil && probe.HTTPGet != nil {
+			probe.HTTPGet.Port = intstr.FromInt(int(healthPort))
+		}
+	}
+	return nil
+}
+
+// addAnnotation adds an annotation to the PodTemplateSpec, initializing the annotation map if needed.
+func addAnnotation(podTemplate *corev1.PodTemplateSpec, key, value string) {
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = make(map[string]string)
+	}
+	podTemplate.Annotations[key] = value
 }
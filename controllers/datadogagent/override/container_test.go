@@ -0,0 +1,201 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func fakePodTemplateSpec() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:  string(apicommonv1.SeccompSetupContainerName),
+					Image: "gcr.io/datadoghq/agent:7.38.0",
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:  string(apicommonv1.CoreAgentContainerName),
+					Image: "gcr.io/datadoghq/agent:7.38.0",
+					Env: []corev1.EnvVar{
+						{Name: apicommon.DDLogLevel, Value: "info"},
+					},
+					ReadinessProbe: &corev1.Probe{
+						Handler: corev1.Handler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt(int(apicommon.DefaultAgentHealthPort))},
+						},
+					},
+					LivenessProbe: &corev1.Probe{
+						Handler: corev1.Handler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/live", Port: intstr.FromInt(int(apicommon.DefaultAgentHealthPort))},
+						},
+					},
+				},
+				{
+					Name:  string(apicommonv1.TraceAgentContainerName),
+					Image: "gcr.io/datadoghq/agent:7.38.0",
+				},
+			},
+		},
+	}
+}
+
+func TestContainer(t *testing.T) {
+	containerName := apicommonv1.CoreAgentContainerName
+
+	tests := []struct {
+		name          string
+		containerName apicommonv1.AgentContainerName
+		override      *v2alpha1.DatadogAgentGenericContainer
+		validate      func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container)
+	}{
+		{
+			name:          "nil override",
+			containerName: containerName,
+			override:      nil,
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Nil(t, container)
+				assert.Equal(t, fakePodTemplateSpec(), podTemplate)
+			},
+		},
+		{
+			name:          "container not present",
+			containerName: apicommonv1.SystemProbeContainerName,
+			override:      &v2alpha1.DatadogAgentGenericContainer{LogLevel: apiutils.NewStringPointer("debug")},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Nil(t, container)
+				assert.Equal(t, fakePodTemplateSpec(), podTemplate)
+			},
+		},
+		{
+			name:          "log level",
+			containerName: containerName,
+			override:      &v2alpha1.DatadogAgentGenericContainer{LogLevel: apiutils.NewStringPointer("debug")},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDLogLevel, Value: "debug"}}, container.Env)
+			},
+		},
+		{
+			name:          "env vars",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				Env: []corev1.EnvVar{
+					{Name: apicommon.DDLogLevel, Value: "warn"},
+					{Name: "FOO", Value: "bar"},
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDLogLevel, Value: "warn"}, {Name: "FOO", Value: "bar"}}, container.Env)
+				// the other containers are untouched
+				assert.Empty(t, podTemplate.Spec.Containers[1].Env)
+			},
+		},
+		{
+			name:          "volume mounts",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				VolumeMounts: []corev1.VolumeMount{{Name: "foo", MountPath: "/foo"}},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, []corev1.VolumeMount{{Name: "foo", MountPath: "/foo"}}, container.VolumeMounts)
+			},
+		},
+		{
+			name:          "resources",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, resource.MustParse("1"), container.Resources.Limits[corev1.ResourceCPU])
+			},
+		},
+		{
+			name:          "command and args",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				Command: []string{"agent", "run"},
+				Args:    []string{"--foo"},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, []string{"agent", "run"}, container.Command)
+				assert.Equal(t, []string{"--foo"}, container.Args)
+			},
+		},
+		{
+			name:          "probes",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				ReadinessProbe: &corev1.Probe{InitialDelaySeconds: 10},
+				LivenessProbe:  &corev1.Probe{InitialDelaySeconds: 20},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, &corev1.Probe{InitialDelaySeconds: 10}, container.ReadinessProbe)
+				assert.Equal(t, &corev1.Probe{InitialDelaySeconds: 20}, container.LivenessProbe)
+			},
+		},
+		{
+			name:          "health port",
+			containerName: containerName,
+			override:      &v2alpha1.DatadogAgentGenericContainer{HealthPort: apiutils.NewInt32Pointer(1234)},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Contains(t, container.Env, corev1.EnvVar{Name: apicommon.DDHealthPort, Value: "1234"})
+				assert.Equal(t, intstr.FromInt(1234), container.ReadinessProbe.HTTPGet.Port)
+				assert.Equal(t, intstr.FromInt(1234), container.LivenessProbe.HTTPGet.Port)
+			},
+		},
+		{
+			name:          "security context",
+			containerName: containerName,
+			override: &v2alpha1.DatadogAgentGenericContainer{
+				SecurityContext: &corev1.SecurityContext{RunAsUser: apiutils.NewInt64Pointer(1000)},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, &corev1.SecurityContext{RunAsUser: apiutils.NewInt64Pointer(1000)}, container.SecurityContext)
+			},
+		},
+		{
+			name:          "apparmor profile",
+			containerName: containerName,
+			override:      &v2alpha1.DatadogAgentGenericContainer{AppArmorProfileName: apiutils.NewStringPointer("unconfined")},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, map[string]string{"container.apparmor.security.beta.kubernetes.io/agent": "unconfined"}, podTemplate.Annotations)
+			},
+		},
+		{
+			name:          "init container",
+			containerName: apicommonv1.SeccompSetupContainerName,
+			override:      &v2alpha1.DatadogAgentGenericContainer{Command: []string{"cp"}},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec, container *corev1.Container) {
+				assert.Equal(t, []string{"cp"}, podTemplate.Spec.InitContainers[0].Command)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podTemplate := fakePodTemplateSpec()
+			container, err := Container(feature.NewPodTemplateManagers(podTemplate), tt.containerName, tt.override)
+			assert.NoError(t, err)
+			tt.validate(t, podTemplate, container)
+		})
+	}
+}
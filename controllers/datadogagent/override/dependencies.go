@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/configmap"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+// Dependencies is used to create the dependencies required by a component override,
+// i.e. the ConfigMaps holding the `ConfigData` of the custom configurations.
+// The ConfigMap names match the ones referenced by `PodTemplateSpec`.
+func Dependencies(manager feature.ResourceManagers, override *v2alpha1.DatadogAgentComponentOverride, componentName v2alpha1.ComponentName, owner metav1.Object) (errs []error) {
+	if override == nil {
+		return nil
+	}
+
+	fileNames := make([]string, 0, len(override.CustomConfigurations))
+	for fileName := range override.CustomConfigurations {
+		fileNames = append(fileNames, string(fileName))
+	}
+	sort.Strings(fileNames)
+
+	for _, name := range fileNames {
+		fileName := v2alpha1.AgentConfigFileName(name)
+		customConfig := override.CustomConfigurations[fileName]
+		cm, err := configmap.BuildConfiguration(owner, customConfig.ConfigData, getCustomConfigConfigMapName(owner.GetName(), componentName, fileName), name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cm != nil {
+			manager.Store().AddOrUpdate(kubernetes.ConfigMapKind, cm)
+		}
+	}
+
+	if override.SecCompCustomProfile != nil && override.SecCompCustomProfile.ConfigData != nil {
+		// The seccomp profile is a JSON document: it is also valid YAML
+		cm, err := configmap.BuildConfiguration(owner, override.SecCompCustomProfile.ConfigData, getSeccompProfileConfigMapName(owner.GetName(), componentName), seccompProfileKey)
+		if err != nil {
+			errs = append(errs, err)
+		} else if cm != nil {
+			manager.Store().AddOrUpdate(kubernetes.ConfigMapKind, cm)
+		}
+	}
+
+	return errs
+}
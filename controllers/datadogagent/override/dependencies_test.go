@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/dependencies"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/pkg/kubernetes"
+)
+
+func TestDependencies(t *testing.T) {
+	owner := &v2alpha1.DatadogAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		override  *v2alpha1.DatadogAgentComponentOverride
+		wantErr   bool
+		wantCMs   map[string]map[string]string
+		wantNoCMs []string
+	}{
+		{
+			name:     "nil override",
+			override: nil,
+		},
+		{
+			name: "custom configurations",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				CustomConfigurations: map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig{
+					v2alpha1.AgentGeneralConfigFile: {ConfigData: apiutils.NewStringPointer("foo: bar")},
+					v2alpha1.SystemProbeConfigFile:  {ConfigMap: &apicommonv1.ConfigMapConfig{Name: "sp-config"}},
+				},
+			},
+			wantCMs: map[string]map[string]string{
+				"foo-cluster-agent-datadog-yaml": {"datadog.yaml": "foo: bar"},
+			},
+			wantNoCMs: []string{"foo-cluster-agent-system-probe-yaml"},
+		},
+		{
+			name: "invalid custom configuration",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				CustomConfigurations: map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig{
+					v2alpha1.AgentGeneralConfigFile: {ConfigData: apiutils.NewStringPointer("foo: : bar")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "seccomp custom profile",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				SecCompCustomProfile: &v2alpha1.CustomConfig{ConfigData: apiutils.NewStringPointer(`{"defaultAction": "SCMP_ACT_ERRNO"}`)},
+			},
+			wantCMs: map[string]map[string]string{
+				"foo-cluster-agent-seccomp-profile": {"system-probe-seccomp.json": `{"defaultAction": "SCMP_ACT_ERRNO"}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := dependencies.NewStore(nil)
+			errs := Dependencies(feature.NewResourceManagers(store), tt.override, v2alpha1.ClusterAgentComponentName, owner)
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+
+			for name, data := range tt.wantCMs {
+				obj, found := store.Get(kubernetes.ConfigMapKind, "bar", name)
+				if assert.True(t, found, "ConfigMap %s should be in the store", name) {
+					assert.Equal(t, data, obj.(*corev1.ConfigMap).Data)
+				}
+			}
+			for _, name := range tt.wantNoCMs {
+				_, found := store.Get(kubernetes.ConfigMapKind, "bar", name)
+				assert.False(t, found, "ConfigMap %s should not be in the store", name)
+			}
+		})
+	}
+}
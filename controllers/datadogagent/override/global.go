@@ -6,16 +6,188 @@
 package override
 
 import (
+	"encoding/json"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
-	corev1 "k8s.io/api/core/v1"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/merger"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
 )
 
+const fieldPathStatusHostIP = "status.hostIP"
+
 // ApplyGlobalSettings use to apply global setting to a PodTemplateSpec
-func ApplyGlobalSettings(manager feature.PodTemplateManagers, config *v2alpha1.GlobalConfig) *corev1.PodTemplateSpec {
-	// TODO(operator-ga): implement ApplyGlobalSettings
+// Global settings are applied before the features, so a feature or an override can still change them.
+func ApplyGlobalSettings(manager feature.PodTemplateManagers, config *v2alpha1.GlobalConfig, componentName v2alpha1.ComponentName) *corev1.PodTemplateSpec {
+	podTemplate := manager.PodTemplateSpec()
 
 	// set image registry
+	registry := string(defaulting.DefaultImageRegistry)
+	if config != nil && config.Registry != nil && *config.Registry != "" {
+		registry = *config.Registry
+	}
+	for _, container := range allContainers(podTemplate) {
+		if container.Image != "" {
+			container.Image = getImageWithRegistry(container.Image, registry)
+		}
+	}
+
+	if config == nil {
+		return podTemplate
+	}
+
+	if config.ClusterName != nil {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDClusterName,
+			Value: *config.ClusterName,
+		})
+	}
+
+	if config.Site != nil {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDSite,
+			Value: *config.Site,
+		})
+	}
+
+	if config.LogLevel != nil {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDLogLevel,
+			Value: *config.LogLevel,
+		})
+	}
+
+	if len(config.Tags) > 0 {
+		addJSONEnvVar(manager, apicommon.DDTags, config.Tags)
+	}
+
+	if len(config.PodLabelsAsTags) > 0 {
+		addJSONEnvVar(manager, apicommon.DDPodLabelsAsTags, config.PodLabelsAsTags)
+	}
+
+	if len(config.PodAnnotationsAsTags) > 0 {
+		addJSONEnvVar(manager, apicommon.DDPodAnnotationsAsTags, config.PodAnnotationsAsTags)
+	}
+
+	// The kubelet and the container runtime are only reached by the node Agent
+	if componentName == v2alpha1.NodeAgentComponentName {
+		applyKubeletSettings(manager, config.Kubelet)
+		applyRuntimeSocketSettings(manager, config.CriSocketPath, config.DockerSocketPath)
+	}
+
+	return podTemplate
+}
+
+// addJSONEnvVar adds an env var with the JSON representation of the value to all the containers.
+func addJSONEnvVar(manager feature.PodTemplateManagers, name string, value interface{}) {
+	// Marshalling a []string or a map[string]string can't fail
+	jsonValue, _ := json.Marshal(value)
+	manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:  name,
+		Value: string(jsonValue),
+	})
+}
+
+func applyKubeletSettings(manager feature.PodTemplateManagers, kubelet *apicommonv1.KubeletConfig) {
+	// Host valueFrom
+	kubeletHostValueFrom := &corev1.EnvVarSource{
+		FieldRef: &corev1.ObjectFieldSelector{
+			FieldPath: fieldPathStatusHostIP,
+		},
+	}
+	if kubelet != nil && kubelet.Host != nil {
+		kubeletHostValueFrom = kubelet.Host.DeepCopy()
+	}
+	manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+		Name:      apicommon.DDKubeletHost,
+		ValueFrom: kubeletHostValueFrom,
+	})
+
+	if kubelet == nil {
+		return
+	}
+
+	// TLS Verify
+	if kubelet.TLSVerify != nil {
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDKubeletTLSVerify,
+			Value: apiutils.BoolToString(kubelet.TLSVerify),
+		})
+	}
+
+	// CA Path
+	if kubelet.AgentCAPath != "" || kubelet.HostCAPath != "" {
+		agentCAPath := kubelet.AgentCAPath
+		if agentCAPath == "" {
+			agentCAPath = apicommon.DefaultKubeletAgentCAPath
+		}
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDKubeletCAPath,
+			Value: agentCAPath,
+		})
+
+		if kubelet.HostCAPath != "" {
+			fileVolumeType := corev1.HostPathFile
+			kubeletCAVolume := &corev1.Volume{
+				Name: apicommon.KubeletCAVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: kubelet.HostCAPath,
+						Type: &fileVolumeType,
+					},
+				},
+			}
+			kubeletCAVolumeMount := &corev1.VolumeMount{
+				Name:      apicommon.KubeletCAVolumeName,
+				MountPath: agentCAPath,
+				ReadOnly:  true,
+			}
+			manager.Volume().AddVolume(kubeletCAVolume, kubeletCAVolumeMount)
+		}
+	}
+}
+
+func applyRuntimeSocketSettings(manager feature.PodTemplateManagers, criSocketPath, dockerSocketPath *string) {
+	socketPath := ""
+	if criSocketPath != nil {
+		socketPath = *criSocketPath
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DDCriSocketPath,
+			Value: filepath.Join(apicommon.HostCriSocketPathPrefix, *criSocketPath),
+		})
+	}
+	if dockerSocketPath != nil {
+		if socketPath == "" {
+			socketPath = *dockerSocketPath
+		}
+		manager.EnvVar().AddEnvVar(&corev1.EnvVar{
+			Name:  apicommon.DockerHost,
+			Value: "unix://" + filepath.Join(apicommon.HostCriSocketPathPrefix, *dockerSocketPath),
+		})
+	}
 
-	return manager.PodTemplateSpec()
+	runtimeDir := apicommon.DefaultRuntimeDir
+	if socketPath != "" {
+		runtimeDir = filepath.Dir(socketPath)
+	}
+	runtimeVolume := &corev1.Volume{
+		Name: apicommon.CriSocketVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: runtimeDir,
+			},
+		},
+	}
+	runtimeVolumeMount := &corev1.VolumeMount{
+		Name:      apicommon.CriSocketVolumeName,
+		MountPath: filepath.Join(apicommon.HostCriSocketPathPrefix, runtimeDir),
+		ReadOnly:  true,
+	}
+	_ = manager.Volume().AddVolumeWithMergeFunc(runtimeVolume, runtimeVolumeMount, merger.OverrideCurrentVolumeMergeFunction, merger.OverrideCurrentVolumeMountMergeFunction)
 }
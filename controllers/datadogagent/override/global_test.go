@@ -0,0 +1,160 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func TestApplyGlobalSettings(t *testing.T) {
+	hostPathFile := corev1.HostPathFile
+	kubeletHostEnvVar := corev1.EnvVar{
+		Name:      apicommon.DDKubeletHost,
+		ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"}},
+	}
+
+	tests := []struct {
+		name          string
+		componentName v2alpha1.ComponentName
+		config        *v2alpha1.GlobalConfig
+		validate      func(t *testing.T, podTemplate *corev1.PodTemplateSpec)
+	}{
+		{
+			name:          "nil config",
+			componentName: v2alpha1.ClusterAgentComponentName,
+			config:        nil,
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, fakePodTemplateSpec(), podTemplate)
+			},
+		},
+		{
+			name:          "registry",
+			componentName: v2alpha1.ClusterAgentComponentName,
+			config:        &v2alpha1.GlobalConfig{Registry: apiutils.NewStringPointer("public.ecr.aws/datadog")},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				for _, container := range allContainers(podTemplate) {
+					assert.Equal(t, "public.ecr.aws/datadog/agent:7.38.0", container.Image)
+				}
+			},
+		},
+		{
+			name:          "cluster name, site, log level and tags",
+			componentName: v2alpha1.ClusterAgentComponentName,
+			config: &v2alpha1.GlobalConfig{
+				ClusterName:          apiutils.NewStringPointer("my-cluster"),
+				Site:                 apiutils.NewStringPointer("datadoghq.eu"),
+				LogLevel:             apiutils.NewStringPointer("debug"),
+				Tags:                 []string{"env:prod", "team:foo"},
+				PodLabelsAsTags:      map[string]string{"app": "app"},
+				PodAnnotationsAsTags: map[string]string{"owner": "owner"},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				want := []corev1.EnvVar{
+					{Name: apicommon.DDClusterName, Value: "my-cluster"},
+					{Name: apicommon.DDSite, Value: "datadoghq.eu"},
+					{Name: apicommon.DDLogLevel, Value: "debug"},
+					{Name: apicommon.DDTags, Value: `["env:prod","team:foo"]`},
+					{Name: apicommon.DDPodLabelsAsTags, Value: `{"app":"app"}`},
+					{Name: apicommon.DDPodAnnotationsAsTags, Value: `{"owner":"owner"}`},
+				}
+				assert.ElementsMatch(t, want, podTemplate.Spec.Containers[0].Env)
+				assert.ElementsMatch(t, want, podTemplate.Spec.Containers[1].Env)
+				// no kubelet or runtime settings outside the node Agent
+				assert.Empty(t, podTemplate.Spec.Volumes)
+			},
+		},
+		{
+			name:          "node agent default kubelet and runtime settings",
+			componentName: v2alpha1.NodeAgentComponentName,
+			config:        &v2alpha1.GlobalConfig{},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Contains(t, podTemplate.Spec.Containers[0].Env, kubeletHostEnvVar)
+				assert.Equal(t, []corev1.Volume{{
+					Name:         apicommon.CriSocketVolumeName,
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run"}},
+				}}, podTemplate.Spec.Volumes)
+				assert.Contains(t, podTemplate.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name: apicommon.CriSocketVolumeName, MountPath: "/host/var/run", ReadOnly: true,
+				})
+			},
+		},
+		{
+			name:          "node agent kubelet",
+			componentName: v2alpha1.NodeAgentComponentName,
+			config: &v2alpha1.GlobalConfig{
+				Kubelet: &apicommonv1.KubeletConfig{
+					Host:       &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+					TLSVerify:  apiutils.NewBoolPointer(false),
+					HostCAPath: "/etc/kubernetes/ca.crt",
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				env := podTemplate.Spec.Containers[0].Env
+				assert.Contains(t, env, corev1.EnvVar{
+					Name:      apicommon.DDKubeletHost,
+					ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}},
+				})
+				assert.Contains(t, env, corev1.EnvVar{Name: apicommon.DDKubeletTLSVerify, Value: "false"})
+				assert.Contains(t, env, corev1.EnvVar{Name: apicommon.DDKubeletCAPath, Value: apicommon.DefaultKubeletAgentCAPath})
+				assert.Contains(t, podTemplate.Spec.Volumes, corev1.Volume{
+					Name:         apicommon.KubeletCAVolumeName,
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/etc/kubernetes/ca.crt", Type: &hostPathFile}},
+				})
+				assert.Contains(t, podTemplate.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name: apicommon.KubeletCAVolumeName, MountPath: apicommon.DefaultKubeletAgentCAPath, ReadOnly: true,
+				})
+			},
+		},
+		{
+			name:          "node agent CRI and docker sockets",
+			componentName: v2alpha1.NodeAgentComponentName,
+			config: &v2alpha1.GlobalConfig{
+				CriSocketPath:    apiutils.NewStringPointer("/run/containerd/containerd.sock"),
+				DockerSocketPath: apiutils.NewStringPointer("/var/run/docker.sock"),
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				env := podTemplate.Spec.Containers[0].Env
+				assert.Contains(t, env, corev1.EnvVar{Name: apicommon.DDCriSocketPath, Value: "/host/run/containerd/containerd.sock"})
+				assert.Contains(t, env, corev1.EnvVar{Name: apicommon.DockerHost, Value: "unix:///host/var/run/docker.sock"})
+				assert.Equal(t, []corev1.Volume{{
+					Name:         apicommon.CriSocketVolumeName,
+					VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/run/containerd"}},
+				}}, podTemplate.Spec.Volumes)
+				assert.Contains(t, podTemplate.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name: apicommon.CriSocketVolumeName, MountPath: "/host/run/containerd", ReadOnly: true,
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podTemplate := fakePodTemplateSpec()
+			got := ApplyGlobalSettings(feature.NewPodTemplateManagers(podTemplate), tt.config, tt.componentName)
+			assert.Equal(t, podTemplate, got)
+			tt.validate(t, podTemplate)
+		})
+	}
+}
+
+func TestApplyGlobalSettingsDefaultRegistry(t *testing.T) {
+	podTemplate := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: string(apicommonv1.ClusterAgentContainerName), Image: "cluster-agent:1.19.0"}},
+		},
+	}
+	ApplyGlobalSettings(feature.NewPodTemplateManagers(podTemplate), &v2alpha1.GlobalConfig{}, v2alpha1.ClusterAgentComponentName)
+	assert.Equal(t, "gcr.io/datadoghq/cluster-agent:1.19.0", podTemplate.Spec.Containers[0].Image)
+}
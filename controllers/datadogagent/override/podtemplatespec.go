@@ -6,24 +6,223 @@
 package override
 
 import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
 	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
 	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/merger"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/object/volume"
 )
 
 // PodTemplateSpec use to override a corev1.PodTemplateSpec with a 2alpha1.DatadogAgentPodTemplateOverride.
-func PodTemplateSpec(manager feature.PodTemplateManagers, override *v2alpha1.DatadogAgentComponentOverride) (*corev1.PodTemplateSpec, error) {
-	// TODO(operator-ga): implement OverridePodTemplate
+// It is applied after the features, so the override always takes precedence over the features' defaults.
+// The component name and the DatadogAgent name are used to reference the ConfigMaps created by `Dependencies`.
+func PodTemplateSpec(manager feature.PodTemplateManagers, override *v2alpha1.DatadogAgentComponentOverride, componentName v2alpha1.ComponentName, ddaName string) (*corev1.PodTemplateSpec, error) {
+	podTemplate := manager.PodTemplateSpec()
+	if override == nil {
+		return podTemplate, nil
+	}
+
+	if override.ServiceAccountName != nil && !apiutils.BoolValue(override.CreateRbac) {
+		podTemplate.Spec.ServiceAccountName = *override.ServiceAccountName
+	}
+
+	if override.Image != nil {
+		overrideContainerImages(podTemplate, override.Image)
+	}
+
+	var errs []error
+
+	// Component env vars are applied before the container ones: priority is Container > Component
+	for id := range override.Env {
+		if err := manager.EnvVar().AddEnvVarWithMergeFunc(&override.Env[id], merger.OverrideCurrentEnvVarMergeFunction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, overrideCustomConfigurations(manager, override.CustomConfigurations, componentName, ddaName)...)
+
+	if override.ExtraConfd != nil {
+		if err := overrideConfigDirectory(manager, override.ExtraConfd, apicommon.ConfdVolumeName, apicommon.ConfdVolumePath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if override.ExtraChecksd != nil {
+		if err := overrideConfigDirectory(manager, override.ExtraChecksd, apicommon.ChecksdVolumeName, apicommon.ChecksdVolumePath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for id := range override.Volumes {
+		if _, err := merger.AddVolumeToPod(&podTemplate.Spec, &override.Volumes[id], merger.OverrideCurrentVolumeMergeFunction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if override.SecurityContext != nil {
+		podTemplate.Spec.SecurityContext = override.SecurityContext.DeepCopy()
+	}
+
+	if override.PriorityClassName != nil {
+		podTemplate.Spec.PriorityClassName = *override.PriorityClassName
+	}
+
+	if override.Affinity != nil {
+		podTemplate.Spec.Affinity = override.Affinity.DeepCopy()
+	}
+
+	if override.NodeSelector != nil {
+		podTemplate.Spec.NodeSelector = override.NodeSelector
+	}
+
+	if override.Tolerations != nil {
+		podTemplate.Spec.Tolerations = override.Tolerations
+	}
+
+	for key, value := range override.Annotations {
+		addAnnotation(podTemplate, key, value)
+	}
+
+	if len(override.Labels) > 0 && podTemplate.Labels == nil {
+		podTemplate.Labels = make(map[string]string, len(override.Labels))
+	}
+	for key, value := range override.Labels {
+		podTemplate.Labels[key] = value
+	}
+
+	if override.HostNetwork != nil {
+		podTemplate.Spec.HostNetwork = *override.HostNetwork
+	}
+
+	if override.HostPID != nil {
+		podTemplate.Spec.HostPID = *override.HostPID
+	}
+
+	errs = append(errs, overrideSeccomp(podTemplate, override, componentName, ddaName)...)
+
+	// Container overrides are applied last, in a deterministic order
+	containerNames := make([]string, 0, len(override.Containers))
+	for name := range override.Containers {
+		containerNames = append(containerNames, string(name))
+	}
+	sort.Strings(containerNames)
+	for _, name := range containerNames {
+		containerName := apicommonv1.AgentContainerName(name)
+		if _, err := Container(manager, containerName, override.Containers[containerName]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return podTemplate, errors.NewAggregate(errs)
+}
+
+// overrideContainerImages overrides the image of all the containers and init containers of the PodTemplateSpec.
+func overrideContainerImages(podTemplate *corev1.PodTemplateSpec, imageOverride *apicommonv1.AgentImageConfig) {
+	for _, container := range allContainers(podTemplate) {
+		container.Image = overrideImage(container.Image, imageOverride)
+		if imageOverride.PullPolicy != nil {
+			container.ImagePullPolicy = *imageOverride.PullPolicy
+		}
+	}
+
+	if imageOverride.PullSecrets != nil {
+		podTemplate.Spec.ImagePullSecrets = *imageOverride.PullSecrets
+	}
+}
+
+// overrideCustomConfigurations mounts the custom configuration files in the containers, in the Agent configuration directory.
+func overrideCustomConfigurations(manager feature.PodTemplateManagers, customConfigs map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig, componentName v2alpha1.ComponentName, ddaName string) []error {
+	fileNames := make([]string, 0, len(customConfigs))
+	for fileName := range customConfigs {
+		fileNames = append(fileNames, string(fileName))
+	}
+	sort.Strings(fileNames)
 
 	var errs []error
-	// Loop over container
-	for _, container := range override.Containers {
-		if _, err := Container(manager, container); err != nil {
+	for _, name := range fileNames {
+		fileName := v2alpha1.AgentConfigFileName(name)
+		customConfig := customConfigs[fileName]
+		cfcm := v2alpha1.ConvertCustomConfig(&customConfig)
+		volumeName := getCustomConfigVolumeName(fileName)
+
+		configVolume := volume.GetVolumeFromCustomConfigSpec(cfcm, getCustomConfigConfigMapName(ddaName, componentName, fileName), volumeName)
+		setConfigMapVolumeItems(&configVolume, cfcm.ConfigMap)
+		configVolumeMount := volume.GetVolumeMountFromCustomConfigSpec(cfcm, volumeName, filepath.Join(apicommon.ConfigVolumePath, name), name)
+		if err := manager.Volume().AddVolumeWithMergeFunc(&configVolume, &configVolumeMount, merger.OverrideCurrentVolumeMergeFunction, merger.OverrideCurrentVolumeMountMergeFunction); err != nil {
 			errs = append(errs, err)
 		}
 	}
+	return errs
+}
+
+// overrideConfigDirectory replaces the volume backing a configuration directory (conf.d, checks.d) with a ConfigMap.
+func overrideConfigDirectory(manager feature.PodTemplateManagers, customConfig *v2alpha1.CustomConfig, volumeName, volumePath string) error {
+	if customConfig.ConfigMap == nil {
+		return fmt.Errorf("the %s override only supports a ConfigMap reference", volumeName)
+	}
+
+	configMap := v2alpha1.ConvertCustomConfig(customConfig).ConfigMap
+	configVolume := volume.GetVolumeFromConfigMapConfig(configMap, "", volumeName)
+	setConfigMapVolumeItems(&configVolume, configMap)
+	// The whole directory is replaced, so the volume is mounted without subPath
+	configVolumeMount := corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: volumePath,
+		ReadOnly:  true,
+	}
+	return manager.Volume().AddVolumeWithMergeFunc(&configVolume, &configVolumeMount, merger.OverrideCurrentVolumeMergeFunction, merger.OverrideCurrentVolumeMountMergeFunction)
+}
+
+// overrideSeccomp configures the System Probe seccomp profile: profile name annotation, host root directory,
+// and custom profile installed by the seccomp-setup init container.
+func overrideSeccomp(podTemplate *corev1.PodTemplateSpec, override *v2alpha1.DatadogAgentComponentOverride, componentName v2alpha1.ComponentName, ddaName string) []error {
+	var errs []error
+
+	if override.SecCompProfileName != nil {
+		addAnnotation(podTemplate, apicommon.SystemProbeSecCompAnnotationKey, *override.SecCompProfileName)
+	}
+
+	if override.SecCompRootPath != nil {
+		rootVolume := corev1.Volume{
+			Name: apicommon.SeccompRootVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: *override.SecCompRootPath,
+				},
+			},
+		}
+		if _, err := merger.AddVolumeToPod(&podTemplate.Spec, &rootVolume, merger.OverrideCurrentVolumeMergeFunction); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if override.SecCompCustomProfile != nil {
+		cfcm := v2alpha1.ConvertCustomConfig(override.SecCompCustomProfile)
+		profileVolume := volume.GetVolumeFromCustomConfigSpec(cfcm, getSeccompProfileConfigMapName(ddaName, componentName), apicommon.SeccompSecurityVolumeName)
+		setConfigMapVolumeItems(&profileVolume, cfcm.ConfigMap)
+		if _, err := merger.AddVolumeToPod(&podTemplate.Spec, &profileVolume, merger.OverrideCurrentVolumeMergeFunction); err != nil {
+			errs = append(errs, err)
+		}
+
+		if setupContainer := getContainer(podTemplate, string(apicommonv1.SeccompSetupContainerName)); setupContainer != nil {
+			profileVolumeMount := corev1.VolumeMount{
+				Name:      apicommon.SeccompSecurityVolumeName,
+				MountPath: apicommon.SeccompSecurityVolumePath,
+			}
+			if _, err := merger.AddVolumeMountToContainer(setupContainer, &profileVolumeMount, merger.OverrideCurrentVolumeMountMergeFunction); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
 
-	return nil, errors.NewAggregate(errs)
+	return errs
 }
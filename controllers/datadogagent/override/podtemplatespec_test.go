@@ -0,0 +1,247 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func TestPodTemplateSpec(t *testing.T) {
+	ddaName := "foo"
+	pullPolicy := corev1.PullAlways
+
+	tests := []struct {
+		name     string
+		override *v2alpha1.DatadogAgentComponentOverride
+		wantErr  bool
+		validate func(t *testing.T, podTemplate *corev1.PodTemplateSpec)
+	}{
+		{
+			name:     "nil override",
+			override: nil,
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, fakePodTemplateSpec(), podTemplate)
+			},
+		},
+		{
+			name: "service account name",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				ServiceAccountName: apiutils.NewStringPointer("custom-sa"),
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, "custom-sa", podTemplate.Spec.ServiceAccountName)
+			},
+		},
+		{
+			name: "service account name ignored when the RBAC are created",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				CreateRbac:         apiutils.NewBoolPointer(true),
+				ServiceAccountName: apiutils.NewStringPointer("custom-sa"),
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Empty(t, podTemplate.Spec.ServiceAccountName)
+			},
+		},
+		{
+			name: "image tag",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Image: &apicommonv1.AgentImageConfig{
+					Tag:         "7.39.0",
+					JMXEnabled:  true,
+					PullPolicy:  &pullPolicy,
+					PullSecrets: &[]corev1.LocalObjectReference{{Name: "secret"}},
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				for _, container := range allContainers(podTemplate) {
+					assert.Equal(t, "gcr.io/datadoghq/agent:7.39.0-jmx", container.Image)
+					assert.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+				}
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: "secret"}}, podTemplate.Spec.ImagePullSecrets)
+			},
+		},
+		{
+			name: "full image name",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Image: &apicommonv1.AgentImageConfig{Name: "docker.io/datadog/agent:latest"},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				for _, container := range allContainers(podTemplate) {
+					assert.Equal(t, "docker.io/datadog/agent:latest", container.Image)
+				}
+			},
+		},
+		{
+			name: "env vars, container has priority over component",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Env: []corev1.EnvVar{{Name: apicommon.DDLogLevel, Value: "warn"}},
+				Containers: map[apicommonv1.AgentContainerName]*v2alpha1.DatadogAgentGenericContainer{
+					apicommonv1.CoreAgentContainerName: {LogLevel: apiutils.NewStringPointer("debug")},
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDLogLevel, Value: "debug"}}, podTemplate.Spec.Containers[0].Env)
+				assert.Equal(t, []corev1.EnvVar{{Name: apicommon.DDLogLevel, Value: "warn"}}, podTemplate.Spec.Containers[1].Env)
+			},
+		},
+		{
+			name: "custom configurations",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				CustomConfigurations: map[v2alpha1.AgentConfigFileName]v2alpha1.CustomConfig{
+					v2alpha1.AgentGeneralConfigFile: {ConfigData: apiutils.NewStringPointer("foo: bar")},
+					v2alpha1.SystemProbeConfigFile: {ConfigMap: &apicommonv1.ConfigMapConfig{
+						Name:  "sp-config",
+						Items: []corev1.KeyToPath{{Key: "sp", Path: "system-probe.yaml"}},
+					}},
+				},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.Volume{
+					{
+						Name: "custom-config-datadog-yaml",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "foo-agent-datadog-yaml"},
+						}},
+					},
+					{
+						Name: "custom-config-system-probe-yaml",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "sp-config"},
+							Items:                []corev1.KeyToPath{{Key: "sp", Path: "system-probe.yaml"}},
+						}},
+					},
+				}, podTemplate.Spec.Volumes)
+				assert.Equal(t, []corev1.VolumeMount{
+					{Name: "custom-config-datadog-yaml", MountPath: "/etc/datadog-agent/datadog.yaml", SubPath: "datadog.yaml", ReadOnly: true},
+					{Name: "custom-config-system-probe-yaml", MountPath: "/etc/datadog-agent/system-probe.yaml", SubPath: "system-probe.yaml", ReadOnly: true},
+				}, podTemplate.Spec.Containers[0].VolumeMounts)
+			},
+		},
+		{
+			name: "extra confd and checksd",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				ExtraConfd:   &v2alpha1.CustomConfig{ConfigMap: &apicommonv1.ConfigMapConfig{Name: "confd"}},
+				ExtraChecksd: &v2alpha1.CustomConfig{ConfigMap: &apicommonv1.ConfigMapConfig{Name: "checksd"}},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.Volume{
+					{
+						Name: apicommon.ConfdVolumeName,
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "confd"},
+						}},
+					},
+					{
+						Name: apicommon.ChecksdVolumeName,
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "checksd"},
+						}},
+					},
+				}, podTemplate.Spec.Volumes)
+				assert.Equal(t, []corev1.VolumeMount{
+					{Name: apicommon.ConfdVolumeName, MountPath: apicommon.ConfdVolumePath, ReadOnly: true},
+					{Name: apicommon.ChecksdVolumeName, MountPath: apicommon.ChecksdVolumePath, ReadOnly: true},
+				}, podTemplate.Spec.Containers[0].VolumeMounts)
+			},
+		},
+		{
+			name: "extra confd with config data",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				ExtraConfd: &v2alpha1.CustomConfig{ConfigData: apiutils.NewStringPointer("foo: bar")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "volumes",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Volumes: []corev1.Volume{{Name: "foo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, []corev1.Volume{{Name: "foo", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}, podTemplate.Spec.Volumes)
+			},
+		},
+		{
+			name: "pod scheduling and security",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				SecurityContext:   &corev1.PodSecurityContext{RunAsUser: apiutils.NewInt64Pointer(1000)},
+				PriorityClassName: apiutils.NewStringPointer("system-node-critical"),
+				Affinity:          &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+				NodeSelector:      map[string]string{"foo": "bar"},
+				Tolerations:       []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+				HostNetwork:       apiutils.NewBoolPointer(true),
+				HostPID:           apiutils.NewBoolPointer(true),
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: apiutils.NewInt64Pointer(1000)}, podTemplate.Spec.SecurityContext)
+				assert.Equal(t, "system-node-critical", podTemplate.Spec.PriorityClassName)
+				assert.Equal(t, &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}, podTemplate.Spec.Affinity)
+				assert.Equal(t, map[string]string{"foo": "bar"}, podTemplate.Spec.NodeSelector)
+				assert.Equal(t, []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, podTemplate.Spec.Tolerations)
+				assert.True(t, podTemplate.Spec.HostNetwork)
+				assert.True(t, podTemplate.Spec.HostPID)
+			},
+		},
+		{
+			name: "labels and annotations",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, map[string]string{"foo": "bar"}, podTemplate.Labels)
+				assert.Equal(t, map[string]string{"bar": "baz"}, podTemplate.Annotations)
+			},
+		},
+		{
+			name: "seccomp",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				SecCompRootPath:      apiutils.NewStringPointer("/var/lib/seccomp"),
+				SecCompProfileName:   apiutils.NewStringPointer("localhost/system-probe"),
+				SecCompCustomProfile: &v2alpha1.CustomConfig{ConfigData: apiutils.NewStringPointer("{}")},
+			},
+			validate: func(t *testing.T, podTemplate *corev1.PodTemplateSpec) {
+				assert.Equal(t, map[string]string{apicommon.SystemProbeSecCompAnnotationKey: "localhost/system-probe"}, podTemplate.Annotations)
+				assert.Equal(t, []corev1.Volume{
+					{
+						Name:         apicommon.SeccompRootVolumeName,
+						VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/seccomp"}},
+					},
+					{
+						Name: apicommon.SeccompSecurityVolumeName,
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "foo-agent-seccomp-profile"},
+						}},
+					},
+				}, podTemplate.Spec.Volumes)
+				assert.Equal(t, []corev1.VolumeMount{
+					{Name: apicommon.SeccompSecurityVolumeName, MountPath: apicommon.SeccompSecurityVolumePath},
+				}, podTemplate.Spec.InitContainers[0].VolumeMounts)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podTemplate := fakePodTemplateSpec()
+			_, err := PodTemplateSpec(feature.NewPodTemplateManagers(podTemplate), tt.override, v2alpha1.NodeAgentComponentName, ddaName)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tt.validate(t, podTemplate)
+		})
+	}
+}
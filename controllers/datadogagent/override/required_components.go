@@ -0,0 +1,36 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+// RequiredComponents is used to force disable the components for which the override sets `Disabled`,
+// whatever the features require.
+func RequiredComponents(reqComp *feature.RequiredComponents, overrides map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride) {
+	for componentName, override := range overrides {
+		if override == nil || !apiutils.BoolValue(override.Disabled) {
+			continue
+		}
+
+		var component *feature.RequiredComponent
+		switch componentName {
+		case v2alpha1.NodeAgentComponentName:
+			component = &reqComp.Agent
+		case v2alpha1.ClusterAgentComponentName:
+			component = &reqComp.ClusterAgent
+		case v2alpha1.ClusterChecksRunnerComponentName:
+			component = &reqComp.ClusterChecksRunner
+		default:
+			continue
+		}
+		component.IsRequired = apiutils.NewBoolPointer(false)
+		component.Containers = nil
+	}
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+	"github.com/DataDog/datadog-operator/controllers/datadogagent/feature"
+)
+
+func TestRequiredComponents(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride
+		want      feature.RequiredComponents
+	}{
+		{
+			name:      "no override",
+			overrides: nil,
+			want: feature.RequiredComponents{
+				Agent:               feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true), Containers: []apicommonv1.AgentContainerName{apicommonv1.CoreAgentContainerName}},
+				ClusterAgent:        feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+				ClusterChecksRunner: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+			},
+		},
+		{
+			name: "disabled components",
+			overrides: map[v2alpha1.ComponentName]*v2alpha1.DatadogAgentComponentOverride{
+				v2alpha1.NodeAgentComponentName:           {Disabled: apiutils.NewBoolPointer(true)},
+				v2alpha1.ClusterAgentComponentName:        {Disabled: apiutils.NewBoolPointer(false)},
+				v2alpha1.ClusterChecksRunnerComponentName: {Disabled: apiutils.NewBoolPointer(true)},
+			},
+			want: feature.RequiredComponents{
+				Agent:               feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(false)},
+				ClusterAgent:        feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+				ClusterChecksRunner: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(false)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqComp := feature.RequiredComponents{
+				Agent:               feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true), Containers: []apicommonv1.AgentContainerName{apicommonv1.CoreAgentContainerName}},
+				ClusterAgent:        feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+				ClusterChecksRunner: feature.RequiredComponent{IsRequired: apiutils.NewBoolPointer(true)},
+			}
+			RequiredComponents(&reqComp, tt.overrides)
+			assert.Equal(t, tt.want, reqComp)
+		})
+	}
+}
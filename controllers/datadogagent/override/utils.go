@@ -0,0 +1,137 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	apicommonv1 "github.com/DataDog/datadog-operator/apis/datadoghq/common/v1"
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	"github.com/DataDog/datadog-operator/pkg/defaulting"
+)
+
+const (
+	customConfigVolumePrefix = "custom-config"
+	seccompProfileKey        = "system-probe-seccomp.json"
+	seccompProfileSuffix     = "seccomp-profile"
+)
+
+// getComponentSuffix returns the suffix used to name the resources of a component
+func getComponentSuffix(componentName v2alpha1.ComponentName) string {
+	switch componentName {
+	case v2alpha1.ClusterAgentComponentName:
+		return apicommon.DefaultClusterAgentResourceSuffix
+	case v2alpha1.ClusterChecksRunnerComponentName:
+		return apicommon.DefaultClusterChecksRunnerResourceSuffix
+	default:
+		return apicommon.DefaultAgentResourceSuffix
+	}
+}
+
+// sanitizeFileName transforms a configuration file name into a string usable in a Kubernetes resource name
+func sanitizeFileName(fileName string) string {
+	return strings.ReplaceAll(strings.ToLower(fileName), ".", "-")
+}
+
+// getCustomConfigConfigMapName returns the name of the ConfigMap created from a custom configuration `ConfigData`
+func getCustomConfigConfigMapName(ddaName string, componentName v2alpha1.ComponentName, fileName v2alpha1.AgentConfigFileName) string {
+	return fmt.Sprintf("%s-%s-%s", ddaName, getComponentSuffix(componentName), sanitizeFileName(string(fileName)))
+}
+
+// getCustomConfigVolumeName returns the name of the volume mounting a custom configuration file
+func getCustomConfigVolumeName(fileName v2alpha1.AgentConfigFileName) string {
+	return fmt.Sprintf("%s-%s", customConfigVolumePrefix, sanitizeFileName(string(fileName)))
+}
+
+// getSeccompProfileConfigMapName returns the name of the ConfigMap created from a custom seccomp profile `ConfigData`
+func getSeccompProfileConfigMapName(ddaName string, componentName v2alpha1.ComponentName) string {
+	return fmt.Sprintf("%s-%s-%s", ddaName, getComponentSuffix(componentName), seccompProfileSuffix)
+}
+
+// splitImage splits an image string into its registry and its `name:tag` parts.
+// The registry is empty if the image doesn't contain one.
+func splitImage(image string) (registry, nameAndTag string) {
+	id := strings.LastIndex(image, "/")
+	if id < 0 {
+		return "", image
+	}
+	return image[:id], image[id+1:]
+}
+
+// getImageWithRegistry replaces the registry of an image string
+func getImageWithRegistry(image, registry string) string {
+	_, nameAndTag := splitImage(image)
+	return fmt.Sprintf("%s/%s", registry, nameAndTag)
+}
+
+// overrideImage returns the image string resulting of the override of the current container image.
+// The registry of the current image is kept, as well as its name and tag if the override doesn't provide them.
+func overrideImage(currentImage string, imageOverride *apicommonv1.AgentImageConfig) string {
+	if defaulting.IsImageNameContainsTag(imageOverride.Name) {
+		// The image name corresponds to a full image string
+		return imageOverride.Name
+	}
+
+	registry, nameAndTag := splitImage(currentImage)
+	if registry == "" {
+		registry = string(defaulting.DefaultImageRegistry)
+	}
+	currentName, currentTag := nameAndTag, ""
+	if id := strings.LastIndex(nameAndTag, ":"); id >= 0 {
+		currentName, currentTag = nameAndTag[:id], nameAndTag[id+1:]
+	}
+
+	name := imageOverride.Name
+	if name == "" {
+		name = currentName
+	}
+	tag := imageOverride.Tag
+	if tag == "" {
+		tag = strings.TrimSuffix(currentTag, defaulting.JMXTagSuffix)
+	}
+
+	img := defaulting.NewImage(name, tag, imageOverride.JMXEnabled)
+	defaulting.WithRegistry(defaulting.ContainerRegistry(registry))(img)
+	return img.String()
+}
+
+// getContainer returns the container (or init container) matching the name in the PodTemplateSpec
+func getContainer(podTemplate *corev1.PodTemplateSpec, containerName string) *corev1.Container {
+	for id := range podTemplate.Spec.Containers {
+		if podTemplate.Spec.Containers[id].Name == containerName {
+			return &podTemplate.Spec.Containers[id]
+		}
+	}
+	for id := range podTemplate.Spec.InitContainers {
+		if podTemplate.Spec.InitContainers[id].Name == containerName {
+			return &podTemplate.Spec.InitContainers[id]
+		}
+	}
+	return nil
+}
+
+// allContainers returns pointers to all the containers and init containers of the PodTemplateSpec
+func allContainers(podTemplate *corev1.PodTemplateSpec) []*corev1.Container {
+	containers := make([]*corev1.Container, 0, len(podTemplate.Spec.Containers)+len(podTemplate.Spec.InitContainers))
+	for id := range podTemplate.Spec.InitContainers {
+		containers = append(containers, &podTemplate.Spec.InitContainers[id])
+	}
+	for id := range podTemplate.Spec.Containers {
+		containers = append(containers, &podTemplate.Spec.Containers[id])
+	}
+	return containers
+}
+
+// setConfigMapVolumeItems projects the items of a ConfigMapConfig in a ConfigMap volume
+func setConfigMapVolumeItems(vol *corev1.Volume, configMap *apicommonv1.ConfigMapConfig) {
+	if configMap != nil && len(configMap.Items) > 0 && vol.ConfigMap != nil {
+		vol.ConfigMap.Items = configMap.Items
+	}
+}
@@ -0,0 +1,44 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+)
+
+// Deployment is used to override the workload level settings of a Deployment (name, replicas).
+func Deployment(deployment *appsv1.Deployment, override *v2alpha1.DatadogAgentComponentOverride) *appsv1.Deployment {
+	if override == nil {
+		return deployment
+	}
+
+	if override.Name != nil {
+		deployment.Name = *override.Name
+	}
+
+	if override.Replicas != nil {
+		replicas := *override.Replicas
+		deployment.Spec.Replicas = &replicas
+	}
+
+	return deployment
+}
+
+// DaemonSet is used to override the workload level settings of a DaemonSet (name).
+// Replicas are not applicable to a DaemonSet.
+func DaemonSet(daemonset *appsv1.DaemonSet, override *v2alpha1.DatadogAgentComponentOverride) *appsv1.DaemonSet {
+	if override == nil {
+		return daemonset
+	}
+
+	if override.Name != nil {
+		daemonset.Name = *override.Name
+	}
+
+	return daemonset
+}
@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package override
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func TestDeployment(t *testing.T) {
+	tests := []struct {
+		name         string
+		override     *v2alpha1.DatadogAgentComponentOverride
+		wantName     string
+		wantReplicas *int32
+	}{
+		{
+			name:         "nil override",
+			override:     nil,
+			wantName:     "foo-cluster-agent",
+			wantReplicas: apiutils.NewInt32Pointer(1),
+		},
+		{
+			name: "name and replicas",
+			override: &v2alpha1.DatadogAgentComponentOverride{
+				Name:     apiutils.NewStringPointer("custom-dca"),
+				Replicas: apiutils.NewInt32Pointer(3),
+			},
+			wantName:     "custom-dca",
+			wantReplicas: apiutils.NewInt32Pointer(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-cluster-agent"},
+				Spec:       appsv1.DeploymentSpec{Replicas: apiutils.NewInt32Pointer(1)},
+			}
+			Deployment(deployment, tt.override)
+			assert.Equal(t, tt.wantName, deployment.Name)
+			assert.Equal(t, tt.wantReplicas, deployment.Spec.Replicas)
+		})
+	}
+}
+
+func TestDaemonSet(t *testing.T) {
+	daemonset := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "foo-agent"}}
+	DaemonSet(daemonset, &v2alpha1.DatadogAgentComponentOverride{
+		Name:     apiutils.NewStringPointer("custom-agent"),
+		Replicas: apiutils.NewInt32Pointer(3),
+	})
+	assert.Equal(t, "custom-agent", daemonset.Name)
+}
@@ -0,0 +1,257 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	ctrUtils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	defaultRequeuePeriod    = 60 * time.Second
+	defaultErrRequeuePeriod = 5 * time.Second
+)
+
+// Reconciler reconciles a DatadogDashboard object
+type Reconciler struct {
+	client        client.Client
+	datadogClient *datadogapiclientv1.APIClient
+	datadogAuth   context.Context
+	versionInfo   *version.Info
+	log           logr.Logger
+	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(client client.Client, ddClient datadogclient.DatadogClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	return &Reconciler{
+		client:        client,
+		datadogClient: ddClient.Client,
+		datadogAuth:   ddClient.Auth,
+		versionInfo:   versionInfo,
+		scheme:        scheme,
+		log:           log,
+		recorder:      recorder,
+	}, nil
+}
+
+// Reconcile is similar to reconciler.Reconcile interface, but taking a context
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, request)
+}
+
+// Reconcile loop for DatadogDashboard
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogdashboard", req.NamespacedName)
+	logger.Info("Reconciling DatadogDashboard")
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &datadoghqv1alpha1.DatadogDashboard{}
+	var result ctrl.Result
+	err := r.client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return result, nil
+		}
+		// Error reading the object - requeue the request
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	newStatus := instance.Status.DeepCopy()
+
+	if result, err = r.handleFinalizer(logger, instance); ctrUtils.ShouldReturn(result, err) {
+		return result, err
+	}
+
+	// Validate the DatadogDashboard spec
+	if err = datadoghqv1alpha1.IsValidDatadogDashboard(&instance.Spec); err != nil {
+		logger.Error(err, "invalid DatadogDashboard spec")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	if err != nil {
+		logger.Error(err, "error generating hash")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Create or update dashboard, or check for drift. Fall through this block (without returning)
+	// if the result should be requeued with the default period
+	if instance.Status.ID == "" {
+		logger.V(1).Info("Dashboard ID is not set; creating dashboard in Datadog")
+		if err = r.create(logger, instance, newStatus, now); err != nil {
+			logger.Error(err, "error creating dashboard")
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else if instanceSpecHash != instance.Status.CurrentHash {
+		if err = r.update(logger, instance, newStatus, now); err != nil {
+			logger.Error(err, "error updating dashboard", "Dashboard ID", instance.Status.ID)
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else {
+		// Spec has not changed, check if the dashboard was modified in Datadog since the last sync.
+		// We only do it every defaultRequeuePeriod to avoid overloading APIServer and DD
+		if instance.Status.DashboardLastSyncTime != nil {
+			nextUpdateIn := defaultRequeuePeriod - now.Sub(instance.Status.DashboardLastSyncTime.Time)
+			if nextUpdateIn > 0 {
+				return ctrl.Result{RequeueAfter: nextUpdateIn}, nil
+			}
+		}
+
+		var drifted bool
+		if drifted, err = r.get(logger, instance, newStatus, now); err != nil {
+			logger.Error(err, "error getting dashboard", "Dashboard ID", instance.Status.ID)
+		} else if drifted {
+			logger.Info("Dashboard was modified outside Kubernetes; restoring it from the DatadogDashboard spec", "Dashboard ID", instance.Status.ID)
+			if err = r.update(logger, instance, newStatus, now); err != nil {
+				logger.Error(err, "error updating dashboard", "Dashboard ID", instance.Status.ID)
+			}
+		}
+	}
+
+	// Requeue
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result.RequeueAfter = defaultRequeuePeriod
+	}
+
+	// Update the status
+	return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+}
+
+func (r *Reconciler) create(logger logr.Logger, datadogDashboard *datadoghqv1alpha1.DatadogDashboard, status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time) error {
+	// Create dashboard in Datadog
+	d, err := createDashboard(r.datadogAuth, r.datadogClient, datadogDashboard)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDashboardSyncStatusCreateError
+		return err
+	}
+	event := buildEventInfo(datadogDashboard.Name, datadogDashboard.Namespace, datadog.CreationEvent)
+	r.recordEvent(datadogDashboard, event)
+
+	// As this is a new dashboard, add static information to status
+	status.ID = d.GetId()
+	status.URL = d.GetUrl()
+	status.Creator = d.GetAuthorHandle()
+	createdTime := metav1.NewTime(d.GetCreatedAt())
+	status.Created = &createdTime
+	setSyncedStatus(d, status, now)
+
+	// Set Created Condition
+	condition.UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeCreated, corev1.ConditionTrue, "DatadogDashboard Created")
+	logger.Info("Created a new DatadogDashboard", "Dashboard Namespace", datadogDashboard.Namespace, "Dashboard Name", datadogDashboard.Name, "Dashboard ID", d.GetId())
+
+	return nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, datadogDashboard *datadoghqv1alpha1.DatadogDashboard, status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time) error {
+	// Update dashboard in Datadog
+	d, err := updateDashboard(r.datadogAuth, r.datadogClient, datadogDashboard)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDashboardSyncStatusUpdateError
+		return err
+	}
+
+	event := buildEventInfo(datadogDashboard.Name, datadogDashboard.Namespace, datadog.UpdateEvent)
+	r.recordEvent(datadogDashboard, event)
+
+	status.URL = d.GetUrl()
+	setSyncedStatus(d, status, now)
+
+	// Set Updated Condition
+	condition.UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeUpdated, corev1.ConditionTrue, "DatadogDashboard Updated")
+	logger.Info("Updated DatadogDashboard", "Dashboard Namespace", datadogDashboard.Namespace, "Dashboard Name", datadogDashboard.Name, "Dashboard ID", datadogDashboard.Status.ID)
+
+	return nil
+}
+
+// get fetches the dashboard from Datadog and returns whether it was modified outside Kubernetes
+// since the last time it was synced
+func (r *Reconciler) get(logger logr.Logger, datadogDashboard *datadoghqv1alpha1.DatadogDashboard, status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time) (bool, error) {
+	d, err := getDashboard(r.datadogAuth, r.datadogClient, datadogDashboard.Status.ID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDashboardSyncStatusGetError
+		return false, err
+	}
+
+	drifted := isDrifted(d, status)
+	status.URL = d.GetUrl()
+	status.DashboardLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogDashboardSyncStatusOK
+	logger.V(1).Info("Synced DatadogDashboard state", "Dashboard Namespace", datadogDashboard.Namespace, "Dashboard Name", datadogDashboard.Name, "Dashboard ID", datadogDashboard.Status.ID)
+
+	return drifted, nil
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogDashboard *datadoghqv1alpha1.DatadogDashboard, now metav1.Time, status *datadoghqv1alpha1.DatadogDashboardStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
+	// Update Error and Active conditions
+	condition.SetDatadogDashboardErrorActiveConditions(status, now, currentErr)
+
+	if !apiequality.Semantic.DeepEqual(&datadogDashboard.Status, status) {
+		datadogDashboard.Status = *status
+		if err := r.client.Status().Update(context.TODO(), datadogDashboard); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogDashboard status due to update conflict")
+
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogDashboard status")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	return result, nil
+}
+
+// setSyncedStatus records the Datadog modification time of a dashboard that was just pushed
+func setSyncedStatus(d datadogapiclientv1.Dashboard, status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time) {
+	modifiedTime := metav1.NewTime(d.GetModifiedAt())
+	status.DashboardLastModifiedTime = &modifiedTime
+	status.DashboardLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogDashboardSyncStatusOK
+}
+
+// isDrifted returns true if the dashboard was modified in Datadog after the controller last pushed it.
+// Timestamps are compared with a one second precision, as metav1.Time is serialized without sub-second precision.
+func isDrifted(d datadogapiclientv1.Dashboard, status *datadoghqv1alpha1.DatadogDashboardStatus) bool {
+	modifiedAt, ok := d.GetModifiedAtOk()
+	if !ok || status.DashboardLastModifiedTime == nil {
+		return false
+	}
+
+	return modifiedAt.Unix() > status.DashboardLastModifiedTime.Unix()
+}
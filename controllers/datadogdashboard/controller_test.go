@@ -0,0 +1,360 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+)
+
+const (
+	resourcesName      = "foo"
+	resourcesNamespace = "bar"
+	dashboardID        = "abc-def-ghi"
+)
+
+func TestReconcileDatadogDashboard_Reconcile(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconcileDatadogDashboard_Reconcile"})
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogDashboard{})
+
+	type args struct {
+		request              reconcile.Request
+		firstAction          func(c client.Client)
+		firstReconcileCount  int
+		secondAction         func(c client.Client)
+		secondReconcileCount int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantResult reconcile.Result
+		wantErr    bool
+		wantFunc   func(c client.Client) error
+	}{
+		{
+			name: "DatadogDashboard not created",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+			},
+			wantResult: reconcile.Result{},
+		},
+		{
+			name: "DatadogDashboard created, add finalizer",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDashboard())
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dd := &datadoghqv1alpha1.DatadogDashboard{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd); err != nil {
+					return err
+				}
+				assert.Contains(t, dd.GetFinalizers(), "finalizer.dashboard.datadoghq.com")
+				return nil
+			},
+		},
+		{
+			name: "DatadogDashboard created, check status",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDashboard())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dd := &datadoghqv1alpha1.DatadogDashboard{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd); err != nil {
+					return err
+				}
+				assert.Equal(t, dashboardID, dd.Status.ID)
+				assert.Equal(t, "/dashboard/abc-def-ghi/test-dashboard", dd.Status.URL)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDashboardSyncStatusOK, dd.Status.SyncStatus)
+				hash, _ := comparison.GenerateMD5ForSpec(dd.Spec)
+				assert.Equal(t, hash, dd.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDashboard exists, needs update",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDashboard())
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					dd := &datadoghqv1alpha1.DatadogDashboard{}
+					_ = c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd)
+					dd.Spec.Title = "Updated dashboard"
+					_ = c.Update(context.TODO(), dd)
+				},
+				secondReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dd := &datadoghqv1alpha1.DatadogDashboard{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd); err != nil {
+					return err
+				}
+				// Make sure status hash is up to date
+				hash, _ := comparison.GenerateMD5ForSpec(dd.Spec)
+				assert.Equal(t, hash, dd.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDashboard exists, needs delete",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					err := c.Create(context.TODO(), testDatadogDashboard())
+					assert.NoError(t, err)
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					err := c.Delete(context.TODO(), testDatadogDashboard())
+					assert.NoError(t, err)
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantErr:    true,
+			wantFunc: func(c client.Client) error {
+				dd := &datadoghqv1alpha1.DatadogDashboard{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			name: "DatadogDashboard with an invalid spec",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dd := testDatadogDashboard()
+					dd.Spec.LayoutType = ""
+					_ = c.Create(context.TODO(), dd)
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dd := &datadoghqv1alpha1.DatadogDashboard{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dd); err != nil {
+					return err
+				}
+				assert.Equal(t, "", dd.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDashboardConditionTypeError, dd.Status.Conditions[0].Type)
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonDashboard, _ := json.Marshal(genericDashboard(dashboardID))
+			httpServer := newTestServer(jsonDashboard)
+			defer httpServer.Close()
+
+			client, testAuth := setupTestClient(httpServer)
+
+			// Set up
+			r := &Reconciler{
+				client:        fake.NewFakeClient(),
+				datadogClient: client,
+				datadogAuth:   testAuth,
+				scheme:        s,
+				recorder:      recorder,
+				log:           logf.Log.WithName(tt.name),
+			}
+
+			// First dashboard action
+			if tt.args.firstAction != nil {
+				tt.args.firstAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.firstReconcileCount == 0 {
+					tt.args.firstReconcileCount = 1
+				}
+			}
+			var result ctrl.Result
+			var err error
+			for i := 0; i < tt.args.firstReconcileCount; i++ {
+				result, err = r.Reconcile(context.TODO(), tt.args.request)
+			}
+
+			assert.NoError(t, err, "ReconcileDatadogDashboard.Reconcile() unexpected error: %v", err)
+			assert.Equal(t, tt.wantResult, result, "ReconcileDatadogDashboard.Reconcile() unexpected result")
+
+			// Second dashboard action
+			if tt.args.secondAction != nil {
+				tt.args.secondAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.secondReconcileCount == 0 {
+					tt.args.secondReconcileCount = 1
+				}
+			}
+			for i := 0; i < tt.args.secondReconcileCount; i++ {
+				_, err := r.Reconcile(context.TODO(), tt.args.request)
+				assert.NoError(t, err, "ReconcileDatadogDashboard.Reconcile() unexpected error: %v", err)
+			}
+
+			if tt.wantFunc != nil {
+				err := tt.wantFunc(r.client)
+				if tt.wantErr {
+					assert.Error(t, err, "ReconcileDatadogDashboard.Reconcile() expected an error")
+				} else {
+					assert.NoError(t, err, "ReconcileDatadogDashboard.Reconcile() wantFunc validation error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func Test_isDrifted(t *testing.T) {
+	lastModified := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	lastModifiedMeta := metav1.NewTime(lastModified)
+
+	tests := []struct {
+		name       string
+		modifiedAt *time.Time
+		status     *datadoghqv1alpha1.DatadogDashboardStatus
+		want       bool
+	}{
+		{
+			name:       "never synced",
+			modifiedAt: &lastModified,
+			status:     &datadoghqv1alpha1.DatadogDashboardStatus{},
+			want:       false,
+		},
+		{
+			name:   "no modification time returned by Datadog",
+			status: &datadoghqv1alpha1.DatadogDashboardStatus{DashboardLastModifiedTime: &lastModifiedMeta},
+			want:   false,
+		},
+		{
+			name:       "same modification time, sub-second precision is ignored",
+			modifiedAt: timePtr(lastModified.Add(300 * time.Millisecond)),
+			status:     &datadoghqv1alpha1.DatadogDashboardStatus{DashboardLastModifiedTime: &lastModifiedMeta},
+			want:       false,
+		},
+		{
+			name:       "modified in Datadog after the last sync",
+			modifiedAt: timePtr(lastModified.Add(time.Minute)),
+			status:     &datadoghqv1alpha1.DatadogDashboardStatus{DashboardLastModifiedTime: &lastModifiedMeta},
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := datadogapiclientv1.Dashboard{ModifiedAt: tt.modifiedAt}
+			assert.Equal(t, tt.want, isDrifted(d, tt.status))
+		})
+	}
+}
+
+func Test_handleFinalizer(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogDashboard{})
+	metaNow := metav1.NewTime(time.Now())
+
+	r := &Reconciler{
+		client: fake.NewFakeClient(),
+		scheme: s,
+		log:    logf.Log.WithName("Test_handleFinalizer"),
+	}
+
+	testCases := []struct {
+		name                 string
+		dd                   *datadoghqv1alpha1.DatadogDashboard
+		finalizerShouldExist bool
+	}{
+		{
+			name: "a new DatadogDashboard object gets a finalizer added successfully",
+			dd: &datadoghqv1alpha1.DatadogDashboard{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-dashboard",
+				},
+			},
+			finalizerShouldExist: true,
+		},
+		{
+			name: "a DatadogDashboard object without ID has a deletion timestamp",
+			dd: &datadoghqv1alpha1.DatadogDashboard{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-dashboard-deleted",
+					DeletionTimestamp: &metaNow,
+					Finalizers:        []string{datadogDashboardFinalizer},
+				},
+			},
+			finalizerShouldExist: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_ = r.client.Create(context.TODO(), test.dd)
+			_, err := r.handleFinalizer(r.log, test.dd)
+			assert.NoError(t, err)
+			assert.Equal(t, test.finalizerShouldExist, utils.ContainsString(test.dd.GetFinalizers(), datadogDashboardFinalizer))
+		})
+	}
+}
+
+func newRequest(ns, name string) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+func testDatadogDashboard() *datadoghqv1alpha1.DatadogDashboard {
+	dd := genericDatadogDashboard()
+	dd.ObjectMeta = metav1.ObjectMeta{
+		Namespace: resourcesNamespace,
+		Name:      resourcesName,
+	}
+
+	return dd
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,139 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func buildDashboard(dd *datadoghqv1alpha1.DatadogDashboard) (*datadogapiclientv1.Dashboard, error) {
+	widgets := []datadogapiclientv1.Widget{}
+	if dd.Spec.Widgets != "" {
+		if err := json.Unmarshal([]byte(dd.Spec.Widgets), &widgets); err != nil {
+			return nil, fmt.Errorf("error parsing dashboard widgets: %w", err)
+		}
+	}
+
+	d := datadogapiclientv1.NewDashboard(datadogapiclientv1.DashboardLayoutType(dd.Spec.LayoutType), dd.Spec.Title, widgets)
+	d.SetDescription(buildDescription(dd.Spec.Description))
+
+	if dd.Spec.ReflowType != nil {
+		d.SetReflowType(datadogapiclientv1.DashboardReflowType(*dd.Spec.ReflowType))
+	}
+
+	if len(dd.Spec.NotifyList) > 0 {
+		d.SetNotifyList(dd.Spec.NotifyList)
+	}
+
+	if len(dd.Spec.RestrictedRoles) > 0 {
+		d.SetRestrictedRoles(dd.Spec.RestrictedRoles)
+	}
+
+	if len(dd.Spec.TemplateVariables) > 0 {
+		templateVariables := make([]datadogapiclientv1.DashboardTemplateVariable, 0, len(dd.Spec.TemplateVariables))
+		for _, tv := range dd.Spec.TemplateVariables {
+			templateVariable := datadogapiclientv1.NewDashboardTemplateVariable(tv.Name)
+			if tv.Prefix != nil {
+				templateVariable.SetPrefix(*tv.Prefix)
+			}
+			if tv.Default != nil {
+				templateVariable.SetDefault(*tv.Default)
+			}
+			if len(tv.AvailableValues) > 0 {
+				templateVariable.SetAvailableValues(tv.AvailableValues)
+			}
+			templateVariables = append(templateVariables, *templateVariable)
+		}
+		d.SetTemplateVariables(templateVariables)
+	}
+
+	if len(dd.Spec.TemplateVariablePresets) > 0 {
+		presets := make([]datadogapiclientv1.DashboardTemplateVariablePreset, 0, len(dd.Spec.TemplateVariablePresets))
+		for _, p := range dd.Spec.TemplateVariablePresets {
+			preset := datadogapiclientv1.NewDashboardTemplateVariablePreset()
+			preset.SetName(p.Name)
+			values := make([]datadogapiclientv1.DashboardTemplateVariablePresetValue, 0, len(p.TemplateVariables))
+			for _, v := range p.TemplateVariables {
+				value := datadogapiclientv1.NewDashboardTemplateVariablePresetValue()
+				value.SetName(v.Name)
+				value.SetValue(v.Value)
+				values = append(values, *value)
+			}
+			preset.SetTemplateVariables(values)
+			presets = append(presets, *preset)
+		}
+		d.SetTemplateVariablePresets(presets)
+	}
+
+	return d, nil
+}
+
+// buildDescription appends the required tags to the dashboard description. Dashboards do not
+// support tags in the Datadog API, so the description is used to find dashboards managed from Kubernetes.
+func buildDescription(description string) string {
+	requiredTags := strings.Join(getRequiredTags(), " ")
+	if description == "" {
+		return requiredTags
+	}
+
+	return fmt.Sprintf("%s\n\n%s", description, requiredTags)
+}
+
+func getRequiredTags() []string {
+	return []string{"generated:kubernetes"}
+}
+
+func getDashboard(auth context.Context, client *datadogapiclientv1.APIClient, dashboardID string) (datadogapiclientv1.Dashboard, error) {
+	d, _, err := client.DashboardsApi.GetDashboard(auth, dashboardID)
+	if err != nil {
+		return datadogapiclientv1.Dashboard{}, datadogclient.TranslateClientError(err, "error getting dashboard")
+	}
+
+	return d, nil
+}
+
+func createDashboard(auth context.Context, client *datadogapiclientv1.APIClient, dd *datadoghqv1alpha1.DatadogDashboard) (datadogapiclientv1.Dashboard, error) {
+	d, err := buildDashboard(dd)
+	if err != nil {
+		return datadogapiclientv1.Dashboard{}, err
+	}
+
+	dCreated, _, err := client.DashboardsApi.CreateDashboard(auth, *d)
+	if err != nil {
+		return datadogapiclientv1.Dashboard{}, datadogclient.TranslateClientError(err, "error creating dashboard")
+	}
+
+	return dCreated, nil
+}
+
+func updateDashboard(auth context.Context, client *datadogapiclientv1.APIClient, dd *datadoghqv1alpha1.DatadogDashboard) (datadogapiclientv1.Dashboard, error) {
+	d, err := buildDashboard(dd)
+	if err != nil {
+		return datadogapiclientv1.Dashboard{}, err
+	}
+
+	dUpdated, _, err := client.DashboardsApi.UpdateDashboard(auth, dd.Status.ID, *d)
+	if err != nil {
+		return datadogapiclientv1.Dashboard{}, datadogclient.TranslateClientError(err, "error updating dashboard")
+	}
+
+	return dUpdated, nil
+}
+
+func deleteDashboard(auth context.Context, client *datadogapiclientv1.APIClient, dashboardID string) error {
+	if _, _, err := client.DashboardsApi.DeleteDashboard(auth, dashboardID); err != nil {
+		return datadogclient.TranslateClientError(err, "error deleting dashboard")
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const testWidgets = `[{"definition": {"type": "note", "content": "hello"}}, {"definition": {"type": "timeseries", "requests": [{"q": "avg:system.cpu.user{*}"}]}}]`
+
+func Test_buildDashboard(t *testing.T) {
+	prefix := "env"
+	defaultEnv := "prod"
+	reflow := datadoghqv1alpha1.DatadogDashboardReflowTypeAuto
+
+	dd := &datadoghqv1alpha1.DatadogDashboard{
+		Spec: datadoghqv1alpha1.DatadogDashboardSpec{
+			Title:           "Test dashboard",
+			Description:     "A dashboard managed by the operator",
+			LayoutType:      datadoghqv1alpha1.DatadogDashboardLayoutTypeOrdered,
+			ReflowType:      &reflow,
+			NotifyList:      []string{"test@example.com"},
+			RestrictedRoles: []string{"role-id"},
+			Widgets:         testWidgets,
+			TemplateVariables: []datadoghqv1alpha1.DatadogDashboardTemplateVariable{
+				{
+					Name:            "env",
+					Prefix:          &prefix,
+					Default:         &defaultEnv,
+					AvailableValues: []string{"prod", "staging"},
+				},
+			},
+			TemplateVariablePresets: []datadoghqv1alpha1.DatadogDashboardTemplateVariablePreset{
+				{
+					Name: "staging",
+					TemplateVariables: []datadoghqv1alpha1.DatadogDashboardTemplateVariablePresetValue{
+						{Name: "env", Value: "staging"},
+					},
+				},
+			},
+		},
+	}
+
+	dashboard, err := buildDashboard(dd)
+	assert.NoError(t, err)
+
+	assert.Equal(t, dd.Spec.Title, dashboard.GetTitle(), "discrepancy found in parameter: Title")
+	assert.Equal(t, "A dashboard managed by the operator\n\ngenerated:kubernetes", dashboard.GetDescription(), "discrepancy found in parameter: Description")
+	assert.Equal(t, string(dd.Spec.LayoutType), string(dashboard.GetLayoutType()), "discrepancy found in parameter: LayoutType")
+	assert.Equal(t, string(*dd.Spec.ReflowType), string(dashboard.GetReflowType()), "discrepancy found in parameter: ReflowType")
+	assert.Equal(t, dd.Spec.NotifyList, dashboard.GetNotifyList(), "discrepancy found in parameter: NotifyList")
+	assert.Equal(t, dd.Spec.RestrictedRoles, dashboard.GetRestrictedRoles(), "discrepancy found in parameter: RestrictedRoles")
+	assert.Len(t, dashboard.GetWidgets(), 2, "discrepancy found in parameter: Widgets")
+
+	templateVariables := dashboard.GetTemplateVariables()
+	assert.Len(t, templateVariables, 1)
+	assert.Equal(t, "env", templateVariables[0].GetName(), "discrepancy found in parameter: TemplateVariables.Name")
+	assert.Equal(t, prefix, templateVariables[0].GetPrefix(), "discrepancy found in parameter: TemplateVariables.Prefix")
+	assert.Equal(t, defaultEnv, templateVariables[0].GetDefault(), "discrepancy found in parameter: TemplateVariables.Default")
+	assert.Equal(t, []string{"prod", "staging"}, templateVariables[0].GetAvailableValues(), "discrepancy found in parameter: TemplateVariables.AvailableValues")
+
+	presets := dashboard.GetTemplateVariablePresets()
+	assert.Len(t, presets, 1)
+	assert.Equal(t, "staging", presets[0].GetName(), "discrepancy found in parameter: TemplateVariablePresets.Name")
+	assert.Equal(t, "staging", presets[0].GetTemplateVariables()[0].GetValue(), "discrepancy found in parameter: TemplateVariablePresets.TemplateVariables")
+
+	// Invalid widgets
+	dd.Spec.Widgets = "{"
+	_, err = buildDashboard(dd)
+	assert.Error(t, err)
+}
+
+func Test_buildDescription(t *testing.T) {
+	assert.Equal(t, "generated:kubernetes", buildDescription(""))
+	assert.Equal(t, "foo\n\ngenerated:kubernetes", buildDescription("foo"))
+}
+
+func Test_getDashboard(t *testing.T) {
+	expectedDashboard := genericDashboard("abc-def-ghi")
+	jsonDashboard, _ := json.Marshal(expectedDashboard)
+	httpServer := newTestServer(jsonDashboard)
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	val, err := getDashboard(testAuth, client, "abc-def-ghi")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedDashboard.GetId(), val.GetId())
+	assert.Equal(t, expectedDashboard.GetTitle(), val.GetTitle())
+	assert.Equal(t, expectedDashboard.GetUrl(), val.GetUrl())
+}
+
+func Test_createDashboard(t *testing.T) {
+	expectedDashboard := genericDashboard("abc-def-ghi")
+	jsonDashboard, _ := json.Marshal(expectedDashboard)
+	httpServer := newTestServer(jsonDashboard)
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	dashboard, err := createDashboard(testAuth, client, genericDatadogDashboard())
+	assert.Nil(t, err)
+	assert.Equal(t, "abc-def-ghi", dashboard.GetId(), "discrepancy found in parameter: Id")
+	assert.Equal(t, genericDatadogDashboard().Spec.Title, dashboard.GetTitle(), "discrepancy found in parameter: Title")
+}
+
+func Test_updateDashboard(t *testing.T) {
+	expectedDashboard := genericDashboard("abc-def-ghi")
+	jsonDashboard, _ := json.Marshal(expectedDashboard)
+	httpServer := newTestServer(jsonDashboard)
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	dd := genericDatadogDashboard()
+	dd.Status.ID = "abc-def-ghi"
+	dashboard, err := updateDashboard(testAuth, client, dd)
+	assert.Nil(t, err)
+	assert.Equal(t, dd.Spec.Title, dashboard.GetTitle(), "discrepancy found in parameter: Title")
+}
+
+func Test_deleteDashboard(t *testing.T) {
+	httpServer := newTestServer([]byte(`{"deleted_dashboard_id": "abc-def-ghi"}`))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	err := deleteDashboard(testAuth, client, "abc-def-ghi")
+	assert.Nil(t, err)
+}
+
+func genericDashboard(id string) datadogapiclientv1.Dashboard {
+	now := time.Now().UTC().Truncate(time.Second)
+	d := datadogapiclientv1.NewDashboard(datadogapiclientv1.DASHBOARDLAYOUTTYPE_ORDERED, "Test dashboard", []datadogapiclientv1.Widget{})
+	d.SetId(id)
+	d.SetUrl("/dashboard/" + id + "/test-dashboard")
+	d.SetAuthorHandle("test_user")
+	d.SetCreatedAt(now)
+	d.SetModifiedAt(now)
+
+	return *d
+}
+
+func genericDatadogDashboard() *datadoghqv1alpha1.DatadogDashboard {
+	return &datadoghqv1alpha1.DatadogDashboard{
+		Spec: datadoghqv1alpha1.DatadogDashboardSpec{
+			Title:      "Test dashboard",
+			LayoutType: datadoghqv1alpha1.DatadogDashboardLayoutTypeOrdered,
+			Widgets:    testWidgets,
+		},
+	}
+}
+
+func newTestServer(body []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+}
+
+func setupTestClient(httpServer *httptest.Server) (*datadogapiclientv1.APIClient, context.Context) {
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = httpServer.Client()
+	client := datadogapiclientv1.NewAPIClient(testConfig)
+
+	testAuth := context.WithValue(
+		context.Background(),
+		datadogapiclientv1.ContextAPIKeys,
+		map[string]datadogapiclientv1.APIKey{
+			"apiKeyAuth": {
+				Key: "DUMMY_API_KEY",
+			},
+			"appKeyAuth": {
+				Key: "DUMMY_APP_KEY",
+			},
+		},
+	)
+	parsedAPIURL, _ := url.Parse(httpServer.URL)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerIndex, 1)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerVariables, map[string]string{
+		"name":     parsedAPIURL.Host,
+		"protocol": parsedAPIURL.Scheme,
+	})
+
+	return client, testAuth
+}
@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdashboard
+
+import (
+	"context"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+)
+
+const (
+	datadogDashboardFinalizer = "finalizer.dashboard.datadoghq.com"
+)
+
+func (r *Reconciler) handleFinalizer(logger logr.Logger, dd *datadoghqv1alpha1.DatadogDashboard) (ctrl.Result, error) {
+	// Check if the DatadogDashboard instance is marked to be deleted, which is indicated by the deletion timestamp being set.
+	if dd.GetDeletionTimestamp() != nil {
+		if utils.ContainsString(dd.GetFinalizers(), datadogDashboardFinalizer) {
+			r.finalizeDatadogDashboard(logger, dd)
+
+			dd.SetFinalizers(utils.RemoveString(dd.GetFinalizers(), datadogDashboardFinalizer))
+			err := r.client.Update(context.TODO(), dd)
+			if err != nil {
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, err
+			}
+		}
+
+		// Requeue until the object was properly deleted by Kuberentes
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	// Add finalizer for this resource if it doesn't already exist.
+	if !utils.ContainsString(dd.GetFinalizers(), datadogDashboardFinalizer) {
+		if err := r.addFinalizer(logger, dd); err != nil {
+			return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	// Proceed in reconcile loop.
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) finalizeDatadogDashboard(logger logr.Logger, dd *datadoghqv1alpha1.DatadogDashboard) {
+	if dd.Status.ID == "" {
+		return
+	}
+
+	if err := deleteDashboard(r.datadogAuth, r.datadogClient, dd.Status.ID); err != nil {
+		logger.Error(err, "failed to finalize dashboard", "Dashboard ID", dd.Status.ID)
+
+		return
+	}
+	logger.Info("Successfully finalized DatadogDashboard", "Dashboard ID", dd.Status.ID)
+	event := buildEventInfo(dd.Name, dd.Namespace, datadog.DeletionEvent)
+	r.recordEvent(dd, event)
+}
+
+func (r *Reconciler) addFinalizer(logger logr.Logger, dd *datadoghqv1alpha1.DatadogDashboard) error {
+	logger.Info("Adding Finalizer for the DatadogDashboard")
+
+	dd.SetFinalizers(append(dd.GetFinalizers(), datadogDashboardFinalizer))
+
+	err := r.client.Update(context.TODO(), dd)
+	if err != nil {
+		logger.Error(err, "failed to update DatadogDashboard with finalizer", "Dashboard ID", dd.Status.ID)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	ctrUtils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	defaultRequeuePeriod    = 60 * time.Second
+	defaultErrRequeuePeriod = 5 * time.Second
+)
+
+// Reconciler reconciles a DatadogDowntime object
+type Reconciler struct {
+	client        client.Client
+	datadogClient *datadogapiclientv1.APIClient
+	datadogAuth   context.Context
+	versionInfo   *version.Info
+	log           logr.Logger
+	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(client client.Client, ddClient datadogclient.DatadogClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	return &Reconciler{
+		client:        client,
+		datadogClient: ddClient.Client,
+		datadogAuth:   ddClient.Auth,
+		versionInfo:   versionInfo,
+		scheme:        scheme,
+		log:           log,
+		recorder:      recorder,
+	}, nil
+}
+
+// Reconcile is similar to reconciler.Reconcile interface, but taking a context
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, request)
+}
+
+// Reconcile loop for DatadogDowntime
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogdowntime", req.NamespacedName)
+	logger.Info("Reconciling DatadogDowntime")
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &datadoghqv1alpha1.DatadogDowntime{}
+	var result ctrl.Result
+	err := r.client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return result, nil
+		}
+		// Error reading the object - requeue the request
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	newStatus := instance.Status.DeepCopy()
+
+	if result, err = r.handleFinalizer(logger, instance); ctrUtils.ShouldReturn(result, err) {
+		return result, err
+	}
+
+	// Validate the DatadogDowntime spec
+	if err = datadoghqv1alpha1.IsValidDatadogDowntime(&instance.Spec); err != nil {
+		logger.Error(err, "invalid DatadogDowntime spec")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Resolve the DatadogMonitor reference. The downtime is not created or updated until the referenced
+	// monitor exists in Datadog; the controller is notified when its status changes.
+	monitorID, err := r.resolveMonitorID(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error resolving monitor reference")
+		newStatus.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusMonitorRefError
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, ctrl.Result{RequeueAfter: defaultRequeuePeriod})
+	}
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	if err != nil {
+		logger.Error(err, "error generating hash")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Create or update downtime, or sync its state. Fall through this block (without returning)
+	// if the result should be requeued with the default period
+	if instance.Status.ID == 0 {
+		logger.V(1).Info("Downtime ID is not set; creating downtime in Datadog")
+		if err = r.create(logger, instance, newStatus, monitorID, now); err != nil {
+			logger.Error(err, "error creating downtime")
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else if instanceSpecHash != instance.Status.CurrentHash || monitorID != instance.Status.MonitorID {
+		if err = r.update(logger, instance, newStatus, monitorID, now); err != nil {
+			logger.Error(err, "error updating downtime", "Downtime ID", instance.Status.ID)
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else {
+		// Spec has not changed, just check if the downtime is active.
+		// We only do it every defaultRequeuePeriod to avoid overloading APIServer and DD
+		if instance.Status.DowntimeLastSyncTime != nil {
+			nextUpdateIn := defaultRequeuePeriod - now.Sub(instance.Status.DowntimeLastSyncTime.Time)
+			if nextUpdateIn > 0 {
+				return ctrl.Result{RequeueAfter: nextUpdateIn}, nil
+			}
+		}
+
+		if err = r.get(logger, instance, newStatus, now); err != nil {
+			logger.Error(err, "error getting downtime", "Downtime ID", instance.Status.ID)
+		}
+	}
+
+	// Requeue
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result.RequeueAfter = defaultRequeuePeriod
+	}
+
+	// Update the status
+	return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+}
+
+func (r *Reconciler) create(logger logr.Logger, datadogDowntime *datadoghqv1alpha1.DatadogDowntime, status *datadoghqv1alpha1.DatadogDowntimeStatus, monitorID int, now metav1.Time) error {
+	// Create downtime in Datadog
+	d, err := createDowntime(r.datadogAuth, r.datadogClient, datadogDowntime, monitorID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusCreateError
+		return err
+	}
+	event := buildEventInfo(datadogDowntime.Name, datadogDowntime.Namespace, datadog.CreationEvent)
+	r.recordEvent(datadogDowntime, event)
+
+	// As this is a new downtime, add static information to status
+	status.ID = int(d.GetId())
+	status.MonitorID = monitorID
+	status.Active = isActive(d)
+	status.DowntimeLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusOK
+
+	// Set Created Condition
+	condition.UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeCreated, corev1.ConditionTrue, "DatadogDowntime Created")
+	logger.Info("Created a new DatadogDowntime", "Downtime Namespace", datadogDowntime.Namespace, "Downtime Name", datadogDowntime.Name, "Downtime ID", d.GetId())
+
+	return nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, datadogDowntime *datadoghqv1alpha1.DatadogDowntime, status *datadoghqv1alpha1.DatadogDowntimeStatus, monitorID int, now metav1.Time) error {
+	// Update downtime in Datadog
+	d, err := updateDowntime(r.datadogAuth, r.datadogClient, datadogDowntime, monitorID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusUpdateError
+		return err
+	}
+
+	event := buildEventInfo(datadogDowntime.Name, datadogDowntime.Namespace, datadog.UpdateEvent)
+	r.recordEvent(datadogDowntime, event)
+
+	status.MonitorID = monitorID
+	status.Active = isActive(d)
+	status.DowntimeLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusOK
+
+	// Set Updated Condition
+	condition.UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeUpdated, corev1.ConditionTrue, "DatadogDowntime Updated")
+	logger.Info("Updated DatadogDowntime", "Downtime Namespace", datadogDowntime.Namespace, "Downtime Name", datadogDowntime.Name, "Downtime ID", datadogDowntime.Status.ID)
+
+	return nil
+}
+
+func (r *Reconciler) get(logger logr.Logger, datadogDowntime *datadoghqv1alpha1.DatadogDowntime, status *datadoghqv1alpha1.DatadogDowntimeStatus, now metav1.Time) error {
+	d, err := getDowntime(r.datadogAuth, r.datadogClient, datadogDowntime.Status.ID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusGetError
+		return err
+	}
+
+	status.Active = isActive(d)
+	status.DowntimeLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogDowntimeSyncStatusOK
+	logger.V(1).Info("Synced DatadogDowntime state", "Downtime Namespace", datadogDowntime.Namespace, "Downtime Name", datadogDowntime.Name, "Downtime ID", datadogDowntime.Status.ID)
+
+	return nil
+}
+
+// resolveMonitorID returns the ID of the DatadogMonitor in Spec.MonitorRef, or 0 if the downtime does not reference
+// a DatadogMonitor. It returns an error if the referenced DatadogMonitor does not exist or has no ID yet.
+func (r *Reconciler) resolveMonitorID(ctx context.Context, datadogDowntime *datadoghqv1alpha1.DatadogDowntime) (int, error) {
+	ref := datadogDowntime.Spec.MonitorRef
+	if ref == nil {
+		return 0, nil
+	}
+
+	dm := &datadoghqv1alpha1.DatadogMonitor{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: datadogDowntime.Namespace, Name: ref.Name}, dm); err != nil {
+		return 0, fmt.Errorf("unable to get DatadogMonitor %s/%s: %w", datadogDowntime.Namespace, ref.Name, err)
+	}
+	if dm.Status.ID == 0 {
+		return 0, fmt.Errorf("DatadogMonitor %s/%s has not been created in Datadog yet", datadogDowntime.Namespace, ref.Name)
+	}
+
+	return dm.Status.ID, nil
+}
+
+// RequestsForDatadogMonitor returns the reconcile requests of the DatadogDowntimes referencing a DatadogMonitor,
+// so that downtimes are created or updated when the monitor ID becomes available or changes
+func (r *Reconciler) RequestsForDatadogMonitor(obj client.Object) []reconcile.Request {
+	downtimeList := &datadoghqv1alpha1.DatadogDowntimeList{}
+	if err := r.client.List(context.TODO(), downtimeList, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list DatadogDowntimes", "namespace", obj.GetNamespace())
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, dt := range downtimeList.Items {
+		if dt.Spec.MonitorRef != nil && dt.Spec.MonitorRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dt.Namespace, Name: dt.Name}})
+		}
+	}
+
+	return requests
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogDowntime *datadoghqv1alpha1.DatadogDowntime, now metav1.Time, status *datadoghqv1alpha1.DatadogDowntimeStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
+	// Update Error and Active conditions
+	condition.SetDatadogDowntimeErrorActiveConditions(status, now, currentErr)
+
+	if !apiequality.Semantic.DeepEqual(&datadogDowntime.Status, status) {
+		datadogDowntime.Status = *status
+		if err := r.client.Status().Update(context.TODO(), datadogDowntime); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogDowntime status due to update conflict")
+
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogDowntime status")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,356 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+)
+
+const (
+	resourcesName      = "foo"
+	resourcesNamespace = "bar"
+	downtimeID         = 12
+)
+
+func TestReconcileDatadogDowntime_Reconcile(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconcileDatadogDowntime_Reconcile"})
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogDowntime{}, &datadoghqv1alpha1.DatadogDowntimeList{}, &datadoghqv1alpha1.DatadogMonitor{})
+
+	type args struct {
+		request              reconcile.Request
+		firstAction          func(c client.Client)
+		firstReconcileCount  int
+		secondAction         func(c client.Client)
+		secondReconcileCount int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantResult reconcile.Result
+		wantErr    bool
+		wantFunc   func(c client.Client) error
+	}{
+		{
+			name: "DatadogDowntime not created",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+			},
+			wantResult: reconcile.Result{},
+		},
+		{
+			name: "DatadogDowntime created, add finalizer",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDowntime())
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				assert.Contains(t, dt.GetFinalizers(), "finalizer.downtime.datadoghq.com")
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime created, check status",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDowntime())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				assert.Equal(t, downtimeID, dt.Status.ID)
+				assert.True(t, dt.Status.Active)
+				assert.NotNil(t, dt.Status.DowntimeLastSyncTime)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeSyncStatusOK, dt.Status.SyncStatus)
+				hash, _ := comparison.GenerateMD5ForSpec(dt.Spec)
+				assert.Equal(t, hash, dt.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime exists, needs update",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogDowntime())
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					dt := &datadoghqv1alpha1.DatadogDowntime{}
+					_ = c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt)
+					dt.Spec.Scope = []string{"env:staging"}
+					_ = c.Update(context.TODO(), dt)
+				},
+				secondReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				// Make sure status hash is up to date
+				hash, _ := comparison.GenerateMD5ForSpec(dt.Spec)
+				assert.Equal(t, hash, dt.Status.CurrentHash)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeConditionTypeUpdated, dt.Status.Conditions[len(dt.Status.Conditions)-1].Type)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime exists, needs delete",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					err := c.Create(context.TODO(), testDatadogDowntime())
+					assert.NoError(t, err)
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					err := c.Delete(context.TODO(), testDatadogDowntime())
+					assert.NoError(t, err)
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantErr:    true,
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime references a DatadogMonitor without ID",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testMonitor(0))
+					_ = c.Create(context.TODO(), testMonitorDatadogDowntime())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dt.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeSyncStatusMonitorRefError, dt.Status.SyncStatus)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeConditionTypeError, dt.Status.Conditions[0].Type)
+				assert.Equal(t, "DatadogMonitor bar/foo-monitor has not been created in Datadog yet", dt.Status.Conditions[0].Message)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime references a DatadogMonitor, created once the monitor has an ID",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testMonitor(0))
+					_ = c.Create(context.TODO(), testMonitorDatadogDowntime())
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					dm := &datadoghqv1alpha1.DatadogMonitor{}
+					_ = c.Get(context.TODO(), types.NamespacedName{Name: "foo-monitor", Namespace: resourcesNamespace}, dm)
+					dm.Status.ID = 456
+					_ = c.Status().Update(context.TODO(), dm)
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				assert.Equal(t, downtimeID, dt.Status.ID)
+				assert.Equal(t, 456, dt.Status.MonitorID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeSyncStatusOK, dt.Status.SyncStatus)
+				return nil
+			},
+		},
+		{
+			name: "DatadogDowntime with an invalid spec",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dt := testDatadogDowntime()
+					dt.Spec.End, dt.Spec.Start = dt.Spec.Start, dt.Spec.End
+					_ = c.Create(context.TODO(), dt)
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dt := &datadoghqv1alpha1.DatadogDowntime{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dt); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dt.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogDowntimeConditionTypeError, dt.Status.Conditions[0].Type)
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := newTestServer(genericDowntime(downtimeID, true))
+			defer httpServer.Close()
+
+			client, testAuth := setupTestClient(httpServer)
+
+			// Set up
+			r := &Reconciler{
+				client:        fake.NewFakeClient(),
+				datadogClient: client,
+				datadogAuth:   testAuth,
+				scheme:        s,
+				recorder:      recorder,
+				log:           logf.Log.WithName(tt.name),
+			}
+
+			// First downtime action
+			if tt.args.firstAction != nil {
+				tt.args.firstAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.firstReconcileCount == 0 {
+					tt.args.firstReconcileCount = 1
+				}
+			}
+			var result ctrl.Result
+			var err error
+			for i := 0; i < tt.args.firstReconcileCount; i++ {
+				result, err = r.Reconcile(context.TODO(), tt.args.request)
+			}
+
+			assert.NoError(t, err, "ReconcileDatadogDowntime.Reconcile() unexpected error: %v", err)
+			assert.Equal(t, tt.wantResult, result, "ReconcileDatadogDowntime.Reconcile() unexpected result")
+
+			// Second downtime action
+			if tt.args.secondAction != nil {
+				tt.args.secondAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.secondReconcileCount == 0 {
+					tt.args.secondReconcileCount = 1
+				}
+			}
+			for i := 0; i < tt.args.secondReconcileCount; i++ {
+				_, err := r.Reconcile(context.TODO(), tt.args.request)
+				assert.NoError(t, err, "ReconcileDatadogDowntime.Reconcile() unexpected error: %v", err)
+			}
+
+			if tt.wantFunc != nil {
+				err := tt.wantFunc(r.client)
+				if tt.wantErr {
+					assert.Error(t, err, "ReconcileDatadogDowntime.Reconcile() expected an error")
+				} else {
+					assert.NoError(t, err, "ReconcileDatadogDowntime.Reconcile() wantFunc validation error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestReconciler_RequestsForDatadogMonitor(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogDowntime{}, &datadoghqv1alpha1.DatadogDowntimeList{}, &datadoghqv1alpha1.DatadogMonitor{})
+
+	referencing := testMonitorDatadogDowntime()
+	notReferencing := testMonitorDatadogDowntime()
+	notReferencing.Name = "other"
+	notReferencing.Spec.MonitorRef = &datadoghqv1alpha1.DatadogDowntimeMonitorReference{Name: "other-monitor"}
+	noReference := testDatadogDowntime()
+	noReference.Name = "no-ref"
+	otherNamespace := testMonitorDatadogDowntime()
+	otherNamespace.Namespace = "other"
+
+	r := &Reconciler{
+		client: fake.NewFakeClient(referencing, notReferencing, noReference, otherNamespace),
+		scheme: s,
+		log:    logf.Log.WithName("TestReconciler_RequestsForDatadogMonitor"),
+	}
+
+	requests := r.RequestsForDatadogMonitor(testMonitor(456))
+	assert.Equal(t, []reconcile.Request{newRequest(resourcesNamespace, resourcesName)}, requests)
+}
+
+func newRequest(ns, name string) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+func testDatadogDowntime() *datadoghqv1alpha1.DatadogDowntime {
+	dt := genericDatadogDowntime()
+	dt.ObjectMeta = metav1.ObjectMeta{
+		Namespace: resourcesNamespace,
+		Name:      resourcesName,
+	}
+
+	return dt
+}
+
+func testMonitorDatadogDowntime() *datadoghqv1alpha1.DatadogDowntime {
+	dt := testDatadogDowntime()
+	dt.Spec.MonitorTags = nil
+	dt.Spec.MonitorRef = &datadoghqv1alpha1.DatadogDowntimeMonitorReference{Name: "foo-monitor"}
+
+	return dt
+}
+
+func testMonitor(id int) *datadoghqv1alpha1.DatadogMonitor {
+	return &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      "foo-monitor",
+		},
+		Status: datadoghqv1alpha1.DatadogMonitorStatus{
+			ID: id,
+		},
+	}
+}
@@ -0,0 +1,134 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+// buildDowntime converts a DatadogDowntime into a Datadog downtime. Unset optional fields are explicitly
+// cleared so that the result can also be used to update an existing downtime.
+func buildDowntime(dt *datadoghqv1alpha1.DatadogDowntime, monitorID int) *datadogapiclientv1.Downtime {
+	d := datadogapiclientv1.NewDowntime()
+
+	if dt.Spec.Message != nil {
+		d.SetMessage(*dt.Spec.Message)
+	}
+
+	if monitorID != 0 {
+		d.SetMonitorId(int64(monitorID))
+	} else {
+		d.SetMonitorIdNil()
+	}
+
+	// `*` is the Datadog default for both fields, and matches all monitors and all scopes
+	monitorTags := []string{"*"}
+	if len(dt.Spec.MonitorTags) > 0 {
+		monitorTags = dt.Spec.MonitorTags
+	}
+	d.SetMonitorTags(monitorTags)
+
+	scope := []string{"*"}
+	if len(dt.Spec.Scope) > 0 {
+		scope = dt.Spec.Scope
+	}
+	d.SetScope(scope)
+
+	if dt.Spec.Start != nil {
+		d.SetStart(dt.Spec.Start.Unix())
+	}
+
+	if dt.Spec.End != nil {
+		d.SetEnd(dt.Spec.End.Unix())
+	} else {
+		d.SetEndNil()
+	}
+
+	if dt.Spec.Timezone != "" {
+		d.SetTimezone(dt.Spec.Timezone)
+	}
+
+	if dt.Spec.Recurrence != nil {
+		d.SetRecurrence(*buildRecurrence(dt.Spec.Recurrence))
+	} else {
+		d.SetRecurrenceNil()
+	}
+
+	return d
+}
+
+func buildRecurrence(spec *datadoghqv1alpha1.DatadogDowntimeRecurrence) *datadogapiclientv1.DowntimeRecurrence {
+	r := datadogapiclientv1.NewDowntimeRecurrence()
+	r.SetType(string(spec.Type))
+
+	if spec.Period != nil {
+		r.SetPeriod(*spec.Period)
+	}
+
+	if len(spec.WeekDays) > 0 {
+		r.SetWeekDays(spec.WeekDays)
+	}
+
+	if spec.RRule != "" {
+		r.SetRrule(spec.RRule)
+	}
+
+	if spec.UntilDate != nil {
+		r.SetUntilDate(spec.UntilDate.Unix())
+	}
+
+	if spec.UntilOccurrences != nil {
+		r.SetUntilOccurrences(*spec.UntilOccurrences)
+	}
+
+	return r
+}
+
+// isActive returns true if the downtime is currently silencing monitors
+func isActive(d datadogapiclientv1.Downtime) bool {
+	return d.GetActive() && !d.GetDisabled()
+}
+
+func getDowntime(auth context.Context, client *datadogapiclientv1.APIClient, downtimeID int) (datadogapiclientv1.Downtime, error) {
+	d, _, err := client.DowntimesApi.GetDowntime(auth, int64(downtimeID))
+	if err != nil {
+		return datadogapiclientv1.Downtime{}, datadogclient.TranslateClientError(err, "error getting downtime")
+	}
+
+	return d, nil
+}
+
+func createDowntime(auth context.Context, client *datadogapiclientv1.APIClient, dt *datadoghqv1alpha1.DatadogDowntime, monitorID int) (datadogapiclientv1.Downtime, error) {
+	d := buildDowntime(dt, monitorID)
+	dCreated, _, err := client.DowntimesApi.CreateDowntime(auth, *d)
+	if err != nil {
+		return datadogapiclientv1.Downtime{}, datadogclient.TranslateClientError(err, "error creating downtime")
+	}
+
+	return dCreated, nil
+}
+
+func updateDowntime(auth context.Context, client *datadogapiclientv1.APIClient, dt *datadoghqv1alpha1.DatadogDowntime, monitorID int) (datadogapiclientv1.Downtime, error) {
+	d := buildDowntime(dt, monitorID)
+	dUpdated, _, err := client.DowntimesApi.UpdateDowntime(auth, int64(dt.Status.ID), *d)
+	if err != nil {
+		return datadogapiclientv1.Downtime{}, datadogclient.TranslateClientError(err, "error updating downtime")
+	}
+
+	return dUpdated, nil
+}
+
+func cancelDowntime(auth context.Context, client *datadogapiclientv1.APIClient, downtimeID int) error {
+	if _, err := client.DowntimesApi.CancelDowntime(auth, int64(downtimeID)); err != nil {
+		return datadogclient.TranslateClientError(err, "error canceling downtime")
+	}
+
+	return nil
+}
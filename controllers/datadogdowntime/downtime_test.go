@@ -0,0 +1,206 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_buildDowntime(t *testing.T) {
+	message := "Scheduled maintenance"
+	start := metav1.NewTime(time.Unix(1633024800, 0))
+	end := metav1.NewTime(time.Unix(1633028400, 0))
+	until := metav1.NewTime(time.Unix(1640995200, 0))
+	period := int32(1)
+
+	dt := &datadoghqv1alpha1.DatadogDowntime{
+		Spec: datadoghqv1alpha1.DatadogDowntimeSpec{
+			Message:     &message,
+			MonitorTags: []string{"team:checkout"},
+			Scope:       []string{"env:prod"},
+			Start:       &start,
+			End:         &end,
+			Timezone:    "Europe/Paris",
+			Recurrence: &datadoghqv1alpha1.DatadogDowntimeRecurrence{
+				Type:      datadoghqv1alpha1.DatadogDowntimeRecurrenceTypeWeeks,
+				Period:    &period,
+				WeekDays:  []string{"Sat", "Sun"},
+				UntilDate: &until,
+			},
+		},
+	}
+
+	d := buildDowntime(dt, 0)
+	assert.Equal(t, message, d.GetMessage())
+	assert.Equal(t, []string{"team:checkout"}, d.GetMonitorTags())
+	assert.Equal(t, []string{"env:prod"}, d.GetScope())
+	assert.Equal(t, int64(1633024800), d.GetStart())
+	assert.Equal(t, int64(1633028400), d.GetEnd())
+	assert.Equal(t, "Europe/Paris", d.GetTimezone())
+	// Unset fields are sent as null so that updates clear them
+	monitorID, isSet := d.GetMonitorIdOk()
+	assert.Nil(t, monitorID)
+	assert.True(t, isSet)
+
+	r := d.GetRecurrence()
+	assert.Equal(t, "weeks", r.GetType())
+	assert.Equal(t, int32(1), r.GetPeriod())
+	assert.Equal(t, []string{"Sat", "Sun"}, r.GetWeekDays())
+	assert.Equal(t, int64(1640995200), r.GetUntilDate())
+	_, hasRRule := r.GetRruleOk()
+	assert.False(t, hasRRule)
+
+	// Downtime on a single monitor, with no end and no recurrence
+	dt.Spec = datadoghqv1alpha1.DatadogDowntimeSpec{
+		MonitorRef: &datadoghqv1alpha1.DatadogDowntimeMonitorReference{Name: "foo-monitor"},
+		Start:      &start,
+	}
+	d = buildDowntime(dt, 123)
+	assert.Equal(t, int64(123), d.GetMonitorId())
+	assert.Equal(t, []string{"*"}, d.GetMonitorTags())
+	assert.Equal(t, []string{"*"}, d.GetScope())
+	dEnd, hasEnd := d.GetEndOk()
+	assert.Nil(t, dEnd)
+	assert.True(t, hasEnd)
+	recurrence, hasRecurrence := d.GetRecurrenceOk()
+	assert.Nil(t, recurrence)
+	assert.True(t, hasRecurrence)
+}
+
+func Test_isActive(t *testing.T) {
+	d := genericDowntime(12, true)
+	assert.True(t, isActive(d))
+
+	d.SetDisabled(true)
+	assert.False(t, isActive(d))
+
+	assert.False(t, isActive(genericDowntime(12, false)))
+}
+
+func Test_createDowntime(t *testing.T) {
+	dt := genericDatadogDowntime()
+
+	httpServer := newTestServer(genericDowntime(12, true))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	d, err := createDowntime(testAuth, client, dt, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), d.GetId())
+	assert.True(t, d.GetActive())
+}
+
+func Test_updateDowntime(t *testing.T) {
+	dt := genericDatadogDowntime()
+	dt.Status.ID = 12
+
+	httpServer := newTestServer(genericDowntime(12, false))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	d, err := updateDowntime(testAuth, client, dt, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), d.GetId())
+	assert.False(t, d.GetActive())
+}
+
+func Test_getDowntime(t *testing.T) {
+	httpServer := newTestServer(genericDowntime(12, true))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	d, err := getDowntime(testAuth, client, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), d.GetId())
+}
+
+func Test_cancelDowntime(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	err := cancelDowntime(testAuth, client, 12)
+	assert.NoError(t, err)
+}
+
+func genericDatadogDowntime() *datadoghqv1alpha1.DatadogDowntime {
+	message := "Scheduled maintenance"
+	start := metav1.NewTime(time.Now())
+	end := metav1.NewTime(start.Add(time.Hour))
+
+	return &datadoghqv1alpha1.DatadogDowntime{
+		Spec: datadoghqv1alpha1.DatadogDowntimeSpec{
+			Message:     &message,
+			MonitorTags: []string{"team:checkout"},
+			Scope:       []string{"env:prod"},
+			Start:       &start,
+			End:         &end,
+		},
+	}
+}
+
+func genericDowntime(id int64, active bool) datadogapiclientv1.Downtime {
+	d := datadogapiclientv1.NewDowntime()
+	d.SetId(id)
+	d.SetActive(active)
+	d.SetDisabled(false)
+
+	return *d
+}
+
+func newTestServer(downtime datadogapiclientv1.Downtime) *httptest.Server {
+	jsonDowntime, _ := json.Marshal(downtime)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jsonDowntime)
+	}))
+}
+
+func setupTestClient(httpServer *httptest.Server) (*datadogapiclientv1.APIClient, context.Context) {
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = httpServer.Client()
+	client := datadogapiclientv1.NewAPIClient(testConfig)
+
+	testAuth := context.WithValue(
+		context.Background(),
+		datadogapiclientv1.ContextAPIKeys,
+		map[string]datadogapiclientv1.APIKey{
+			"apiKeyAuth": {
+				Key: "DUMMY_API_KEY",
+			},
+			"appKeyAuth": {
+				Key: "DUMMY_APP_KEY",
+			},
+		},
+	)
+	parsedAPIURL, _ := url.Parse(httpServer.URL)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerIndex, 1)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerVariables, map[string]string{
+		"name":     parsedAPIURL.Host,
+		"protocol": parsedAPIURL.Scheme,
+	})
+
+	return client, testAuth
+}
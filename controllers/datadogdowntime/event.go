@@ -0,0 +1,26 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogdowntime
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+)
+
+const datadogDowntimeKind = "DatadogDowntime"
+
+// buildEventInfo creates a new EventInfo instance.
+func buildEventInfo(name, ns string, eventType datadog.EventType) utils.EventInfo {
+	return utils.BuildEventInfo(name, ns, datadogDowntimeKind, eventType)
+}
+
+// recordEvent wraps the manager event recorder.
+func (r *Reconciler) recordEvent(dt *datadoghqv1alpha1.DatadogDowntime, info utils.EventInfo) {
+	r.recorder.Event(dt, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
+}
@@ -0,0 +1,145 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// monitorReferenceRegexp matches the references to other DatadogMonitors in the query of a composite monitor,
+// for example `{{ monitor "namespace/name" }}`, or `{{ monitor "name" }}` for a DatadogMonitor in the same namespace
+var monitorReferenceRegexp = regexp.MustCompile(`\{\{\s*monitor\s+"([^"]*)"\s*\}\}`)
+
+// parseMonitorReference returns the DatadogMonitor referenced by `namespace/name` or `name`
+func parseMonitorReference(ref, namespace string) (types.NamespacedName, error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return types.NamespacedName{Namespace: namespace, Name: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return types.NamespacedName{}, fmt.Errorf("invalid monitor reference %q, must be formatted as namespace/name or name", ref)
+	}
+}
+
+// getMonitorReferences returns the DatadogMonitors referenced in the query of a composite monitor
+func getMonitorReferences(dm *datadoghqv1alpha1.DatadogMonitor) ([]types.NamespacedName, error) {
+	if dm.Spec.Type != datadoghqv1alpha1.DatadogMonitorTypeComposite {
+		return nil, nil
+	}
+
+	var refs []types.NamespacedName
+	for _, match := range monitorReferenceRegexp.FindAllStringSubmatch(dm.Spec.Query, -1) {
+		ref, err := parseMonitorReference(match[1], dm.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Namespace == dm.Namespace && ref.Name == dm.Name {
+			return nil, fmt.Errorf("DatadogMonitor %s cannot reference itself", ref)
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
+
+// resolveMonitorReferences returns the DatadogMonitor to send to Datadog: for a composite monitor, a copy whose query
+// has the references to other DatadogMonitors replaced by their monitor IDs. It returns an error if a referenced
+// DatadogMonitor does not exist or has not been created in Datadog yet.
+func (r *Reconciler) resolveMonitorReferences(ctx context.Context, dm *datadoghqv1alpha1.DatadogMonitor) (*datadoghqv1alpha1.DatadogMonitor, error) {
+	refs, err := getMonitorReferences(dm)
+	if err != nil || len(refs) == 0 {
+		return dm, err
+	}
+
+	ids := make(map[types.NamespacedName]int, len(refs))
+	for _, ref := range refs {
+		referenced := &datadoghqv1alpha1.DatadogMonitor{}
+		if err := r.client.Get(ctx, ref, referenced); err != nil {
+			return nil, fmt.Errorf("unable to get DatadogMonitor %s: %w", ref, err)
+		}
+		if referenced.Status.ID == 0 {
+			return nil, fmt.Errorf("DatadogMonitor %s has not been created in Datadog yet", ref)
+		}
+		ids[ref] = referenced.Status.ID
+	}
+
+	resolved := dm.DeepCopy()
+	resolved.Spec.Query = monitorReferenceRegexp.ReplaceAllStringFunc(dm.Spec.Query, func(match string) string {
+		// References were already parsed successfully above
+		ref, _ := parseMonitorReference(monitorReferenceRegexp.FindStringSubmatch(match)[1], dm.Namespace)
+
+		return strconv.Itoa(ids[ref])
+	})
+
+	return resolved, nil
+}
+
+// MonitorReferencesIndexKey is the field index of the DatadogMonitors referenced by a composite DatadogMonitor,
+// formatted as namespace/name
+const MonitorReferencesIndexKey = "spec.query.monitorReferences"
+
+// IndexMonitorReferences returns the DatadogMonitors referenced by a composite DatadogMonitor, to be indexed with MonitorReferencesIndexKey
+func IndexMonitorReferences(obj client.Object) []string {
+	dm, ok := obj.(*datadoghqv1alpha1.DatadogMonitor)
+	if !ok {
+		return nil
+	}
+
+	refs, err := getMonitorReferences(dm)
+	if err != nil {
+		return nil
+	}
+
+	values := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		values = append(values, ref.String())
+	}
+
+	return values
+}
+
+// RequestsForDatadogMonitor returns the reconcile requests of the composite DatadogMonitors referencing a DatadogMonitor,
+// so that they are created or updated when the monitor ID becomes available or changes
+func (r *Reconciler) RequestsForDatadogMonitor(obj client.Object) []reconcile.Request {
+	referenced := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	monitorList := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err := r.client.List(context.TODO(), monitorList, client.MatchingFields{MonitorReferencesIndexKey: referenced.String()}); err != nil {
+		r.log.Error(err, "unable to list DatadogMonitors")
+
+		return nil
+	}
+
+	// The references are checked again, in case the client does not support the field index
+	requests := []reconcile.Request{}
+	for i := range monitorList.Items {
+		dm := &monitorList.Items[i]
+		refs, err := getMonitorReferences(dm)
+		if err != nil {
+			continue
+		}
+		for _, ref := range refs {
+			if ref == referenced {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,164 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_getMonitorReferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		monType  datadoghqv1alpha1.DatadogMonitorType
+		wantRefs []types.NamespacedName
+		wantErr  string
+	}{
+		{
+			name:    "not a composite monitor",
+			query:   `{{ monitor "foo" }}`,
+			monType: datadoghqv1alpha1.DatadogMonitorTypeMetric,
+		},
+		{
+			name:    "composite monitor with IDs only",
+			query:   "123 && 456",
+			monType: datadoghqv1alpha1.DatadogMonitorTypeComposite,
+		},
+		{
+			name:    "composite monitor with references",
+			query:   `{{ monitor "foo-disk" }} && ({{monitor "other/foo-cpu"}} || 789)`,
+			monType: datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			wantRefs: []types.NamespacedName{
+				{Namespace: resourcesNamespace, Name: "foo-disk"},
+				{Namespace: "other", Name: "foo-cpu"},
+			},
+		},
+		{
+			name:    "invalid reference",
+			query:   `{{ monitor "a/b/c" }} && 123`,
+			monType: datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			wantErr: `invalid monitor reference "a/b/c", must be formatted as namespace/name or name`,
+		},
+		{
+			name:    "empty reference",
+			query:   `{{ monitor "" }} && 123`,
+			monType: datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			wantErr: `invalid monitor reference "", must be formatted as namespace/name or name`,
+		},
+		{
+			name:    "self reference",
+			query:   `{{ monitor "bar/foo" }} && 123`,
+			monType: datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			wantErr: "DatadogMonitor bar/foo cannot reference itself",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			dm := testCompositeMonitor()
+			dm.Spec.Type = test.monType
+			dm.Spec.Query = test.query
+
+			refs, err := getMonitorReferences(dm)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantRefs, refs)
+			}
+		})
+	}
+}
+
+func Test_resolveMonitorReferences(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{}, &datadoghqv1alpha1.DatadogMonitorList{})
+
+	r := &Reconciler{
+		client: fake.NewFakeClient(
+			testReferencedMonitor(resourcesNamespace, "foo-disk", 123),
+			testReferencedMonitor("other", "foo-cpu", 456),
+			testReferencedMonitor(resourcesNamespace, "foo-new", 0),
+		),
+		scheme: s,
+		log:    logf.Log.WithName("Test_resolveMonitorReferences"),
+	}
+
+	// Non composite monitors are returned as is
+	dm := testMetricMonitor()
+	resolved, err := r.resolveMonitorReferences(context.TODO(), dm)
+	assert.NoError(t, err)
+	assert.Same(t, dm, resolved)
+
+	// References are replaced by IDs, without modifying the original object
+	dm = testCompositeMonitor()
+	dm.Spec.Query = `{{ monitor "foo-disk" }} && !{{ monitor "other/foo-cpu" }} && {{ monitor "bar/foo-disk" }}`
+	resolved, err = r.resolveMonitorReferences(context.TODO(), dm)
+	assert.NoError(t, err)
+	assert.Equal(t, "123 && !456 && 123", resolved.Spec.Query)
+	assert.Equal(t, `{{ monitor "foo-disk" }} && !{{ monitor "other/foo-cpu" }} && {{ monitor "bar/foo-disk" }}`, dm.Spec.Query)
+
+	// Referenced monitor not created in Datadog yet
+	dm.Spec.Query = `{{ monitor "foo-disk" }} && {{ monitor "foo-new" }}`
+	_, err = r.resolveMonitorReferences(context.TODO(), dm)
+	assert.EqualError(t, err, "DatadogMonitor bar/foo-new has not been created in Datadog yet")
+
+	// Referenced monitor does not exist
+	dm.Spec.Query = `{{ monitor "foo-disk" }} && {{ monitor "missing" }}`
+	_, err = r.resolveMonitorReferences(context.TODO(), dm)
+	assert.Error(t, err)
+}
+
+func TestReconciler_RequestsForDatadogMonitor(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{}, &datadoghqv1alpha1.DatadogMonitorList{})
+
+	referencing := testCompositeMonitor()
+	referencing.Spec.Query = `{{ monitor "other/foo-cpu" }} && 123`
+	notReferencing := testCompositeMonitor()
+	notReferencing.Name = "not-referencing"
+	notReferencing.Spec.Query = `{{ monitor "foo-cpu" }} && 123`
+	otherNamespace := testCompositeMonitor()
+	otherNamespace.Namespace = "other"
+	otherNamespace.Name = "other-composite"
+	otherNamespace.Spec.Query = `{{ monitor "foo-cpu" }} || 123`
+	notComposite := testReferencedMonitor("other", "foo-cpu", 456)
+
+	r := &Reconciler{
+		client: fake.NewFakeClient(referencing, notReferencing, otherNamespace, notComposite),
+		scheme: s,
+		log:    logf.Log.WithName("TestReconciler_RequestsForDatadogMonitor"),
+	}
+
+	requests := r.RequestsForDatadogMonitor(notComposite)
+	assert.ElementsMatch(t, []reconcile.Request{
+		newRequest(resourcesNamespace, resourcesName),
+		newRequest("other", "other-composite"),
+	}, requests)
+}
+
+func TestIndexMonitorReferences(t *testing.T) {
+	dm := testCompositeMonitor()
+	dm.Spec.Query = `{{ monitor "foo-disk" }} && !{{ monitor "other/foo-cpu" }}`
+	assert.Equal(t, []string{"bar/foo-disk", "other/foo-cpu"}, IndexMonitorReferences(dm))
+
+	// Invalid references are not indexed
+	dm.Spec.Query = `{{ monitor "a/b/c" }} && 123`
+	assert.Empty(t, IndexMonitorReferences(dm))
+
+	// Non composite monitors do not reference other monitors
+	assert.Empty(t, IndexMonitorReferences(testMetricMonitor()))
+}
@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -51,30 +53,65 @@ var supportedMonitorTypes = map[string]bool{
 	string(datadogapiclientv1.MONITORTYPE_SLO_ALERT):             true,
 	string(datadogapiclientv1.MONITORTYPE_EVENT_V2_ALERT):        true,
 	string(datadogapiclientv1.MONITORTYPE_AUDIT_ALERT):           true,
+	string(datadogapiclientv1.MONITORTYPE_COMPOSITE):             true,
+}
+
+// ReconcilerOptions provides options read from command line
+type ReconcilerOptions struct {
+	// AllowCrossNamespaceCredentials allows DatadogMonitors to reference credentials Secrets of other namespaces
+	AllowCrossNamespaceCredentials bool
+	// MonitorPollInterval is the interval at which the state of the monitors is fetched in bulk. When it is 0, the
+	// state of each monitor is fetched separately every defaultRequeuePeriod.
+	MonitorPollInterval time.Duration
+	// DefaultDeletionPolicy is the deletion policy of the DatadogMonitors that do not define one
+	DefaultDeletionPolicy datadoghqv1alpha1.DatadogMonitorDeletionPolicy
 }
 
 // Reconciler reconciles a DatadogMonitor object
 type Reconciler struct {
-	client        client.Client
-	datadogClient *datadogapiclientv1.APIClient
-	datadogAuth   context.Context
-	versionInfo   *version.Info
-	log           logr.Logger
-	scheme        *runtime.Scheme
-	recorder      record.EventRecorder
+	options        ReconcilerOptions
+	client         client.Client
+	datadogClient  *datadogapiclientv1.APIClient
+	datadogAuth    context.Context
+	datadogClients *datadogclient.ClientCache
+	poller         *Poller
+	versionInfo    *version.Info
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	recorder       record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object. ddClient uses the credentials of the operator, it manages the
+// monitors of the DatadogMonitors that do not reference other credentials.
+func NewReconciler(options ReconcilerOptions, client client.Client, ddClient datadogclient.DatadogClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	switch options.DefaultDeletionPolicy {
+	case "", datadoghqv1alpha1.DatadogMonitorDeletionPolicyDelete, datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan:
+	default:
+		return nil, fmt.Errorf("invalid default deletion policy %q, must be one of %s or %s", options.DefaultDeletionPolicy, datadoghqv1alpha1.DatadogMonitorDeletionPolicyDelete, datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan)
+	}
+
+	r := &Reconciler{
+		options:        options,
+		client:         client,
+		datadogClient:  ddClient.Client,
+		datadogAuth:    ddClient.Auth,
+		datadogClients: datadogclient.NewClientCache(),
+		versionInfo:    versionInfo,
+		scheme:         scheme,
+		log:            log,
+		recorder:       recorder,
+	}
+	if options.MonitorPollInterval > 0 {
+		r.poller = newPoller(r, options.MonitorPollInterval)
+	}
+
+	return r, nil
 }
 
-// NewReconciler returns a new Reconciler object
-func NewReconciler(client client.Client, ddClient datadogclient.DatadogClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
-	return &Reconciler{
-		client:        client,
-		datadogClient: ddClient.Client,
-		datadogAuth:   ddClient.Auth,
-		versionInfo:   versionInfo,
-		scheme:        scheme,
-		log:           log,
-		recorder:      recorder,
-	}, nil
+// Poller returns the Poller fetching the state of the monitors in bulk, or nil if the state of each monitor is
+// fetched separately
+func (r *Reconciler) Poller() *Poller {
+	return r.poller
 }
 
 // Reconcile is similar to reconciler.Reconcile interface, but taking a context
@@ -105,18 +142,39 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 
 	newStatus := instance.Status.DeepCopy()
 
-	if result, err = r.handleFinalizer(logger, instance); ctrUtils.ShouldReturn(result, err) {
+	if result, err = r.handleFinalizer(ctx, logger, instance); ctrUtils.ShouldReturn(result, err) {
 		return result, err
 	}
 
 	// Validate the DatadogMonitor spec
 	if err = datadoghqv1alpha1.IsValidDatadogMonitor(&instance.Spec); err != nil {
 		logger.Error(err, "invalid DatadogMonitor spec")
+		newStatus.SyncStatus = datadoghqv1alpha1.SyncStatusValidateError
 
 		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 	}
 
-	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	// Get the client of the Datadog organization the monitor is managed in
+	ddClient, credentialsSource, err := r.getDatadogClient(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error getting Datadog credentials")
+		newStatus.SyncStatus = datadoghqv1alpha1.SyncStatusCredentialsError
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, ctrl.Result{RequeueAfter: defaultRequeuePeriod})
+	}
+
+	// Resolve the references to other DatadogMonitors of composite monitors. The monitor is not created or updated
+	// until all the referenced monitors exist in Datadog; the controller is notified when their status changes.
+	resolved, err := r.resolveMonitorReferences(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error resolving monitor references")
+		newStatus.SyncStatus = datadoghqv1alpha1.SyncStatusMonitorRefError
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, ctrl.Result{RequeueAfter: defaultRequeuePeriod})
+	}
+
+	// The hash of the resolved spec also changes when a referenced monitor is re-created with a new ID
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&resolved.Spec)
 	if err != nil {
 		logger.Error(err, "error generating hash")
 
@@ -136,10 +194,21 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 				return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 			}
 
-			if err = r.create(logger, instance, newStatus, now); err != nil {
-				logger.Error(err, "error creating monitor")
+			if _, found := instance.Annotations[datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey]; found {
+				// Take ownership of an existing monitor. The hash is only set if the monitor already matches the spec,
+				// otherwise the monitor is overwritten with the spec on the next reconcile, after the differences are reported
+				var inSync bool
+				if inSync, err = r.adopt(logger, ddClient, resolved, newStatus, now); err != nil {
+					logger.Error(err, "error adopting monitor")
+				} else if inSync {
+					newStatus.CurrentHash = instanceSpecHash
+				}
+			} else {
+				if err = r.create(logger, ddClient, resolved, newStatus, now); err != nil {
+					logger.Error(err, "error creating monitor")
+				}
+				newStatus.CurrentHash = instanceSpecHash
 			}
-			newStatus.CurrentHash = instanceSpecHash
 		} else {
 			err = fmt.Errorf("monitor type %v not supported", instance.Spec.Type)
 			logger.Error(err, "error creating monitor")
@@ -154,11 +223,20 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 				return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 			}
 			// Update action
-			if err = r.update(logger, instance, newStatus, now); err != nil {
+			if err = r.update(logger, ddClient, resolved, newStatus, now); err != nil {
 				logger.Error(err, "error updating monitor", "Monitor ID", instance.Status.ID)
 			} else {
 				newStatus.CurrentHash = instanceSpecHash
 			}
+		} else if polled, found := r.poller.getMonitor(ddClient, instance.Status.ID); found {
+			// Spec has not changed, and the monitor state is polled in bulk: the poller triggers a reconcile after each poll
+			if lastUpdate := instance.Status.MonitorStateLastUpdateTime; lastUpdate != nil && !polled.time.Truncate(time.Second).After(lastUpdate.Time) {
+				return ctrl.Result{RequeueAfter: defaultRequeuePeriod}, nil
+			}
+
+			if err = r.syncState(logger, ddClient, resolved, polled.monitor, polled.downtimes, newStatus, now); err != nil {
+				logger.Error(err, "error syncing monitor state", "Monitor ID", instance.Status.ID)
+			}
 		} else {
 			// Spec has not changed, just check if monitor state has changed (alert, warn, OK, etc.)
 			// We only do it every defaultRequeuePeriod to avoid overloading APIServer and DD
@@ -171,12 +249,18 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 				}
 			}
 
-			if err = r.get(logger, instance, newStatus, now); err != nil {
+			if err = r.get(logger, ddClient, resolved, newStatus, now); err != nil {
 				logger.Error(err, "error getting monitor", "Monitor ID", instance.Status.ID)
 			}
 		}
 	}
 
+	// Record the credentials of the monitor once it exists, so that it keeps being managed, and is deleted, in the
+	// same organization even if the credentials Secret of the namespace is created or deleted
+	if newStatus.ID != 0 && newStatus.CredentialsSource == nil {
+		newStatus.CredentialsSource = credentialsSource
+	}
+
 	// Requeue
 	if !result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = defaultRequeuePeriod
@@ -186,14 +270,14 @@ func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Reques
 	return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
 }
 
-func (r *Reconciler) create(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
+func (r *Reconciler) create(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
 	// Validate monitor in Datadog
-	if err := validateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if err := validateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		return err
 	}
 
 	// Create monitor in Datadog
-	m, err := createMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor)
+	m, err := createMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor)
 	if err != nil {
 		return err
 	}
@@ -216,15 +300,56 @@ func (r *Reconciler) create(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 	return nil
 }
 
-func (r *Reconciler) update(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
+// adopt takes ownership of the existing monitor whose ID is in the DatadogMonitorAdoptIDAnnotationKey annotation, and
+// reports its differences with the spec. It returns true if the monitor already matches the spec.
+func (r *Reconciler) adopt(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) (bool, error) {
+	value := datadogMonitor.Annotations[datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey]
+	monitorID, err := strconv.Atoi(value)
+	if err != nil || monitorID <= 0 {
+		return false, fmt.Errorf("invalid %s annotation %q, must be a monitor ID", datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey, value)
+	}
+
+	// Get the existing monitor from Datadog
+	m, err := getMonitor(ddClient.Auth, ddClient.Client, monitorID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.SyncStatusGetError
+		return false, err
+	}
+
+	desired, _ := buildMonitor(logger, datadogMonitor)
+	diffs := diffMonitor(m, *desired)
+	message := fmt.Sprintf("Adopted monitor %d", monitorID)
+	if len(diffs) > 0 {
+		message = fmt.Sprintf("%s, overwriting with the spec: %s", message, strings.Join(diffs, "; "))
+	}
+	event := buildEventInfo(datadogMonitor.Name, datadogMonitor.Namespace, datadog.AdoptionEvent)
+	r.recordEventWithDetails(datadogMonitor, corev1.EventTypeNormal, event, message)
+
+	// The monitor is now managed by the DatadogMonitor, add static information to status
+	status.ID = monitorID
+	creator := m.GetCreator()
+	status.Creator = creator.GetEmail()
+	createdTime := metav1.NewTime(m.GetCreated())
+	status.Created = &createdTime
+	status.Primary = true
+	status.SyncStatus = datadoghqv1alpha1.SyncStatusOK
+
+	// Set Adopted Condition
+	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeAdopted, corev1.ConditionTrue, message)
+	logger.Info("Adopted an existing monitor", "Monitor Namespace", datadogMonitor.Namespace, "Monitor Name", datadogMonitor.Name, "Monitor ID", monitorID, "Differences", diffs)
+
+	return len(diffs) == 0, nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
 	// Validate monitor in Datadog
-	if err := validateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if err := validateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		status.SyncStatus = datadoghqv1alpha1.SyncStatusValidateError
 		return err
 	}
 
 	// Update monitor in Datadog
-	if _, err := updateMonitor(r.datadogAuth, logger, r.datadogClient, datadogMonitor); err != nil {
+	if _, err := updateMonitor(ddClient.Auth, logger, ddClient.Client, datadogMonitor); err != nil {
 		status.SyncStatus = datadoghqv1alpha1.SyncStatusUpdateError
 		return err
 	}
@@ -240,20 +365,35 @@ func (r *Reconciler) update(logger logr.Logger, datadogMonitor *datadoghqv1alpha
 	return nil
 }
 
-func (r *Reconciler) get(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
+func (r *Reconciler) get(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
 	// Get monitor from Datadog and update resource status if needed
-	m, err := getMonitor(r.datadogAuth, r.datadogClient, datadogMonitor.Status.ID)
+	m, err := getMonitor(ddClient.Auth, ddClient.Client, datadogMonitor.Status.ID)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.SyncStatusGetError
+		return err
+	}
+
+	// Get the downtimes silencing the monitor, whether they target it by ID, tags or scope
+	downtimes, err := getMonitorDowntimes(ddClient.Auth, ddClient.Client, datadogMonitor.Status.ID)
 	if err != nil {
+		convertStateToStatus(m, status, now)
 		status.SyncStatus = datadoghqv1alpha1.SyncStatusGetError
 		return err
 	}
 
+	return r.syncState(logger, ddClient, datadogMonitor, m, downtimes, status, now)
+}
+
+// syncState updates the status with the state and downtimes of the monitor in Datadog, and checks its drift
+func (r *Reconciler) syncState(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, m datadogapiclientv1.Monitor, downtimes []datadogapiclientv1.Downtime, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
 	convertStateToStatus(m, status, now)
+	convertDowntimesToStatus(downtimes, status)
 	status.MonitorStateLastUpdateTime = &now
 	status.SyncStatus = datadoghqv1alpha1.SyncStatusOK
 	logger.V(1).Info("Synced DatadogMonitor state", "Monitor Namespace", datadogMonitor.Namespace, "Monitor Name", datadogMonitor.Name, "Monitor ID", datadogMonitor.Status.ID)
 
-	return nil
+	// Report or remediate the changes made to the monitor outside Kubernetes
+	return r.checkDrift(logger, ddClient, datadogMonitor, m, status, now)
 }
 
 func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, now metav1.Time, status *datadoghqv1alpha1.DatadogMonitorStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
@@ -285,26 +425,9 @@ func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogMonitor *da
 	return result, nil
 }
 
+// checkRequiredTags adds the required tags to DatadogMonitors admitted without the mutating webhook
 func (r *Reconciler) checkRequiredTags(logger logr.Logger, datadogMonitor *datadoghqv1alpha1.DatadogMonitor) (ctrl.Result, error) {
-	tagsToAdd := []string{}
-	var found bool
-	tags := datadogMonitor.Spec.Tags
-	for _, rT := range getRequiredTags() {
-		found = false
-		for _, t := range tags {
-			if t == rT {
-				found = true
-				break
-			}
-		}
-		if !found {
-			tagsToAdd = append(tagsToAdd, rT)
-		}
-	}
-
-	if len(tagsToAdd) > 0 {
-		tags = append(tags, tagsToAdd...)
-		datadogMonitor.Spec.Tags = tags
+	if datadoghqv1alpha1.DefaultDatadogMonitor(datadogMonitor) {
 		err := r.client.Update(context.TODO(), datadogMonitor)
 		if err != nil {
 			logger.Error(err, "failed to update DatadogMonitor with required tags")
@@ -320,11 +443,7 @@ func (r *Reconciler) checkRequiredTags(logger logr.Logger, datadogMonitor *datad
 	return ctrl.Result{}, nil
 }
 
-func getRequiredTags() []string {
-	return []string{"generated:kubernetes"}
-}
-
-// convertStateToStatus updates status.MonitorState, status.TriggeredState, and status.DowntimeStatus according to the current state of the monitor
+// convertStateToStatus updates status.MonitorState and status.TriggeredState according to the current state of the monitor
 func convertStateToStatus(monitor datadogapiclientv1.Monitor, newStatus *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) {
 	// If monitor group is in Alert, Warn or No Data, then add its info to the TriggeredState
 	triggeredStates := []datadoghqv1alpha1.DatadogMonitorTriggeredState{}
@@ -358,8 +477,22 @@ func convertStateToStatus(monitor datadogapiclientv1.Monitor, newStatus *datadog
 	if newStatus.MonitorState != oldMonitorState {
 		newStatus.MonitorStateLastTransitionTime = &now
 	}
-	// TODO Updating this requires having the API client also return any matching downtime objects
-	newStatus.DowntimeStatus = datadoghqv1alpha1.DatadogMonitorDowntimeStatus{}
+}
+
+// convertDowntimesToStatus updates status.DowntimeStatus according to the downtimes matching the monitor.
+// When several downtimes are active, the one with the lowest ID is reported.
+func convertDowntimesToStatus(downtimes []datadogapiclientv1.Downtime, newStatus *datadoghqv1alpha1.DatadogMonitorStatus) {
+	downtimeStatus := datadoghqv1alpha1.DatadogMonitorDowntimeStatus{}
+	for _, downtime := range downtimes {
+		if !downtime.GetActive() || downtime.GetDisabled() {
+			continue
+		}
+		if !downtimeStatus.IsDowntimed || int(downtime.GetId()) < downtimeStatus.DowntimeID {
+			downtimeStatus.IsDowntimed = true
+			downtimeStatus.DowntimeID = int(downtime.GetId())
+		}
+	}
+	newStatus.DowntimeStatus = downtimeStatus
 }
 
 func isSupportedMonitorType(monitorType datadoghqv1alpha1.DatadogMonitorType) bool {
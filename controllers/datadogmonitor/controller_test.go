@@ -104,6 +104,116 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "DatadogMonitor adopts an existing monitor, reports the differences",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Annotations = map[string]string{datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey: "12345"}
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 3,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 12345, dm.Status.ID)
+				assert.True(t, dm.Status.Primary)
+				assert.Equal(t, datadoghqv1alpha1.SyncStatusOK, dm.Status.SyncStatus)
+				// The spec is not pushed before the differences are reported
+				assert.Equal(t, "", dm.Status.CurrentHash)
+				adopted := dm.Status.Conditions[len(dm.Status.Conditions)-1]
+				assert.Equal(t, datadoghqv1alpha1.DatadogMonitorConditionTypeAdopted, adopted.Type)
+				assert.Contains(t, adopted.Message, "Adopted monitor 12345, overwriting with the spec: ")
+				assert.Contains(t, adopted.Message, `name: "" -> "test monitor"`)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor adopts an existing monitor, then overwrites it",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Annotations = map[string]string{datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey: "12345"}
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 4,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 12345, dm.Status.ID)
+				hash, _ := comparison.GenerateMD5ForSpec(&dm.Spec)
+				assert.Equal(t, hash, dm.Status.CurrentHash)
+				assert.Equal(t, datadoghqv1alpha1.DatadogMonitorConditionTypeUpdated, dm.Status.Conditions[len(dm.Status.Conditions)-1].Type)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor with an invalid adoption annotation",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Annotations = map[string]string{datadoghqv1alpha1.DatadogMonitorAdoptIDAnnotationKey: "foo"}
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 3,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dm.Status.ID)
+				errorMessage := ""
+				for _, cond := range dm.Status.Conditions {
+					if cond.Type == datadoghqv1alpha1.DatadogMonitorConditionTypeError && cond.Status == corev1.ConditionTrue {
+						errorMessage = cond.Message
+					}
+				}
+				assert.Equal(t, `invalid monitor.datadoghq.com/adopt-id annotation "foo", must be a monitor ID`, errorMessage)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor with an invalid query",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					dm := genericDatadogMonitor()
+					dm.Spec.Query = "avg(last_10m):avg:system.disk.in_use{*} by {host}"
+					_ = c.Create(context.TODO(), dm)
+				},
+				firstReconcileCount: 3,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dm.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.SyncStatusValidateError, dm.Status.SyncStatus)
+				errorMessage := ""
+				for _, cond := range dm.Status.Conditions {
+					if cond.Type == datadoghqv1alpha1.DatadogMonitorConditionTypeError && cond.Status == corev1.ConditionTrue {
+						errorMessage = cond.Message
+					}
+				}
+				assert.Equal(t, "spec.Query is invalid: missing comparison to a threshold, e.g. > 10", errorMessage)
+				return nil
+			},
+		},
 		{
 			name: "DatadogMonitor exists, check required tags",
 			args: args{
@@ -398,7 +508,60 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 			},
 		},
 		{
-			name: "DatadogMonitor of unsupported type (composite)",
+			name: "DatadogMonitor, composite alert",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testReferencedMonitor(resourcesNamespace, "foo-disk", 123))
+					_ = c.Create(context.TODO(), testReferencedMonitor("other", "foo-cpu", 456))
+					_ = c.Create(context.TODO(), testCompositeMonitor())
+				},
+
+				firstReconcileCount: 10,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantErr:    false,
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.NotContains(t, dm.Status.Conditions[0].Message, "error")
+				// The hash covers the query sent to Datadog, with the references resolved
+				resolved := dm.DeepCopy()
+				resolved.Spec.Query = "123 && 456"
+				hash, _ := comparison.GenerateMD5ForSpec(&resolved.Spec)
+				assert.Equal(t, hash, dm.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor, composite alert referencing a DatadogMonitor without ID",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testReferencedMonitor(resourcesNamespace, "foo-disk", 123))
+					_ = c.Create(context.TODO(), testReferencedMonitor("other", "foo-cpu", 0))
+					_ = c.Create(context.TODO(), testCompositeMonitor())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantErr:    false,
+			wantFunc: func(c client.Client) error {
+				dm := &datadoghqv1alpha1.DatadogMonitor{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, dm); err != nil {
+					return err
+				}
+				assert.Equal(t, 0, dm.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.SyncStatusMonitorRefError, dm.Status.SyncStatus)
+				assert.Equal(t, datadoghqv1alpha1.DatadogMonitorConditionTypeError, dm.Status.Conditions[0].Type)
+				assert.Equal(t, "DatadogMonitor other/foo-cpu has not been created in Datadog yet", dm.Status.Conditions[0].Message)
+				return nil
+			},
+		},
+		{
+			name: "DatadogMonitor of unsupported type (synthetics)",
 			args: args{
 				request: newRequest(resourcesNamespace, resourcesName),
 				firstAction: func(c client.Client) {
@@ -413,7 +576,7 @@ func TestReconcileDatadogMonitor_Reconcile(t *testing.T) {
 						},
 						Spec: datadoghqv1alpha1.DatadogMonitorSpec{
 							Query:   "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1",
-							Type:    datadoghqv1alpha1.DatadogMonitorTypeComposite,
+							Type:    datadoghqv1alpha1.DatadogMonitorType(datadogapiclientv1.MONITORTYPE_SYNTHETICS_ALERT),
 							Name:    "test monitor",
 							Message: "something is wrong",
 						},
@@ -725,6 +888,63 @@ func Test_convertStateToStatus(t *testing.T) {
 	}
 }
 
+func Test_convertDowntimesToStatus(t *testing.T) {
+	newDowntime := func(id int64, active, disabled bool) datadogapiclientv1.Downtime {
+		d := datadogapiclientv1.NewDowntime()
+		d.SetId(id)
+		d.SetActive(active)
+		d.SetDisabled(disabled)
+		return *d
+	}
+
+	tests := []struct {
+		name       string
+		downtimes  []datadogapiclientv1.Downtime
+		status     *datadoghqv1alpha1.DatadogMonitorStatus
+		wantStatus datadoghqv1alpha1.DatadogMonitorDowntimeStatus
+	}{
+		{
+			name:       "no downtime",
+			status:     &datadoghqv1alpha1.DatadogMonitorStatus{},
+			wantStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{},
+		},
+		{
+			name:       "one active downtime",
+			downtimes:  []datadogapiclientv1.Downtime{newDowntime(12, true, false)},
+			status:     &datadoghqv1alpha1.DatadogMonitorStatus{},
+			wantStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 12},
+		},
+		{
+			name:       "several active downtimes, report the lowest ID",
+			downtimes:  []datadogapiclientv1.Downtime{newDowntime(34, true, false), newDowntime(12, true, false), newDowntime(56, true, false)},
+			status:     &datadoghqv1alpha1.DatadogMonitorStatus{},
+			wantStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 12},
+		},
+		{
+			name:       "scheduled and disabled downtimes are ignored",
+			downtimes:  []datadogapiclientv1.Downtime{newDowntime(12, false, false), newDowntime(34, true, true)},
+			status:     &datadoghqv1alpha1.DatadogMonitorStatus{},
+			wantStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{},
+		},
+		{
+			name:      "downtime ended",
+			downtimes: []datadogapiclientv1.Downtime{},
+			status: &datadoghqv1alpha1.DatadogMonitorStatus{
+				DowntimeStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 12},
+			},
+			wantStatus: datadoghqv1alpha1.DatadogMonitorDowntimeStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convertDowntimesToStatus(tt.downtimes, tt.status)
+
+			assert.Equal(t, tt.wantStatus, tt.status.DowntimeStatus)
+		})
+	}
+}
+
 func genericDatadogMonitor() *datadoghqv1alpha1.DatadogMonitor {
 	return &datadoghqv1alpha1.DatadogMonitor{
 		TypeMeta: metav1.TypeMeta{
@@ -959,3 +1179,31 @@ func testAuditMonitor() *datadoghqv1alpha1.DatadogMonitor {
 		},
 	}
 }
+
+func testCompositeMonitor() *datadoghqv1alpha1.DatadogMonitor {
+	return &datadoghqv1alpha1.DatadogMonitor{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DatadogMonitor",
+			APIVersion: fmt.Sprintf("%s/%s", datadoghqv1alpha1.GroupVersion.Group, datadoghqv1alpha1.GroupVersion.Version),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      resourcesName,
+		},
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Query:   "{{ monitor \"foo-disk\" }} && {{ monitor \"other/foo-cpu\" }}",
+			Type:    datadoghqv1alpha1.DatadogMonitorTypeComposite,
+			Name:    "test composite monitor",
+			Message: "something is wrong",
+		},
+	}
+}
+
+func testReferencedMonitor(namespace, name string, id int) *datadoghqv1alpha1.DatadogMonitor {
+	dm := testMetricMonitor()
+	dm.Namespace = namespace
+	dm.Name = name
+	dm.Status.ID = id
+
+	return dm
+}
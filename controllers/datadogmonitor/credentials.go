@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/DataDog/datadog-operator/apis/datadoghq/common"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/config"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+// getDatadogClient returns the client of the Datadog organization the monitor is managed in, and the source of its
+// credentials. The credentials recorded in the status when the monitor was created are used, so that an existing
+// monitor is always managed in its organization; otherwise they are resolved with resolveCredentialsSource.
+// A recorded credentials Secret of another namespace is only used while cross-namespace references are allowed.
+func (r *Reconciler) getDatadogClient(ctx context.Context, dm *datadoghqv1alpha1.DatadogMonitor) (datadogclient.DatadogClient, *datadoghqv1alpha1.DatadogMonitorCredentialsSource, error) {
+	source := dm.Status.CredentialsSource
+	if source == nil {
+		var err error
+		if source, err = r.resolveCredentialsSource(ctx, dm); err != nil {
+			return datadogclient.DatadogClient{}, nil, err
+		}
+	} else if source.Secret != nil {
+		if err := r.checkCredentialsSecretNamespace(source.Secret, dm.Namespace); err != nil {
+			return datadogclient.DatadogClient{}, nil, err
+		}
+	}
+
+	ddClient, err := r.getCredentialsSourceClient(ctx, source)
+	if err != nil {
+		return datadogclient.DatadogClient{}, nil, err
+	}
+
+	return ddClient, source, nil
+}
+
+// resolveCredentialsSource returns the credentials a monitor should be managed with: the credentials Secret
+// referenced by the DatadogMonitor, or else the credentials Secret of its namespace, or else the credentials of the
+// operator
+func (r *Reconciler) resolveCredentialsSource(ctx context.Context, dm *datadoghqv1alpha1.DatadogMonitor) (*datadoghqv1alpha1.DatadogMonitorCredentialsSource, error) {
+	if dm.Spec.Credentials != nil {
+		secretRef := &datadoghqv1alpha1.DatadogMonitorCredentials{
+			SecretName:      dm.Spec.Credentials.SecretName,
+			SecretNamespace: dm.Spec.Credentials.SecretNamespace,
+		}
+		if secretRef.SecretNamespace == "" {
+			secretRef.SecretNamespace = dm.Namespace
+		}
+		if err := r.checkCredentialsSecretNamespace(secretRef, dm.Namespace); err != nil {
+			return nil, err
+		}
+
+		return &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Secret: secretRef}, nil
+	}
+
+	secretName := types.NamespacedName{Namespace: dm.Namespace, Name: datadoghqv1alpha1.DatadogMonitorCredentialsSecretName}
+	if err := r.client.Get(ctx, secretName, &corev1.Secret{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true}, nil
+		}
+
+		return nil, fmt.Errorf("unable to get the credentials Secret %s: %w", secretName, err)
+	}
+
+	return &datadoghqv1alpha1.DatadogMonitorCredentialsSource{
+		Secret: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: secretName.Name, SecretNamespace: secretName.Namespace},
+	}, nil
+}
+
+// checkCredentialsSecretNamespace returns an error if the credentials Secret is in another namespace than the
+// DatadogMonitor, and cross-namespace references are not allowed
+func (r *Reconciler) checkCredentialsSecretNamespace(secretRef *datadoghqv1alpha1.DatadogMonitorCredentials, namespace string) error {
+	if secretRef.SecretNamespace != namespace && !r.options.AllowCrossNamespaceCredentials {
+		return fmt.Errorf("the credentials Secret %s/%s is not in the namespace of the DatadogMonitor, and cross-namespace references are not allowed", secretRef.SecretNamespace, secretRef.SecretName)
+	}
+
+	return nil
+}
+
+// getCredentialsSourceClient returns the client of the Datadog organization of a credentials source. It fails if the
+// credentials Secret of the source does not exist, instead of falling back to the credentials of the operator.
+func (r *Reconciler) getCredentialsSourceClient(ctx context.Context, source *datadoghqv1alpha1.DatadogMonitorCredentialsSource) (datadogclient.DatadogClient, error) {
+	if source.Secret == nil {
+		return datadogclient.DatadogClient{Client: r.datadogClient, Auth: r.datadogAuth}, nil
+	}
+
+	secretName := types.NamespacedName{Namespace: source.Secret.SecretNamespace, Name: source.Secret.SecretName}
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, secretName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.datadogClients.Remove(secretName.String())
+		}
+
+		return datadogclient.DatadogClient{}, fmt.Errorf("unable to get the credentials Secret %s: %w", secretName, err)
+	}
+
+	creds, apiURL := getSecretCredentials(secret)
+	ddClient, err := r.datadogClients.Get(secretName.String(), creds, apiURL)
+	if err != nil {
+		return datadogclient.DatadogClient{}, fmt.Errorf("invalid credentials Secret %s: %w", secretName, err)
+	}
+
+	return ddClient, nil
+}
+
+// getSecretCredentials returns the credentials and API URL of a credentials Secret. When the Secret has no site
+// nor URL, the API URL of the operator is used.
+func getSecretCredentials(secret *corev1.Secret) (config.Creds, string) {
+	creds := config.Creds{
+		APIKey: string(secret.Data[common.DefaultAPIKeyKey]),
+		AppKey: string(secret.Data[common.DefaultAPPKeyKey]),
+	}
+
+	if apiURL := string(secret.Data[datadoghqv1alpha1.DatadogMonitorCredentialsURLKey]); apiURL != "" {
+		return creds, apiURL
+	}
+	if site := string(secret.Data[datadoghqv1alpha1.DatadogMonitorCredentialsSiteKey]); site != "" {
+		return creds, datadogclient.GetSiteAPIURL(site)
+	}
+
+	return creds, os.Getenv(config.DDURLEnvVar)
+}
+
+// CredentialsSecretEventHandler removes the client of a credentials Secret from the cache when the Secret is deleted.
+// The client of an updated Secret is replaced on its next use.
+func (r *Reconciler) CredentialsSecretEventHandler() handler.EventHandler {
+	return handler.Funcs{
+		DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			r.datadogClients.Remove(types.NamespacedName{Namespace: e.Object.GetNamespace(), Name: e.Object.GetName()}.String())
+		},
+	}
+}
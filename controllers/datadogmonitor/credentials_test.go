@@ -0,0 +1,305 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/config"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func Test_getDatadogClient(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{})
+
+	defaultClient := datadogapiclientv1.NewAPIClient(datadogapiclientv1.NewConfiguration())
+
+	tests := []struct {
+		name        string
+		credentials *datadoghqv1alpha1.DatadogMonitorCredentials
+		secrets     []*corev1.Secret
+		source      *datadoghqv1alpha1.DatadogMonitorCredentialsSource
+		options     ReconcilerOptions
+		wantDefault bool
+		wantHost    string
+		wantSource  *datadoghqv1alpha1.DatadogMonitorCredentialsSource
+		wantErr     string
+	}{
+		{
+			name:        "operator credentials",
+			wantDefault: true,
+			wantSource:  &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true},
+		},
+		{
+			name:       "namespace credentials",
+			secrets:    []*corev1.Secret{credentialsSecret(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName, "datadoghq.eu")},
+			wantHost:   "api.datadoghq.eu",
+			wantSource: secretCredentialsSource(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName),
+		},
+		{
+			name:        "referenced credentials",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a"},
+			secrets: []*corev1.Secret{
+				credentialsSecret(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName, "datadoghq.eu"),
+				credentialsSecret(resourcesNamespace, "team-a", "us3.datadoghq.com"),
+			},
+			wantHost:   "api.us3.datadoghq.com",
+			wantSource: secretCredentialsSource(resourcesNamespace, "team-a"),
+		},
+		{
+			name:        "recorded operator credentials",
+			secrets:     []*corev1.Secret{credentialsSecret(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName, "datadoghq.eu")},
+			source:      &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true},
+			wantDefault: true,
+			wantSource:  &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true},
+		},
+		{
+			name:        "recorded credentials take precedence over the referenced ones",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-b"},
+			secrets: []*corev1.Secret{
+				credentialsSecret(resourcesNamespace, "team-a", "us3.datadoghq.com"),
+				credentialsSecret(resourcesNamespace, "team-b", "datadoghq.eu"),
+			},
+			source:     secretCredentialsSource(resourcesNamespace, "team-a"),
+			wantHost:   "api.us3.datadoghq.com",
+			wantSource: secretCredentialsSource(resourcesNamespace, "team-a"),
+		},
+		{
+			name:    "recorded namespace credentials not found",
+			source:  secretCredentialsSource(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName),
+			wantErr: `unable to get the credentials Secret bar/datadog-monitor-credentials: secrets "datadog-monitor-credentials" not found`,
+		},
+		{
+			name:        "referenced credentials not found",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a"},
+			wantErr:     `unable to get the credentials Secret bar/team-a: secrets "team-a" not found`,
+		},
+		{
+			name:        "cross-namespace credentials not allowed",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a", SecretNamespace: "datadog"},
+			secrets:     []*corev1.Secret{credentialsSecret("datadog", "team-a", "datadoghq.eu")},
+			wantErr:     "the credentials Secret datadog/team-a is not in the namespace of the DatadogMonitor, and cross-namespace references are not allowed",
+		},
+		{
+			name:        "cross-namespace credentials allowed",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a", SecretNamespace: "datadog"},
+			secrets:     []*corev1.Secret{credentialsSecret("datadog", "team-a", "datadoghq.eu")},
+			options:     ReconcilerOptions{AllowCrossNamespaceCredentials: true},
+			wantHost:    "api.datadoghq.eu",
+			wantSource:  secretCredentialsSource("datadog", "team-a"),
+		},
+		{
+			name:    "recorded cross-namespace credentials no longer allowed",
+			secrets: []*corev1.Secret{credentialsSecret("datadog", "team-a", "datadoghq.eu")},
+			source:  secretCredentialsSource("datadog", "team-a"),
+			wantErr: "the credentials Secret datadog/team-a is not in the namespace of the DatadogMonitor, and cross-namespace references are not allowed",
+		},
+		{
+			name:       "recorded cross-namespace credentials allowed",
+			secrets:    []*corev1.Secret{credentialsSecret("datadog", "team-a", "datadoghq.eu")},
+			source:     secretCredentialsSource("datadog", "team-a"),
+			options:    ReconcilerOptions{AllowCrossNamespaceCredentials: true},
+			wantHost:   "api.datadoghq.eu",
+			wantSource: secretCredentialsSource("datadog", "team-a"),
+		},
+		{
+			name:        "credentials without app key",
+			credentials: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a"},
+			secrets: []*corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: resourcesNamespace, Name: "team-a"},
+				Data:       map[string][]byte{"api_key": []byte("api")},
+			}},
+			wantErr: "invalid credentials Secret bar/team-a: error obtaining API key and/or app key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []client.Object{}
+			for _, secret := range tt.secrets {
+				objects = append(objects, secret)
+			}
+			r := &Reconciler{
+				options:        tt.options,
+				client:         fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+				datadogClient:  defaultClient,
+				datadogAuth:    context.TODO(),
+				datadogClients: datadogclient.NewClientCache(),
+			}
+			dm := genericDatadogMonitor()
+			dm.Spec.Credentials = tt.credentials
+			dm.Status.CredentialsSource = tt.source
+
+			got, source, err := r.getDatadogClient(context.TODO(), dm)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSource, source)
+			if tt.wantDefault {
+				assert.Same(t, defaultClient, got.Client)
+				return
+			}
+			assert.NotSame(t, defaultClient, got.Client)
+			assert.Equal(t, tt.wantHost, got.Auth.Value(datadogapiclientv1.ContextServerVariables).(map[string]string)["name"])
+
+			// The client is reused for the same credentials
+			again, _, err := r.getDatadogClient(context.TODO(), dm)
+			require.NoError(t, err)
+			assert.Same(t, got.Client, again.Client)
+		})
+	}
+}
+
+func Test_getDatadogClient_secretChanges(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{})
+
+	secret := credentialsSecret(resourcesNamespace, "team-a", "datadoghq.eu")
+	r := &Reconciler{
+		client:         fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build(),
+		datadogClients: datadogclient.NewClientCache(),
+	}
+	dm := genericDatadogMonitor()
+	dm.Status.CredentialsSource = secretCredentialsSource(resourcesNamespace, "team-a")
+
+	first, _, err := r.getDatadogClient(context.TODO(), dm)
+	require.NoError(t, err)
+
+	// The client is replaced when the Secret changes
+	secret.Data["site"] = []byte("us3.datadoghq.com")
+	require.NoError(t, r.client.Update(context.TODO(), secret))
+	updated, _, err := r.getDatadogClient(context.TODO(), dm)
+	require.NoError(t, err)
+	assert.NotSame(t, first.Client, updated.Client)
+	assert.Equal(t, "api.us3.datadoghq.com", updated.Auth.Value(datadogapiclientv1.ContextServerVariables).(map[string]string)["name"])
+	assert.Equal(t, 1, r.datadogClients.Len())
+
+	// The client is removed when the Secret is deleted
+	r.CredentialsSecretEventHandler().Delete(event.DeleteEvent{Object: secret}, nil)
+	assert.Equal(t, 0, r.datadogClients.Len())
+
+	// Or when the deleted Secret is not found
+	_, err = r.datadogClients.Get(types.NamespacedName{Namespace: resourcesNamespace, Name: "team-a"}.String(), config.Creds{APIKey: "api", AppKey: "app"}, "")
+	require.NoError(t, err)
+	require.NoError(t, r.client.Delete(context.TODO(), secret))
+	_, _, err = r.getDatadogClient(context.TODO(), dm)
+	assert.EqualError(t, err, `unable to get the credentials Secret bar/team-a: secrets "team-a" not found`)
+	assert.Equal(t, 0, r.datadogClients.Len())
+}
+
+func TestReconcileDatadogMonitor_Credentials(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{})
+
+	// The monitors of each organization must only be managed with its API keys
+	var mutex sync.Mutex
+	apiKeys := map[string][]string{}
+	newServer := func(org string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			apiKeys[org] = append(apiKeys[org], r.Header.Get("DD-API-KEY"))
+			mutex.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(genericMonitor(1))
+		}))
+	}
+	operatorServer := newServer("operator")
+	defer operatorServer.Close()
+	teamServer := newServer("team")
+	defer teamServer.Close()
+
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = operatorServer.Client()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: resourcesNamespace, Name: "team"},
+		Data: map[string][]byte{
+			"api_key": []byte("team-api-key"),
+			"app_key": []byte("team-app-key"),
+			"url":     []byte(teamServer.URL),
+		},
+	}
+	dm := genericDatadogMonitor()
+	dm.Spec.Credentials = &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team"}
+
+	r := &Reconciler{
+		client:         fake.NewClientBuilder().WithScheme(s).WithObjects(secret, dm).Build(),
+		datadogClient:  datadogapiclientv1.NewAPIClient(testConfig),
+		datadogAuth:    setupTestAuth(operatorServer.URL),
+		datadogClients: datadogclient.NewClientCache(),
+		scheme:         s,
+		recorder:       record.NewFakeRecorder(10),
+		log:            logf.Log.WithName(t.Name()),
+	}
+
+	// The first reconciles add the finalizer and the required tags, the last one creates the monitor
+	for i := 0; i < 3; i++ {
+		_, err := r.Reconcile(context.TODO(), newRequest(resourcesNamespace, resourcesName))
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, apiKeys["operator"])
+	assert.NotEmpty(t, apiKeys["team"])
+	for _, key := range apiKeys["team"] {
+		assert.Equal(t, "team-api-key", key)
+	}
+
+	// The credentials of the monitor are recorded once it is created
+	got := &datadoghqv1alpha1.DatadogMonitor{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: resourcesName}, got))
+	assert.Equal(t, 1, got.Status.ID)
+	assert.Equal(t, secretCredentialsSource(resourcesNamespace, "team"), got.Status.CredentialsSource)
+}
+
+func credentialsSecret(namespace, name, site string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			"api_key": []byte(name + "-api-key"),
+			"app_key": []byte(name + "-app-key"),
+			"site":    []byte(site),
+		},
+	}
+}
+
+func secretCredentialsSource(namespace, name string) *datadoghqv1alpha1.DatadogMonitorCredentialsSource {
+	return &datadoghqv1alpha1.DatadogMonitorCredentialsSource{
+		Secret: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: name, SecretNamespace: namespace},
+	}
+}
+
+// unreachableCredentialsSecret returns a credentials Secret whose API cannot be reached
+func unreachableCredentialsSecret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data: map[string][]byte{
+			"api_key": []byte(name + "-api-key"),
+			"app_key": []byte(name + "-app-key"),
+			"url":     []byte("http://127.0.0.1:1"),
+		},
+	}
+}
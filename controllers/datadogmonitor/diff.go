@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+)
+
+// diffMonitor returns the differences between a monitor in Datadog and the desired monitor, formatted as
+// `field: live value -> desired value`. Options that are not set in the desired monitor are ignored, as Datadog
+// fills them with default values, and so are the options that the API client cannot read back from Datadog.
+func diffMonitor(live, desired datadogapiclientv1.Monitor) []string {
+	var diffs []string
+	addDiff := func(field string, liveValue, desiredValue interface{}) {
+		if !reflect.DeepEqual(liveValue, desiredValue) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", field, formatDiffValue(liveValue), formatDiffValue(desiredValue)))
+		}
+	}
+
+	addDiff("type", live.GetType(), desired.GetType())
+	addDiff("name", live.GetName(), desired.GetName())
+	addDiff("query", live.GetQuery(), desired.GetQuery())
+	addDiff("message", live.GetMessage(), desired.GetMessage())
+	addDiff("priority", live.GetPriority(), desired.GetPriority())
+	addDiff("tags", sortedStrings(live.GetTags()), sortedStrings(desired.GetTags()))
+	if desired.HasRestrictedRoles() {
+		addDiff("restricted_roles", sortedStrings(live.GetRestrictedRoles()), sortedStrings(desired.GetRestrictedRoles()))
+	}
+
+	liveOptions, desiredOptions := toMap(live.GetOptions()), toMap(readableOptions(desired.GetOptions()))
+	diffMaps("options", liveOptions, desiredOptions, addDiff)
+
+	return diffs
+}
+
+// diffMaps compares the values of the keys set in desired, recursively
+func diffMaps(prefix string, live, desired map[string]interface{}, addDiff func(string, interface{}, interface{})) {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field := fmt.Sprintf("%s.%s", prefix, key)
+		desiredMap, desiredIsMap := desired[key].(map[string]interface{})
+		liveMap, liveIsMap := live[key].(map[string]interface{})
+		if desiredIsMap && (liveIsMap || live[key] == nil) {
+			diffMaps(field, liveMap, desiredMap, addDiff)
+			continue
+		}
+		addDiff(field, live[key], desired[key])
+	}
+}
+
+// readableOptions drops the options set through UnparsedObject, which are not read back into the API client types
+func readableOptions(options datadogapiclientv1.MonitorOptions) datadogapiclientv1.MonitorOptions {
+	if options.UnparsedObject == nil {
+		return options
+	}
+
+	readable := datadogapiclientv1.MonitorOptions{}
+	raw, err := json.Marshal(options)
+	if err != nil {
+		return options
+	}
+	_ = json.Unmarshal(raw, &readable)
+
+	return readable
+}
+
+// toMap converts an API object to its JSON representation, to compare values of different Go types
+func toMap(obj interface{}) map[string]interface{} {
+	m := map[string]interface{}{}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return m
+	}
+	_ = json.Unmarshal(raw, &m)
+
+	return m
+}
+
+func sortedStrings(in []string) []string {
+	out := make([]string, len(in))
+	copy(out, in)
+	sort.Strings(out)
+
+	return out
+}
+
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(raw)
+}
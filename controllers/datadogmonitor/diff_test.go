@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_diffMonitor(t *testing.T) {
+	dm := genericDatadogMonitor()
+	desired, _ := buildMonitor(testLogger, dm)
+
+	// Monitor as returned by Datadog, with default option values
+	live := *datadogapiclientv1.NewMonitor(desired.GetQuery(), desired.GetType())
+	live.SetName(desired.GetName())
+	live.SetMessage(desired.GetMessage())
+	live.SetPriority(desired.GetPriority())
+	live.SetTags([]string{"generated:kubernetes", "env:test"})
+	liveOptions := desired.GetOptions()
+	liveOptions.SetNotifyNoData(false)
+	liveOptions.SetRenotifyInterval(0)
+	live.SetOptions(liveOptions)
+
+	desired.SetTags([]string{"env:test", "generated:kubernetes"})
+	assert.Empty(t, diffMonitor(live, *desired))
+
+	// Monitor edited outside Kubernetes. The warning threshold is not set in the spec, so it is ignored.
+	live.SetName("Edited in the UI")
+	live.SetTags([]string{"env:test"})
+	liveOptions = live.GetOptions()
+	thresholds := liveOptions.GetThresholds()
+	thresholds.SetWarning(0.2)
+	liveOptions.SetThresholds(thresholds)
+	liveOptions.SetNotifyAudit(true)
+	live.SetOptions(liveOptions)
+
+	desiredOptions := desired.GetOptions()
+	desiredOptions.SetNotifyAudit(false)
+	desired.SetOptions(desiredOptions)
+
+	assert.Equal(t, []string{
+		`name: "Edited in the UI" -> "test monitor"`,
+		`tags: ["env:test"] -> ["env:test","generated:kubernetes"]`,
+		`options.notify_audit: true -> false`,
+	}, diffMonitor(live, *desired))
+}
+
+func Test_diffMonitorUnsupportedOptions(t *testing.T) {
+	dm := genericDatadogMonitor()
+	dm.Spec.Options.OnMissingData = datadoghqv1alpha1.DatadogMonitorOptionsOnMissingDataResolve
+	dm.Spec.Options.NotifyBy = []string{"*"}
+	desired, _ := buildMonitor(testLogger, dm)
+
+	// The API client does not read back the options it has no field for
+	raw, err := json.Marshal(desired)
+	assert.NoError(t, err)
+	live := datadogapiclientv1.Monitor{}
+	assert.NoError(t, json.Unmarshal(raw, &live))
+	assert.Nil(t, live.GetOptions().UnparsedObject)
+
+	assert.Empty(t, diffMonitor(live, *desired))
+}
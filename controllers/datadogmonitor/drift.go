@@ -0,0 +1,65 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+// checkDrift compares the monitor in Datadog with the spec, to detect changes made outside Kubernetes. The drift is
+// reported in the Drifted condition and in an event, and the monitor is overwritten with the spec if
+// Spec.DriftRemediation is enforce.
+func (r *Reconciler) checkDrift(logger logr.Logger, ddClient datadogclient.DatadogClient, datadogMonitor *datadoghqv1alpha1.DatadogMonitor, live datadogapiclientv1.Monitor, status *datadoghqv1alpha1.DatadogMonitorStatus, now metav1.Time) error {
+	desired, _ := buildMonitor(logger, datadogMonitor)
+	diffs := diffMonitor(live, *desired)
+	if len(diffs) == 0 {
+		condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, corev1.ConditionFalse, "")
+		return nil
+	}
+
+	enforce := datadogMonitor.Spec.DriftRemediation == datadoghqv1alpha1.DatadogMonitorDriftRemediationEnforce
+	message := "Monitor modified outside Kubernetes"
+	if enforce {
+		message = fmt.Sprintf("%s, overwriting with the spec", message)
+	}
+	message = fmt.Sprintf("%s: %s", message, strings.Join(diffs, "; "))
+
+	// Only record an event when the drift changes, not on every sync
+	if !isDriftReported(status, message) {
+		event := buildEventInfo(datadogMonitor.Name, datadogMonitor.Namespace, datadog.DriftEvent)
+		r.recordEventWithDetails(datadogMonitor, corev1.EventTypeWarning, event, message)
+	}
+	condition.UpdateDatadogMonitorConditions(status, now, datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, corev1.ConditionTrue, message)
+	logger.Info("DatadogMonitor drifted", "Monitor Namespace", datadogMonitor.Namespace, "Monitor Name", datadogMonitor.Name, "Monitor ID", datadogMonitor.Status.ID, "Differences", diffs)
+
+	if enforce {
+		return r.update(logger, ddClient, datadogMonitor, status, now)
+	}
+
+	return nil
+}
+
+// isDriftReported returns true if the Drifted condition already reports the drift
+func isDriftReported(status *datadoghqv1alpha1.DatadogMonitorStatus, message string) bool {
+	for _, cond := range status.Conditions {
+		if cond.Type == datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted {
+			return cond.Status == corev1.ConditionTrue && cond.Message == message
+		}
+	}
+
+	return false
+}
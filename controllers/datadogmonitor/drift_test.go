@@ -0,0 +1,133 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func Test_checkDrift(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+
+	testCases := []struct {
+		name           string
+		remediation    datadoghqv1alpha1.DatadogMonitorDriftRemediation
+		editLive       func(m *datadogapiclientv1.Monitor)
+		previousStatus datadoghqv1alpha1.DatadogMonitorStatus
+		wantDrifted    corev1.ConditionStatus
+		wantMessage    string
+		wantEvent      string
+		wantUpdates    int
+	}{
+		{
+			name:     "no drift",
+			editLive: func(m *datadogapiclientv1.Monitor) {},
+		},
+		{
+			name: "drift resolved",
+			previousStatus: datadoghqv1alpha1.DatadogMonitorStatus{
+				Conditions: []datadoghqv1alpha1.DatadogMonitorCondition{
+					{Type: datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, Status: corev1.ConditionTrue, Message: "Monitor modified outside Kubernetes: foo"},
+				},
+			},
+			editLive:    func(m *datadogapiclientv1.Monitor) {},
+			wantDrifted: corev1.ConditionFalse,
+		},
+		{
+			name:        "drift reported",
+			editLive:    func(m *datadogapiclientv1.Monitor) { m.SetName("Edited in the UI") },
+			wantDrifted: corev1.ConditionTrue,
+			wantMessage: `Monitor modified outside Kubernetes: name: "Edited in the UI" -> "test monitor"`,
+			wantEvent:   `Warning Drift DatadogMonitor bar/foo: Monitor modified outside Kubernetes: name: "Edited in the UI" -> "test monitor"`,
+		},
+		{
+			name: "drift already reported",
+			previousStatus: datadoghqv1alpha1.DatadogMonitorStatus{
+				Conditions: []datadoghqv1alpha1.DatadogMonitorCondition{
+					{Type: datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted, Status: corev1.ConditionTrue, Message: `Monitor modified outside Kubernetes: name: "Edited in the UI" -> "test monitor"`},
+				},
+			},
+			editLive:    func(m *datadogapiclientv1.Monitor) { m.SetName("Edited in the UI") },
+			wantDrifted: corev1.ConditionTrue,
+			wantMessage: `Monitor modified outside Kubernetes: name: "Edited in the UI" -> "test monitor"`,
+		},
+		{
+			name:        "drift enforced",
+			remediation: datadoghqv1alpha1.DatadogMonitorDriftRemediationEnforce,
+			editLive:    func(m *datadogapiclientv1.Monitor) { m.SetMessage("Edited in the UI") },
+			wantDrifted: corev1.ConditionTrue,
+			wantMessage: `Monitor modified outside Kubernetes, overwriting with the spec: message: "Edited in the UI" -> "something is wrong"`,
+			wantEvent:   `Warning Drift DatadogMonitor bar/foo: Monitor modified outside Kubernetes, overwriting with the spec: message: "Edited in the UI" -> "something is wrong"`,
+			wantUpdates: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			updates := 0
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					updates++
+				}
+				w.Header().Set("Content-Type", "application/json")
+			}))
+			defer httpServer.Close()
+
+			testConfig := datadogapiclientv1.NewConfiguration()
+			testConfig.HTTPClient = httpServer.Client()
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				datadogClient: datadogapiclientv1.NewAPIClient(testConfig),
+				datadogAuth:   setupTestAuth(httpServer.URL),
+				log:           testLogger,
+				recorder:      recorder,
+			}
+
+			dm := genericDatadogMonitor()
+			dm.Status.ID = 12345
+			dm.Spec.DriftRemediation = test.remediation
+			live, _ := buildMonitor(testLogger, dm)
+			test.editLive(live)
+			status := test.previousStatus.DeepCopy()
+
+			err := r.checkDrift(testLogger, datadogclient.DatadogClient{Client: r.datadogClient, Auth: r.datadogAuth}, dm, *live, status, now)
+			assert.NoError(t, err)
+
+			var drifted *datadoghqv1alpha1.DatadogMonitorCondition
+			for i := range status.Conditions {
+				if status.Conditions[i].Type == datadoghqv1alpha1.DatadogMonitorConditionTypeDrifted {
+					drifted = &status.Conditions[i]
+				}
+			}
+			if test.wantDrifted == "" {
+				assert.Nil(t, drifted)
+			} else {
+				assert.Equal(t, test.wantDrifted, drifted.Status)
+				assert.Equal(t, test.wantMessage, drifted.Message)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				assert.Equal(t, test.wantEvent, event)
+			default:
+				assert.Equal(t, "", test.wantEvent, "expected an event")
+			}
+			assert.Equal(t, test.wantUpdates, updates)
+		})
+	}
+}
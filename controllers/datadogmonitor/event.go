@@ -6,6 +6,8 @@
 package datadogmonitor
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
@@ -24,3 +26,8 @@ func buildEventInfo(name, ns string, eventType datadog.EventType) utils.EventInf
 func (r *Reconciler) recordEvent(dm *datadoghqv1alpha1.DatadogMonitor, info utils.EventInfo) {
 	r.recorder.Event(dm, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
 }
+
+// recordEventWithDetails wraps the manager event recorder, appending details to the event message.
+func (r *Reconciler) recordEventWithDetails(dm *datadoghqv1alpha1.DatadogMonitor, eventType string, info utils.EventInfo, details string) {
+	r.recorder.Event(dm, eventType, info.GetReason(), fmt.Sprintf("%s: %s", info.GetMessage(), details))
+}
@@ -21,11 +21,14 @@ const (
 	datadogMonitorFinalizer = "finalizer.monitor.datadoghq.com"
 )
 
-func (r *Reconciler) handleFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) (ctrl.Result, error) {
+func (r *Reconciler) handleFinalizer(ctx context.Context, logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) (ctrl.Result, error) {
 	// Check if the DatadogMonitor instance is marked to be deleted, which is indicated by the deletion timestamp being set.
 	if dm.GetDeletionTimestamp() != nil {
 		if utils.ContainsString(dm.GetFinalizers(), datadogMonitorFinalizer) {
-			r.finalizeDatadogMonitor(logger, dm)
+			// Keep the finalizer until the monitor is deleted or orphaned in Datadog, so that it is not leaked
+			if err := r.finalizeDatadogMonitor(ctx, logger, dm); err != nil {
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, err
+			}
 
 			dm.SetFinalizers(utils.RemoveString(dm.GetFinalizers(), datadogMonitorFinalizer))
 			err := r.client.Update(context.TODO(), dm)
@@ -51,18 +54,47 @@ func (r *Reconciler) handleFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.D
 	return ctrl.Result{}, nil
 }
 
-func (r *Reconciler) finalizeDatadogMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) {
+func (r *Reconciler) finalizeDatadogMonitor(ctx context.Context, logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) error {
 	if dm.Status.Primary {
-		err := deleteMonitor(r.datadogAuth, r.datadogClient, dm.Status.ID)
+		deletionPolicy := r.getDeletionPolicy(dm)
+		ddClient, _, err := r.getDatadogClient(ctx, dm)
 		if err != nil {
-			logger.Error(err, "failed to finalize monitor", "Monitor ID", fmt.Sprint(dm.Status.ID))
+			logger.Error(err, "failed to get the credentials to finalize monitor", "Monitor ID", fmt.Sprint(dm.Status.ID), "Deletion Policy", deletionPolicy)
 
-			return
+			return err
+		}
+		if deletionPolicy == datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan {
+			err = orphanMonitor(ddClient.Auth, ddClient.Client, dm.Status.ID, fmt.Sprintf("%s/%s", dm.Namespace, dm.Name))
+		} else {
+			err = deleteMonitor(ddClient.Auth, ddClient.Client, dm.Status.ID)
+		}
+		if err != nil {
+			logger.Error(err, "failed to finalize monitor", "Monitor ID", fmt.Sprint(dm.Status.ID), "Deletion Policy", deletionPolicy)
+
+			return err
+		}
+		logger.Info("Successfully finalized DatadogMonitor", "Monitor ID", fmt.Sprint(dm.Status.ID), "Deletion Policy", deletionPolicy)
+		eventType := datadog.DeletionEvent
+		if deletionPolicy == datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan {
+			eventType = datadog.OrphanEvent
 		}
-		logger.Info("Successfully finalized DatadogMonitor", "Monitor ID", fmt.Sprint(dm.Status.ID))
-		event := buildEventInfo(dm.Name, dm.Namespace, datadog.DeletionEvent)
+		event := buildEventInfo(dm.Name, dm.Namespace, eventType)
 		r.recordEvent(dm, event)
 	}
+
+	return nil
+}
+
+// getDeletionPolicy returns the deletion policy of the DatadogMonitor, or else the default deletion policy of the operator
+func (r *Reconciler) getDeletionPolicy(dm *datadoghqv1alpha1.DatadogMonitor) datadoghqv1alpha1.DatadogMonitorDeletionPolicy {
+	if dm.Spec.DeletionPolicy != "" {
+		return dm.Spec.DeletionPolicy
+	}
+	if r.options.DefaultDeletionPolicy != "" {
+		return r.options.DefaultDeletionPolicy
+	}
+
+	return datadoghqv1alpha1.DatadogMonitorDeletionPolicyDelete
 }
 
 func (r *Reconciler) addFinalizer(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) error {
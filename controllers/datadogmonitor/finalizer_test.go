@@ -7,6 +7,9 @@ package datadogmonitor
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,11 +17,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
 )
 
 var (
@@ -75,7 +82,7 @@ func Test_handleFinalizer(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			reqLogger := testLogger.WithValues("test:", test.name)
 			_ = r.client.Create(context.TODO(), test.dm)
-			_, err := r.handleFinalizer(reqLogger, test.dm)
+			_, err := r.handleFinalizer(context.TODO(), reqLogger, test.dm)
 			assert.NoError(t, err)
 			if test.finalizerShouldExist {
 				assert.True(t, utils.ContainsString(test.dm.GetFinalizers(), datadogMonitorFinalizer))
@@ -85,3 +92,216 @@ func Test_handleFinalizer(t *testing.T) {
 		})
 	}
 }
+
+func Test_finalizeDatadogMonitor(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        datadoghqv1alpha1.DatadogMonitorDeletionPolicy
+		defaultPolicy datadoghqv1alpha1.DatadogMonitorDeletionPolicy
+		source        *datadoghqv1alpha1.DatadogMonitorCredentialsSource
+		secrets       []client.Object
+		failMethod    string
+		failStatus    int
+		wantRequests  []string
+		wantTags      []string
+		wantErr       string
+	}{
+		{
+			name:         "deleted by default",
+			wantRequests: []string{"DELETE /api/v1/monitor/12345"},
+		},
+		{
+			name:         "orphaned",
+			policy:       datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan,
+			wantRequests: []string{"GET /api/v1/monitor/12345", "PUT /api/v1/monitor/12345"},
+			wantTags:     []string{"team:foo", "orphaned_from_datadogmonitor:bar/foo"},
+		},
+		{
+			name:          "orphaned by the default policy of the operator",
+			defaultPolicy: datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan,
+			wantRequests:  []string{"GET /api/v1/monitor/12345", "PUT /api/v1/monitor/12345"},
+			wantTags:      []string{"team:foo", "orphaned_from_datadogmonitor:bar/foo"},
+		},
+		{
+			name:          "deleted despite the default policy of the operator",
+			policy:        datadoghqv1alpha1.DatadogMonitorDeletionPolicyDelete,
+			defaultPolicy: datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan,
+			wantRequests:  []string{"DELETE /api/v1/monitor/12345"},
+		},
+		{
+			name:         "delete failure",
+			failMethod:   http.MethodDelete,
+			failStatus:   http.StatusInternalServerError,
+			wantRequests: []string{"DELETE /api/v1/monitor/12345"},
+			wantErr:      `error deleting monitor: 500 Internal Server Error: {"errors": ["internal error"]}`,
+		},
+		{
+			name:         "already deleted in Datadog",
+			failMethod:   http.MethodDelete,
+			failStatus:   http.StatusNotFound,
+			wantRequests: []string{"DELETE /api/v1/monitor/12345"},
+		},
+		{
+			name:         "orphan failure",
+			policy:       datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan,
+			failMethod:   http.MethodPut,
+			failStatus:   http.StatusInternalServerError,
+			wantRequests: []string{"GET /api/v1/monitor/12345", "PUT /api/v1/monitor/12345"},
+			wantErr:      `error orphaning monitor: 500 Internal Server Error: {"errors": ["internal error"]}`,
+		},
+		{
+			name:         "orphaned monitor already deleted in Datadog",
+			policy:       datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan,
+			failMethod:   http.MethodGet,
+			failStatus:   http.StatusNotFound,
+			wantRequests: []string{"GET /api/v1/monitor/12345"},
+		},
+		{
+			name:         "deleted with the recorded credentials of the operator, despite a namespace credentials Secret",
+			source:       &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true},
+			secrets:      []client.Object{unreachableCredentialsSecret(resourcesNamespace, datadoghqv1alpha1.DatadogMonitorCredentialsSecretName)},
+			wantRequests: []string{"DELETE /api/v1/monitor/12345"},
+		},
+		{
+			name: "recorded credentials Secret deleted",
+			source: &datadoghqv1alpha1.DatadogMonitorCredentialsSource{
+				Secret: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: datadoghqv1alpha1.DatadogMonitorCredentialsSecretName, SecretNamespace: resourcesNamespace},
+			},
+			wantRequests: []string{},
+			wantErr:      `unable to get the credentials Secret bar/datadog-monitor-credentials: secrets "datadog-monitor-credentials" not found`,
+		},
+		{
+			name: "referenced credentials Secret deleted",
+			source: &datadoghqv1alpha1.DatadogMonitorCredentialsSource{
+				Secret: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a", SecretNamespace: resourcesNamespace},
+			},
+			wantRequests: []string{},
+			wantErr:      `unable to get the credentials Secret bar/team-a: secrets "team-a" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := []string{}
+			var gotTags []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == tt.failMethod {
+					w.WriteHeader(tt.failStatus)
+					_, _ = w.Write([]byte(`{"errors": ["internal error"]}`))
+
+					return
+				}
+				switch r.Method {
+				case http.MethodGet:
+					m := genericMonitor(12345)
+					m.SetTags([]string{"generated:kubernetes", "team:foo"})
+					_ = json.NewEncoder(w).Encode(m)
+				case http.MethodPut:
+					u := datadogapiclientv1.MonitorUpdateRequest{}
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&u))
+					gotTags = u.GetTags()
+					_ = json.NewEncoder(w).Encode(genericMonitor(12345))
+				default:
+					_, _ = w.Write([]byte(`{}`))
+				}
+			}))
+			defer ts.Close()
+
+			testConfig := datadogapiclientv1.NewConfiguration()
+			testConfig.HTTPClient = ts.Client()
+			r := &Reconciler{
+				options:        ReconcilerOptions{DefaultDeletionPolicy: tt.defaultPolicy},
+				client:         fake.NewClientBuilder().WithObjects(tt.secrets...).Build(),
+				datadogClient:  datadogapiclientv1.NewAPIClient(testConfig),
+				datadogAuth:    setupTestAuth(ts.URL),
+				datadogClients: datadogclient.NewClientCache(),
+				recorder:       record.NewFakeRecorder(10),
+				log:            testLogger,
+			}
+			dm := genericDatadogMonitor()
+			dm.Spec.DeletionPolicy = tt.policy
+			dm.Status.ID = 12345
+			dm.Status.Primary = true
+			dm.Status.CredentialsSource = tt.source
+
+			err := r.finalizeDatadogMonitor(context.TODO(), testLogger, dm)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+			assert.Equal(t, tt.wantTags, gotTags)
+		})
+	}
+}
+
+func Test_handleFinalizer_credentialsUnavailable(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{})
+	metaNow := metav1.NewTime(time.Now())
+	dm := genericDatadogMonitor()
+	dm.DeletionTimestamp = &metaNow
+	dm.Finalizers = []string{datadogMonitorFinalizer}
+	dm.Status.ID = 12345
+	dm.Status.Primary = true
+	dm.Status.CredentialsSource = &datadoghqv1alpha1.DatadogMonitorCredentialsSource{
+		Secret: &datadoghqv1alpha1.DatadogMonitorCredentials{SecretName: "team-a", SecretNamespace: resourcesNamespace},
+	}
+
+	r := &Reconciler{
+		client:         fake.NewClientBuilder().WithScheme(s).WithObjects(dm).Build(),
+		datadogClients: datadogclient.NewClientCache(),
+		log:            testLogger,
+	}
+
+	// The finalizer is kept until the credentials Secret is available again
+	result, err := r.handleFinalizer(context.TODO(), testLogger, dm)
+	assert.EqualError(t, err, `unable to get the credentials Secret bar/team-a: secrets "team-a" not found`)
+	assert.Equal(t, defaultErrRequeuePeriod, result.RequeueAfter)
+	assert.True(t, utils.ContainsString(dm.GetFinalizers(), datadogMonitorFinalizer))
+}
+
+func Test_handleFinalizer_orphanFailure(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{})
+	metaNow := metav1.NewTime(time.Now())
+	dm := genericDatadogMonitor()
+	dm.DeletionTimestamp = &metaNow
+	dm.Finalizers = []string{datadogMonitorFinalizer}
+	dm.Spec.DeletionPolicy = datadoghqv1alpha1.DatadogMonitorDeletionPolicyOrphan
+	dm.Status.ID = 12345
+	dm.Status.Primary = true
+	dm.Status.CredentialsSource = &datadoghqv1alpha1.DatadogMonitorCredentialsSource{Operator: true}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errors": ["internal error"]}`))
+
+			return
+		}
+		_ = json.NewEncoder(w).Encode(genericMonitor(12345))
+	}))
+	defer ts.Close()
+
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = ts.Client()
+	r := &Reconciler{
+		client:         fake.NewClientBuilder().WithScheme(s).WithObjects(dm).Build(),
+		datadogClient:  datadogapiclientv1.NewAPIClient(testConfig),
+		datadogAuth:    setupTestAuth(ts.URL),
+		datadogClients: datadogclient.NewClientCache(),
+		recorder:       record.NewFakeRecorder(10),
+		log:            testLogger,
+	}
+
+	// The finalizer is kept until the monitor is orphaned in Datadog
+	result, err := r.handleFinalizer(context.TODO(), testLogger, dm)
+	assert.EqualError(t, err, `error orphaning monitor: 500 Internal Server Error: {"errors": ["internal error"]}`)
+	assert.Equal(t, defaultErrRequeuePeriod, result.RequeueAfter)
+	assert.True(t, utils.ContainsString(dm.GetFinalizers(), datadogMonitorFinalizer))
+}
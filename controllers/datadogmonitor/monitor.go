@@ -7,18 +7,21 @@ package datadogmonitor
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"net/url"
+	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 
 	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
 )
 
+// orphanedFromTagPrefix is the prefix of the tag identifying the DatadogMonitor an orphaned monitor was created from
+const orphanedFromTagPrefix = "orphaned_from_datadogmonitor:"
+
 func buildMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) (*datadogapiclientv1.Monitor, *datadogapiclientv1.MonitorUpdateRequest) {
 	monitorType := datadogapiclientv1.MonitorType(string(dm.Spec.Type))
 	name := dm.Spec.Name
@@ -139,6 +142,28 @@ func buildMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) (*da
 		o.SetTimeoutH(*options.TimeoutH)
 	}
 
+	if options.GroupbySimpleMonitor != nil {
+		o.SetGroupbySimpleMonitor(*options.GroupbySimpleMonitor)
+	}
+
+	if options.NewGroupDelay != nil {
+		o.SetNewGroupDelay(*options.NewGroupDelay)
+	}
+
+	if options.RenotifyOccurrences != nil {
+		o.SetRenotifyOccurrences(*options.RenotifyOccurrences)
+	}
+
+	if len(options.RenotifyStatuses) > 0 {
+		statuses := make([]datadogapiclientv1.MonitorRenotifyStatusType, 0, len(options.RenotifyStatuses))
+		for _, status := range options.RenotifyStatuses {
+			statuses = append(statuses, datadogapiclientv1.MonitorRenotifyStatusType(status))
+		}
+		o.SetRenotifyStatuses(statuses)
+	}
+
+	setUnsupportedOptions(&o, options)
+
 	m := datadogapiclientv1.NewMonitor(query, monitorType)
 	{
 		m.SetName(name)
@@ -162,9 +187,61 @@ func buildMonitor(logger logr.Logger, dm *datadoghqv1alpha1.DatadogMonitor) (*da
 	m.SetTags(tags)
 	u.SetTags(tags)
 
+	if len(dm.Spec.RestrictedRoles) > 0 {
+		roles := sortedStrings(dm.Spec.RestrictedRoles)
+		m.SetRestrictedRoles(roles)
+		u.SetRestrictedRoles(roles)
+	}
+
 	return m, u
 }
 
+// setUnsupportedOptions adds the options that the Datadog API client has no field for to the serialized monitor
+// options. The API client sends UnparsedObject as-is in place of the typed fields.
+func setUnsupportedOptions(o *datadogapiclientv1.MonitorOptions, options datadoghqv1alpha1.DatadogMonitorOptions) {
+	extra := map[string]interface{}{}
+
+	if len(options.NotifyBy) > 0 {
+		extra["notify_by"] = options.NotifyBy
+	}
+
+	if options.OnMissingData != "" {
+		extra["on_missing_data"] = string(options.OnMissingData)
+	}
+
+	if options.GroupRetentionDuration != nil {
+		extra["group_retention_duration"] = *options.GroupRetentionDuration
+	}
+
+	if options.NotificationPresetName != "" {
+		extra["notification_preset_name"] = string(options.NotificationPresetName)
+	}
+
+	if options.SchedulingOptions != nil && options.SchedulingOptions.EvaluationWindow != nil {
+		window := map[string]interface{}{}
+		if options.SchedulingOptions.EvaluationWindow.DayStarts != nil {
+			window["day_starts"] = *options.SchedulingOptions.EvaluationWindow.DayStarts
+		}
+		if options.SchedulingOptions.EvaluationWindow.HourStarts != nil {
+			window["hour_starts"] = *options.SchedulingOptions.EvaluationWindow.HourStarts
+		}
+		if options.SchedulingOptions.EvaluationWindow.MonthStarts != nil {
+			window["month_starts"] = *options.SchedulingOptions.EvaluationWindow.MonthStarts
+		}
+		extra["scheduling_options"] = map[string]interface{}{"evaluation_window": window}
+	}
+
+	if len(extra) == 0 {
+		return
+	}
+
+	serialized := toMap(o)
+	for key, value := range extra {
+		serialized[key] = value
+	}
+	o.UnparsedObject = serialized
+}
+
 func getMonitor(auth context.Context, client *datadogapiclientv1.APIClient, monitorID int) (datadogapiclientv1.Monitor, error) {
 	groupStates := "all"
 	optionalParams := datadogapiclientv1.GetMonitorOptionalParameters{
@@ -178,6 +255,15 @@ func getMonitor(auth context.Context, client *datadogapiclientv1.APIClient, moni
 	return m, nil
 }
 
+func getMonitorDowntimes(auth context.Context, client *datadogapiclientv1.APIClient, monitorID int) ([]datadogapiclientv1.Downtime, error) {
+	downtimes, _, err := client.DowntimesApi.ListMonitorDowntimes(auth, int64(monitorID))
+	if err != nil {
+		return nil, translateClientError(err, "error getting monitor downtimes")
+	}
+
+	return downtimes, nil
+}
+
 func validateMonitor(auth context.Context, logger logr.Logger, client *datadogapiclientv1.APIClient, dm *datadoghqv1alpha1.DatadogMonitor) error {
 	m, _ := buildMonitor(logger, dm)
 	if _, _, err := client.MonitorsApi.ValidateMonitor(auth, *m); err != nil {
@@ -215,27 +301,50 @@ func deleteMonitor(auth context.Context, client *datadogapiclientv1.APIClient, m
 	optionalParams := datadogapiclientv1.DeleteMonitorOptionalParameters{
 		Force: &force,
 	}
-	if _, _, err := client.MonitorsApi.DeleteMonitor(auth, int64(monitorID), optionalParams); err != nil {
+	if _, resp, err := client.MonitorsApi.DeleteMonitor(auth, int64(monitorID), optionalParams); err != nil {
+		// The monitor was already deleted in Datadog
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
 		return translateClientError(err, "error deleting monitor")
 	}
 
 	return nil
 }
 
-func translateClientError(err error, msg string) error {
-	if msg == "" {
-		msg = "an error occurred"
+// orphanMonitor removes the required tags of a monitor, so that it is no longer considered managed by a DatadogMonitor,
+// and tags it with the DatadogMonitor it was created from
+func orphanMonitor(auth context.Context, client *datadogapiclientv1.APIClient, monitorID int, origin string) error {
+	m, resp, err := client.MonitorsApi.GetMonitor(auth, int64(monitorID))
+	if err != nil {
+		// The monitor was deleted in Datadog, there is nothing left to orphan
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return translateClientError(err, "error getting monitor")
 	}
 
-	var apiErr datadogapiclientv1.GenericOpenAPIError
-	var errURL *url.Error
-	if errors.As(err, &apiErr) {
-		return fmt.Errorf(msg+": %w: %s", err, apiErr.Body())
+	required := map[string]bool{}
+	for _, tag := range datadoghqv1alpha1.GetDatadogMonitorRequiredTags() {
+		required[tag] = true
+	}
+	tags := []string{}
+	for _, tag := range m.GetTags() {
+		if !required[tag] && !strings.HasPrefix(tag, orphanedFromTagPrefix) {
+			tags = append(tags, tag)
+		}
 	}
+	tags = append(tags, orphanedFromTagPrefix+origin)
 
-	if errors.As(err, &errURL) {
-		return fmt.Errorf(msg+" (url.Error): %s", errURL)
+	u := datadogapiclientv1.MonitorUpdateRequest{}
+	u.SetTags(tags)
+	if _, _, err = client.MonitorsApi.UpdateMonitor(auth, int64(monitorID), u); err != nil {
+		return translateClientError(err, "error orphaning monitor")
 	}
 
-	return fmt.Errorf(msg+": %w", err)
+	return nil
+}
+
+func translateClientError(err error, msg string) error {
+	return datadogclient.TranslateClientError(err, msg)
 }
@@ -7,6 +7,7 @@ package datadogmonitor
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -131,6 +132,67 @@ func Test_buildMonitor(t *testing.T) {
 	assert.Equal(t, "kube_namespace:test", (monitorUR.GetTags())[2], "tags are not properly sorted")
 }
 
+func Test_buildMonitorOptions(t *testing.T) {
+	valFalse := false
+	newGroupDelay := int64(60)
+	renotifyInterval := int64(30)
+	renotifyOccurrences := int64(3)
+	groupRetentionDuration := "2d"
+	dayStarts := "04:00"
+
+	dm := &datadoghqv1alpha1.DatadogMonitor{
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Query:           "logs(\"service:foo status:error\").index(\"*\").rollup(\"count\").by(\"env\").last(\"5m\") > 10",
+			Type:            "log alert",
+			Name:            "Test monitor",
+			Message:         "Something went wrong",
+			RestrictedRoles: []string{"role-b", "role-a"},
+			Options: datadoghqv1alpha1.DatadogMonitorOptions{
+				NotifyBy:               []string{"env"},
+				GroupbySimpleMonitor:   &valFalse,
+				OnMissingData:          datadoghqv1alpha1.DatadogMonitorOptionsOnMissingDataShowAndNotifyNoData,
+				GroupRetentionDuration: &groupRetentionDuration,
+				NewGroupDelay:          &newGroupDelay,
+				RenotifyInterval:       &renotifyInterval,
+				RenotifyStatuses:       []datadoghqv1alpha1.DatadogMonitorRenotifyStatus{"alert", "no data"},
+				RenotifyOccurrences:    &renotifyOccurrences,
+				NotificationPresetName: datadoghqv1alpha1.DatadogMonitorNotificationPresetNameHideQuery,
+				SchedulingOptions: &datadoghqv1alpha1.DatadogMonitorOptionsSchedulingOptions{
+					EvaluationWindow: &datadoghqv1alpha1.DatadogMonitorOptionsEvaluationWindow{
+						DayStarts: &dayStarts,
+					},
+				},
+			},
+		},
+	}
+
+	monitor, monitorUR := buildMonitor(testLogger, dm)
+
+	assert.Equal(t, []string{"role-a", "role-b"}, monitor.GetRestrictedRoles(), "discrepancy found in parameter: RestrictedRoles")
+	assert.Equal(t, []string{"role-a", "role-b"}, monitorUR.GetRestrictedRoles(), "discrepancy found in parameter: RestrictedRoles")
+
+	assert.Equal(t, *dm.Spec.Options.GroupbySimpleMonitor, monitor.Options.GetGroupbySimpleMonitor(), "discrepancy found in parameter: GroupbySimpleMonitor")
+	assert.Equal(t, *dm.Spec.Options.NewGroupDelay, monitor.Options.GetNewGroupDelay(), "discrepancy found in parameter: NewGroupDelay")
+	assert.Equal(t, *dm.Spec.Options.RenotifyOccurrences, monitor.Options.GetRenotifyOccurrences(), "discrepancy found in parameter: RenotifyOccurrences")
+	assert.Equal(t, []datadogapiclientv1.MonitorRenotifyStatusType{"alert", "no data"}, monitor.Options.GetRenotifyStatuses(), "discrepancy found in parameter: RenotifyStatuses")
+
+	// Options without a field in the API client are serialized with the typed ones
+	for _, options := range []*datadogapiclientv1.MonitorOptions{monitor.Options, monitorUR.Options} {
+		raw, err := json.Marshal(options)
+		assert.NoError(t, err)
+		serialized := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(raw, &serialized))
+
+		assert.Equal(t, []interface{}{"env"}, serialized["notify_by"], "discrepancy found in parameter: NotifyBy")
+		assert.Equal(t, "show_and_notify_no_data", serialized["on_missing_data"], "discrepancy found in parameter: OnMissingData")
+		assert.Equal(t, "2d", serialized["group_retention_duration"], "discrepancy found in parameter: GroupRetentionDuration")
+		assert.Equal(t, "hide_query", serialized["notification_preset_name"], "discrepancy found in parameter: NotificationPresetName")
+		assert.Equal(t, map[string]interface{}{"evaluation_window": map[string]interface{}{"day_starts": "04:00"}}, serialized["scheduling_options"], "discrepancy found in parameter: SchedulingOptions")
+		assert.Equal(t, float64(60), serialized["new_group_delay"], "discrepancy found in parameter: NewGroupDelay")
+		assert.Equal(t, false, serialized["groupby_simple_monitor"], "discrepancy found in parameter: GroupbySimpleMonitor")
+	}
+}
+
 func Test_getMonitor(t *testing.T) {
 	mID := 12345
 	expectedMonitor := genericMonitor(mID)
@@ -151,6 +213,32 @@ func Test_getMonitor(t *testing.T) {
 	assert.Equal(t, expectedMonitor, val)
 }
 
+func Test_getMonitorDowntimes(t *testing.T) {
+	mID := 12345
+	downtime := datadogapiclientv1.NewDowntime()
+	downtime.SetId(12)
+	downtime.SetMonitorId(int64(mID))
+	downtime.SetActive(true)
+	expectedDowntimes := []datadogapiclientv1.Downtime{*downtime}
+	jsonDowntimes, _ := json.Marshal(expectedDowntimes)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jsonDowntimes)
+	}))
+	defer httpServer.Close()
+
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = httpServer.Client()
+	client := datadogapiclientv1.NewAPIClient(testConfig)
+	testAuth := setupTestAuth(httpServer.URL)
+
+	val, err := getMonitorDowntimes(testAuth, client, mID)
+	assert.Nil(t, err)
+	assert.Len(t, val, 1)
+	assert.Equal(t, int64(12), val[0].GetId())
+	assert.True(t, val[0].GetActive())
+}
+
 func Test_validateMonitor(t *testing.T) {
 	dm := genericDatadogMonitor()
 
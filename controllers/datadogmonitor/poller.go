@@ -0,0 +1,341 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	pollPageSize = 1000
+	// maxPollBackoff is the maximum delay of the polls of an organization after failed polls
+	maxPollBackoff = 10 * time.Minute
+	// maxRateLimitedRetries is the number of times a request rejected by the rate limit is retried
+	maxRateLimitedRetries = 3
+	pollEventsBufferSize  = 1024
+)
+
+// polledMonitor is the state of a monitor fetched by the Poller
+type polledMonitor struct {
+	monitor   datadogapiclientv1.Monitor
+	downtimes []datadogapiclientv1.Downtime
+	time      time.Time
+}
+
+// orgPoll is the result of the polls of the monitors of a Datadog organization
+type orgPoll struct {
+	monitors map[int]polledMonitor
+	// time is the time of the last successful poll
+	time time.Time
+	// backoff is the delay of the next poll after failed polls
+	backoff time.Duration
+	next    time.Time
+}
+
+// Poller fetches the state of the monitors of all the DatadogMonitors in bulk, with one paginated list of the
+// monitors tagged generated:kubernetes per Datadog organization, instead of one request per monitor. After each poll
+// it triggers the reconciliation of the DatadogMonitors, which update their status with the polled state.
+type Poller struct {
+	reconciler *Reconciler
+	interval   time.Duration
+	log        logr.Logger
+	events     chan event.GenericEvent
+
+	orgs  map[*datadogapiclientv1.APIClient]*orgPoll
+	mutex sync.RWMutex
+}
+
+// newPoller returns a Poller polling the monitors of the DatadogMonitors managed by the reconciler
+func newPoller(r *Reconciler, interval time.Duration) *Poller {
+	return &Poller{
+		reconciler: r,
+		interval:   interval,
+		log:        r.log.WithName("poller"),
+		events:     make(chan event.GenericEvent, pollEventsBufferSize),
+		orgs:       map[*datadogapiclientv1.APIClient]*orgPoll{},
+	}
+}
+
+// Events returns the channel of the DatadogMonitors to reconcile after each poll
+func (p *Poller) Events() <-chan event.GenericEvent {
+	return p.events
+}
+
+// Start polls the monitors every interval until the context is done
+func (p *Poller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// getMonitor returns the last polled state of a monitor, and false if the monitor was not polled recently
+func (p *Poller) getMonitor(ddClient datadogclient.DatadogClient, monitorID int) (polledMonitor, bool) {
+	if p == nil {
+		return polledMonitor{}, false
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	org, found := p.orgs[ddClient.Client]
+	// When the polls fail, the monitors are fetched one by one until the poller recovers
+	if !found || time.Since(org.time) > 2*p.interval {
+		return polledMonitor{}, false
+	}
+	m, found := org.monitors[monitorID]
+
+	return m, found
+}
+
+// poll fetches the monitors of the organizations of all the DatadogMonitors
+func (p *Poller) poll(ctx context.Context) {
+	dmList := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err := p.reconciler.client.List(ctx, dmList); err != nil {
+		p.log.Error(err, "unable to list DatadogMonitors")
+		return
+	}
+
+	// Group the DatadogMonitors by organization
+	clients := map[*datadogapiclientv1.APIClient]datadogclient.DatadogClient{}
+	monitors := map[*datadogapiclientv1.APIClient][]*datadoghqv1alpha1.DatadogMonitor{}
+	for i := range dmList.Items {
+		dm := &dmList.Items[i]
+		if dm.Status.ID == 0 || !dm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		// Credentials errors are reported in the status by the reconciler
+		ddClient, _, err := p.reconciler.getDatadogClient(ctx, dm)
+		if err != nil {
+			continue
+		}
+		clients[ddClient.Client] = ddClient
+		monitors[ddClient.Client] = append(monitors[ddClient.Client], dm)
+	}
+
+	// Forget the organizations that no longer have monitors
+	p.mutex.Lock()
+	for client := range p.orgs {
+		if _, found := clients[client]; !found {
+			delete(p.orgs, client)
+		}
+	}
+	p.mutex.Unlock()
+
+	for client, ddClient := range clients {
+		if ctx.Err() != nil {
+			return
+		}
+		p.pollOrg(ctx, ddClient, monitors[client])
+	}
+}
+
+// pollOrg fetches the monitors of an organization, and triggers the reconciliation of its DatadogMonitors
+func (p *Poller) pollOrg(ctx context.Context, ddClient datadogclient.DatadogClient, dms []*datadoghqv1alpha1.DatadogMonitor) {
+	p.mutex.Lock()
+	org, found := p.orgs[ddClient.Client]
+	if !found {
+		org = &orgPoll{}
+		p.orgs[ddClient.Client] = org
+	}
+	next := org.next
+	p.mutex.Unlock()
+
+	if time.Now().Before(next) {
+		return
+	}
+
+	monitors, err := p.fetchMonitors(ctx, ddClient)
+	now := time.Now()
+	p.mutex.Lock()
+	if err != nil {
+		org.backoff *= 2
+		if org.backoff < p.interval {
+			org.backoff = p.interval
+		} else if org.backoff > maxPollBackoff {
+			org.backoff = maxPollBackoff
+		}
+		org.next = now.Add(org.backoff)
+		p.mutex.Unlock()
+		p.log.Error(err, "unable to poll monitors", "Backoff", org.backoff.String())
+
+		return
+	}
+	for id, m := range monitors {
+		m.time = now
+		monitors[id] = m
+	}
+	org.monitors = monitors
+	org.time = now
+	org.backoff = 0
+	org.next = time.Time{}
+	p.mutex.Unlock()
+	p.log.V(1).Info("Polled monitors", "Monitors", len(monitors))
+
+	for _, dm := range dms {
+		if _, found := monitors[dm.Status.ID]; !found {
+			continue
+		}
+		select {
+		case p.events <- event.GenericEvent{Object: dm}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fetchMonitors lists the monitors created from DatadogMonitors in an organization, with the downtimes silencing them
+func (p *Poller) fetchMonitors(ctx context.Context, ddClient datadogclient.DatadogClient) (map[int]polledMonitor, error) {
+	var downtimes []datadogapiclientv1.Downtime
+	err := p.callWithRateLimit(ctx, func() (*http.Response, error) {
+		var resp *http.Response
+		var err error
+		downtimes, resp, err = ddClient.Client.DowntimesApi.ListDowntimes(ddClient.Auth, *datadogapiclientv1.NewListDowntimesOptionalParameters().WithCurrentOnly(true))
+		return resp, err
+	})
+	if err != nil {
+		return nil, translateClientError(err, "error listing downtimes")
+	}
+
+	monitors := map[int]polledMonitor{}
+	params := datadogapiclientv1.NewListMonitorsOptionalParameters().
+		WithGroupStates("all").
+		WithMonitorTags(strings.Join(datadoghqv1alpha1.GetDatadogMonitorRequiredTags(), ",")).
+		WithPageSize(pollPageSize)
+	for page := int64(0); ; page++ {
+		var pageMonitors []datadogapiclientv1.Monitor
+		err = p.callWithRateLimit(ctx, func() (*http.Response, error) {
+			var resp *http.Response
+			var err error
+			pageMonitors, resp, err = ddClient.Client.MonitorsApi.ListMonitors(ddClient.Auth, *params.WithPage(page))
+			return resp, err
+		})
+		if err != nil {
+			return nil, translateClientError(err, "error listing monitors")
+		}
+
+		for _, m := range pageMonitors {
+			monitors[int(m.GetId())] = polledMonitor{monitor: m, downtimes: matchDowntimes(m, downtimes)}
+		}
+		if len(pageMonitors) < pollPageSize {
+			return monitors, nil
+		}
+	}
+}
+
+// callWithRateLimit calls the API, retrying the requests rejected by the rate limit after the end of the rate limit
+// period, and waits before returning when the rate limit is close to be reached
+func (p *Poller) callWithRateLimit(ctx context.Context, call func() (*http.Response, error)) error {
+	for retry := 0; ; retry++ {
+		resp, err := call()
+		delay := time.Duration(0)
+		if rateLimit, found := datadogclient.GetRateLimit(resp); found {
+			delay = rateLimit.Delay()
+		} else if datadogclient.IsRateLimited(resp) {
+			delay = defaultErrRequeuePeriod
+		}
+
+		if delay > 0 {
+			p.log.V(1).Info("Waiting for the API rate limit", "Delay", delay.String())
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if !datadogclient.IsRateLimited(resp) || retry >= maxRateLimitedRetries {
+			return err
+		}
+	}
+}
+
+// matchDowntimes returns the downtimes silencing a monitor, that target it by ID, or by monitor tags and scope
+func matchDowntimes(m datadogapiclientv1.Monitor, downtimes []datadogapiclientv1.Downtime) []datadogapiclientv1.Downtime {
+	tags := map[string]bool{"*": true}
+	for _, tag := range m.GetTags() {
+		tags[tag] = true
+	}
+
+	matched := []datadogapiclientv1.Downtime{}
+	for _, downtime := range downtimes {
+		if monitorID, found := downtime.GetMonitorIdOk(); found && monitorID != nil {
+			if *monitorID == m.GetId() {
+				matched = append(matched, downtime)
+			}
+			continue
+		}
+
+		match := true
+		for _, tag := range downtime.GetMonitorTags() {
+			if !tags[tag] {
+				match = false
+				break
+			}
+		}
+		if match && matchDowntimeScope(m, downtime.GetScope()) {
+			matched = append(matched, downtime)
+		}
+	}
+
+	return matched
+}
+
+// matchDowntimeScope returns whether a downtime scope, e.g. `env:prod,role:db`, matches one of the groups of a
+// monitor. All the tags of the scope must be reported by the same group; the `*` scope matches all the monitors.
+func matchDowntimeScope(m datadogapiclientv1.Monitor, scope []string) bool {
+	scopeTags := []string{}
+	for _, s := range scope {
+		for _, tag := range strings.Split(s, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" && tag != "*" {
+				scopeTags = append(scopeTags, tag)
+			}
+		}
+	}
+	if len(scopeTags) == 0 {
+		return true
+	}
+
+	state := m.GetState()
+	for group := range state.GetGroups() {
+		groupTags := map[string]bool{}
+		for _, tag := range strings.Split(group, ",") {
+			groupTags[strings.TrimSpace(tag)] = true
+		}
+
+		match := true
+		for _, tag := range scopeTags {
+			if !groupTags[tag] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
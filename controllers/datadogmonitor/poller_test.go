@@ -0,0 +1,262 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func TestPoller(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{}, &datadoghqv1alpha1.DatadogMonitorList{})
+
+	var mutex sync.Mutex
+	requests := []string{}
+	rateLimited := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/downtime":
+			assert.Equal(t, "true", r.URL.Query().Get("current_only"))
+			_, _ = w.Write([]byte(`[{"id": 7, "active": true, "monitor_tags": ["team:foo"]}]`))
+		case "/api/v1/monitor":
+			// The first request is rejected by the rate limit, and retried at the end of the period
+			if !rateLimited {
+				rateLimited = true
+				w.Header().Set("X-RateLimit-Limit", "100")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			assert.Equal(t, "generated:kubernetes", r.URL.Query().Get("monitor_tags"))
+			assert.Equal(t, "all", r.URL.Query().Get("group_states"))
+
+			// A full first page, and a second page with the last monitor
+			monitors := []datadogapiclientv1.Monitor{}
+			if r.URL.Query().Get("page") == "0" {
+				for id := int64(1); id <= pollPageSize; id++ {
+					m := *datadogapiclientv1.NewMonitor("avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1", datadogapiclientv1.MONITORTYPE_METRIC_ALERT)
+					m.SetId(id)
+					monitors = append(monitors, m)
+				}
+			} else {
+				m := *datadogapiclientv1.NewMonitor("avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.1", datadogapiclientv1.MONITORTYPE_METRIC_ALERT)
+				m.SetId(pollPageSize + 1)
+				m.SetTags([]string{"generated:kubernetes", "team:foo"})
+				m.SetState(datadogapiclientv1.MonitorState{Groups: &map[string]datadogapiclientv1.MonitorStateGroup{
+					"host:foo": {Status: datadogapiclientv1.MONITOROVERALLSTATES_ALERT.Ptr(), LastTriggeredTs: datadogapiclientv1.PtrInt64(1612244495)},
+				}})
+				monitors = append(monitors, m)
+			}
+			_ = json.NewEncoder(w).Encode(monitors)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	polled := genericDatadogMonitor()
+	polled.Finalizers = []string{datadogMonitorFinalizer}
+	polled.Spec.Tags = []string{"generated:kubernetes"}
+	polled.Status.ID = pollPageSize + 1
+	hash, err := comparison.GenerateMD5ForSpec(&polled.Spec)
+	require.NoError(t, err)
+	polled.Status.CurrentHash = hash
+	notCreated := genericDatadogMonitor()
+	notCreated.Name = "not-created"
+
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = ts.Client()
+	r := &Reconciler{
+		client:         fake.NewClientBuilder().WithScheme(s).WithObjects(polled, notCreated).Build(),
+		datadogClient:  datadogapiclientv1.NewAPIClient(testConfig),
+		datadogAuth:    setupTestAuth(ts.URL),
+		datadogClients: datadogclient.NewClientCache(),
+		scheme:         s,
+		recorder:       record.NewFakeRecorder(10),
+		log:            logf.Log.WithName(t.Name()),
+	}
+	r.poller = newPoller(r, time.Minute)
+
+	r.poller.poll(context.TODO())
+	assert.Equal(t, []string{"/api/v1/downtime", "/api/v1/monitor", "/api/v1/monitor", "/api/v1/monitor"}, requests)
+
+	// Only the DatadogMonitors of the polled monitors are reconciled
+	require.Len(t, r.poller.events, 1)
+	event := <-r.poller.events
+	assert.Equal(t, resourcesName, event.Object.GetName())
+
+	ddClient := datadogclient.DatadogClient{Client: r.datadogClient, Auth: r.datadogAuth}
+	m, found := r.poller.getMonitor(ddClient, pollPageSize+1)
+	require.True(t, found)
+	assert.Len(t, m.downtimes, 1)
+	_, found = r.poller.getMonitor(ddClient, pollPageSize+2)
+	assert.False(t, found)
+
+	// The status is updated with the polled state, without requesting the monitor
+	requests = nil
+	_, err = r.Reconcile(context.TODO(), newRequest(resourcesNamespace, resourcesName))
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	dm := &datadoghqv1alpha1.DatadogMonitor{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: resourcesName}, dm))
+	require.Len(t, dm.Status.TriggeredState, 1)
+	assert.Equal(t, "host:foo", dm.Status.TriggeredState[0].MonitorGroup)
+	assert.Equal(t, datadoghqv1alpha1.DatadogMonitorDowntimeStatus{IsDowntimed: true, DowntimeID: 7}, dm.Status.DowntimeStatus)
+	assert.Equal(t, datadoghqv1alpha1.SyncStatusOK, dm.Status.SyncStatus)
+
+	// The state is only synced again after the next poll
+	_, err = r.Reconcile(context.TODO(), newRequest(resourcesNamespace, resourcesName))
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func TestPoller_backoff(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{}, &datadoghqv1alpha1.DatadogMonitorList{})
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	dm := genericDatadogMonitor()
+	dm.Status.ID = 1
+
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = ts.Client()
+	r := &Reconciler{
+		client:        fake.NewClientBuilder().WithScheme(s).WithObjects(dm).Build(),
+		datadogClient: datadogapiclientv1.NewAPIClient(testConfig),
+		datadogAuth:   setupTestAuth(ts.URL),
+		log:           logf.Log.WithName(t.Name()),
+	}
+	r.poller = newPoller(r, time.Minute)
+
+	r.poller.poll(context.TODO())
+	assert.Equal(t, 1, requests)
+	org := r.poller.orgs[r.datadogClient]
+	assert.Equal(t, time.Minute, org.backoff)
+
+	// The organization is not polled again until the end of the backoff
+	r.poller.poll(context.TODO())
+	assert.Equal(t, 1, requests)
+
+	org.next = time.Now()
+	r.poller.poll(context.TODO())
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2*time.Minute, org.backoff)
+
+	// The monitors are fetched separately while the poller fails
+	_, found := r.poller.getMonitor(datadogclient.DatadogClient{Client: r.datadogClient, Auth: r.datadogAuth}, 1)
+	assert.False(t, found)
+}
+
+func Test_matchDowntimes(t *testing.T) {
+	m := datadogapiclientv1.Monitor{}
+	m.SetId(1)
+	m.SetTags([]string{"generated:kubernetes", "team:foo"})
+
+	downtime := func(id int64, monitorID *int64, monitorTags ...string) datadogapiclientv1.Downtime {
+		d := datadogapiclientv1.Downtime{}
+		d.SetId(id)
+		if monitorID != nil {
+			d.SetMonitorId(*monitorID)
+		}
+		if len(monitorTags) > 0 {
+			d.SetMonitorTags(monitorTags)
+		}
+		return d
+	}
+	downtimes := []datadogapiclientv1.Downtime{
+		downtime(1, datadogapiclientv1.PtrInt64(1)),
+		downtime(2, datadogapiclientv1.PtrInt64(2)),
+		downtime(3, nil, "team:foo"),
+		downtime(4, nil, "team:foo", "env:prod"),
+		downtime(5, nil, "*"),
+		downtime(6, nil),
+	}
+
+	ids := []int64{}
+	for _, d := range matchDowntimes(m, downtimes) {
+		ids = append(ids, d.GetId())
+	}
+	assert.Equal(t, []int64{1, 3, 5, 6}, ids, fmt.Sprintf("unexpected downtimes %v", ids))
+}
+
+func Test_matchDowntimes_scope(t *testing.T) {
+	prod := datadogapiclientv1.Monitor{}
+	prod.SetId(1)
+	prod.SetTags([]string{"generated:kubernetes", "team:foo"})
+	prod.SetState(datadogapiclientv1.MonitorState{Groups: &map[string]datadogapiclientv1.MonitorStateGroup{
+		"env:prod,host:a":    {},
+		"env:staging,host:b": {},
+	}})
+	unrelated := datadogapiclientv1.Monitor{}
+	unrelated.SetId(2)
+	unrelated.SetTags([]string{"generated:kubernetes", "team:bar"})
+	unrelated.SetState(datadogapiclientv1.MonitorState{Groups: &map[string]datadogapiclientv1.MonitorStateGroup{
+		"env:dev,host:c": {},
+	}})
+
+	downtime := func(id int64, scope []string, monitorTags ...string) datadogapiclientv1.Downtime {
+		d := datadogapiclientv1.Downtime{}
+		d.SetId(id)
+		d.SetScope(scope)
+		if len(monitorTags) > 0 {
+			d.SetMonitorTags(monitorTags)
+		}
+		return d
+	}
+	downtimes := []datadogapiclientv1.Downtime{
+		downtime(1, []string{"env:prod"}),
+		downtime(2, []string{"env:prod,host:a"}),
+		downtime(3, []string{"env:prod,host:b"}),
+		downtime(4, []string{"*"}),
+		downtime(5, []string{"env:prod"}, "team:foo"),
+		downtime(6, []string{"env:prod"}, "team:bar"),
+	}
+
+	ids := func(m datadogapiclientv1.Monitor) []int64 {
+		ids := []int64{}
+		for _, d := range matchDowntimes(m, downtimes) {
+			ids = append(ids, d.GetId())
+		}
+		return ids
+	}
+	assert.Equal(t, []int64{1, 2, 4, 5}, ids(prod))
+	// A downtime only targeting a scope is not attached to the monitors without a group in the scope
+	assert.Equal(t, []int64{4}, ids(unrelated))
+}
@@ -9,11 +9,14 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	"github.com/DataDog/datadog-operator/controllers/datadogmonitor"
@@ -28,6 +31,7 @@ type DatadogMonitorReconciler struct {
 	Log         logr.Logger
 	Scheme      *runtime.Scheme
 	Recorder    record.EventRecorder
+	Options     datadogmonitor.ReconcilerOptions
 	internal    *datadogmonitor.Reconciler
 }
 
@@ -42,14 +46,29 @@ func (r *DatadogMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 // SetupWithManager creates a new DatadogMonitor controller.
 func (r *DatadogMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	internal, err := datadogmonitor.NewReconciler(r.Client, r.DDClient, r.VersionInfo, r.Scheme, r.Log, r.Recorder)
+	internal, err := datadogmonitor.NewReconciler(r.Options, r.Client, r.DDClient, r.VersionInfo, r.Scheme, r.Log, r.Recorder)
 	if err != nil {
 		return err
 	}
 	r.internal = internal
 
+	// Composite DatadogMonitors are looked up by the DatadogMonitors they reference
+	if err = mgr.GetFieldIndexer().IndexField(context.TODO(), &datadoghqv1alpha1.DatadogMonitor{}, datadogmonitor.MonitorReferencesIndexKey, datadogmonitor.IndexMonitorReferences); err != nil {
+		return err
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&datadoghqv1alpha1.DatadogMonitor{})
+		For(&datadoghqv1alpha1.DatadogMonitor{}).
+		Watches(&source.Kind{Type: &datadoghqv1alpha1.DatadogMonitor{}}, handler.EnqueueRequestsFromMapFunc(internal.RequestsForDatadogMonitor)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, internal.CredentialsSecretEventHandler())
+
+	// The poller fetches the state of the monitors in bulk, and notifies the DatadogMonitors to update their status
+	if poller := internal.Poller(); poller != nil {
+		if err = mgr.Add(poller); err != nil {
+			return err
+		}
+		builder = builder.Watches(&source.Channel{Source: poller.Events()}, &handler.EnqueueRequestForObject{})
+	}
 
 	err = builder.Complete(r)
 	if err != nil {
@@ -0,0 +1,345 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitortemplate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilserrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+)
+
+const (
+	defaultErrRequeuePeriod = 5 * time.Second
+	datadogMonitorKind      = "DatadogMonitor"
+)
+
+// Reconciler reconciles a DatadogMonitorTemplate object: it generates a DatadogMonitor for each namespace or workload
+// matching its selectors, and prunes the DatadogMonitors of the objects that stop matching. The DatadogMonitors are
+// owned by their DatadogMonitorTemplate, so they are garbage-collected with it.
+type Reconciler struct {
+	client   client.Client
+	log      logr.Logger
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	return &Reconciler{
+		client:   client,
+		scheme:   scheme,
+		log:      log,
+		recorder: recorder,
+	}, nil
+}
+
+// Reconcile is similar to reconciler.Reconcile interface, but taking a context
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, request)
+}
+
+// Reconcile loop for DatadogMonitorTemplate
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogmonitortemplate", req.Name)
+	logger.Info("Reconciling DatadogMonitorTemplate")
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &datadoghqv1alpha1.DatadogMonitorTemplate{}
+	err := r.client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The generated DatadogMonitors are garbage-collected through their owner reference
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	if !instance.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	newStatus := instance.Status.DeepCopy()
+
+	// Validate the DatadogMonitorTemplate spec
+	if err = datadoghqv1alpha1.IsValidDatadogMonitorTemplate(&instance.Spec); err != nil {
+		logger.Error(err, "invalid DatadogMonitorTemplate spec")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, ctrl.Result{})
+	}
+
+	targets, err := r.listTargets(ctx, instance)
+	if err != nil {
+		logger.Error(err, "unable to list the objects matching the selectors")
+
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	// The DatadogMonitors previously generated from the template
+	dmList := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err = r.client.List(ctx, dmList, client.MatchingLabels{datadoghqv1alpha1.DatadogMonitorTemplateNameLabelKey: instance.Name}); err != nil {
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	owned := map[types.NamespacedName]*datadoghqv1alpha1.DatadogMonitor{}
+	for i := range dmList.Items {
+		dm := &dmList.Items[i]
+		if metav1.IsControlledBy(dm, instance) {
+			owned[types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}] = dm
+		}
+	}
+
+	// Generate the DatadogMonitors of the matching objects. An invalid DatadogMonitor does not prevent the other ones
+	// from being generated, and does not delete the DatadogMonitor previously generated for the object: the errors
+	// are reported in the conditions.
+	var errs []error
+	var generated []*datadoghqv1alpha1.DatadogMonitor
+	for _, obj := range targets {
+		key := monitorKey(instance, obj)
+		current, found := owned[key]
+		delete(owned, key)
+
+		dm, syncErr := renderMonitor(instance, obj)
+		if syncErr != nil {
+			logger.Error(syncErr, "unable to render DatadogMonitor")
+			errs = append(errs, syncErr)
+			if found {
+				generated = append(generated, current)
+			}
+			continue
+		}
+		if found {
+			dm, syncErr = r.updateMonitor(ctx, logger, instance, current, dm)
+		} else {
+			dm, syncErr = r.createMonitor(ctx, logger, instance, dm)
+		}
+		if syncErr != nil {
+			logger.Error(syncErr, "unable to generate DatadogMonitor")
+			errs = append(errs, syncErr)
+			continue
+		}
+		generated = append(generated, dm)
+	}
+
+	// The DatadogMonitors of the objects that stopped matching the selectors
+	for _, dm := range owned {
+		if err = r.deleteMonitor(ctx, logger, instance, dm); err != nil {
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+	}
+
+	newStatus.Monitors = monitorStatuses(generated)
+	newStatus.MonitorCount = int32(len(newStatus.Monitors))
+
+	return r.updateStatusIfNeeded(logger, instance, now, newStatus, utilserrors.NewAggregate(errs), ctrl.Result{})
+}
+
+// listTargets lists the namespaces or workloads matching the selectors of a DatadogMonitorTemplate
+func (r *Reconciler) listTargets(ctx context.Context, dmt *datadoghqv1alpha1.DatadogMonitorTemplate) ([]client.Object, error) {
+	nsSelector, err := labelSelectorAsSelector(dmt.Spec.Selector.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	nsList := &corev1.NamespaceList{}
+	if err = r.client.List(ctx, nsList, client.MatchingLabelsSelector{Selector: nsSelector}); err != nil {
+		return nil, err
+	}
+
+	var targets []client.Object
+	namespaces := map[string]bool{}
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		if !ns.GetDeletionTimestamp().IsZero() || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		namespaces[ns.Name] = true
+		targets = append(targets, ns)
+	}
+
+	var workloadList client.ObjectList
+	switch dmt.Spec.Selector.GetKind() {
+	case datadoghqv1alpha1.DatadogMonitorTemplateTargetKindNamespace:
+		return targets, nil
+	case datadoghqv1alpha1.DatadogMonitorTemplateTargetKindDeployment:
+		workloadList = &appsv1.DeploymentList{}
+	case datadoghqv1alpha1.DatadogMonitorTemplateTargetKindStatefulSet:
+		workloadList = &appsv1.StatefulSetList{}
+	}
+
+	selector, err := labelSelectorAsSelector(dmt.Spec.Selector.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.client.List(ctx, workloadList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(workloadList)
+	if err != nil {
+		return nil, err
+	}
+
+	targets = nil
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || !namespaces[obj.GetNamespace()] || !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		targets = append(targets, obj)
+	}
+
+	return targets, nil
+}
+
+func (r *Reconciler) createMonitor(ctx context.Context, logger logr.Logger, dmt *datadoghqv1alpha1.DatadogMonitorTemplate, dm *datadoghqv1alpha1.DatadogMonitor) (*datadoghqv1alpha1.DatadogMonitor, error) {
+	// A DatadogMonitor with the same name may exist, created by hand or from another template
+	existing := &datadoghqv1alpha1.DatadogMonitor{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name}, existing)
+	if err == nil {
+		return nil, fmt.Errorf("DatadogMonitor %s/%s already exists and is not generated from the DatadogMonitorTemplate", dm.Namespace, dm.Name)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err = controllerutil.SetControllerReference(dmt, dm, r.scheme); err != nil {
+		return nil, err
+	}
+	if err = r.client.Create(ctx, dm); err != nil {
+		return nil, fmt.Errorf("unable to create DatadogMonitor %s/%s: %w", dm.Namespace, dm.Name, err)
+	}
+	logger.Info("Generated DatadogMonitor", "Monitor Namespace", dm.Namespace, "Monitor Name", dm.Name)
+	r.recordEvent(dmt, dm, datadog.CreationEvent)
+
+	return dm, nil
+}
+
+func (r *Reconciler) updateMonitor(ctx context.Context, logger logr.Logger, dmt *datadoghqv1alpha1.DatadogMonitorTemplate, current, desired *datadoghqv1alpha1.DatadogMonitor) (*datadoghqv1alpha1.DatadogMonitor, error) {
+	if apiequality.Semantic.DeepEqual(current.Spec, desired.Spec) {
+		return current, nil
+	}
+
+	updated := current.DeepCopy()
+	updated.Spec = desired.Spec
+	if err := r.client.Update(ctx, updated); err != nil {
+		return nil, fmt.Errorf("unable to update DatadogMonitor %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+	logger.Info("Updated generated DatadogMonitor", "Monitor Namespace", updated.Namespace, "Monitor Name", updated.Name)
+	r.recordEvent(dmt, updated, datadog.UpdateEvent)
+
+	return updated, nil
+}
+
+func (r *Reconciler) deleteMonitor(ctx context.Context, logger logr.Logger, dmt *datadoghqv1alpha1.DatadogMonitorTemplate, dm *datadoghqv1alpha1.DatadogMonitor) error {
+	if err := r.client.Delete(ctx, dm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete DatadogMonitor %s/%s: %w", dm.Namespace, dm.Name, err)
+	}
+	logger.Info("Deleted generated DatadogMonitor", "Monitor Namespace", dm.Namespace, "Monitor Name", dm.Name)
+	r.recordEvent(dmt, dm, datadog.DeletionEvent)
+
+	return nil
+}
+
+// recordEvent records an event on the DatadogMonitorTemplate about one of its DatadogMonitors
+func (r *Reconciler) recordEvent(dmt *datadoghqv1alpha1.DatadogMonitorTemplate, dm *datadoghqv1alpha1.DatadogMonitor, eventType datadog.EventType) {
+	info := utils.BuildEventInfo(dm.Name, dm.Namespace, datadogMonitorKind, eventType)
+	r.recorder.Event(dmt, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
+}
+
+// RequestsForKind returns a function mapping the namespaces or workloads of a kind to the reconcile requests of the
+// DatadogMonitorTemplates selecting that kind. Namespaces are mapped to all the DatadogMonitorTemplates, as the
+// namespace selectors apply to the workloads too.
+func (r *Reconciler) RequestsForKind(kind datadoghqv1alpha1.DatadogMonitorTemplateTargetKind) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		dmtList := &datadoghqv1alpha1.DatadogMonitorTemplateList{}
+		if err := r.client.List(context.TODO(), dmtList); err != nil {
+			r.log.Error(err, "unable to list DatadogMonitorTemplates")
+
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, dmt := range dmtList.Items {
+			if kind == datadoghqv1alpha1.DatadogMonitorTemplateTargetKindNamespace || dmt.Spec.Selector.GetKind() == kind {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: dmt.Name}})
+			}
+		}
+
+		return requests
+	}
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, dmt *datadoghqv1alpha1.DatadogMonitorTemplate, now metav1.Time, status *datadoghqv1alpha1.DatadogMonitorTemplateStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
+	// Update Error and Active conditions
+	condition.SetDatadogMonitorTemplateErrorActiveConditions(status, now, currentErr)
+
+	if !apiequality.Semantic.DeepEqual(&dmt.Status, status) {
+		dmt.Status = *status
+		if err := r.client.Status().Update(context.TODO(), dmt); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogMonitorTemplate status due to update conflict")
+
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogMonitorTemplate status")
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// monitorStatuses returns the states of the generated DatadogMonitors, sorted by namespace and name
+func monitorStatuses(monitors []*datadoghqv1alpha1.DatadogMonitor) []datadoghqv1alpha1.DatadogMonitorTemplateMonitorStatus {
+	var statuses []datadoghqv1alpha1.DatadogMonitorTemplateMonitorStatus
+	for _, dm := range monitors {
+		statuses = append(statuses, datadoghqv1alpha1.DatadogMonitorTemplateMonitorStatus{
+			Namespace:    dm.Namespace,
+			Name:         dm.Name,
+			ID:           dm.Status.ID,
+			MonitorState: dm.Status.MonitorState,
+			SyncStatus:   dm.Status.SyncStatus,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// labelSelectorAsSelector converts a label selector, selecting everything when it is not set
+func labelSelectorAsSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
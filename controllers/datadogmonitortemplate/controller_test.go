@@ -0,0 +1,262 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitortemplate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const templateName = "restarts"
+
+func TestReconciler_Reconcile(t *testing.T) {
+	s := testScheme()
+
+	dmt := &datadoghqv1alpha1.DatadogMonitorTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: templateName, UID: "0a1b2c"},
+		Spec: datadoghqv1alpha1.DatadogMonitorTemplateSpec{
+			Selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"monitoring": "enabled"}},
+			},
+			Template: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "Restarts in {{ .Name }}",
+				Message: "Pods restart in {{ .Name }}",
+				Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Name }}} > 5",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			},
+		},
+	}
+	// A DatadogMonitor created by hand with the name of a generated one is left untouched
+	existing := &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: templateName},
+		Spec:       datadoghqv1alpha1.DatadogMonitorSpec{Query: "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5"},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			dmt,
+			existing,
+			testNamespace("team-a", map[string]string{"monitoring": "enabled"}),
+			testNamespace("team-b", map[string]string{"monitoring": "enabled"}),
+			testNamespace("team-c", nil),
+		).Build(),
+		scheme:   s,
+		log:      logf.Log.WithName(t.Name()),
+		recorder: recorder,
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: templateName}}
+
+	// A DatadogMonitor is generated in each selected namespace, owned by the DatadogMonitorTemplate
+	_, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	dm := getDatadogMonitor(t, r.client, "team-a")
+	assert.True(t, metav1.IsControlledBy(dm, dmt))
+	assert.Equal(t, "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:team-a} > 5", dm.Spec.Query)
+	assert.Equal(t, existing.Spec, getDatadogMonitor(t, r.client, "team-b").Spec)
+	assert.Equal(t, "Normal Create DatadogMonitor team-a/restarts", <-recorder.Events)
+
+	dmt = getTemplate(t, r.client)
+	assert.Equal(t, []datadoghqv1alpha1.DatadogMonitorTemplateMonitorStatus{{Namespace: "team-a", Name: templateName}}, dmt.Status.Monitors)
+	assert.Equal(t, int32(1), dmt.Status.MonitorCount)
+	assert.Equal(t, "DatadogMonitor team-b/restarts already exists and is not generated from the DatadogMonitorTemplate", getCondition(dmt, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError).Message)
+
+	// New matching namespaces get a DatadogMonitor, and the status reports the state of the DatadogMonitors
+	require.NoError(t, r.client.Delete(context.TODO(), existing))
+	ns := &corev1.Namespace{}
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: "team-c"}, ns))
+	ns.Labels = map[string]string{"monitoring": "enabled"}
+	require.NoError(t, r.client.Update(context.TODO(), ns))
+	dm.Status.ID = 12345
+	dm.Status.MonitorState = datadoghqv1alpha1.DatadogMonitorStateOK
+	dm.Status.SyncStatus = datadoghqv1alpha1.SyncStatusOK
+	require.NoError(t, r.client.Status().Update(context.TODO(), dm))
+
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	getDatadogMonitor(t, r.client, "team-b")
+	getDatadogMonitor(t, r.client, "team-c")
+	assert.Equal(t, "Normal Create DatadogMonitor team-b/restarts", <-recorder.Events)
+	assert.Equal(t, "Normal Create DatadogMonitor team-c/restarts", <-recorder.Events)
+
+	dmt = getTemplate(t, r.client)
+	assert.Equal(t, []datadoghqv1alpha1.DatadogMonitorTemplateMonitorStatus{
+		{Namespace: "team-a", Name: templateName, ID: 12345, MonitorState: datadoghqv1alpha1.DatadogMonitorStateOK, SyncStatus: datadoghqv1alpha1.SyncStatusOK},
+		{Namespace: "team-b", Name: templateName},
+		{Namespace: "team-c", Name: templateName},
+	}, dmt.Status.Monitors)
+	assert.Equal(t, corev1.ConditionFalse, getCondition(dmt, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError).Status)
+	assert.Equal(t, corev1.ConditionTrue, getCondition(dmt, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeActive).Status)
+
+	// The DatadogMonitors are kept in sync with the template
+	dmt.Spec.Template.Query = "avg(last_15m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Name }}} > 10"
+	require.NoError(t, r.client.Update(context.TODO(), dmt))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "avg(last_15m):avg:kubernetes.containers.restarts{kube_namespace:team-a} > 10", getDatadogMonitor(t, r.client, "team-a").Spec.Query)
+	assert.Len(t, recorder.Events, 3)
+	for i := 0; i < 3; i++ {
+		assert.Contains(t, <-recorder.Events, "Normal Update DatadogMonitor")
+	}
+
+	// The DatadogMonitors are kept when the template cannot be rendered
+	dmt = getTemplate(t, r.client)
+	dmt.Spec.Template.Message = "Pods restart in {{ .Name }} @{{ .Labels.team }}"
+	require.NoError(t, r.client.Update(context.TODO(), dmt))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	dmt = getTemplate(t, r.client)
+	assert.Equal(t, int32(3), dmt.Status.MonitorCount)
+	assert.Equal(t, corev1.ConditionTrue, getCondition(dmt, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError).Status)
+	assert.Contains(t, getCondition(dmt, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError).Message, `map has no entry for key "team"`)
+	assert.Empty(t, recorder.Events)
+	dmt.Spec.Template.Message = "Pods restart in {{ .Name }}"
+	require.NoError(t, r.client.Update(context.TODO(), dmt))
+
+	// Nothing changes without changes of the template or of the selected namespaces
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
+	// The DatadogMonitors of the namespaces that stop matching the selector are deleted
+	require.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: "team-a"}, ns))
+	ns.Labels = nil
+	require.NoError(t, r.client.Update(context.TODO(), ns))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: templateName}, &datadoghqv1alpha1.DatadogMonitor{})
+	assert.True(t, apierrors.IsNotFound(err), "the DatadogMonitor should be deleted")
+	assert.Equal(t, "Normal Delete DatadogMonitor team-a/restarts", <-recorder.Events)
+	assert.Equal(t, int32(2), getTemplate(t, r.client).Status.MonitorCount)
+}
+
+func TestReconciler_listTargets(t *testing.T) {
+	s := testScheme()
+	deployment := func(namespace, name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+	}
+	r := &Reconciler{
+		client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+			testNamespace("team-a", map[string]string{"monitoring": "enabled"}),
+			testNamespace("team-b", nil),
+			deployment("team-a", "web", map[string]string{"tier": "web"}),
+			deployment("team-a", "worker", map[string]string{"tier": "worker"}),
+			deployment("team-b", "web", map[string]string{"tier": "web"}),
+			&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "db"}},
+		).Build(),
+		scheme: s,
+		log:    logf.Log.WithName(t.Name()),
+	}
+
+	tests := []struct {
+		name     string
+		selector datadoghqv1alpha1.DatadogMonitorTemplateSelector
+		want     []string
+	}{
+		{
+			name: "all namespaces",
+			want: []string{"team-a", "team-b"},
+		},
+		{
+			name: "selected namespaces",
+			selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"monitoring": "enabled"}},
+			},
+			want: []string{"team-a"},
+		},
+		{
+			name: "deployments of all namespaces",
+			selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{
+				Kind:          datadoghqv1alpha1.DatadogMonitorTemplateTargetKindDeployment,
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			},
+			want: []string{"team-a/web", "team-b/web"},
+		},
+		{
+			name: "deployments of selected namespaces",
+			selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{
+				Kind:              datadoghqv1alpha1.DatadogMonitorTemplateTargetKindDeployment,
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"monitoring": "enabled"}},
+			},
+			want: []string{"team-a/web", "team-a/worker"},
+		},
+		{
+			name: "statefulsets",
+			selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{
+				Kind: datadoghqv1alpha1.DatadogMonitorTemplateTargetKindStatefulSet,
+			},
+			want: []string{"team-a/db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmt := &datadoghqv1alpha1.DatadogMonitorTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: templateName},
+				Spec:       datadoghqv1alpha1.DatadogMonitorTemplateSpec{Selector: tt.selector},
+			}
+			targets, err := r.listTargets(context.TODO(), dmt)
+			require.NoError(t, err)
+			var keys []string
+			for _, obj := range targets {
+				keys = append(keys, objectKey(obj))
+			}
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+}
+
+func testScheme() *runtime.Scheme {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion,
+		&datadoghqv1alpha1.DatadogMonitorTemplate{},
+		&datadoghqv1alpha1.DatadogMonitorTemplateList{},
+		&datadoghqv1alpha1.DatadogMonitor{},
+		&datadoghqv1alpha1.DatadogMonitorList{},
+	)
+	return s
+}
+
+func testNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func getDatadogMonitor(t *testing.T, c client.Client, namespace string) *datadoghqv1alpha1.DatadogMonitor {
+	dm := &datadoghqv1alpha1.DatadogMonitor{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: templateName}, dm))
+	return dm
+}
+
+func getTemplate(t *testing.T, c client.Client) *datadoghqv1alpha1.DatadogMonitorTemplate {
+	dmt := &datadoghqv1alpha1.DatadogMonitorTemplate{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: templateName}, dmt))
+	return dmt
+}
+
+func getCondition(dmt *datadoghqv1alpha1.DatadogMonitorTemplate, conditionType datadoghqv1alpha1.DatadogMonitorTemplateConditionType) datadoghqv1alpha1.DatadogMonitorTemplateCondition {
+	for _, condition := range dmt.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition
+		}
+	}
+	return datadoghqv1alpha1.DatadogMonitorTemplateCondition{}
+}
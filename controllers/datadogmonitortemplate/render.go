@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitortemplate
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// templateData is the data the name, message and query of a DatadogMonitorTemplate are rendered with
+type templateData struct {
+	// Kind is the kind of the matched object: Namespace, Deployment or StatefulSet
+	Kind string
+	// Name is the name of the matched object
+	Name string
+	// Namespace is the namespace of the matched workload, or the name of the matched namespace
+	Namespace string
+	// Labels are the labels of the matched object
+	Labels map[string]string
+	// Annotations are the annotations of the matched object
+	Annotations map[string]string
+}
+
+// renderMonitor renders the DatadogMonitor generated from a DatadogMonitorTemplate for a matched namespace or workload
+func renderMonitor(dmt *datadoghqv1alpha1.DatadogMonitorTemplate, obj client.Object) (*datadoghqv1alpha1.DatadogMonitor, error) {
+	kind := dmt.Spec.Selector.GetKind()
+	key := monitorKey(dmt, obj)
+	data := templateData{
+		Kind:        string(kind),
+		Name:        obj.GetName(),
+		Namespace:   key.Namespace,
+		Labels:      obj.GetLabels(),
+		Annotations: obj.GetAnnotations(),
+	}
+
+	spec := dmt.Spec.Template.DeepCopy()
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"spec.Template.Name", &spec.Name},
+		{"spec.Template.Message", &spec.Message},
+		{"spec.Template.Query", &spec.Query},
+	} {
+		rendered, err := renderField(field.name, *field.value, data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render the DatadogMonitor of %s %s: %w", kind, objectKey(obj), err)
+		}
+		*field.value = rendered
+	}
+
+	dm := &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Labels:    map[string]string{datadoghqv1alpha1.DatadogMonitorTemplateNameLabelKey: dmt.Name},
+		},
+		Spec: *spec,
+	}
+	// Add the required tags added by the DatadogMonitor webhook and controller, so that the DatadogMonitor is not
+	// updated back and forth
+	datadoghqv1alpha1.DefaultDatadogMonitor(dm)
+	if err := datadoghqv1alpha1.IsValidDatadogMonitor(&dm.Spec); err != nil {
+		return nil, fmt.Errorf("invalid DatadogMonitor %s/%s rendered for %s %s: %w", dm.Namespace, dm.Name, kind, objectKey(obj), err)
+	}
+
+	return dm, nil
+}
+
+// monitorKey returns the namespace and name of the DatadogMonitor generated from a DatadogMonitorTemplate for a matched
+// namespace or workload
+func monitorKey(dmt *datadoghqv1alpha1.DatadogMonitorTemplate, obj client.Object) types.NamespacedName {
+	if dmt.Spec.Selector.GetKind() == datadoghqv1alpha1.DatadogMonitorTemplateTargetKindNamespace {
+		return types.NamespacedName{Namespace: obj.GetName(), Name: dmt.Name}
+	}
+	return types.NamespacedName{Namespace: obj.GetNamespace(), Name: fmt.Sprintf("%s-%s", dmt.Name, obj.GetName())}
+}
+
+func renderField(name, value string, data templateData) (string, error) {
+	// Referencing a missing label or annotation with .Labels.key is an error, `index` can be used for optional ones
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func objectKey(obj client.Object) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+}
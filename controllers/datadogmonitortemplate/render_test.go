@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogmonitortemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_renderMonitor(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "bar", Labels: map[string]string{"team": "web"}},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "bar",
+			Name:        "foo",
+			Labels:      map[string]string{"team": "web"},
+			Annotations: map[string]string{"owner": "@web-team"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		kind     datadoghqv1alpha1.DatadogMonitorTemplateTargetKind
+		template datadoghqv1alpha1.DatadogMonitorSpec
+		obj      client.Object
+		want     *datadoghqv1alpha1.DatadogMonitor
+		wantErr  string
+	}{
+		{
+			name: "namespace",
+			template: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "Restarts in {{ .Namespace }}",
+				Message: "Pods restart in {{ .Name }} @{{ .Labels.team }}",
+				Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Namespace }}} > 5",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+				Tags:    []string{"team:{{ .Labels.team }}"},
+			},
+			obj: namespace,
+			want: &datadoghqv1alpha1.DatadogMonitor{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "bar",
+					Name:      "restarts",
+					Labels:    map[string]string{"monitors.datadoghq.com/datadogmonitortemplate": "restarts"},
+				},
+				Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+					Name:    "Restarts in bar",
+					Message: "Pods restart in bar @web",
+					Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:bar} > 5",
+					Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+					// Only the name, message and query are templated
+					Tags: []string{"team:{{ .Labels.team }}", "generated:kubernetes"},
+				},
+			},
+		},
+		{
+			name: "deployment",
+			kind: datadoghqv1alpha1.DatadogMonitorTemplateTargetKindDeployment,
+			template: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "{{ .Kind }} {{ .Namespace }}/{{ .Name }} restarts",
+				Message: "Pods restart {{ index .Annotations \"owner\" }}{{ index .Annotations \"missing\" }}",
+				Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Namespace }},kube_deployment:{{ .Name }}} > 5",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			},
+			obj: deployment,
+			want: &datadoghqv1alpha1.DatadogMonitor{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "bar",
+					Name:      "restarts-foo",
+					Labels:    map[string]string{"monitors.datadoghq.com/datadogmonitortemplate": "restarts"},
+				},
+				Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+					Name:    "Deployment bar/foo restarts",
+					Message: "Pods restart @web-team",
+					Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:bar,kube_deployment:foo} > 5",
+					Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+					Tags:    []string{"generated:kubernetes"},
+				},
+			},
+		},
+		{
+			name: "missing label",
+			template: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "Restarts in {{ .Namespace }}",
+				Message: "Pods restart @{{ .Labels.owner }}",
+				Query:   "avg(last_10m):avg:kubernetes.containers.restarts{kube_namespace:{{ .Namespace }}} > 5",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			},
+			obj:     namespace,
+			wantErr: `unable to render the DatadogMonitor of Namespace bar: template: spec.Template.Message:1:24: executing "spec.Template.Message" at <.Labels.owner>: map has no entry for key "owner"`,
+		},
+		{
+			name: "invalid rendered monitor",
+			template: datadoghqv1alpha1.DatadogMonitorSpec{
+				Name:    "Restarts in {{ .Namespace }}",
+				Message: "Pods restart",
+				Query:   "{{ index .Labels \"query\" }}",
+				Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			},
+			obj:     namespace,
+			wantErr: "invalid DatadogMonitor bar/restarts rendered for Namespace bar: spec.Query must be defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmt := &datadoghqv1alpha1.DatadogMonitorTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "restarts"},
+				Spec: datadoghqv1alpha1.DatadogMonitorTemplateSpec{
+					Selector: datadoghqv1alpha1.DatadogMonitorTemplateSelector{Kind: tt.kind},
+					Template: tt.template,
+				},
+			}
+
+			dm, err := renderMonitor(dmt, tt.obj)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dm)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/datadogmonitortemplate"
+)
+
+// DatadogMonitorTemplateReconciler reconciles a DatadogMonitorTemplate object.
+type DatadogMonitorTemplateReconciler struct {
+	Client   client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	internal *datadogmonitortemplate.Reconciler
+}
+
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogmonitortemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogmonitortemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogmonitortemplates/finalizers,verbs=update
+// +kubebuilder:rbac:groups=datadoghq.com,resources=datadogmonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+
+// Reconcile loop for DatadogMonitorTemplate.
+func (r *DatadogMonitorTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.internal.Reconcile(ctx, req)
+}
+
+// SetupWithManager creates a new DatadogMonitorTemplate controller.
+func (r *DatadogMonitorTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	internal, err := datadogmonitortemplate.NewReconciler(r.Client, r.Scheme, r.Log, r.Recorder)
+	if err != nil {
+		return err
+	}
+	r.internal = internal
+
+	// The status updates of the DatadogMonitorTemplates do not need to be reconciled
+	generationChanged := builder.WithPredicates(predicate.GenerationChangedPredicate{})
+	// The templates are rendered with the labels and annotations of the matched objects
+	metadataChanged := builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&datadoghqv1alpha1.DatadogMonitorTemplate{}, generationChanged).
+		Owns(&datadoghqv1alpha1.DatadogMonitor{}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(internal.RequestsForKind(datadoghqv1alpha1.DatadogMonitorTemplateTargetKindNamespace)), metadataChanged).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(internal.RequestsForKind(datadoghqv1alpha1.DatadogMonitorTemplateTargetKindDeployment)), metadataChanged).
+		Watches(&source.Kind{Type: &appsv1.StatefulSet{}}, handler.EnqueueRequestsFromMapFunc(internal.RequestsForKind(datadoghqv1alpha1.DatadogMonitorTemplateTargetKindStatefulSet)), metadataChanged)
+
+	err = builder.Complete(r)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,352 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	ctrUtils "github.com/DataDog/datadog-operator/pkg/controller/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/condition"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+const (
+	defaultRequeuePeriod    = 60 * time.Second
+	defaultErrRequeuePeriod = 5 * time.Second
+)
+
+// Reconciler reconciles a DatadogSLO object
+type Reconciler struct {
+	client        client.Client
+	datadogClient *datadogapiclientv1.APIClient
+	datadogAuth   context.Context
+	versionInfo   *version.Info
+	log           logr.Logger
+	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(client client.Client, ddClient datadogclient.DatadogClient, versionInfo *version.Info, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	return &Reconciler{
+		client:        client,
+		datadogClient: ddClient.Client,
+		datadogAuth:   ddClient.Auth,
+		versionInfo:   versionInfo,
+		scheme:        scheme,
+		log:           log,
+		recorder:      recorder,
+	}, nil
+}
+
+// Reconcile is similar to reconciler.Reconcile interface, but taking a context
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, request)
+}
+
+// Reconcile loop for DatadogSLO
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues("datadogslo", req.NamespacedName)
+	logger.Info("Reconciling DatadogSLO")
+	now := metav1.NewTime(time.Now())
+
+	// Get instance
+	instance := &datadoghqv1alpha1.DatadogSLO{}
+	var result ctrl.Result
+	err := r.client.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return result, nil
+		}
+		// Error reading the object - requeue the request
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+
+	newStatus := instance.Status.DeepCopy()
+
+	if result, err = r.handleFinalizer(logger, instance); ctrUtils.ShouldReturn(result, err) {
+		return result, err
+	}
+
+	// Validate the DatadogSLO spec
+	if err = datadoghqv1alpha1.IsValidDatadogSLO(&instance.Spec); err != nil {
+		logger.Error(err, "invalid DatadogSLO spec")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Resolve the DatadogMonitor references. The SLO is not created or updated until all the referenced
+	// monitors exist in Datadog; the controller is notified when their status changes.
+	monitorIDs, err := r.resolveMonitorIDs(ctx, instance)
+	if err != nil {
+		logger.Error(err, "error resolving monitor references")
+		newStatus.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusMonitorRefError
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, ctrl.Result{RequeueAfter: defaultRequeuePeriod})
+	}
+
+	instanceSpecHash, err := comparison.GenerateMD5ForSpec(&instance.Spec)
+	if err != nil {
+		logger.Error(err, "error generating hash")
+
+		return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+	}
+
+	// Create or update SLO, or sync the SLI value. Fall through this block (without returning)
+	// if the result should be requeued with the default period
+	if instance.Status.ID == "" {
+		logger.V(1).Info("SLO ID is not set; creating SLO in Datadog")
+		// Make sure required tags are present
+		if result, err = r.checkRequiredTags(logger, instance); err != nil || result.Requeue {
+			return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+		}
+
+		if err = r.create(logger, instance, newStatus, monitorIDs, now); err != nil {
+			logger.Error(err, "error creating SLO")
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else if instanceSpecHash != instance.Status.CurrentHash || !apiequality.Semantic.DeepEqual(monitorIDs, instance.Status.MonitorIDs) {
+		// Make sure required tags are present
+		if result, err = r.checkRequiredTags(logger, instance); err != nil || result.Requeue {
+			return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+		}
+
+		if err = r.update(logger, instance, newStatus, monitorIDs, now); err != nil {
+			logger.Error(err, "error updating SLO", "SLO ID", instance.Status.ID)
+		} else {
+			newStatus.CurrentHash = instanceSpecHash
+		}
+	} else {
+		// Spec has not changed, just sync the SLI value and the error budget.
+		// We only do it every defaultRequeuePeriod to avoid overloading APIServer and DD
+		if instance.Status.SLOStatusLastSyncTime != nil {
+			nextUpdateIn := defaultRequeuePeriod - now.Sub(instance.Status.SLOStatusLastSyncTime.Time)
+			if nextUpdateIn > 0 {
+				return ctrl.Result{RequeueAfter: nextUpdateIn}, nil
+			}
+		}
+
+		if err = r.get(logger, instance, newStatus, now); err != nil {
+			logger.Error(err, "error getting SLO history", "SLO ID", instance.Status.ID)
+		}
+	}
+
+	// Requeue
+	if !result.Requeue && result.RequeueAfter == 0 {
+		result.RequeueAfter = defaultRequeuePeriod
+	}
+
+	// Update the status
+	return r.updateStatusIfNeeded(logger, instance, now, newStatus, err, result)
+}
+
+func (r *Reconciler) create(logger logr.Logger, datadogSLO *datadoghqv1alpha1.DatadogSLO, status *datadoghqv1alpha1.DatadogSLOStatus, monitorIDs []int64, now metav1.Time) error {
+	// Create SLO in Datadog
+	s, err := createSLO(r.datadogAuth, r.datadogClient, datadogSLO, monitorIDs)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusCreateError
+		return err
+	}
+	event := buildEventInfo(datadogSLO.Name, datadogSLO.Namespace, datadog.CreationEvent)
+	r.recordEvent(datadogSLO, event)
+
+	// As this is a new SLO, add static information to status
+	status.ID = s.GetId()
+	creator := s.GetCreator()
+	status.Creator = creator.GetEmail()
+	createdTime := metav1.NewTime(time.Unix(s.GetCreatedAt(), 0))
+	status.Created = &createdTime
+	status.MonitorIDs = monitorIDs
+	status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusOK
+
+	// Set Created Condition
+	condition.UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeCreated, corev1.ConditionTrue, "DatadogSLO Created")
+	logger.Info("Created a new DatadogSLO", "SLO Namespace", datadogSLO.Namespace, "SLO Name", datadogSLO.Name, "SLO ID", s.GetId())
+
+	return nil
+}
+
+func (r *Reconciler) update(logger logr.Logger, datadogSLO *datadoghqv1alpha1.DatadogSLO, status *datadoghqv1alpha1.DatadogSLOStatus, monitorIDs []int64, now metav1.Time) error {
+	// Update SLO in Datadog
+	if _, err := updateSLO(r.datadogAuth, r.datadogClient, datadogSLO, monitorIDs); err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusUpdateError
+		return err
+	}
+
+	event := buildEventInfo(datadogSLO.Name, datadogSLO.Namespace, datadog.UpdateEvent)
+	r.recordEvent(datadogSLO, event)
+
+	status.MonitorIDs = monitorIDs
+	status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusOK
+
+	// Set Updated Condition
+	condition.UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeUpdated, corev1.ConditionTrue, "DatadogSLO Updated")
+	logger.Info("Updated DatadogSLO", "SLO Namespace", datadogSLO.Namespace, "SLO Name", datadogSLO.Name, "SLO ID", datadogSLO.Status.ID)
+
+	return nil
+}
+
+func (r *Reconciler) get(logger logr.Logger, datadogSLO *datadoghqv1alpha1.DatadogSLO, status *datadoghqv1alpha1.DatadogSLOStatus, now metav1.Time) error {
+	threshold := datadogSLO.Spec.Thresholds[0]
+	history, err := getSLOHistory(r.datadogAuth, r.datadogClient, datadogSLO.Status.ID, threshold.Timeframe, now.Time)
+	if err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusGetError
+		return err
+	}
+
+	if err = convertHistoryToStatus(history, threshold, status); err != nil {
+		status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusGetError
+		return err
+	}
+	status.SLOStatusLastSyncTime = &now
+	status.SyncStatus = datadoghqv1alpha1.DatadogSLOSyncStatusOK
+	logger.V(1).Info("Synced DatadogSLO state", "SLO Namespace", datadogSLO.Namespace, "SLO Name", datadogSLO.Name, "SLO ID", datadogSLO.Status.ID)
+
+	return nil
+}
+
+// resolveMonitorIDs returns the IDs of the monitors in Spec.MonitorIDs, followed by the IDs of the DatadogMonitors
+// in Spec.MonitorRefs. It returns an error if a referenced DatadogMonitor does not exist or has no ID yet.
+func (r *Reconciler) resolveMonitorIDs(ctx context.Context, datadogSLO *datadoghqv1alpha1.DatadogSLO) ([]int64, error) {
+	monitorIDs := []int64{}
+	seen := map[int64]bool{}
+	add := func(id int64) {
+		if !seen[id] {
+			seen[id] = true
+			monitorIDs = append(monitorIDs, id)
+		}
+	}
+
+	for _, id := range datadogSLO.Spec.MonitorIDs {
+		add(id)
+	}
+
+	for _, ref := range datadogSLO.Spec.MonitorRefs {
+		dm := &datadoghqv1alpha1.DatadogMonitor{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: datadogSLO.Namespace, Name: ref.Name}, dm); err != nil {
+			return nil, fmt.Errorf("unable to get DatadogMonitor %s/%s: %w", datadogSLO.Namespace, ref.Name, err)
+		}
+		if dm.Status.ID == 0 {
+			return nil, fmt.Errorf("DatadogMonitor %s/%s has not been created in Datadog yet", datadogSLO.Namespace, ref.Name)
+		}
+		add(int64(dm.Status.ID))
+	}
+
+	if len(monitorIDs) == 0 {
+		return nil, nil
+	}
+
+	return monitorIDs, nil
+}
+
+// RequestsForDatadogMonitor returns the reconcile requests of the DatadogSLOs referencing a DatadogMonitor,
+// so that monitor-based SLOs are created or updated when the monitor ID becomes available or changes
+func (r *Reconciler) RequestsForDatadogMonitor(obj client.Object) []reconcile.Request {
+	sloList := &datadoghqv1alpha1.DatadogSLOList{}
+	if err := r.client.List(context.TODO(), sloList, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "unable to list DatadogSLOs", "namespace", obj.GetNamespace())
+
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, slo := range sloList.Items {
+		for _, ref := range slo.Spec.MonitorRefs {
+			if ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: slo.Namespace, Name: slo.Name}})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+func (r *Reconciler) checkRequiredTags(logger logr.Logger, datadogSLO *datadoghqv1alpha1.DatadogSLO) (ctrl.Result, error) {
+	tagsToAdd := []string{}
+	var found bool
+	tags := datadogSLO.Spec.Tags
+	for _, rT := range getRequiredTags() {
+		found = false
+		for _, t := range tags {
+			if t == rT {
+				found = true
+				break
+			}
+		}
+		if !found {
+			tagsToAdd = append(tagsToAdd, rT)
+		}
+	}
+
+	if len(tagsToAdd) > 0 {
+		tags = append(tags, tagsToAdd...)
+		datadogSLO.Spec.Tags = tags
+		err := r.client.Update(context.TODO(), datadogSLO)
+		if err != nil {
+			logger.Error(err, "failed to update DatadogSLO with required tags")
+
+			return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+		logger.Info("Added required tags", "SLO Namespace", datadogSLO.Namespace, "SLO Name", datadogSLO.Name, "SLO ID", datadogSLO.Status.ID)
+
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	// Proceed in reconcile loop.
+	return ctrl.Result{}, nil
+}
+
+func getRequiredTags() []string {
+	return []string{"generated:kubernetes"}
+}
+
+func (r *Reconciler) updateStatusIfNeeded(logger logr.Logger, datadogSLO *datadoghqv1alpha1.DatadogSLO, now metav1.Time, status *datadoghqv1alpha1.DatadogSLOStatus, currentErr error, result ctrl.Result) (ctrl.Result, error) {
+	// Update Error and Active conditions
+	condition.SetDatadogSLOErrorActiveConditions(status, now, currentErr)
+
+	if !apiequality.Semantic.DeepEqual(&datadogSLO.Status, status) {
+		datadogSLO.Status = *status
+		if err := r.client.Status().Update(context.TODO(), datadogSLO); err != nil {
+			if apierrors.IsConflict(err) {
+				logger.Error(err, "unable to update DatadogSLO status due to update conflict")
+
+				return ctrl.Result{Requeue: true, RequeueAfter: defaultErrRequeuePeriod}, nil
+			}
+			logger.Error(err, "unable to update DatadogSLO status")
+
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod}, nil
+	}
+
+	return result, nil
+}
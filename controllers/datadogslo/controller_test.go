@@ -0,0 +1,396 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/comparison"
+)
+
+const (
+	resourcesName      = "foo"
+	resourcesNamespace = "bar"
+	sloID              = "abc123"
+)
+
+func TestReconcileDatadogSLO_Reconcile(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "TestReconcileDatadogSLO_Reconcile"})
+
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogSLO{}, &datadoghqv1alpha1.DatadogSLOList{}, &datadoghqv1alpha1.DatadogMonitor{})
+
+	type args struct {
+		request              reconcile.Request
+		firstAction          func(c client.Client)
+		firstReconcileCount  int
+		secondAction         func(c client.Client)
+		secondReconcileCount int
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantResult reconcile.Result
+		wantErr    bool
+		wantFunc   func(c client.Client) error
+	}{
+		{
+			name: "DatadogSLO not created",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+			},
+			wantResult: reconcile.Result{},
+		},
+		{
+			name: "DatadogSLO created, add finalizer",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogSLO())
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Contains(t, slo.GetFinalizers(), "finalizer.slo.datadoghq.com")
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO created, add required tags",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogSLO())
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Contains(t, slo.Spec.Tags, "generated:kubernetes")
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO created, check status",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogSLO())
+				},
+				firstReconcileCount: 3,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Equal(t, sloID, slo.Status.ID)
+				assert.Equal(t, "test@example.com", slo.Status.Creator)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOSyncStatusOK, slo.Status.SyncStatus)
+				hash, _ := comparison.GenerateMD5ForSpec(slo.Spec)
+				assert.Equal(t, hash, slo.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO created, sync SLI value and error budget",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogSLO())
+				},
+				firstReconcileCount: 4,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Equal(t, "99.950", slo.Status.SLIValue)
+				assert.Equal(t, "50.000", slo.Status.ErrorBudgetRemaining)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOTimeframeSevenDays, slo.Status.Timeframe)
+				assert.NotNil(t, slo.Status.SLOStatusLastSyncTime)
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO exists, needs update",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testDatadogSLO())
+				},
+				firstReconcileCount: 3,
+				secondAction: func(c client.Client) {
+					slo := &datadoghqv1alpha1.DatadogSLO{}
+					_ = c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo)
+					slo.Spec.Name = "Updated SLO"
+					_ = c.Update(context.TODO(), slo)
+				},
+				secondReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				// Make sure status hash is up to date
+				hash, _ := comparison.GenerateMD5ForSpec(slo.Spec)
+				assert.Equal(t, hash, slo.Status.CurrentHash)
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO exists, needs delete",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					err := c.Create(context.TODO(), testDatadogSLO())
+					assert.NoError(t, err)
+				},
+				firstReconcileCount: 3,
+				secondAction: func(c client.Client) {
+					err := c.Delete(context.TODO(), testDatadogSLO())
+					assert.NoError(t, err)
+				},
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantErr:    true,
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			name: "Monitor DatadogSLO references a DatadogMonitor without ID",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testMonitor(0))
+					_ = c.Create(context.TODO(), testMonitorDatadogSLO())
+				},
+				firstReconcileCount: 3,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Equal(t, "", slo.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOSyncStatusMonitorRefError, slo.Status.SyncStatus)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOConditionTypeError, slo.Status.Conditions[0].Type)
+				assert.Equal(t, "DatadogMonitor bar/foo-monitor has not been created in Datadog yet", slo.Status.Conditions[0].Message)
+				return nil
+			},
+		},
+		{
+			name: "Monitor DatadogSLO references a DatadogMonitor, created once the monitor has an ID",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					_ = c.Create(context.TODO(), testMonitor(0))
+					_ = c.Create(context.TODO(), testMonitorDatadogSLO())
+				},
+				firstReconcileCount: 2,
+				secondAction: func(c client.Client) {
+					dm := &datadoghqv1alpha1.DatadogMonitor{}
+					_ = c.Get(context.TODO(), types.NamespacedName{Name: "foo-monitor", Namespace: resourcesNamespace}, dm)
+					dm.Status.ID = 456
+					_ = c.Status().Update(context.TODO(), dm)
+				},
+				secondReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Equal(t, sloID, slo.Status.ID)
+				assert.Equal(t, []int64{123, 456}, slo.Status.MonitorIDs)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOSyncStatusOK, slo.Status.SyncStatus)
+				return nil
+			},
+		},
+		{
+			name: "DatadogSLO with an invalid spec",
+			args: args{
+				request: newRequest(resourcesNamespace, resourcesName),
+				firstAction: func(c client.Client) {
+					slo := testDatadogSLO()
+					slo.Spec.Thresholds = nil
+					_ = c.Create(context.TODO(), slo)
+				},
+				firstReconcileCount: 2,
+			},
+			wantResult: reconcile.Result{Requeue: true, RequeueAfter: defaultRequeuePeriod},
+			wantFunc: func(c client.Client) error {
+				slo := &datadoghqv1alpha1.DatadogSLO{}
+				if err := c.Get(context.TODO(), types.NamespacedName{Name: resourcesName, Namespace: resourcesNamespace}, slo); err != nil {
+					return err
+				}
+				assert.Equal(t, "", slo.Status.ID)
+				assert.Equal(t, datadoghqv1alpha1.DatadogSLOConditionTypeError, slo.Status.Conditions[0].Type)
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpServer := newTestServer(genericSLO(sloID), genericSLOHistory(99.95))
+			defer httpServer.Close()
+
+			client, testAuth := setupTestClient(httpServer)
+
+			// Set up
+			r := &Reconciler{
+				client:        fake.NewFakeClient(),
+				datadogClient: client,
+				datadogAuth:   testAuth,
+				scheme:        s,
+				recorder:      recorder,
+				log:           logf.Log.WithName(tt.name),
+			}
+
+			// First SLO action
+			if tt.args.firstAction != nil {
+				tt.args.firstAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.firstReconcileCount == 0 {
+					tt.args.firstReconcileCount = 1
+				}
+			}
+			var result ctrl.Result
+			var err error
+			for i := 0; i < tt.args.firstReconcileCount; i++ {
+				result, err = r.Reconcile(context.TODO(), tt.args.request)
+			}
+
+			assert.NoError(t, err, "ReconcileDatadogSLO.Reconcile() unexpected error: %v", err)
+			assert.Equal(t, tt.wantResult, result, "ReconcileDatadogSLO.Reconcile() unexpected result")
+
+			// Second SLO action
+			if tt.args.secondAction != nil {
+				tt.args.secondAction(r.client)
+				// Make sure there's minimum 1 reconcile loop
+				if tt.args.secondReconcileCount == 0 {
+					tt.args.secondReconcileCount = 1
+				}
+			}
+			for i := 0; i < tt.args.secondReconcileCount; i++ {
+				_, err := r.Reconcile(context.TODO(), tt.args.request)
+				assert.NoError(t, err, "ReconcileDatadogSLO.Reconcile() unexpected error: %v", err)
+			}
+
+			if tt.wantFunc != nil {
+				err := tt.wantFunc(r.client)
+				if tt.wantErr {
+					assert.Error(t, err, "ReconcileDatadogSLO.Reconcile() expected an error")
+				} else {
+					assert.NoError(t, err, "ReconcileDatadogSLO.Reconcile() wantFunc validation error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestReconciler_RequestsForDatadogMonitor(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogSLO{}, &datadoghqv1alpha1.DatadogSLOList{}, &datadoghqv1alpha1.DatadogMonitor{})
+
+	referencing := testMonitorDatadogSLO()
+	notReferencing := testMonitorDatadogSLO()
+	notReferencing.Name = "other"
+	notReferencing.Spec.MonitorRefs = []datadoghqv1alpha1.DatadogSLOMonitorReference{{Name: "other-monitor"}}
+	otherNamespace := testMonitorDatadogSLO()
+	otherNamespace.Namespace = "other"
+
+	r := &Reconciler{
+		client: fake.NewFakeClient(referencing, notReferencing, otherNamespace),
+		scheme: s,
+		log:    logf.Log.WithName("TestReconciler_RequestsForDatadogMonitor"),
+	}
+
+	requests := r.RequestsForDatadogMonitor(testMonitor(456))
+	assert.Equal(t, []reconcile.Request{newRequest(resourcesNamespace, resourcesName)}, requests)
+}
+
+func newRequest(ns, name string) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+func testDatadogSLO() *datadoghqv1alpha1.DatadogSLO {
+	slo := genericDatadogSLO()
+	slo.ObjectMeta = metav1.ObjectMeta{
+		Namespace: resourcesNamespace,
+		Name:      resourcesName,
+	}
+
+	return slo
+}
+
+func testMonitorDatadogSLO() *datadoghqv1alpha1.DatadogSLO {
+	slo := testDatadogSLO()
+	slo.Spec.Type = datadoghqv1alpha1.DatadogSLOTypeMonitor
+	slo.Spec.Query = nil
+	slo.Spec.MonitorIDs = []int64{123}
+	slo.Spec.MonitorRefs = []datadoghqv1alpha1.DatadogSLOMonitorReference{{Name: "foo-monitor"}}
+
+	return slo
+}
+
+func testMonitor(id int) *datadoghqv1alpha1.DatadogMonitor {
+	return &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      "foo-monitor",
+		},
+		Status: datadoghqv1alpha1.DatadogMonitorStatus{
+			ID: id,
+		},
+	}
+}
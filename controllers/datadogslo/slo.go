@@ -0,0 +1,190 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/pkg/datadogclient"
+)
+
+func buildSLO(slo *datadoghqv1alpha1.DatadogSLO, monitorIDs []int64) (*datadogapiclientv1.ServiceLevelObjectiveRequest, *datadogapiclientv1.ServiceLevelObjective, error) {
+	thresholds, err := buildThresholds(slo.Spec.Thresholds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sloType := datadogapiclientv1.SLOType(slo.Spec.Type)
+	s := datadogapiclientv1.NewServiceLevelObjectiveRequest(slo.Spec.Name, thresholds, sloType)
+	u := datadogapiclientv1.NewServiceLevelObjective(slo.Spec.Name, thresholds, sloType)
+
+	if slo.Spec.Description != nil {
+		s.SetDescription(*slo.Spec.Description)
+		u.SetDescription(*slo.Spec.Description)
+	}
+
+	if slo.Spec.Query != nil {
+		query := datadogapiclientv1.NewServiceLevelObjectiveQuery(slo.Spec.Query.Denominator, slo.Spec.Query.Numerator)
+		s.SetQuery(*query)
+		u.SetQuery(*query)
+	}
+
+	if len(monitorIDs) > 0 {
+		s.SetMonitorIds(monitorIDs)
+		u.SetMonitorIds(monitorIDs)
+	}
+
+	if len(slo.Spec.Groups) > 0 {
+		s.SetGroups(slo.Spec.Groups)
+		u.SetGroups(slo.Spec.Groups)
+	}
+
+	tags := slo.Spec.Tags
+	sort.Strings(tags)
+	s.SetTags(tags)
+	u.SetTags(tags)
+
+	return s, u, nil
+}
+
+func buildThresholds(specThresholds []datadoghqv1alpha1.DatadogSLOThreshold) ([]datadogapiclientv1.SLOThreshold, error) {
+	thresholds := make([]datadogapiclientv1.SLOThreshold, 0, len(specThresholds))
+	for _, st := range specThresholds {
+		target, err := strconv.ParseFloat(st.Target, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SLO target %q: %w", st.Target, err)
+		}
+		threshold := datadogapiclientv1.NewSLOThreshold(target, datadogapiclientv1.SLOTimeframe(st.Timeframe))
+		threshold.SetTargetDisplay(st.Target)
+		if st.Warning != nil {
+			warning, err := strconv.ParseFloat(*st.Warning, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing SLO warning %q: %w", *st.Warning, err)
+			}
+			threshold.SetWarning(warning)
+			threshold.SetWarningDisplay(*st.Warning)
+		}
+		thresholds = append(thresholds, *threshold)
+	}
+
+	return thresholds, nil
+}
+
+func createSLO(auth context.Context, client *datadogapiclientv1.APIClient, slo *datadoghqv1alpha1.DatadogSLO, monitorIDs []int64) (datadogapiclientv1.ServiceLevelObjective, error) {
+	s, _, err := buildSLO(slo, monitorIDs)
+	if err != nil {
+		return datadogapiclientv1.ServiceLevelObjective{}, err
+	}
+
+	resp, _, err := client.ServiceLevelObjectivesApi.CreateSLO(auth, *s)
+	if err != nil {
+		return datadogapiclientv1.ServiceLevelObjective{}, datadogclient.TranslateClientError(err, "error creating SLO")
+	}
+
+	return firstSLO(resp)
+}
+
+func updateSLO(auth context.Context, client *datadogapiclientv1.APIClient, slo *datadoghqv1alpha1.DatadogSLO, monitorIDs []int64) (datadogapiclientv1.ServiceLevelObjective, error) {
+	_, u, err := buildSLO(slo, monitorIDs)
+	if err != nil {
+		return datadogapiclientv1.ServiceLevelObjective{}, err
+	}
+
+	resp, _, err := client.ServiceLevelObjectivesApi.UpdateSLO(auth, slo.Status.ID, *u)
+	if err != nil {
+		return datadogapiclientv1.ServiceLevelObjective{}, datadogclient.TranslateClientError(err, "error updating SLO")
+	}
+
+	return firstSLO(resp)
+}
+
+func deleteSLO(auth context.Context, client *datadogapiclientv1.APIClient, sloID string) error {
+	if _, _, err := client.ServiceLevelObjectivesApi.DeleteSLO(auth, sloID); err != nil {
+		return datadogclient.TranslateClientError(err, "error deleting SLO")
+	}
+
+	return nil
+}
+
+// getSLOHistory returns the history of the SLO over the timeframe ending now
+func getSLOHistory(auth context.Context, client *datadogapiclientv1.APIClient, sloID string, timeframe datadoghqv1alpha1.DatadogSLOTimeframe, now time.Time) (datadogapiclientv1.SLOHistoryResponseData, error) {
+	duration, err := timeframeDuration(timeframe)
+	if err != nil {
+		return datadogapiclientv1.SLOHistoryResponseData{}, err
+	}
+
+	resp, _, err := client.ServiceLevelObjectivesApi.GetSLOHistory(auth, sloID, now.Add(-duration).Unix(), now.Unix())
+	if err != nil {
+		return datadogapiclientv1.SLOHistoryResponseData{}, datadogclient.TranslateClientError(err, "error getting SLO history")
+	}
+
+	return resp.GetData(), nil
+}
+
+// firstSLO returns the SLO of a create or update response, which the Datadog API wraps in a list
+func firstSLO(resp datadogapiclientv1.SLOListResponse) (datadogapiclientv1.ServiceLevelObjective, error) {
+	data := resp.GetData()
+	if len(data) == 0 {
+		return datadogapiclientv1.ServiceLevelObjective{}, fmt.Errorf("no SLO returned by the Datadog API: %v", resp.GetErrors())
+	}
+
+	return data[0], nil
+}
+
+func timeframeDuration(timeframe datadoghqv1alpha1.DatadogSLOTimeframe) (time.Duration, error) {
+	switch timeframe {
+	case datadoghqv1alpha1.DatadogSLOTimeframeSevenDays:
+		return 7 * 24 * time.Hour, nil
+	case datadoghqv1alpha1.DatadogSLOTimeframeThirtyDays:
+		return 30 * 24 * time.Hour, nil
+	case datadoghqv1alpha1.DatadogSLOTimeframeNinetyDays:
+		return 90 * 24 * time.Hour, nil
+	}
+
+	return 0, fmt.Errorf("unsupported SLO timeframe %q", timeframe)
+}
+
+// errorBudgetRemaining returns the percentage of the error budget that remains for an SLI value and a target,
+// both expressed as percentages. The error budget is the allowed share of bad events or downtime: 100 - target.
+func errorBudgetRemaining(sli, target float64) float64 {
+	return (sli - target) / (100 - target) * 100
+}
+
+// convertHistoryToStatus updates status.SLIValue and status.ErrorBudgetRemaining according to the SLO history
+// over the timeframe of the first threshold
+func convertHistoryToStatus(history datadogapiclientv1.SLOHistoryResponseData, threshold datadoghqv1alpha1.DatadogSLOThreshold, newStatus *datadoghqv1alpha1.DatadogSLOStatus) error {
+	newStatus.Timeframe = threshold.Timeframe
+
+	overall := history.GetOverall()
+	sli, ok := overall.GetSliValueOk()
+	if !ok || sli == nil {
+		// No data yet, e.g. for a new SLO
+		newStatus.SLIValue = ""
+		newStatus.ErrorBudgetRemaining = ""
+
+		return nil
+	}
+
+	target, err := strconv.ParseFloat(threshold.Target, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing SLO target %q: %w", threshold.Target, err)
+	}
+
+	newStatus.SLIValue = formatPercentage(*sli)
+	newStatus.ErrorBudgetRemaining = formatPercentage(errorBudgetRemaining(*sli, target))
+
+	return nil
+}
+
+func formatPercentage(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
@@ -0,0 +1,292 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogslo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+func Test_buildSLO(t *testing.T) {
+	description := "Checkout requests succeed"
+	warning := "99.95"
+
+	slo := &datadoghqv1alpha1.DatadogSLO{
+		Spec: datadoghqv1alpha1.DatadogSLOSpec{
+			Name:        "Test SLO",
+			Description: &description,
+			Tags:        []string{"team:checkout", "env:prod"},
+			Type:        datadoghqv1alpha1.DatadogSLOTypeMonitor,
+			Groups:      []string{"region:us1"},
+			Thresholds: []datadoghqv1alpha1.DatadogSLOThreshold{
+				{Timeframe: datadoghqv1alpha1.DatadogSLOTimeframeSevenDays, Target: "99.9", Warning: &warning},
+			},
+		},
+	}
+
+	s, u, err := buildSLO(slo, []int64{123, 456})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Test SLO", s.GetName())
+	assert.Equal(t, "Test SLO", u.GetName())
+	assert.Equal(t, datadogapiclientv1.SLOTYPE_MONITOR, s.GetType())
+	assert.Equal(t, description, s.GetDescription())
+	assert.Equal(t, []int64{123, 456}, s.GetMonitorIds())
+	assert.Equal(t, []int64{123, 456}, u.GetMonitorIds())
+	assert.Equal(t, []string{"region:us1"}, s.GetGroups())
+	assert.Equal(t, []string{"env:prod", "team:checkout"}, s.GetTags())
+	assert.Equal(t, []string{"env:prod", "team:checkout"}, u.GetTags())
+	_, hasQuery := s.GetQueryOk()
+	assert.False(t, hasQuery)
+
+	thresholds := s.GetThresholds()
+	assert.Len(t, thresholds, 1)
+	assert.Equal(t, datadogapiclientv1.SLOTIMEFRAME_SEVEN_DAYS, thresholds[0].GetTimeframe())
+	assert.Equal(t, 99.9, thresholds[0].GetTarget())
+	assert.Equal(t, "99.9", thresholds[0].GetTargetDisplay())
+	assert.Equal(t, 99.95, thresholds[0].GetWarning())
+	assert.Equal(t, thresholds, u.GetThresholds())
+
+	// Metric SLO
+	slo.Spec.Type = datadoghqv1alpha1.DatadogSLOTypeMetric
+	slo.Spec.Query = &datadoghqv1alpha1.DatadogSLOQuery{
+		Numerator:   "sum:requests.success{*}.as_count()",
+		Denominator: "sum:requests.total{*}.as_count()",
+	}
+	s, _, err = buildSLO(slo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, datadogapiclientv1.SLOTYPE_METRIC, s.GetType())
+	assert.Equal(t, "sum:requests.success{*}.as_count()", s.Query.GetNumerator())
+	assert.Equal(t, "sum:requests.total{*}.as_count()", s.Query.GetDenominator())
+	_, hasMonitors := s.GetMonitorIdsOk()
+	assert.False(t, hasMonitors)
+
+	// Invalid target
+	slo.Spec.Thresholds[0].Target = "foo"
+	_, _, err = buildSLO(slo, nil)
+	assert.EqualError(t, err, `error parsing SLO target "foo": strconv.ParseFloat: parsing "foo": invalid syntax`)
+}
+
+func Test_errorBudgetRemaining(t *testing.T) {
+	testCases := []struct {
+		name   string
+		sli    float64
+		target float64
+		want   string
+	}{
+		{
+			name:   "no error",
+			sli:    100,
+			target: 99.9,
+			want:   "100.000",
+		},
+		{
+			name:   "half of the budget consumed",
+			sli:    99.95,
+			target: 99.9,
+			want:   "50.000",
+		},
+		{
+			name:   "budget exhausted",
+			sli:    99.9,
+			target: 99.9,
+			want:   "0.000",
+		},
+		{
+			name:   "budget overspent",
+			sli:    98,
+			target: 99,
+			want:   "-100.000",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, formatPercentage(errorBudgetRemaining(test.sli, test.target)))
+		})
+	}
+}
+
+func Test_convertHistoryToStatus(t *testing.T) {
+	threshold := datadoghqv1alpha1.DatadogSLOThreshold{Timeframe: datadoghqv1alpha1.DatadogSLOTimeframeThirtyDays, Target: "99"}
+
+	// With data
+	status := &datadoghqv1alpha1.DatadogSLOStatus{}
+	err := convertHistoryToStatus(genericSLOHistory(99.75), threshold, status)
+	assert.NoError(t, err)
+	assert.Equal(t, datadoghqv1alpha1.DatadogSLOTimeframeThirtyDays, status.Timeframe)
+	assert.Equal(t, "99.750", status.SLIValue)
+	assert.Equal(t, "75.000", status.ErrorBudgetRemaining)
+
+	// No data
+	err = convertHistoryToStatus(datadogapiclientv1.SLOHistoryResponseData{}, threshold, status)
+	assert.NoError(t, err)
+	assert.Equal(t, "", status.SLIValue)
+	assert.Equal(t, "", status.ErrorBudgetRemaining)
+}
+
+func Test_timeframeDuration(t *testing.T) {
+	d, err := timeframeDuration(datadoghqv1alpha1.DatadogSLOTimeframeNinetyDays)
+	assert.NoError(t, err)
+	assert.Equal(t, 90*24*time.Hour, d)
+
+	_, err = timeframeDuration("1d")
+	assert.EqualError(t, err, `unsupported SLO timeframe "1d"`)
+}
+
+func Test_createSLO(t *testing.T) {
+	sloID := "abc123"
+	slo := genericDatadogSLO()
+
+	httpServer := newTestServer(genericSLO(sloID), genericSLOHistory(99.9))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	s, err := createSLO(testAuth, client, slo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sloID, s.GetId())
+	assert.Equal(t, slo.Spec.Name, s.GetName())
+}
+
+func Test_updateSLO(t *testing.T) {
+	sloID := "abc123"
+	slo := genericDatadogSLO()
+	slo.Status.ID = sloID
+
+	httpServer := newTestServer(genericSLO(sloID), genericSLOHistory(99.9))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	s, err := updateSLO(testAuth, client, slo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sloID, s.GetId())
+}
+
+func Test_getSLOHistory(t *testing.T) {
+	sloID := "abc123"
+
+	httpServer := newTestServer(genericSLO(sloID), genericSLOHistory(99.9))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	h, err := getSLOHistory(testAuth, client, sloID, datadoghqv1alpha1.DatadogSLOTimeframeSevenDays, time.Now())
+	assert.NoError(t, err)
+	overall := h.GetOverall()
+	assert.Equal(t, 99.9, overall.GetSliValue())
+}
+
+func Test_deleteSLO(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": ["abc123"]}`))
+	}))
+	defer httpServer.Close()
+
+	client, testAuth := setupTestClient(httpServer)
+
+	err := deleteSLO(testAuth, client, "abc123")
+	assert.NoError(t, err)
+}
+
+func genericDatadogSLO() *datadoghqv1alpha1.DatadogSLO {
+	return &datadoghqv1alpha1.DatadogSLO{
+		Spec: datadoghqv1alpha1.DatadogSLOSpec{
+			Name: "Test SLO",
+			Type: datadoghqv1alpha1.DatadogSLOTypeMetric,
+			Query: &datadoghqv1alpha1.DatadogSLOQuery{
+				Numerator:   "sum:requests.success{*}.as_count()",
+				Denominator: "sum:requests.total{*}.as_count()",
+			},
+			Thresholds: []datadoghqv1alpha1.DatadogSLOThreshold{
+				{Timeframe: datadoghqv1alpha1.DatadogSLOTimeframeSevenDays, Target: "99.9"},
+			},
+		},
+	}
+}
+
+func genericSLO(id string) datadogapiclientv1.SLOListResponse {
+	s := datadogapiclientv1.NewServiceLevelObjective("Test SLO", []datadogapiclientv1.SLOThreshold{
+		*datadogapiclientv1.NewSLOThreshold(99.9, datadogapiclientv1.SLOTIMEFRAME_SEVEN_DAYS),
+	}, datadogapiclientv1.SLOTYPE_METRIC)
+	s.SetId(id)
+	s.SetCreatedAt(1633024800)
+	creator := datadogapiclientv1.NewCreator()
+	creator.SetEmail("test@example.com")
+	s.SetCreator(*creator)
+
+	resp := datadogapiclientv1.NewSLOListResponse()
+	resp.SetData([]datadogapiclientv1.ServiceLevelObjective{*s})
+
+	return *resp
+}
+
+func genericSLOHistory(sli float64) datadogapiclientv1.SLOHistoryResponseData {
+	overall := datadogapiclientv1.NewSLOHistorySLIData()
+	overall.SetSliValue(sli)
+	data := datadogapiclientv1.NewSLOHistoryResponseData()
+	data.SetOverall(*overall)
+
+	return *data
+}
+
+// newTestServer returns a server answering SLO history requests with the history, and other requests with the SLO
+func newTestServer(slo datadogapiclientv1.SLOListResponse, history datadogapiclientv1.SLOHistoryResponseData) *httptest.Server {
+	jsonSLO, _ := json.Marshal(slo)
+	historyResp := datadogapiclientv1.NewSLOHistoryResponse()
+	historyResp.SetData(history)
+	jsonHistory, _ := json.Marshal(historyResp)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/history") {
+			_, _ = w.Write(jsonHistory)
+			return
+		}
+		_, _ = w.Write(jsonSLO)
+	}))
+}
+
+func setupTestClient(httpServer *httptest.Server) (*datadogapiclientv1.APIClient, context.Context) {
+	testConfig := datadogapiclientv1.NewConfiguration()
+	testConfig.HTTPClient = httpServer.Client()
+	testConfig.SetUnstableOperationEnabled("GetSLOHistory", true)
+	client := datadogapiclientv1.NewAPIClient(testConfig)
+
+	testAuth := context.WithValue(
+		context.Background(),
+		datadogapiclientv1.ContextAPIKeys,
+		map[string]datadogapiclientv1.APIKey{
+			"apiKeyAuth": {
+				Key: "DUMMY_API_KEY",
+			},
+			"appKeyAuth": {
+				Key: "DUMMY_APP_KEY",
+			},
+		},
+	)
+	parsedAPIURL, _ := url.Parse(httpServer.URL)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerIndex, 1)
+	testAuth = context.WithValue(testAuth, datadogapiclientv1.ContextServerVariables, map[string]string{
+		"name":     parsedAPIURL.Host,
+		"protocol": parsedAPIURL.Scheme,
+	})
+
+	return client, testAuth
+}
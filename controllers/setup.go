@@ -12,6 +12,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/DataDog/datadog-operator/controllers/datadogagent"
+	"github.com/DataDog/datadog-operator/controllers/datadogmonitor"
+	"github.com/DataDog/datadog-operator/controllers/workloadmonitor"
 	"github.com/DataDog/datadog-operator/pkg/config"
 	"github.com/DataDog/datadog-operator/pkg/datadogclient"
 
@@ -22,8 +24,13 @@ import (
 )
 
 const (
-	agentControllerName   = "DatadogAgent"
-	monitorControllerName = "DatadogMonitor"
+	agentControllerName     = "DatadogAgent"
+	monitorControllerName   = "DatadogMonitor"
+	dashboardControllerName = "DatadogDashboard"
+	sloControllerName       = "DatadogSLO"
+	downtimeControllerName  = "DatadogDowntime"
+	workloadMonitorsName    = "WorkloadMonitors"
+	monitorTemplateName     = "DatadogMonitorTemplate"
 )
 
 // SetupOptions defines options for setting up controllers to ease testing
@@ -32,6 +39,12 @@ type SetupOptions struct {
 	SupportCilium            bool
 	Creds                    config.Creds
 	DatadogMonitorEnabled    bool
+	DatadogMonitorOptions    datadogmonitor.ReconcilerOptions
+	WorkloadMonitorsEnabled  bool
+	MonitorTemplatesEnabled  bool
+	DatadogDashboardEnabled  bool
+	DatadogSLOEnabled        bool
+	DatadogDowntimeEnabled   bool
 	OperatorMetricsEnabled   bool
 	V2APIEnabled             bool
 }
@@ -39,8 +52,13 @@ type SetupOptions struct {
 type starterFunc func(logr.Logger, manager.Manager, *version.Info, SetupOptions) error
 
 var controllerStarters = map[string]starterFunc{
-	agentControllerName:   startDatadogAgent,
-	monitorControllerName: startDatadogMonitor,
+	agentControllerName:     startDatadogAgent,
+	monitorControllerName:   startDatadogMonitor,
+	dashboardControllerName: startDatadogDashboard,
+	sloControllerName:       startDatadogSLO,
+	downtimeControllerName:  startDatadogDowntime,
+	workloadMonitorsName:    startWorkloadMonitors,
+	monitorTemplateName:     startDatadogMonitorTemplate,
 }
 
 // SetupControllers starts all controllers (also used by e2e tests)
@@ -102,5 +120,110 @@ func startDatadogMonitor(logger logr.Logger, mgr manager.Manager, vInfo *version
 		Log:         ctrl.Log.WithName("controllers").WithName(monitorControllerName),
 		Scheme:      mgr.GetScheme(),
 		Recorder:    mgr.GetEventRecorderFor(monitorControllerName),
+		Options:     options.DatadogMonitorOptions,
+	}).SetupWithManager(mgr)
+}
+
+func startWorkloadMonitors(logger logr.Logger, mgr manager.Manager, vInfo *version.Info, options SetupOptions) error {
+	if !options.DatadogMonitorEnabled || !options.WorkloadMonitorsEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", workloadMonitorsName)
+
+		return nil
+	}
+
+	for _, workload := range []workloadmonitor.Workload{workloadmonitor.Deployment, workloadmonitor.StatefulSet} {
+		err := (&WorkloadMonitorReconciler{
+			Client:   mgr.GetClient(),
+			Workload: workload,
+			Log:      ctrl.Log.WithName("controllers").WithName(workloadMonitorsName).WithName(workload.Kind),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor(workloadMonitorsName),
+		}).SetupWithManager(mgr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startDatadogMonitorTemplate(logger logr.Logger, mgr manager.Manager, vInfo *version.Info, options SetupOptions) error {
+	if !options.DatadogMonitorEnabled || !options.MonitorTemplatesEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", monitorTemplateName)
+
+		return nil
+	}
+
+	return (&DatadogMonitorTemplateReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName(monitorTemplateName),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor(monitorTemplateName),
+	}).SetupWithManager(mgr)
+}
+
+func startDatadogDashboard(logger logr.Logger, mgr manager.Manager, vInfo *version.Info, options SetupOptions) error {
+	if !options.DatadogDashboardEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", dashboardControllerName)
+
+		return nil
+	}
+
+	ddClient, err := datadogclient.InitDatadogClient(options.Creds)
+	if err != nil {
+		return fmt.Errorf("unable to create Datadog API Client: %w", err)
+	}
+
+	return (&DatadogDashboardReconciler{
+		Client:      mgr.GetClient(),
+		DDClient:    ddClient,
+		VersionInfo: vInfo,
+		Log:         ctrl.Log.WithName("controllers").WithName(dashboardControllerName),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor(dashboardControllerName),
+	}).SetupWithManager(mgr)
+}
+
+func startDatadogSLO(logger logr.Logger, mgr manager.Manager, vInfo *version.Info, options SetupOptions) error {
+	if !options.DatadogSLOEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", sloControllerName)
+
+		return nil
+	}
+
+	ddClient, err := datadogclient.InitDatadogClient(options.Creds)
+	if err != nil {
+		return fmt.Errorf("unable to create Datadog API Client: %w", err)
+	}
+
+	return (&DatadogSLOReconciler{
+		Client:      mgr.GetClient(),
+		DDClient:    ddClient,
+		VersionInfo: vInfo,
+		Log:         ctrl.Log.WithName("controllers").WithName(sloControllerName),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor(sloControllerName),
+	}).SetupWithManager(mgr)
+}
+
+func startDatadogDowntime(logger logr.Logger, mgr manager.Manager, vInfo *version.Info, options SetupOptions) error {
+	if !options.DatadogDowntimeEnabled {
+		logger.Info("Feature disabled, not starting the controller", "controller", downtimeControllerName)
+
+		return nil
+	}
+
+	ddClient, err := datadogclient.InitDatadogClient(options.Creds)
+	if err != nil {
+		return fmt.Errorf("unable to create Datadog API Client: %w", err)
+	}
+
+	return (&DatadogDowntimeReconciler{
+		Client:      mgr.GetClient(),
+		DDClient:    ddClient,
+		VersionInfo: vInfo,
+		Log:         ctrl.Log.WithName("controllers").WithName(downtimeControllerName),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor(downtimeControllerName),
 	}).SetupWithManager(mgr)
 }
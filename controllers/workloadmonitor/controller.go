@@ -0,0 +1,222 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmonitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	"github.com/DataDog/datadog-operator/controllers/utils"
+	"github.com/DataDog/datadog-operator/pkg/controller/utils/datadog"
+)
+
+const (
+	defaultErrRequeuePeriod = 5 * time.Second
+	datadogMonitorKind      = "DatadogMonitor"
+	invalidAnnotationReason = "InvalidMonitorAnnotation"
+)
+
+// Workload is a kind of workload whose annotations declare monitors
+type Workload struct {
+	// Kind is the kind of the workload
+	Kind string
+	// tag is the tag of the workload name on its metrics
+	tag string
+	// metric is the name of the workload kind in the kubernetes_state metrics
+	metric string
+	// readyReplicasMetric is the kubernetes_state metric of the ready replicas of the workload
+	readyReplicasMetric string
+	newObject           func() client.Object
+}
+
+// NewObject returns an empty object of the workload kind
+func (w Workload) NewObject() client.Object {
+	return w.newObject()
+}
+
+var (
+	// Deployment generates DatadogMonitors from the annotations of Deployments
+	Deployment = Workload{
+		Kind:                "Deployment",
+		tag:                 "kube_deployment",
+		metric:              "deployment",
+		readyReplicasMetric: "replicas_available",
+		newObject:           func() client.Object { return &appsv1.Deployment{} },
+	}
+	// StatefulSet generates DatadogMonitors from the annotations of StatefulSets
+	StatefulSet = Workload{
+		Kind:                "StatefulSet",
+		tag:                 "kube_stateful_set",
+		metric:              "statefulset",
+		readyReplicasMetric: "replicas_ready",
+		newObject:           func() client.Object { return &appsv1.StatefulSet{} },
+	}
+)
+
+// Reconciler generates the DatadogMonitors declared by the monitors.datadoghq.com annotations of the workloads of a
+// kind. The DatadogMonitors are owned by their workload, so they are garbage-collected with it.
+type Reconciler struct {
+	workload Workload
+	client   client.Client
+	log      logr.Logger
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// NewReconciler returns a new Reconciler object
+func NewReconciler(workload Workload, client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*Reconciler, error) {
+	return &Reconciler{
+		workload: workload,
+		client:   client,
+		scheme:   scheme,
+		log:      log,
+		recorder: recorder,
+	}, nil
+}
+
+// Reconcile is similar to reconciler.Reconcile interface, but taking a context
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	return r.internalReconcile(ctx, request)
+}
+
+// Reconcile loop for the workloads
+func (r *Reconciler) internalReconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	logger := r.log.WithValues(r.workload.tag, req.NamespacedName)
+
+	obj := r.workload.NewObject()
+	if err := r.client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The generated DatadogMonitors are garbage-collected through their owner reference
+			return reconcile.Result{}, nil
+		}
+
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	desired, invalid, errs := renderMonitors(r.workload, obj)
+	invalidAnnotations := make(map[string]bool, len(invalid))
+	for _, key := range invalid {
+		invalidAnnotations[key] = true
+	}
+	for _, err := range errs {
+		logger.Error(err, "invalid monitor annotation")
+		r.recorder.Event(obj, corev1.EventTypeWarning, invalidAnnotationReason, err.Error())
+	}
+
+	// The DatadogMonitors previously generated for the workload
+	dmList := &datadoghqv1alpha1.DatadogMonitorList{}
+	if err := r.client.List(ctx, dmList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+	}
+	owned := map[string]*datadoghqv1alpha1.DatadogMonitor{}
+	others := map[string]bool{}
+	for i := range dmList.Items {
+		dm := &dmList.Items[i]
+		if metav1.IsControlledBy(dm, obj) {
+			owned[dm.Name] = dm
+		} else {
+			others[dm.Name] = true
+		}
+	}
+
+	for _, dm := range desired {
+		if others[dm.Name] {
+			err := fmt.Errorf("DatadogMonitor %s/%s already exists and is not generated from the %s", dm.Namespace, dm.Name, r.workload.Kind)
+			logger.Error(err, "unable to generate DatadogMonitor")
+			r.recorder.Event(obj, corev1.EventTypeWarning, invalidAnnotationReason, err.Error())
+			continue
+		}
+
+		current, found := owned[dm.Name]
+		delete(owned, dm.Name)
+		if !found {
+			if err := r.createMonitor(ctx, logger, obj, dm); err != nil {
+				return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+			}
+			continue
+		}
+		if err := r.updateMonitor(ctx, logger, obj, current, dm); err != nil {
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+	}
+
+	// The DatadogMonitors whose annotation was removed. The DatadogMonitors of invalid annotations are kept until the
+	// annotation is fixed or removed, so that a typo does not delete a live monitor.
+	for _, dm := range owned {
+		if invalidAnnotations[datadoghqv1alpha1.DatadogMonitorWorkloadAnnotationPrefix+dm.Labels[datadoghqv1alpha1.DatadogMonitorTemplateLabelKey]] {
+			continue
+		}
+		if err := r.deleteMonitor(ctx, logger, obj, dm); err != nil {
+			return ctrl.Result{RequeueAfter: defaultErrRequeuePeriod}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) createMonitor(ctx context.Context, logger logr.Logger, obj client.Object, dm *datadoghqv1alpha1.DatadogMonitor) error {
+	if err := controllerutil.SetControllerReference(obj, dm, r.scheme); err != nil {
+		return err
+	}
+	if err := r.client.Create(ctx, dm); err != nil {
+		return fmt.Errorf("unable to create DatadogMonitor %s/%s: %w", dm.Namespace, dm.Name, err)
+	}
+	logger.Info("Generated DatadogMonitor", "Monitor Namespace", dm.Namespace, "Monitor Name", dm.Name)
+	r.recordEvent(obj, dm, datadog.CreationEvent)
+
+	return nil
+}
+
+func (r *Reconciler) updateMonitor(ctx context.Context, logger logr.Logger, obj client.Object, current, desired *datadoghqv1alpha1.DatadogMonitor) error {
+	if apiequality.Semantic.DeepEqual(current.Spec, desired.Spec) && apiequality.Semantic.DeepEqual(current.Labels, desired.Labels) {
+		return nil
+	}
+
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Spec = desired.Spec
+	if err := r.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("unable to update DatadogMonitor %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+	logger.Info("Updated generated DatadogMonitor", "Monitor Namespace", updated.Namespace, "Monitor Name", updated.Name)
+	r.recordEvent(obj, updated, datadog.UpdateEvent)
+
+	return nil
+}
+
+func (r *Reconciler) deleteMonitor(ctx context.Context, logger logr.Logger, obj client.Object, dm *datadoghqv1alpha1.DatadogMonitor) error {
+	if err := r.client.Delete(ctx, dm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete DatadogMonitor %s/%s: %w", dm.Namespace, dm.Name, err)
+	}
+	logger.Info("Deleted generated DatadogMonitor", "Monitor Namespace", dm.Namespace, "Monitor Name", dm.Name)
+	r.recordEvent(obj, dm, datadog.DeletionEvent)
+
+	return nil
+}
+
+// recordEvent records an event on the workload about one of its DatadogMonitors
+func (r *Reconciler) recordEvent(obj client.Object, dm *datadoghqv1alpha1.DatadogMonitor, eventType datadog.EventType) {
+	info := utils.BuildEventInfo(dm.Name, dm.Namespace, datadogMonitorKind, eventType)
+	r.recorder.Event(obj, corev1.EventTypeNormal, info.GetReason(), info.GetMessage())
+}
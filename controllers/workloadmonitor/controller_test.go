@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+const (
+	resourcesName      = "foo"
+	resourcesNamespace = "bar"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(datadoghqv1alpha1.GroupVersion, &datadoghqv1alpha1.DatadogMonitor{}, &datadoghqv1alpha1.DatadogMonitorList{})
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: resourcesNamespace,
+			Name:      resourcesName,
+			UID:       "0a1b2c",
+			Annotations: map[string]string{
+				"monitors.datadoghq.com/restarts": "critical>5",
+				"monitors.datadoghq.com/replicas": "critical>=2",
+			},
+		},
+	}
+	// A DatadogMonitor created by hand with the name of a generated one is left untouched
+	existing := &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: resourcesNamespace, Name: "deployment-foo-replicas"},
+		Spec:       datadoghqv1alpha1.DatadogMonitorSpec{Query: "avg(last_10m):avg:system.disk.in_use{*} by {host} > 0.5"},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		workload: Deployment,
+		client:   fake.NewClientBuilder().WithScheme(s).WithObjects(deployment, existing).Build(),
+		scheme:   s,
+		log:      logf.Log.WithName(t.Name()),
+		recorder: recorder,
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: resourcesNamespace, Name: resourcesName}}
+
+	// The DatadogMonitors are generated, owned by the Deployment
+	_, err := r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	dm := getDatadogMonitor(t, r.client, "deployment-foo-restarts")
+	assert.True(t, metav1.IsControlledBy(dm, deployment))
+	assert.Equal(t, "change(sum(last_5m),last_5m):exclude_null(avg:kubernetes.containers.restarts{kube_namespace:bar,kube_deployment:foo} by {pod_name}) > 5", dm.Spec.Query)
+	assert.Equal(t, existing.Spec, getDatadogMonitor(t, r.client, "deployment-foo-replicas").Spec)
+	assert.Equal(t, "Warning InvalidMonitorAnnotation DatadogMonitor bar/deployment-foo-replicas already exists and is not generated from the Deployment", <-recorder.Events)
+	assert.Equal(t, "Normal Create DatadogMonitor bar/deployment-foo-restarts", <-recorder.Events)
+
+	// The DatadogMonitors are kept in sync with the annotations
+	deployment = getDeployment(t, r.client)
+	deployment.Annotations["monitors.datadoghq.com/restarts"] = "critical>10,warning>5"
+	require.NoError(t, r.client.Update(context.TODO(), deployment))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	dm = getDatadogMonitor(t, r.client, "deployment-foo-restarts")
+	assert.Equal(t, "change(sum(last_5m),last_5m):exclude_null(avg:kubernetes.containers.restarts{kube_namespace:bar,kube_deployment:foo} by {pod_name}) > 10", dm.Spec.Query)
+	assert.Equal(t, "5", *dm.Spec.Options.Thresholds.Warning)
+	// The conflict with the existing DatadogMonitor is reported on every reconcile
+	<-recorder.Events
+	assert.Equal(t, "Normal Update DatadogMonitor bar/deployment-foo-restarts", <-recorder.Events)
+
+	// Nothing changes without changes of the annotations
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	<-recorder.Events
+	assert.Empty(t, recorder.Events)
+
+	// The DatadogMonitors of invalid annotations are kept, and the invalid annotations are reported
+	deployment = getDeployment(t, r.client)
+	deployment.Annotations["monitors.datadoghq.com/restarts"] = "critical>>10"
+	require.NoError(t, r.client.Update(context.TODO(), deployment))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	dm = getDatadogMonitor(t, r.client, "deployment-foo-restarts")
+	assert.Equal(t, "change(sum(last_5m),last_5m):exclude_null(avg:kubernetes.containers.restarts{kube_namespace:bar,kube_deployment:foo} by {pod_name}) > 10", dm.Spec.Query)
+	assert.Equal(t, `Warning InvalidMonitorAnnotation invalid annotation monitors.datadoghq.com/restarts: invalid threshold "critical>>10", must be like critical>5`, <-recorder.Events)
+	<-recorder.Events
+	assert.Empty(t, recorder.Events)
+
+	// The DatadogMonitors are deleted with their annotation
+	deployment = getDeployment(t, r.client)
+	delete(deployment.Annotations, "monitors.datadoghq.com/restarts")
+	require.NoError(t, r.client.Update(context.TODO(), deployment))
+	_, err = r.Reconcile(context.TODO(), request)
+	require.NoError(t, err)
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: "deployment-foo-restarts"}, &datadoghqv1alpha1.DatadogMonitor{})
+	assert.True(t, apierrors.IsNotFound(err), "the DatadogMonitor should be deleted")
+	<-recorder.Events
+	assert.Equal(t, "Normal Delete DatadogMonitor bar/deployment-foo-restarts", <-recorder.Events)
+}
+
+func getDatadogMonitor(t *testing.T, c client.Client, name string) *datadoghqv1alpha1.DatadogMonitor {
+	dm := &datadoghqv1alpha1.DatadogMonitor{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: name}, dm))
+	return dm
+}
+
+func getDeployment(t *testing.T, c client.Client) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: resourcesNamespace, Name: resourcesName}, deployment))
+	return deployment
+}
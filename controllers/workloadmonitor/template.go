@@ -0,0 +1,155 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmonitor
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+)
+
+// monitorTemplate is a built-in monitor that can be declared on workloads with the
+// monitors.datadoghq.com/<template name> annotation
+type monitorTemplate struct {
+	// query returns the query of the monitor without its comparison, for the workload matching the scope
+	query   func(w Workload, scope string) string
+	name    string
+	message string
+}
+
+// monitorTemplates are the built-in monitor templates, by annotation name
+var monitorTemplates = map[string]monitorTemplate{
+	"restarts": {
+		query: func(w Workload, scope string) string {
+			return fmt.Sprintf("change(sum(last_5m),last_5m):exclude_null(avg:kubernetes.containers.restarts{%s} by {pod_name})", scope)
+		},
+		name:    "[kubernetes] Pods of %s %s are restarting",
+		message: "Pods of %s %s are restarting multiple times in the last five minutes.",
+	},
+	"replicas": {
+		query: func(w Workload, scope string) string {
+			return fmt.Sprintf("max(last_15m):sum:kubernetes_state.%[1]s.replicas_desired{%[2]s} - sum:kubernetes_state.%[1]s.%[3]s{%[2]s}", w.metric, scope, w.readyReplicasMetric)
+		},
+		name:    "[kubernetes] Replicas of %s %s are down",
+		message: "Replica pods of %s %s are down.",
+	},
+	"crashloopbackoff": {
+		query: func(w Workload, scope string) string {
+			return fmt.Sprintf("max(last_10m):max:kubernetes_state.container.status_report.count.waiting{reason:crashloopbackoff,%s} by {pod_name}", scope)
+		},
+		name:    "[kubernetes] Pod {{pod_name.name}} of %s %s is CrashloopBackOff",
+		message: "Pod {{pod_name.name}} of %s %s is in CrashloopBackOff.",
+	},
+	"imagepullbackoff": {
+		query: func(w Workload, scope string) string {
+			return fmt.Sprintf("max(last_10m):max:kubernetes_state.container.status_report.count.waiting{reason:imagepullbackoff,%s} by {pod_name}", scope)
+		},
+		name:    "[kubernetes] Pod {{pod_name.name}} of %s %s is ImagePullBackOff",
+		message: "Pod {{pod_name.name}} of %s %s is ImagePullBackOff. This could happen for several reasons, for example a bad image path or tag or if the credentials for pulling images are not configured properly.",
+	},
+}
+
+var thresholdRegexp = regexp.MustCompile(`^(critical|warning)\s*(>=|<=|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)$`)
+
+// parseThresholds parses the thresholds of an annotation value, like `critical>5` or `critical>=10,warning>=5`, and
+// returns their comparator
+func parseThresholds(value string) (string, datadoghqv1alpha1.DatadogMonitorOptionsThresholds, error) {
+	comparator := ""
+	thresholds := datadoghqv1alpha1.DatadogMonitorOptionsThresholds{}
+	for _, part := range strings.Split(value, ",") {
+		match := thresholdRegexp.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return "", thresholds, fmt.Errorf("invalid threshold %q, must be like critical>5", strings.TrimSpace(part))
+		}
+		if comparator != "" && match[2] != comparator {
+			return "", thresholds, fmt.Errorf("the thresholds of %q must use the same comparator", value)
+		}
+		comparator = match[2]
+
+		threshold := match[3]
+		if match[1] == "critical" {
+			thresholds.Critical = &threshold
+		} else {
+			thresholds.Warning = &threshold
+		}
+	}
+	if thresholds.Critical == nil {
+		return "", thresholds, fmt.Errorf("missing critical threshold in %q", value)
+	}
+
+	return comparator, thresholds, nil
+}
+
+// renderMonitors returns the DatadogMonitors declared by the annotations of a workload, and the names and errors of the
+// invalid annotations
+func renderMonitors(w Workload, obj client.Object) ([]*datadoghqv1alpha1.DatadogMonitor, []string, []error) {
+	var keys []string
+	for key := range obj.GetAnnotations() {
+		if strings.HasPrefix(key, datadoghqv1alpha1.DatadogMonitorWorkloadAnnotationPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var monitors []*datadoghqv1alpha1.DatadogMonitor
+	var invalid []string
+	var errs []error
+	for _, key := range keys {
+		templateName := strings.TrimPrefix(key, datadoghqv1alpha1.DatadogMonitorWorkloadAnnotationPrefix)
+		template, found := monitorTemplates[templateName]
+		if !found {
+			invalid = append(invalid, key)
+			errs = append(errs, fmt.Errorf("unknown monitor template in annotation %s", key))
+			continue
+		}
+		comparator, thresholds, err := parseThresholds(obj.GetAnnotations()[key])
+		if err != nil {
+			invalid = append(invalid, key)
+			errs = append(errs, fmt.Errorf("invalid annotation %s: %w", key, err))
+			continue
+		}
+		monitors = append(monitors, renderMonitor(w, obj, templateName, template, comparator, thresholds))
+	}
+
+	return monitors, invalid, errs
+}
+
+// renderMonitor returns the DatadogMonitor generated from a template for a workload
+func renderMonitor(w Workload, obj client.Object, templateName string, template monitorTemplate, comparator string, thresholds datadoghqv1alpha1.DatadogMonitorOptionsThresholds) *datadoghqv1alpha1.DatadogMonitor {
+	scope := fmt.Sprintf("kube_namespace:%s,%s:%s", obj.GetNamespace(), w.tag, obj.GetName())
+	workload := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	tags := append([]string{
+		"integration:kubernetes",
+		fmt.Sprintf("kube_namespace:%s", obj.GetNamespace()),
+		fmt.Sprintf("%s:%s", w.tag, obj.GetName()),
+	}, datadoghqv1alpha1.GetDatadogMonitorRequiredTags()...)
+
+	return &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: obj.GetNamespace(),
+			Name:      fmt.Sprintf("%s-%s-%s", strings.ToLower(w.Kind), obj.GetName(), templateName),
+			Labels: map[string]string{
+				datadoghqv1alpha1.DatadogMonitorTemplateLabelKey: templateName,
+			},
+		},
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Query:   fmt.Sprintf("%s %s %s", template.query(w, scope), comparator, *thresholds.Critical),
+			Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			Name:    fmt.Sprintf(template.name, w.Kind, workload),
+			Message: fmt.Sprintf(template.message, w.Kind, workload),
+			Tags:    tags,
+			Options: datadoghqv1alpha1.DatadogMonitorOptions{
+				Thresholds: &thresholds,
+			},
+		},
+	}
+}
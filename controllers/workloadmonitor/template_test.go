@@ -0,0 +1,135 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmonitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+	apiutils "github.com/DataDog/datadog-operator/apis/utils"
+)
+
+func Test_parseThresholds(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		wantComparator string
+		wantThresholds datadoghqv1alpha1.DatadogMonitorOptionsThresholds
+		wantErr        string
+	}{
+		{
+			name:           "critical threshold",
+			value:          "critical>5",
+			wantComparator: ">",
+			wantThresholds: datadoghqv1alpha1.DatadogMonitorOptionsThresholds{Critical: apiutils.NewStringPointer("5")},
+		},
+		{
+			name:           "critical and warning thresholds",
+			value:          "critical >= 10, warning >= 2.5",
+			wantComparator: ">=",
+			wantThresholds: datadoghqv1alpha1.DatadogMonitorOptionsThresholds{Critical: apiutils.NewStringPointer("10"), Warning: apiutils.NewStringPointer("2.5")},
+		},
+		{
+			name:    "missing critical threshold",
+			value:   "warning>5",
+			wantErr: `missing critical threshold in "warning>5"`,
+		},
+		{
+			name:    "different comparators",
+			value:   "critical>5,warning<2",
+			wantErr: `the thresholds of "critical>5,warning<2" must use the same comparator`,
+		},
+		{
+			name:    "invalid threshold",
+			value:   "critical=5",
+			wantErr: `invalid threshold "critical=5", must be like critical>5`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparator, thresholds, err := parseThresholds(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantComparator, comparator)
+			assert.Equal(t, tt.wantThresholds, thresholds)
+		})
+	}
+}
+
+func Test_renderMonitors(t *testing.T) {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "bar",
+			Name:      "foo",
+			Annotations: map[string]string{
+				"monitors.datadoghq.com/replicas": "critical>=2,warning>=1",
+				"monitors.datadoghq.com/unknown":  "critical>1",
+				"monitors.datadoghq.com/restarts": "critical",
+				"other.datadoghq.com/restarts":    "critical>5",
+			},
+		},
+	}
+
+	monitors, invalid, errs := renderMonitors(StatefulSet, statefulSet)
+	assert.Equal(t, []string{"monitors.datadoghq.com/restarts", "monitors.datadoghq.com/unknown"}, invalid)
+	assert.Equal(t, []string{
+		`invalid annotation monitors.datadoghq.com/restarts: invalid threshold "critical", must be like critical>5`,
+		"unknown monitor template in annotation monitors.datadoghq.com/unknown",
+	}, errorStrings(errs))
+	require.Len(t, monitors, 1)
+	assert.Equal(t, &datadoghqv1alpha1.DatadogMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "bar",
+			Name:      "statefulset-foo-replicas",
+			Labels:    map[string]string{"monitors.datadoghq.com/template": "replicas"},
+		},
+		Spec: datadoghqv1alpha1.DatadogMonitorSpec{
+			Query:   "max(last_15m):sum:kubernetes_state.statefulset.replicas_desired{kube_namespace:bar,kube_stateful_set:foo} - sum:kubernetes_state.statefulset.replicas_ready{kube_namespace:bar,kube_stateful_set:foo} >= 2",
+			Type:    datadoghqv1alpha1.DatadogMonitorTypeQuery,
+			Name:    "[kubernetes] Replicas of StatefulSet bar/foo are down",
+			Message: "Replica pods of StatefulSet bar/foo are down.",
+			Tags:    []string{"integration:kubernetes", "kube_namespace:bar", "kube_stateful_set:foo", "generated:kubernetes"},
+			Options: datadoghqv1alpha1.DatadogMonitorOptions{
+				Thresholds: &datadoghqv1alpha1.DatadogMonitorOptionsThresholds{
+					Critical: apiutils.NewStringPointer("2"),
+					Warning:  apiutils.NewStringPointer("1"),
+				},
+			},
+		},
+	}, monitors[0])
+
+	// All the templates render valid DatadogMonitors
+	for name := range monitorTemplates {
+		for _, w := range []Workload{Deployment, StatefulSet} {
+			obj := w.NewObject()
+			obj.SetNamespace("bar")
+			obj.SetName("foo")
+			obj.SetAnnotations(map[string]string{datadoghqv1alpha1.DatadogMonitorWorkloadAnnotationPrefix + name: "critical>5"})
+			monitors, invalid, errs := renderMonitors(w, obj)
+			require.Empty(t, invalid)
+			require.Empty(t, errs)
+			require.Len(t, monitors, 1)
+			assert.NoError(t, datadoghqv1alpha1.IsValidDatadogMonitor(&monitors[0].Spec), "%s %s", w.Kind, name)
+		}
+	}
+}
+
+func errorStrings(errs []error) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
@@ -30,6 +30,7 @@ import (
 	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
 	datadoghqv2alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v2alpha1"
 	"github.com/DataDog/datadog-operator/controllers"
+	"github.com/DataDog/datadog-operator/controllers/datadogmonitor"
 	"github.com/DataDog/datadog-operator/pkg/config"
 	"github.com/DataDog/datadog-operator/pkg/controller/debug"
 	"github.com/DataDog/datadog-operator/pkg/secrets"
@@ -85,7 +86,11 @@ func main() {
 	flag.DurationVar(&leaderElectionLeaseDuration, "leader-election-lease-duration", 60*time.Second, "Define LeaseDuration as well as RenewDeadline (leaseDuration / 2) and RetryPeriod (leaseDuration / 4)")
 
 	// Custom flags
-	var printVersion, pprofActive, supportExtendedDaemonset, supportCilium, datadogMonitorEnabled, operatorMetricsEnabled, webhookEnabled, v2APIEnabled bool
+	var printVersion, pprofActive, supportExtendedDaemonset, supportCilium, datadogMonitorEnabled, datadogDashboardEnabled, datadogSLOEnabled, datadogDowntimeEnabled, operatorMetricsEnabled, webhookEnabled, v2APIEnabled bool
+	var datadogMonitorAllowCrossNamespaceCredentials bool
+	var datadogMonitorPollInterval time.Duration
+	var datadogMonitorDefaultDeletionPolicy string
+	var workloadMonitorsEnabled, monitorTemplatesEnabled bool
 	var logEncoder, secretBackendCommand string
 	var secretBackendArgs stringSlice
 	flag.StringVar(&logEncoder, "logEncoder", "json", "log encoding ('json' or 'console')")
@@ -97,9 +102,17 @@ func main() {
 	flag.BoolVar(&supportExtendedDaemonset, "supportExtendedDaemonset", false, "Support usage of Datadog ExtendedDaemonset CRD.")
 	flag.BoolVar(&supportCilium, "supportCilium", false, "Support usage of Cilium network policies.")
 	flag.BoolVar(&datadogMonitorEnabled, "datadogMonitorEnabled", false, "Enable the DatadogMonitor controller")
+	flag.BoolVar(&datadogMonitorAllowCrossNamespaceCredentials, "datadogMonitorAllowCrossNamespaceCredentials", false, "Allow DatadogMonitors to reference credentials Secrets of other namespaces")
+	flag.DurationVar(&datadogMonitorPollInterval, "datadogMonitorPollInterval", 0, "Interval at which the state of the monitors of the DatadogMonitors is fetched in bulk, 0 fetches the state of each monitor separately")
+	flag.StringVar(&datadogMonitorDefaultDeletionPolicy, "datadogMonitorDefaultDeletionPolicy", string(datadoghqv1alpha1.DatadogMonitorDeletionPolicyDelete), "Deletion policy of the DatadogMonitors that do not define one: Delete deletes their monitor, Orphan keeps it in Datadog")
+	flag.BoolVar(&workloadMonitorsEnabled, "workloadMonitorsEnabled", false, "Generate DatadogMonitors from the monitors.datadoghq.com annotations of Deployments and StatefulSets, requires the DatadogMonitor controller")
+	flag.BoolVar(&monitorTemplatesEnabled, "monitorTemplatesEnabled", false, "Enable the DatadogMonitorTemplate controller, requires the DatadogMonitor controller")
+	flag.BoolVar(&datadogDashboardEnabled, "datadogDashboardEnabled", false, "Enable the DatadogDashboard controller")
+	flag.BoolVar(&datadogSLOEnabled, "datadogSLOEnabled", false, "Enable the DatadogSLO controller")
+	flag.BoolVar(&datadogDowntimeEnabled, "datadogDowntimeEnabled", false, "Enable the DatadogDowntime controller")
 	flag.BoolVar(&operatorMetricsEnabled, "operatorMetricsEnabled", true, "Enable sending operator metrics to Datadog")
 	flag.BoolVar(&v2APIEnabled, "v2APIEnabled", false, "Enable the v2 api")
-	flag.BoolVar(&webhookEnabled, "webhookEnabled", true, "Enable CRD conversion webhook.")
+	flag.BoolVar(&webhookEnabled, "webhookEnabled", true, "Enable CRD conversion and admission webhooks.")
 
 	// Parsing flags
 	flag.Parse()
@@ -146,7 +159,7 @@ func main() {
 	customSetupEndpoints(pprofActive, mgr)
 
 	creds, err := config.NewCredentialManager().GetCredentials()
-	if err != nil && datadogMonitorEnabled {
+	if err != nil && (datadogMonitorEnabled || datadogDashboardEnabled || datadogSLOEnabled || datadogDowntimeEnabled) {
 		setupLog.Error(err, "Unable to get credentials")
 		os.Exit(1)
 	}
@@ -156,8 +169,18 @@ func main() {
 		SupportCilium:            supportCilium,
 		Creds:                    creds,
 		DatadogMonitorEnabled:    datadogMonitorEnabled,
+		DatadogDashboardEnabled:  datadogDashboardEnabled,
+		DatadogSLOEnabled:        datadogSLOEnabled,
+		DatadogDowntimeEnabled:   datadogDowntimeEnabled,
 		OperatorMetricsEnabled:   operatorMetricsEnabled,
 		V2APIEnabled:             v2APIEnabled,
+		WorkloadMonitorsEnabled:  workloadMonitorsEnabled,
+		MonitorTemplatesEnabled:  monitorTemplatesEnabled,
+		DatadogMonitorOptions: datadogmonitor.ReconcilerOptions{
+			AllowCrossNamespaceCredentials: datadogMonitorAllowCrossNamespaceCredentials,
+			MonitorPollInterval:            datadogMonitorPollInterval,
+			DefaultDeletionPolicy:          datadoghqv1alpha1.DatadogMonitorDeletionPolicy(datadogMonitorDefaultDeletionPolicy),
+		},
 	}
 
 	if err = controllers.SetupControllers(setupLog, mgr, options); err != nil {
@@ -170,6 +193,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DatadogAgent")
 			os.Exit(1)
 		}
+		if err = (&datadoghqv1alpha1.DatadogMonitor{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DatadogMonitor")
+			os.Exit(1)
+		}
 	}
 
 	// +kubebuilder:scaffold:builder
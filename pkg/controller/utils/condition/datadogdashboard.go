@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package condition
+
+import (
+	"fmt"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDatadogDashboardErrorActiveConditions sets the Error and Active DatadogDashboardConditionTypes to True or False
+func SetDatadogDashboardErrorActiveConditions(status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time, err error) {
+	if err != nil {
+		// Set the error condition to True
+		UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeError, corev1.ConditionTrue, fmt.Sprintf("%v", err))
+		// Set the active condition to False
+		UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeActive, corev1.ConditionFalse, "DatadogDashboard error")
+	} else {
+		// Set the error condition to False
+		UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeError, corev1.ConditionFalse, "")
+		// Set the active condition to True
+		UpdateDatadogDashboardConditions(status, now, datadoghqv1alpha1.DatadogDashboardConditionTypeActive, corev1.ConditionTrue, "DatadogDashboard ready")
+	}
+}
+
+// UpdateDatadogDashboardConditions is used to update a DatadogDashboardConditionType in conditions
+func UpdateDatadogDashboardConditions(status *datadoghqv1alpha1.DatadogDashboardStatus, now metav1.Time, t datadoghqv1alpha1.DatadogDashboardConditionType, conditionStatus corev1.ConditionStatus, desc string) {
+	conditionIndex := getIndexForDatadogDashboardConditionType(status, t)
+	// If condition type already exists, update it. Otherwise, create it (if the new condition status is True)
+	if conditionIndex > -1 {
+		SetDatadogDashboardCondition(&status.Conditions[conditionIndex], now, conditionStatus, desc)
+	} else if conditionStatus == corev1.ConditionTrue {
+		status.Conditions = append(status.Conditions, NewDatadogDashboardCondition(t, conditionStatus, now, "", desc))
+	}
+}
+
+// SetDatadogDashboardCondition is used to set a specific DatadogDashboardConditionType
+func SetDatadogDashboardCondition(condition *datadoghqv1alpha1.DatadogDashboardCondition, now metav1.Time, conditionStatus corev1.ConditionStatus, desc string) *datadoghqv1alpha1.DatadogDashboardCondition {
+	if condition.Status != conditionStatus {
+		condition.LastTransitionTime = now
+		condition.Status = conditionStatus
+	}
+	condition.LastUpdateTime = now
+	condition.Message = desc
+
+	return condition
+}
+
+// NewDatadogDashboardCondition returns a new DatadogDashboardCondition
+func NewDatadogDashboardCondition(conditionType datadoghqv1alpha1.DatadogDashboardConditionType, conditionStatus corev1.ConditionStatus, now metav1.Time, reason, message string) datadoghqv1alpha1.DatadogDashboardCondition {
+	return datadoghqv1alpha1.DatadogDashboardCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func getIndexForDatadogDashboardConditionType(status *datadoghqv1alpha1.DatadogDashboardStatus, t datadoghqv1alpha1.DatadogDashboardConditionType) int {
+	idx := -1
+	if status == nil {
+		return idx
+	}
+
+	for i, condition := range status.Conditions {
+		if condition.Type == t {
+			idx = i
+			break
+		}
+	}
+
+	return idx
+}
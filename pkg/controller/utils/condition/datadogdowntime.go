@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package condition
+
+import (
+	"fmt"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDatadogDowntimeErrorActiveConditions sets the Error and Active DatadogDowntimeConditionTypes to True or False
+func SetDatadogDowntimeErrorActiveConditions(status *datadoghqv1alpha1.DatadogDowntimeStatus, now metav1.Time, err error) {
+	if err != nil {
+		// Set the error condition to True
+		UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeError, corev1.ConditionTrue, fmt.Sprintf("%v", err))
+		// Set the active condition to False
+		UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeActive, corev1.ConditionFalse, "DatadogDowntime error")
+	} else {
+		// Set the error condition to False
+		UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeError, corev1.ConditionFalse, "")
+		// Set the active condition to True
+		UpdateDatadogDowntimeConditions(status, now, datadoghqv1alpha1.DatadogDowntimeConditionTypeActive, corev1.ConditionTrue, "DatadogDowntime ready")
+	}
+}
+
+// UpdateDatadogDowntimeConditions is used to update a DatadogDowntimeConditionType in conditions
+func UpdateDatadogDowntimeConditions(status *datadoghqv1alpha1.DatadogDowntimeStatus, now metav1.Time, t datadoghqv1alpha1.DatadogDowntimeConditionType, conditionStatus corev1.ConditionStatus, desc string) {
+	conditionIndex := getIndexForDatadogDowntimeConditionType(status, t)
+	// If condition type already exists, update it. Otherwise, create it (if the new condition status is True)
+	if conditionIndex > -1 {
+		SetDatadogDowntimeCondition(&status.Conditions[conditionIndex], now, conditionStatus, desc)
+	} else if conditionStatus == corev1.ConditionTrue {
+		status.Conditions = append(status.Conditions, NewDatadogDowntimeCondition(t, conditionStatus, now, "", desc))
+	}
+}
+
+// SetDatadogDowntimeCondition is used to set a specific DatadogDowntimeConditionType
+func SetDatadogDowntimeCondition(condition *datadoghqv1alpha1.DatadogDowntimeCondition, now metav1.Time, conditionStatus corev1.ConditionStatus, desc string) *datadoghqv1alpha1.DatadogDowntimeCondition {
+	if condition.Status != conditionStatus {
+		condition.LastTransitionTime = now
+		condition.Status = conditionStatus
+	}
+	condition.LastUpdateTime = now
+	condition.Message = desc
+
+	return condition
+}
+
+// NewDatadogDowntimeCondition returns a new DatadogDowntimeCondition
+func NewDatadogDowntimeCondition(conditionType datadoghqv1alpha1.DatadogDowntimeConditionType, conditionStatus corev1.ConditionStatus, now metav1.Time, reason, message string) datadoghqv1alpha1.DatadogDowntimeCondition {
+	return datadoghqv1alpha1.DatadogDowntimeCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func getIndexForDatadogDowntimeConditionType(status *datadoghqv1alpha1.DatadogDowntimeStatus, t datadoghqv1alpha1.DatadogDowntimeConditionType) int {
+	idx := -1
+	if status == nil {
+		return idx
+	}
+
+	for i, condition := range status.Conditions {
+		if condition.Type == t {
+			idx = i
+			break
+		}
+	}
+
+	return idx
+}
@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package condition
+
+import (
+	"fmt"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDatadogMonitorTemplateErrorActiveConditions sets the Error and Active DatadogMonitorTemplateConditionTypes to True or False
+func SetDatadogMonitorTemplateErrorActiveConditions(status *datadoghqv1alpha1.DatadogMonitorTemplateStatus, now metav1.Time, err error) {
+	if err != nil {
+		// Set the error condition to True
+		UpdateDatadogMonitorTemplateConditions(status, now, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError, corev1.ConditionTrue, fmt.Sprintf("%v", err))
+		// Set the active condition to False
+		UpdateDatadogMonitorTemplateConditions(status, now, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeActive, corev1.ConditionFalse, "DatadogMonitorTemplate error")
+	} else {
+		// Set the error condition to False
+		UpdateDatadogMonitorTemplateConditions(status, now, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeError, corev1.ConditionFalse, "")
+		// Set the active condition to True
+		UpdateDatadogMonitorTemplateConditions(status, now, datadoghqv1alpha1.DatadogMonitorTemplateConditionTypeActive, corev1.ConditionTrue, "DatadogMonitorTemplate ready")
+	}
+}
+
+// UpdateDatadogMonitorTemplateConditions is used to update a DatadogMonitorTemplateConditionType in conditions
+func UpdateDatadogMonitorTemplateConditions(status *datadoghqv1alpha1.DatadogMonitorTemplateStatus, now metav1.Time, t datadoghqv1alpha1.DatadogMonitorTemplateConditionType, conditionStatus corev1.ConditionStatus, desc string) {
+	conditionIndex := getIndexForDatadogMonitorTemplateConditionType(status, t)
+	// If condition type already exists, update it. Otherwise, create it (if the new condition status is True)
+	if conditionIndex > -1 {
+		SetDatadogMonitorTemplateCondition(&status.Conditions[conditionIndex], now, conditionStatus, desc)
+	} else if conditionStatus == corev1.ConditionTrue {
+		status.Conditions = append(status.Conditions, NewDatadogMonitorTemplateCondition(t, conditionStatus, now, "", desc))
+	}
+}
+
+// SetDatadogMonitorTemplateCondition is used to set a specific DatadogMonitorTemplateConditionType
+// The condition is left untouched when neither its status nor its message change: the DatadogMonitorTemplates are
+// reconciled on every status change of their DatadogMonitors, which must not update their status each time.
+func SetDatadogMonitorTemplateCondition(condition *datadoghqv1alpha1.DatadogMonitorTemplateCondition, now metav1.Time, conditionStatus corev1.ConditionStatus, desc string) *datadoghqv1alpha1.DatadogMonitorTemplateCondition {
+	if condition.Status == conditionStatus && condition.Message == desc {
+		return condition
+	}
+	if condition.Status != conditionStatus {
+		condition.LastTransitionTime = now
+		condition.Status = conditionStatus
+	}
+	condition.LastUpdateTime = now
+	condition.Message = desc
+
+	return condition
+}
+
+// NewDatadogMonitorTemplateCondition returns a new DatadogMonitorTemplateCondition
+func NewDatadogMonitorTemplateCondition(conditionType datadoghqv1alpha1.DatadogMonitorTemplateConditionType, conditionStatus corev1.ConditionStatus, now metav1.Time, reason, message string) datadoghqv1alpha1.DatadogMonitorTemplateCondition {
+	return datadoghqv1alpha1.DatadogMonitorTemplateCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func getIndexForDatadogMonitorTemplateConditionType(status *datadoghqv1alpha1.DatadogMonitorTemplateStatus, t datadoghqv1alpha1.DatadogMonitorTemplateConditionType) int {
+	idx := -1
+	if status == nil {
+		return idx
+	}
+
+	for i, condition := range status.Conditions {
+		if condition.Type == t {
+			idx = i
+			break
+		}
+	}
+
+	return idx
+}
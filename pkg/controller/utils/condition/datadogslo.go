@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package condition
+
+import (
+	"fmt"
+
+	datadoghqv1alpha1 "github.com/DataDog/datadog-operator/apis/datadoghq/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetDatadogSLOErrorActiveConditions sets the Error and Active DatadogSLOConditionTypes to True or False
+func SetDatadogSLOErrorActiveConditions(status *datadoghqv1alpha1.DatadogSLOStatus, now metav1.Time, err error) {
+	if err != nil {
+		// Set the error condition to True
+		UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeError, corev1.ConditionTrue, fmt.Sprintf("%v", err))
+		// Set the active condition to False
+		UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeActive, corev1.ConditionFalse, "DatadogSLO error")
+	} else {
+		// Set the error condition to False
+		UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeError, corev1.ConditionFalse, "")
+		// Set the active condition to True
+		UpdateDatadogSLOConditions(status, now, datadoghqv1alpha1.DatadogSLOConditionTypeActive, corev1.ConditionTrue, "DatadogSLO ready")
+	}
+}
+
+// UpdateDatadogSLOConditions is used to update a DatadogSLOConditionType in conditions
+func UpdateDatadogSLOConditions(status *datadoghqv1alpha1.DatadogSLOStatus, now metav1.Time, t datadoghqv1alpha1.DatadogSLOConditionType, conditionStatus corev1.ConditionStatus, desc string) {
+	conditionIndex := getIndexForDatadogSLOConditionType(status, t)
+	// If condition type already exists, update it. Otherwise, create it (if the new condition status is True)
+	if conditionIndex > -1 {
+		SetDatadogSLOCondition(&status.Conditions[conditionIndex], now, conditionStatus, desc)
+	} else if conditionStatus == corev1.ConditionTrue {
+		status.Conditions = append(status.Conditions, NewDatadogSLOCondition(t, conditionStatus, now, "", desc))
+	}
+}
+
+// SetDatadogSLOCondition is used to set a specific DatadogSLOConditionType
+func SetDatadogSLOCondition(condition *datadoghqv1alpha1.DatadogSLOCondition, now metav1.Time, conditionStatus corev1.ConditionStatus, desc string) *datadoghqv1alpha1.DatadogSLOCondition {
+	if condition.Status != conditionStatus {
+		condition.LastTransitionTime = now
+		condition.Status = conditionStatus
+	}
+	condition.LastUpdateTime = now
+	condition.Message = desc
+
+	return condition
+}
+
+// NewDatadogSLOCondition returns a new DatadogSLOCondition
+func NewDatadogSLOCondition(conditionType datadoghqv1alpha1.DatadogSLOConditionType, conditionStatus corev1.ConditionStatus, now metav1.Time, reason, message string) datadoghqv1alpha1.DatadogSLOCondition {
+	return datadoghqv1alpha1.DatadogSLOCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+func getIndexForDatadogSLOConditionType(status *datadoghqv1alpha1.DatadogSLOStatus, t datadoghqv1alpha1.DatadogSLOConditionType) int {
+	idx := -1
+	if status == nil {
+		return idx
+	}
+
+	for i, condition := range status.Conditions {
+		if condition.Type == t {
+			idx = i
+			break
+		}
+	}
+
+	return idx
+}
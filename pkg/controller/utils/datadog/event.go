@@ -25,6 +25,12 @@ const (
 	UpdateEvent EventType = "Update"
 	// DeletionEvent should be used for resource deletion events
 	DeletionEvent EventType = "Delete"
+	// AdoptionEvent should be used when taking ownership of an existing resource
+	AdoptionEvent EventType = "Adopt"
+	// DriftEvent should be used when a resource is modified outside Kubernetes
+	DriftEvent EventType = "Drift"
+	// OrphanEvent should be used when a resource is deleted but its Datadog counterpart is kept
+	OrphanEvent EventType = "Orphan"
 )
 
 // crDetected returns the detection event of a CR
@@ -8,6 +8,7 @@ package utils
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apicommon "github.com/DataDog/datadog-operator/apis/datadoghq/common"
@@ -69,11 +70,11 @@ func ShouldReturn(result reconcile.Result, err error) bool {
 }
 
 // GetDatadogLeaderElectionResourceName returns the name of the ConfigMap used by the cluster agent to elect a leader
-func GetDatadogLeaderElectionResourceName(dda *datadoghqv1alpha1.DatadogAgent) string {
-	return fmt.Sprintf("%s-leader-election", dda.Name)
+func GetDatadogLeaderElectionResourceName(dda metav1.Object) string {
+	return fmt.Sprintf("%s-leader-election", dda.GetName())
 }
 
 // GetDatadogTokenResourceName returns the name of the ConfigMap used by the cluster agent to store token
-func GetDatadogTokenResourceName(dda *datadoghqv1alpha1.DatadogAgent) string {
-	return fmt.Sprintf("%stoken", dda.Name)
+func GetDatadogTokenResourceName(dda metav1.Object) string {
+	return fmt.Sprintf("%stoken", dda.GetName())
 }
@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"sync"
+
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+// ClientCache keeps the Datadog API Clients by credentials source, like the namespace/name of a credentials Secret,
+// so that they are reused across reconciles.
+type ClientCache struct {
+	clients map[string]cachedClient
+	mutex   sync.Mutex
+}
+
+type cachedClient struct {
+	creds  config.Creds
+	apiURL string
+	client DatadogClient
+}
+
+// NewClientCache returns an empty ClientCache.
+func NewClientCache() *ClientCache {
+	return &ClientCache{
+		clients: map[string]cachedClient{},
+	}
+}
+
+// Get returns the Datadog API Client of a credentials source, initializing it if it is not cached yet or if the
+// credentials or API URL of the source changed.
+func (c *ClientCache) Get(source string, creds config.Creds, apiURL string) (DatadogClient, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if cached, found := c.clients[source]; found && cached.creds == creds && cached.apiURL == apiURL {
+		return cached.client, nil
+	}
+
+	client, err := NewDatadogClient(creds, apiURL)
+	if err != nil {
+		delete(c.clients, source)
+		return DatadogClient{}, err
+	}
+	c.clients[source] = cachedClient{creds: creds, apiURL: apiURL, client: client}
+
+	return client, nil
+}
+
+// Remove removes the Datadog API Client of a credentials source from the cache, for example when its credentials
+// Secret is deleted.
+func (c *ClientCache) Remove(source string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.clients, source)
+}
+
+// Len returns the number of cached Datadog API Clients.
+func (c *ClientCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.clients)
+}
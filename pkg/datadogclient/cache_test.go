@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-operator/pkg/config"
+)
+
+func TestClientCache(t *testing.T) {
+	cache := NewClientCache()
+	creds := config.Creds{APIKey: "api", AppKey: "app"}
+
+	first, err := cache.Get("bar/team-a", creds, "")
+	require.NoError(t, err)
+	again, err := cache.Get("bar/team-a", creds, "")
+	require.NoError(t, err)
+	assert.Same(t, first.Client, again.Client, "the client should be reused for the same credentials and URL")
+
+	otherSource, err := cache.Get("bar/team-b", creds, "")
+	require.NoError(t, err)
+	assert.NotSame(t, first.Client, otherSource.Client, "the client should be specific to the credentials source")
+
+	otherSite, err := cache.Get("bar/team-a", creds, GetSiteAPIURL("datadoghq.eu"))
+	require.NoError(t, err)
+	assert.NotSame(t, first.Client, otherSite.Client, "the client should be replaced when the API URL changes")
+
+	otherCreds, err := cache.Get("bar/team-a", config.Creds{APIKey: "api", AppKey: "other"}, GetSiteAPIURL("datadoghq.eu"))
+	require.NoError(t, err)
+	assert.NotSame(t, otherSite.Client, otherCreds.Client, "the client should be replaced when the credentials change")
+	assert.Equal(t, 2, cache.Len(), "the replaced clients should not be kept")
+
+	cache.Remove("bar/team-b")
+	assert.Equal(t, 1, cache.Len())
+
+	_, err = cache.Get("bar/team-a", config.Creds{APIKey: "api"}, "")
+	assert.Error(t, err)
+	assert.Equal(t, 0, cache.Len(), "the client of invalid credentials should not be kept")
+	_, err = cache.Get("bar/team-a", creds, "datadoghq.eu")
+	assert.Error(t, err, "the URL should have a scheme and a host")
+}
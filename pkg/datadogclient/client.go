@@ -25,6 +25,11 @@ type DatadogClient struct {
 
 // InitDatadogClient initializes the Datadog API Client and establishes credentials.
 func InitDatadogClient(creds config.Creds) (DatadogClient, error) {
+	return NewDatadogClient(creds, os.Getenv(config.DDURLEnvVar))
+}
+
+// NewDatadogClient initializes a Datadog API Client for the credentials, sending the requests to apiURL if it is set.
+func NewDatadogClient(creds config.Creds, apiURL string) (DatadogClient, error) {
 	if creds.APIKey == "" || creds.AppKey == "" {
 		return DatadogClient{}, errors.New("error obtaining API key and/or app key")
 	}
@@ -43,8 +48,10 @@ func InitDatadogClient(creds config.Creds) (DatadogClient, error) {
 		},
 	)
 	configV1 := datadogapiclientv1.NewConfiguration()
+	// The SLO history is used by the DatadogSLO controller to report the SLI value and the error budget.
+	configV1.SetUnstableOperationEnabled("GetSLOHistory", true)
 
-	if apiURL := os.Getenv(config.DDURLEnvVar); apiURL != "" {
+	if apiURL != "" {
 		parsedAPIURL, parseErr := url.Parse(apiURL)
 		if parseErr != nil {
 			return DatadogClient{}, fmt.Errorf(`invalid API Url : %w`, parseErr)
@@ -63,3 +70,8 @@ func InitDatadogClient(creds config.Creds) (DatadogClient, error) {
 
 	return DatadogClient{Client: client, Auth: authV1}, nil
 }
+
+// GetSiteAPIURL returns the URL of the API of a Datadog site, for example datadoghq.eu
+func GetSiteAPIURL(site string) string {
+	return fmt.Sprintf("https://api.%s", site)
+}
@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+)
+
+// TranslateClientError wraps an error returned by the Datadog API client, adding the body of
+// the API response when available.
+func TranslateClientError(err error, msg string) error {
+	if msg == "" {
+		msg = "an error occurred"
+	}
+
+	var apiErr datadogapiclientv1.GenericOpenAPIError
+	var errURL *url.Error
+	if errors.As(err, &apiErr) {
+		return fmt.Errorf(msg+": %w: %s", err, apiErr.Body())
+	}
+
+	if errors.As(err, &errURL) {
+		return fmt.Errorf(msg+" (url.Error): %s", errURL)
+	}
+
+	return fmt.Errorf(msg+": %w", err)
+}
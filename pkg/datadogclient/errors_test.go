@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	datadogapiclientv1 "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
+)
+
+func TestTranslateClientError(t *testing.T) {
+	var ErrGeneric = errors.New("generic error")
+
+	testCases := []struct {
+		name                   string
+		error                  error
+		message                string
+		expectedErrorType      error
+		expectedError          error
+		expectedErrorInterface interface{}
+	}{
+		{
+			name:              "no message, generic error",
+			error:             ErrGeneric,
+			message:           "",
+			expectedErrorType: ErrGeneric,
+		},
+		{
+			name:              "generic message, generic error",
+			error:             ErrGeneric,
+			message:           "generic message",
+			expectedErrorType: ErrGeneric,
+		},
+		{
+			name:                   "generic message, error type datadogapiclientv1.GenericOpenAPIError",
+			error:                  datadogapiclientv1.GenericOpenAPIError{},
+			message:                "generic message",
+			expectedErrorInterface: &datadogapiclientv1.GenericOpenAPIError{},
+		},
+		{
+			name:          "generic message, error type *url.Error",
+			error:         &url.Error{Err: fmt.Errorf("generic url error")},
+			message:       "generic message",
+			expectedError: fmt.Errorf("generic message (url.Error):  \"\": generic url error"),
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result := TranslateClientError(test.error, test.message)
+
+			if test.expectedErrorType != nil {
+				assert.True(t, errors.Is(result, test.expectedErrorType))
+			}
+
+			if test.expectedErrorInterface != nil {
+				assert.True(t, errors.As(result, test.expectedErrorInterface))
+			}
+
+			if test.expectedError != nil {
+				assert.Equal(t, test.expectedError, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+
+	// rateLimitReserve is the fraction of the rate limit below which requests are spread over the rest of the period,
+	// to leave room for the other requests using the same API key
+	rateLimitReserve = 0.2
+)
+
+// RateLimit is the state of the rate limit of an API endpoint, reported by the X-RateLimit-* headers of its responses
+type RateLimit struct {
+	// Limit is the number of requests allowed in the period
+	Limit int
+	// Remaining is the number of requests remaining in the current period
+	Remaining int
+	// Reset is the time until the current period ends
+	Reset time.Duration
+}
+
+// GetRateLimit returns the rate limit reported by an API response, and false if the response has no rate limit headers
+func GetRateLimit(resp *http.Response) (RateLimit, bool) {
+	if resp == nil {
+		return RateLimit{}, false
+	}
+
+	limit, errLimit := strconv.Atoi(resp.Header.Get(rateLimitLimitHeader))
+	remaining, errRemaining := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader))
+	reset, errReset := strconv.Atoi(resp.Header.Get(rateLimitResetHeader))
+	if errLimit != nil || errRemaining != nil || errReset != nil {
+		return RateLimit{}, false
+	}
+
+	return RateLimit{Limit: limit, Remaining: remaining, Reset: time.Duration(reset) * time.Second}, true
+}
+
+// Delay returns how long to wait before the next request: until the end of the period when no request remains, and
+// the remaining requests spread over the rest of the period when few remain
+func (l RateLimit) Delay() time.Duration {
+	if l.Remaining <= 0 {
+		return l.Reset
+	}
+	if float64(l.Remaining) < rateLimitReserve*float64(l.Limit) {
+		return l.Reset / time.Duration(l.Remaining+1)
+	}
+
+	return 0
+}
+
+// IsRateLimited returns true if the request was rejected because of the rate limit
+func IsRateLimited(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package datadogclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		want      RateLimit
+		wantFound bool
+		wantDelay time.Duration
+	}{
+		{
+			name: "no headers",
+		},
+		{
+			name:      "requests remaining",
+			headers:   map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "50", "X-RateLimit-Reset": "30"},
+			want:      RateLimit{Limit: 100, Remaining: 50, Reset: 30 * time.Second},
+			wantFound: true,
+		},
+		{
+			name:      "few requests remaining",
+			headers:   map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "9", "X-RateLimit-Reset": "30"},
+			want:      RateLimit{Limit: 100, Remaining: 9, Reset: 30 * time.Second},
+			wantFound: true,
+			wantDelay: 3 * time.Second,
+		},
+		{
+			name:      "no request remaining",
+			headers:   map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"},
+			want:      RateLimit{Limit: 100, Remaining: 0, Reset: 30 * time.Second},
+			wantFound: true,
+			wantDelay: 30 * time.Second,
+		},
+		{
+			name:    "invalid headers",
+			headers: map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "none", "X-RateLimit-Reset": "30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+
+			got, found := GetRateLimit(resp)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantDelay, got.Delay())
+		})
+	}
+}